@@ -77,20 +77,61 @@ func AddConfigurationItem(w http.ResponseWriter, r *http.Request, _ httprouter.P
 }
 
 func addItem(item *ConfigurationItem, lookupID string) error {
+	var (
+		err   error
+		jsonb []byte
+		tx    *sql.Tx
+	)
+
+	if jsonb, err = json.Marshal(item); err != nil {
+		return err
+	}
+
+lookupcheck:
+	if err = ensureLookup(item, lookupID); err != nil {
+		return err
+	}
+	if tx, err = beginLookupChange(lookupID); err == sql.ErrNoRows {
+		// the lookup id was deleted with its last item in between
+		goto lookupcheck
+	} else if err != nil {
+		return err
+	}
+
+	if _, err = tx.Stmt(Eye.run.insert_item).Exec(
+		item.ConfigurationItemId.String(),
+		lookupID,
+		jsonb,
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err = tx.Stmt(Eye.run.clr_deletion).Exec(
+		item.ConfigurationItemId.String(),
+		lookupID,
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	notifyLookup(lookupID)
+	return nil
+}
+
+// ensureLookup creates lookupID for item if it does not exist
+func ensureLookup(item *ConfigurationItem, lookupID string) error {
 	var (
 		hostid int64
 		err    error
 		look   string
-		jsonb  []byte
 	)
 
 	// string was generated from uint64, we need int now
 	if hostid, err = strconv.ParseInt(item.HostId, 10, 64); err != nil {
 		return err
 	}
-	if jsonb, err = json.Marshal(item); err != nil {
-		return err
-	}
 
 lookupcheck:
 	err = Eye.run.check_lookup.QueryRow(lookupID).Scan(&look)
@@ -109,13 +150,7 @@ lookupcheck:
 	if lookupID != look {
 		panic(`Database corrupted`)
 	}
-
-	_, err = Eye.run.insert_item.Exec(
-		item.ConfigurationItemId.String(),
-		lookupID,
-		jsonb,
-	)
-	return err
+	return nil
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,295 @@
+/*
+Copyright (c) 2016, Jörg Pernfuß <code.jpe@gmail.com>
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+* Redistributions of source code must retain the above copyright notice, this
+  list of conditions and the following disclaimer.
+
+* Redistributions in binary form must reproduce the above copyright notice,
+  this list of conditions and the following disclaimer in the documentation
+  and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1and1/soma/lib/auth"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	roleRead  = `read`
+	roleWrite = `write`
+)
+
+// eyeToken is a SOMA issued token in the format required for
+// verification
+type eyeToken struct {
+	validFrom    time.Time
+	expiresAt    time.Time
+	binToken     []byte
+	binExpiresAt []byte
+	salt         []byte
+}
+
+// tokenCache holds all tokens read from the database
+var tokenCache = struct {
+	sync.RWMutex
+	m map[string]*eyeToken
+}{m: map[string]*eyeToken{}}
+
+// setup validates the authentication configuration and resolves the
+// addresses notify callbacks are accepted from
+func (a *AuthConfig) setup(soma *url.URL) error {
+	var err error
+
+	if a.OpenInstance {
+		log.Println(`Authentication disabled, running open instance`)
+		return nil
+	}
+	if (a.TokenKey == ``) != (a.TokenSeed == ``) {
+		return fmt.Errorf(`Token authentication requires token.key and token.seed`)
+	}
+	if a.TokenKey != `` {
+		if a.key, err = hex.DecodeString(a.TokenKey); err != nil {
+			return fmt.Errorf("Invalid token.key: %s", err.Error())
+		}
+		if a.seed, err = hex.DecodeString(a.TokenSeed); err != nil {
+			return fmt.Errorf("Invalid token.seed: %s", err.Error())
+		}
+	}
+	if a.key == nil && a.ClientCA == `` {
+		return fmt.Errorf(`No authentication method configured`)
+	}
+
+	// notify callbacks are accepted from the SOMA instance only
+	a.notify = map[string]bool{}
+	for _, host := range append([]string{soma.Host}, a.NotifySources...) {
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.Trim(host, `[]`)
+		if ip := net.ParseIP(host); ip != nil {
+			a.notify[ip.String()] = true
+			continue
+		}
+		addrs, err := net.LookupIP(host)
+		if err != nil {
+			return fmt.Errorf("Could not resolve notify source %s: %s",
+				host, err.Error())
+		}
+		for _, ip := range addrs {
+			a.notify[ip.String()] = true
+		}
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS configuration for client certificate
+// authentication. It returns nil if no client CA is configured.
+func (a *AuthConfig) tlsConfig() (*tls.Config, error) {
+	if a.OpenInstance || a.ClientCA == `` {
+		return nil, nil
+	}
+	pem, err := ioutil.ReadFile(a.ClientCA)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("No certificates found in %s", a.ClientCA)
+	}
+	return &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}, nil
+}
+
+// hasRole checks if name is granted role within the lists read and
+// write. The write role includes the read role.
+func hasRole(name, role string, read, write []string) bool {
+	for _, n := range write {
+		if n == name {
+			return true
+		}
+	}
+	if role != roleRead {
+		return false
+	}
+	for _, n := range read {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticated wraps h and only delegates requests from clients
+// that have been granted role, either via a verified client
+// certificate or a SOMA issued token
+func Authenticated(role string, h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request,
+		ps httprouter.Params) {
+
+		if Eye.Auth.OpenInstance {
+			h(w, r, ps)
+			return
+		}
+
+		// client certificates have been verified by the TLS stack
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+			name := r.TLS.VerifiedChains[0][0].Subject.CommonName
+			if !hasRole(name, role, Eye.Auth.ReadCerts,
+				Eye.Auth.WriteCerts) {
+				dispatchForbidden(&w, fmt.Sprintf(
+					"Certificate %s lacks role %s", name, role))
+				return
+			}
+			h(w, r, ps)
+			return
+		}
+
+		user, token, ok := r.BasicAuth()
+		if !ok || Eye.Auth.key == nil {
+			dispatchUnauthorized(&w, `Authentication required`)
+			return
+		}
+		if err := verifyToken(user, token, r.RemoteAddr); err != nil {
+			dispatchUnauthorized(&w, fmt.Sprintf(
+				"Authentication failed for %s: %s", user, err.Error()))
+			return
+		}
+		if !hasRole(user, role, Eye.Auth.ReadUsers,
+			Eye.Auth.WriteUsers) {
+			dispatchForbidden(&w, fmt.Sprintf(
+				"User %s lacks role %s", user, role))
+			return
+		}
+		h(w, r, ps)
+	}
+}
+
+// NotifySource wraps h and only delegates requests originating from
+// the SOMA instance
+func NotifySource(h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request,
+		ps httprouter.Params) {
+
+		if Eye.Auth.OpenInstance {
+			h(w, r, ps)
+			return
+		}
+
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		if ip := net.ParseIP(strings.Split(host, `%`)[0]); ip == nil ||
+			!Eye.Auth.notify[ip.String()] {
+			dispatchForbidden(&w, fmt.Sprintf(
+				"Rejected notify from %s", r.RemoteAddr))
+			return
+		}
+		h(w, r, ps)
+	}
+}
+
+// verifyToken checks the token presented by user connecting from
+// addr against the token database
+func verifyToken(user, token, addr string) error {
+	tok, err := lookupToken(token)
+	if err != nil {
+		return err
+	}
+	if time.Now().UTC().Before(tok.validFrom.UTC()) ||
+		time.Now().UTC().After(tok.expiresAt.UTC()) {
+		return fmt.Errorf(`Token expired`)
+	}
+	expires := make([]byte, len(tok.binExpiresAt))
+	copy(expires, tok.binExpiresAt)
+	if !auth.Verify(user, addr, tok.binToken, Eye.Auth.key,
+		Eye.Auth.seed, expires, tok.salt) {
+		return auth.ErrAuth
+	}
+	return nil
+}
+
+// lookupToken returns token from the cache, reading it from the
+// database if required
+func lookupToken(token string) (*eyeToken, error) {
+	var (
+		err            error
+		salt           string
+		validF, validU time.Time
+		bToken, bSalt  []byte
+		bExpires       []byte
+	)
+
+	tokenCache.RLock()
+	tok, ok := tokenCache.m[token]
+	tokenCache.RUnlock()
+	if ok {
+		return tok, nil
+	}
+
+	err = Eye.run.token.QueryRow(token).Scan(&salt, &validF, &validU)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf(`Unknown token`)
+	} else if err != nil {
+		return nil, err
+	}
+	if bToken, err = hex.DecodeString(token); err != nil {
+		return nil, err
+	}
+	if bSalt, err = hex.DecodeString(salt); err != nil {
+		return nil, err
+	}
+	// tokens are computed over the millisecond precision UTC expiry
+	// timestamp
+	validU = validU.UTC().Truncate(time.Millisecond)
+	if bExpires, err = validU.MarshalBinary(); err != nil {
+		return nil, err
+	}
+
+	tok = &eyeToken{
+		validFrom:    validF,
+		expiresAt:    validU,
+		binToken:     bToken,
+		binExpiresAt: bExpires,
+		salt:         bSalt,
+	}
+	tokenCache.Lock()
+	tokenCache.m[token] = tok
+	tokenCache.Unlock()
+	return tok, nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
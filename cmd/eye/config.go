@@ -22,9 +22,11 @@ type EyeConfig struct {
 	Environment string     `json:"environment" valid:"alpha"`
 	ReadOnly    bool       `json:"readonly,string" valid:"-"`
 	Volatile    bool       `json:"volatile,string" valid:"-"`
+	Rules       string     `json:"itemization.rules" valid:"-"`
 	Daemon      EyeDaemon  `json:"daemon" valid:"required"`
 	Database    DbConfig   `json:"database" valid:"required"`
 	Soma        SomaConfig `json:"soma" valid:"required"`
+	Auth        AuthConfig `json:"authentication" valid:"-"`
 	run         EyeRuntime
 }
 
@@ -43,6 +45,25 @@ type SomaConfig struct {
 	Address string `json:"address" valid:"requrl"`
 }
 
+// AuthConfig configures the authentication of API clients. Clients
+// authenticate with SOMA issued tokens or with client certificates,
+// identified by the common name of the certificate subject. Names
+// listed as write users or certificates also have read access.
+type AuthConfig struct {
+	OpenInstance  bool     `json:"open.instance,string"`
+	TokenKey      string   `json:"token.key"`
+	TokenSeed     string   `json:"token.seed"`
+	ReadUsers     []string `json:"read.users"`
+	WriteUsers    []string `json:"write.users"`
+	ClientCA      string   `json:"client.ca.file"`
+	ReadCerts     []string `json:"read.certificates"`
+	WriteCerts    []string `json:"write.certificates"`
+	NotifySources []string `json:"notify.sources"`
+	key           []byte
+	seed          []byte
+	notify        map[string]bool
+}
+
 type EyeDaemon struct {
 	url    *url.URL
 	Listen string `json:"listen" valid:"ip"`
@@ -66,6 +87,22 @@ type EyeRuntime struct {
 	get_config    *sql.Stmt
 	get_items     *sql.Stmt
 	retrieve      *sql.Stmt
+	token         *sql.Stmt
+	ins_deletion  *sql.Stmt
+	clr_deletion  *sql.Stmt
+	get_revision  *sql.Stmt
+	get_changed   *sql.Stmt
+	get_deleted   *sql.Stmt
+	get_pruned    *sql.Stmt
+	prune         *sql.Stmt
+	lock_lookup   *sql.Stmt
+	fb_enqueue    *sql.Stmt
+	fb_due        *sql.Stmt
+	fb_delete     *sql.Stmt
+	fb_retry      *sql.Stmt
+	fb_list       *sql.Stmt
+	fb_wake       chan struct{}
+	rules         *ItemizationRules
 }
 
 func (c *EyeConfig) readConfigFile(fname string) error {
@@ -8,8 +8,18 @@ package main
 
 import "github.com/satori/go.uuid"
 
+// ConfigurationData is the reply to configuration lookups. Revision
+// is the revision of the lookup id at the time of the request. For
+// incremental requests, Configurations only contains the changed
+// items and Deleted the IDs of the items removed from the lookup id.
+// Full is set if Configurations contains all items of the lookup id.
+// This is also the case for incremental requests whose revision is
+// older than the retention of deletions.
 type ConfigurationData struct {
 	Configurations []ConfigurationItem `json:"configurations"`
+	Deleted        []string            `json:"deleted,omitempty"`
+	Revision       uint64              `json:"revision,omitempty"`
+	Full           bool                `json:"full,omitempty"`
 }
 
 type ConfigurationList struct {
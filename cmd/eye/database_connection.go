@@ -65,6 +65,30 @@ func pingDatabase() {
 func prepareStatements() {
 	var err error
 
+	_, err = Eye.run.conn.Exec(stmtCreateRevisionSequence)
+	log.Println("Creating: eye.configuration_revision")
+	abortOnError(err)
+
+	_, err = Eye.run.conn.Exec(stmtAddItemRevision)
+	log.Println("Creating: eye.configuration_items.revision")
+	abortOnError(err)
+
+	_, err = Eye.run.conn.Exec(stmtCreateConfigurationDeletions)
+	log.Println("Creating: eye.configuration_deletions")
+	abortOnError(err)
+
+	_, err = Eye.run.conn.Exec(stmtAddDeletionTimestamp)
+	log.Println("Creating: eye.configuration_deletions.deleted_at")
+	abortOnError(err)
+
+	_, err = Eye.run.conn.Exec(stmtCreateConfigurationPruned)
+	log.Println("Creating: eye.configuration_pruned")
+	abortOnError(err)
+
+	_, err = Eye.run.conn.Exec(stmtCreateFeedbackOutbox)
+	log.Println("Creating: eye.feedback_outbox")
+	abortOnError(err)
+
 	Eye.run.check_item, err = Eye.run.conn.Prepare(stmtCheckItemExists)
 	log.Println("Preparing: check_item")
 	abortOnError(err)
@@ -112,6 +136,64 @@ func prepareStatements() {
 	Eye.run.update_item, err = Eye.run.conn.Prepare(stmtUpdateConfigurationItem)
 	log.Println("Preparing: update_item")
 	abortOnError(err)
+
+	Eye.run.ins_deletion, err = Eye.run.conn.Prepare(stmtInsertItemDeletion)
+	log.Println("Preparing: ins_deletion")
+	abortOnError(err)
+
+	Eye.run.clr_deletion, err = Eye.run.conn.Prepare(stmtClearItemDeletion)
+	log.Println("Preparing: clr_deletion")
+	abortOnError(err)
+
+	Eye.run.get_revision, err = Eye.run.conn.Prepare(stmtGetLookupRevision)
+	log.Println("Preparing: get_revision")
+	abortOnError(err)
+
+	Eye.run.get_changed, err = Eye.run.conn.Prepare(stmtRetrieveChangedConfigurations)
+	log.Println("Preparing: get_changed")
+	abortOnError(err)
+
+	Eye.run.get_deleted, err = Eye.run.conn.Prepare(stmtRetrieveDeletedConfigurations)
+	log.Println("Preparing: get_deleted")
+	abortOnError(err)
+
+	Eye.run.get_pruned, err = Eye.run.conn.Prepare(stmtGetPrunedRevision)
+	log.Println("Preparing: get_pruned")
+	abortOnError(err)
+
+	Eye.run.prune, err = Eye.run.conn.Prepare(stmtPruneDeletions)
+	log.Println("Preparing: prune")
+	abortOnError(err)
+
+	Eye.run.lock_lookup, err = Eye.run.conn.Prepare(stmtLockLookup)
+	log.Println("Preparing: lock_lookup")
+	abortOnError(err)
+
+	Eye.run.fb_enqueue, err = Eye.run.conn.Prepare(stmtFeedbackEnqueue)
+	log.Println("Preparing: fb_enqueue")
+	abortOnError(err)
+
+	Eye.run.fb_due, err = Eye.run.conn.Prepare(stmtFeedbackDue)
+	log.Println("Preparing: fb_due")
+	abortOnError(err)
+
+	Eye.run.fb_delete, err = Eye.run.conn.Prepare(stmtFeedbackDelete)
+	log.Println("Preparing: fb_delete")
+	abortOnError(err)
+
+	Eye.run.fb_retry, err = Eye.run.conn.Prepare(stmtFeedbackRetry)
+	log.Println("Preparing: fb_retry")
+	abortOnError(err)
+
+	Eye.run.fb_list, err = Eye.run.conn.Prepare(stmtFeedbackList)
+	log.Println("Preparing: fb_list")
+	abortOnError(err)
+
+	if Eye.Auth.key != nil {
+		Eye.run.token, err = Eye.run.conn.Prepare(stmtSelectToken)
+		log.Println("Preparing: token")
+		abortOnError(err)
+	}
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
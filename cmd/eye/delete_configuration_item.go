@@ -53,11 +53,13 @@ func DeleteConfigurationItem(w http.ResponseWriter, r *http.Request, params http
 
 func deleteItem(itemID string) error {
 	var (
-		lookupID string
-		count    int
-		err      error
+		lookupID, lockedLookupID string
+		count                    int
+		err                      error
+		tx                       *sql.Tx
 	)
 
+lookupcheck:
 	if err = Eye.run.get_lookup.QueryRow(itemID).Scan(&lookupID); err == sql.ErrNoRows {
 		// not being able to delete what we do not have is ok
 		return nil
@@ -66,19 +68,49 @@ func deleteItem(itemID string) error {
 		return err
 	}
 
-	if _, err = Eye.run.delete_item.Exec(itemID); err != nil {
+	if tx, err = beginLookupChange(lookupID); err == sql.ErrNoRows {
+		goto lookupcheck
+	} else if err != nil {
 		return err
 	}
+	// the item could have been moved or deleted before the lock was
+	// taken
+	if err = tx.Stmt(Eye.run.get_lookup).QueryRow(itemID).Scan(&lockedLookupID); err == sql.ErrNoRows {
+		tx.Rollback()
+		return nil
+	} else if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if lockedLookupID != lookupID {
+		tx.Rollback()
+		goto lookupcheck
+	}
 
-	if err = Eye.run.item_count.QueryRow(lookupID).Scan(&count); err != nil {
+	if _, err = tx.Stmt(Eye.run.delete_item).Exec(itemID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err = tx.Stmt(Eye.run.ins_deletion).Exec(itemID, lookupID); err != nil {
+		tx.Rollback()
 		return err
 	}
 
-	if count != 0 {
-		return nil
+	if err = tx.Stmt(Eye.run.item_count).QueryRow(lookupID).Scan(&count); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if count == 0 {
+		if _, err = tx.Stmt(Eye.run.delete_lookup).Exec(lookupID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return err
 	}
-	_, err = Eye.run.delete_lookup.Exec(lookupID)
-	return err
+	notifyLookup(lookupID)
+	return nil
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
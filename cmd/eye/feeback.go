@@ -7,28 +7,225 @@
 package main
 
 import (
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"time"
 
+	"github.com/1and1/soma/lib/proto"
+	"github.com/julienschmidt/httprouter"
 	"gopkg.in/resty.v0"
 )
 
+const (
+	// feedbackMinBackoff is the delay before the first retry, it
+	// doubles with every failed attempt up to feedbackMaxBackoff
+	feedbackMinBackoff = 2 * time.Second
+	feedbackMaxBackoff = 10 * time.Minute
+	// feedbackMaxAge is the time after which undelivered feedback is
+	// discarded. SOMA's lifecycle handler will have rescheduled the
+	// deployment by then.
+	feedbackMaxAge = 24 * time.Hour
+)
+
+// FeedbackEntry is a deployment feedback waiting for delivery
+type FeedbackEntry struct {
+	DeploymentId string    `json:"deploymentId"`
+	Result       string    `json:"result"`
+	QueuedAt     time.Time `json:"queuedAt"`
+	Attempts     int       `json:"attempts"`
+	NextAttempt  time.Time `json:"nextAttempt"`
+	LastError    string    `json:"lastError,omitempty"`
+}
+
+// FeedbackList is the reply of the feedback status endpoint
+type FeedbackList struct {
+	Feedback []FeedbackEntry `json:"feedback"`
+}
+
 func Failed(id string) {
-	soma, _ := url.Parse(Eye.Soma.url.String())
-	soma.Path = fmt.Sprintf("/deployments/id/%s/failed", id)
-	client := resty.New().SetTimeout(500 * time.Millisecond)
-	log.Printf("Sending fail feedback for %s\n", id)
-	go client.R().Patch(soma.String())
+	log.Printf("Queueing fail feedback for %s\n", id)
+	enqueueFeedback(id, `failed`)
 }
 
 func Success(id string) {
+	log.Printf("Queueing success feedback for %s\n", id)
+	enqueueFeedback(id, `success`)
+}
+
+// enqueueFeedback persists the feedback in the outbox and wakes up
+// the delivery goroutine
+func enqueueFeedback(id, result string) {
+	if _, err := Eye.run.fb_enqueue.Exec(id, result); err != nil {
+		log.Printf("Failed to queue %s feedback for %s: %s\n",
+			result, id, err.Error())
+		return
+	}
+	select {
+	case Eye.run.fb_wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliverFeedback sends queued feedback to SOMA. Failed deliveries
+// are retried with exponential backoff.
+func deliverFeedback() {
+	ticker := time.NewTicker(time.Second).C
+
+	for {
+		select {
+		case <-ticker:
+		case <-Eye.run.fb_wake:
+		}
+		deliverDueFeedback()
+	}
+}
+
+func deliverDueFeedback() {
+	var (
+		err         error
+		rows        *sql.Rows
+		id, result  string
+		queuedAt    time.Time
+		attempts    int
+		pending     []FeedbackEntry
+		delivered   bool
+		deliveryErr error
+		backoff     time.Duration
+		nextAttempt time.Time
+	)
+
+	if rows, err = Eye.run.fb_due.Query(); err != nil {
+		log.Printf("Failed to read feedback outbox: %s\n", err.Error())
+		return
+	}
+	for rows.Next() {
+		if err = rows.Scan(&id, &result, &queuedAt, &attempts); err != nil {
+			log.Printf("Failed to read feedback outbox: %s\n", err.Error())
+			rows.Close()
+			return
+		}
+		pending = append(pending, FeedbackEntry{
+			DeploymentId: id,
+			Result:       result,
+			QueuedAt:     queuedAt,
+			Attempts:     attempts,
+		})
+	}
+	rows.Close()
+
+	for _, fb := range pending {
+		if delivered, deliveryErr = sendFeedback(fb.DeploymentId,
+			fb.Result); delivered {
+			Eye.run.fb_delete.Exec(fb.DeploymentId, fb.QueuedAt)
+			continue
+		}
+		if time.Since(fb.QueuedAt) > feedbackMaxAge {
+			log.Printf("Discarding %s feedback for %s after %d attempts: %s\n",
+				fb.Result, fb.DeploymentId, fb.Attempts+1,
+				deliveryErr.Error())
+			Eye.run.fb_delete.Exec(fb.DeploymentId, fb.QueuedAt)
+			continue
+		}
+
+		backoff = feedbackMaxBackoff
+		if fb.Attempts < 16 {
+			backoff = feedbackMinBackoff << uint(fb.Attempts)
+		}
+		if backoff > feedbackMaxBackoff {
+			backoff = feedbackMaxBackoff
+		}
+		nextAttempt = time.Now().UTC().Add(backoff)
+		log.Printf("Failed to deliver %s feedback for %s, retry at %s: %s\n",
+			fb.Result, fb.DeploymentId, nextAttempt.Format(time.RFC3339),
+			deliveryErr.Error())
+		Eye.run.fb_retry.Exec(fb.DeploymentId, fb.QueuedAt, nextAttempt,
+			deliveryErr.Error())
+	}
+}
+
+// sendFeedback delivers a single feedback to SOMA. It returns true
+// if the feedback does not have to be sent again.
+func sendFeedback(id, result string) (bool, error) {
+	var (
+		err  error
+		resp *resty.Response
+		res  proto.Result
+	)
 	soma, _ := url.Parse(Eye.Soma.url.String())
-	soma.Path = fmt.Sprintf("/deployments/id/%s/success", id)
-	client := resty.New().SetTimeout(500 * time.Millisecond)
-	log.Printf("Sending success feedback for %s\n", id)
-	go client.R().Patch(soma.String())
+	soma.Path = fmt.Sprintf("/deployments/id/%s/%s", id, result)
+	client := resty.New().SetTimeout(2 * time.Second)
+	log.Printf("Sending %s feedback for %s\n", result, id)
+
+	if resp, err = client.R().Patch(soma.String()); err != nil {
+		return false, err
+	}
+	if resp.StatusCode() > 299 {
+		return false, fmt.Errorf("%s", resp.Status())
+	}
+	if err = json.Unmarshal(resp.Body(), &res); err != nil {
+		return false, err
+	}
+	switch res.StatusCode {
+	case 200:
+		return true, nil
+	case 404:
+		// the check instance no longer exists
+		log.Printf("Dropping %s feedback for unknown deployment %s\n",
+			result, id)
+		return true, nil
+	}
+	if res.Errors != nil && len(*res.Errors) > 0 {
+		return false, fmt.Errorf("SOMA %d: %s", res.StatusCode,
+			(*res.Errors)[0])
+	}
+	return false, fmt.Errorf("SOMA %d: %s", res.StatusCode, res.StatusText)
+}
+
+// ListFeedback returns all feedback waiting for delivery
+func ListFeedback(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var (
+		err   error
+		rows  *sql.Rows
+		list  FeedbackList
+		jsonb []byte
+	)
+
+	if rows, err = Eye.run.fb_list.Query(); err != nil {
+		dispatchInternalServerError(&w, err.Error())
+		return
+	}
+	defer rows.Close()
+
+	list.Feedback = []FeedbackEntry{}
+	for rows.Next() {
+		fb := FeedbackEntry{}
+		if err = rows.Scan(
+			&fb.DeploymentId,
+			&fb.Result,
+			&fb.QueuedAt,
+			&fb.Attempts,
+			&fb.NextAttempt,
+			&fb.LastError,
+		); err != nil {
+			dispatchInternalServerError(&w, err.Error())
+			return
+		}
+		list.Feedback = append(list.Feedback, fb)
+	}
+	if err = rows.Err(); err != nil {
+		dispatchInternalServerError(&w, err.Error())
+		return
+	}
+
+	if jsonb, err = json.Marshal(list); err != nil {
+		dispatchInternalServerError(&w, err.Error())
+		return
+	}
+	dispatchJsonOK(&w, &jsonb)
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -25,20 +25,26 @@ import (
 )
 
 type NotifyMessage struct {
-	Uuid string `json:"uuid" valid:"uuidv4"`
-	Path string `json:"path" valid:"abspath"`
+	Uuid  string   `json:"uuid" valid:"uuidv4,optional"`
+	Uuids []string `json:"uuids" valid:"-"`
+	Path  string   `json:"path" valid:"abspath"`
 }
 
+// maxNotifyBatch is the largest number of deployments accepted
+// within a single notify message
+const maxNotifyBatch = 1024
+
+// notifyToken serializes the processing of notify batches. SOMA
+// backs off and retries while a batch is being processed.
+var notifyToken = make(chan struct{}, 1)
+
 func FetchConfigurationItems(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	var (
-		dec    *json.Decoder
-		msg    NotifyMessage
-		err    error
-		soma   *url.URL
-		client *resty.Client
-		resp   *resty.Response
-		res    proto.Result
-		ok     bool
+		dec  *json.Decoder
+		msg  NotifyMessage
+		err  error
+		ok   bool
+		code int
 	)
 	dec = json.NewDecoder(r.Body)
 	if err = dec.Decode(&msg); err != nil {
@@ -56,45 +62,110 @@ func FetchConfigurationItems(w http.ResponseWriter, r *http.Request, _ httproute
 		return
 	}
 
+	if len(msg.Uuids) > 0 {
+		fetchConfigurationBatch(&w, &msg)
+		return
+	}
+	if msg.Uuid == `` {
+		dispatchBadRequest(&w, `Notify event without deployment id`)
+		return
+	}
+
+	if code, err = fetchDeployment(msg.Path, msg.Uuid); err != nil {
+		switch code {
+		case http.StatusPreconditionFailed:
+			dispatchPrecondition(&w, err.Error())
+		case http.StatusGone:
+			dispatchGone(&w, err.Error())
+		case 422:
+			dispatchUnprocessable(&w, err.Error())
+		default:
+			dispatchInternalServerError(&w, err.Error())
+		}
+		Failed(msg.Uuid)
+		return
+	}
+	dispatchNoContent(&w)
+	Success(msg.Uuid)
+}
+
+// fetchConfigurationBatch processes all deployments of a batched
+// notify message. Individual results are reported to SOMA via the
+// deployment feedback, the reply only acknowledges the batch.
+func fetchConfigurationBatch(w *http.ResponseWriter, msg *NotifyMessage) {
+	if len(msg.Uuids) > maxNotifyBatch {
+		dispatchTooLarge(w, fmt.Sprintf(
+			"Notify batch of %d exceeds maximum size %d",
+			len(msg.Uuids), maxNotifyBatch))
+		return
+	}
+	for _, id := range msg.Uuids {
+		if !govalidator.IsUUIDv4(id) {
+			dispatchBadRequest(w, fmt.Sprintf(
+				"Invalid deployment id in notify batch: %s", id))
+			return
+		}
+	}
+
+	select {
+	case notifyToken <- struct{}{}:
+		defer func() { <-notifyToken }()
+	default:
+		dispatchUnavailable(w, `Busy processing notify batch`)
+		return
+	}
+
+	log.Printf("Processing notify batch of %d deployments\n", len(msg.Uuids))
+	for _, id := range msg.Uuids {
+		if _, err := fetchDeployment(msg.Path, id); err != nil {
+			Failed(id)
+			continue
+		}
+		Success(id)
+	}
+	dispatchNoContent(w)
+}
+
+// fetchDeployment retrieves the deployment id from SOMA and applies
+// it. On error the returned code is the matching HTTP status code.
+func fetchDeployment(path, id string) (int, error) {
+	var (
+		err    error
+		soma   *url.URL
+		client *resty.Client
+		resp   *resty.Response
+		res    proto.Result
+	)
+
 	soma, _ = url.Parse(Eye.Soma.url.String())
-	soma.Path = strings.Replace(fmt.Sprintf("%s/%s", msg.Path, msg.Uuid), `//`, `/`, -1)
+	soma.Path = strings.Replace(fmt.Sprintf("%s/%s", path, id), `//`, `/`, -1)
 	client = resty.New().SetTimeout(500 * time.Millisecond)
 	log.Printf("Fetching deployment: %s\n", soma.String())
 	if resp, err = client.R().Get(soma.String()); err != nil || resp.StatusCode() > 299 {
 		if err == nil {
-			err = fmt.Errorf(resp.Status())
+			err = fmt.Errorf("%s", resp.Status())
 		}
 		log.Printf("Failed to fetch deployment from SOMA: %s\n", err.Error())
-		dispatchPrecondition(&w, err.Error())
-		Failed(msg.Uuid)
-		return
+		return http.StatusPreconditionFailed, err
 	}
 	if err = json.Unmarshal(resp.Body(), &res); err != nil {
 		log.Printf("Error deserializing deployment: %s\n", err.Error())
-		dispatchUnprocessable(&w, err.Error())
-		Failed(msg.Uuid)
-		return
+		return 422, err
 	}
 	if res.StatusCode != 200 {
 		log.Printf("Error in fetched deployment, Statuscode %d\n", res.StatusCode)
-		dispatchGone(&w, err.Error())
-		Failed(msg.Uuid)
-		return
+		return http.StatusGone, fmt.Errorf("Fetched deployment has statuscode %d", res.StatusCode)
 	}
-	if len(*res.Deployments) != 1 {
-		log.Printf("Error, deployment contained wrong deployment count: %d\n", len(*res.Deployments))
-		dispatchPrecondition(&w, err.Error())
-		Failed(msg.Uuid)
-		return
+	if res.Deployments == nil || len(*res.Deployments) != 1 {
+		err = fmt.Errorf("Deployment contained wrong deployment count")
+		log.Printf("Error: %s\n", err.Error())
+		return http.StatusPreconditionFailed, err
 	}
 	if err = CheckUpdateOrInsertOrDelete(&(*res.Deployments)[0]); err != nil {
 		log.Printf("Error processing fetched deployment: %s\n", err.Error())
-		dispatchInternalServerError(&w, err.Error())
-		Failed(msg.Uuid)
-		return
+		return http.StatusInternalServerError, err
 	}
-	dispatchNoContent(&w)
-	Success(msg.Uuid)
+	return http.StatusNoContent, nil
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Written by Jörg Pernfuß <joerg.pernfuss@1und1.de>
+ * All rights reserved.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path"
+
+	"github.com/1and1/soma/lib/proto"
+	"github.com/nahanni/go-ucl"
+)
+
+// ItemizationRules describe how deployments are turned into
+// configuration items. The first rule whose metric pattern matches
+// the metric path of a deployment is applied. The target host lists
+// are used for all rules that do not set their own.
+type ItemizationRules struct {
+	TargetHostProperties   []string          `json:"targethost.properties"`
+	TargetDomainProperties []string          `json:"targethost.domain.properties"`
+	Rules                  []ItemizationRule `json:"rules"`
+}
+
+// ItemizationRule applies to all metrics matching the pattern Metric,
+// using the syntax of path.Match.
+//
+// The values of the SuffixAttributes service attributes are appended
+// to the metric, in order. They are required and also change the
+// lookup id of the item. TagAttributes and TagProperties are added as
+// name:value tags if present.
+//
+// The target host is the value of the first set system property in
+// TargetHostProperties, or the node name within the domain from the
+// first set system property in TargetDomainProperties, or the node
+// name.
+type ItemizationRule struct {
+	Metric                 string   `json:"metric"`
+	SuffixAttributes       []string `json:"suffix.attributes"`
+	TagAttributes          []string `json:"tag.attributes"`
+	TagProperties          []string `json:"tag.properties"`
+	TargetHostProperties   []string `json:"targethost.properties"`
+	TargetDomainProperties []string `json:"targethost.domain.properties"`
+}
+
+// defaultItemizationRules are used if no rules file is configured
+func defaultItemizationRules() *ItemizationRules {
+	rules := &ItemizationRules{
+		TargetHostProperties:   []string{`fqdn`},
+		TargetDomainProperties: []string{`dns_zone`},
+		Rules:                  []ItemizationRule{},
+	}
+	for _, metric := range []string{
+		`disk.write.per.second`,
+		`disk.read.per.second`,
+		`disk.free`,
+		`disk.usage.percent`,
+	} {
+		rules.Rules = append(rules.Rules, ItemizationRule{
+			Metric:           metric,
+			SuffixAttributes: []string{`filesystem`},
+		})
+	}
+	return rules
+}
+
+// loadItemizationRules reads the rules from UCL file fname
+func loadItemizationRules(fname string) (*ItemizationRules, error) {
+	if fname == `` {
+		log.Println(`Using default itemization rules`)
+		return defaultItemizationRules(), nil
+	}
+
+	file, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Loading itemization rules from %s", fname)
+
+	// take detour via JSON to load UCL into struct
+	parser := ucl.NewParser(bytes.NewBuffer(file))
+	uclData, err := parser.Ucl()
+	if err != nil {
+		return nil, fmt.Errorf("UCL error: %s", err.Error())
+	}
+	uclJson, err := json.Marshal(uclData)
+	if err != nil {
+		return nil, err
+	}
+	rules := &ItemizationRules{}
+	if err = json.Unmarshal(uclJson, rules); err != nil {
+		return nil, err
+	}
+	if err = rules.validate(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// validate checks the rules for invalid patterns and empty names
+func (r *ItemizationRules) validate() error {
+	if err := validateNames(`targethost.properties`,
+		r.TargetHostProperties); err != nil {
+		return err
+	}
+	if err := validateNames(`targethost.domain.properties`,
+		r.TargetDomainProperties); err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	for i, rule := range r.Rules {
+		if rule.Metric == `` {
+			return fmt.Errorf("Itemization rule %d has no metric", i)
+		}
+		if _, err := path.Match(rule.Metric, ``); err != nil {
+			return fmt.Errorf("Itemization rule %s: %s", rule.Metric,
+				err.Error())
+		}
+		if seen[rule.Metric] {
+			return fmt.Errorf("Duplicate itemization rule for %s",
+				rule.Metric)
+		}
+		seen[rule.Metric] = true
+
+		for name, list := range map[string][]string{
+			`suffix.attributes`:            rule.SuffixAttributes,
+			`tag.attributes`:               rule.TagAttributes,
+			`tag.properties`:               rule.TagProperties,
+			`targethost.properties`:        rule.TargetHostProperties,
+			`targethost.domain.properties`: rule.TargetDomainProperties,
+		} {
+			if err := validateNames(rule.Metric+`/`+name,
+				list); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateNames(field string, names []string) error {
+	for _, name := range names {
+		if name == `` {
+			return fmt.Errorf("Empty name in itemization rule %s", field)
+		}
+	}
+	return nil
+}
+
+// match returns the first rule matching metric, or nil
+func (r *ItemizationRules) match(metric string) *ItemizationRule {
+	for i := range r.Rules {
+		if ok, _ := path.Match(r.Rules[i].Metric, metric); ok {
+			return &r.Rules[i]
+		}
+	}
+	return nil
+}
+
+// targetHost computes the target host of deployment details using
+// rule, which may be nil
+func (r *ItemizationRules) targetHost(rule *ItemizationRule,
+	details *proto.Deployment) string {
+
+	hostProps, domainProps := r.TargetHostProperties,
+		r.TargetDomainProperties
+	if rule != nil && len(rule.TargetHostProperties) > 0 {
+		hostProps = rule.TargetHostProperties
+	}
+	if rule != nil && len(rule.TargetDomainProperties) > 0 {
+		domainProps = rule.TargetDomainProperties
+	}
+
+	for _, prop := range hostProps {
+		if v := GetSystemPropertyValue(details, prop); v != `` {
+			return v
+		}
+	}
+	for _, prop := range domainProps {
+		if v := GetSystemPropertyValue(details, prop); v != `` {
+			return fmt.Sprintf("%s.%s", details.Node.Name, v)
+		}
+	}
+	return details.Node.Name
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
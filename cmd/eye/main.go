@@ -66,6 +66,9 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	if Eye.run.rules, err = loadItemizationRules(Eye.Rules); err != nil {
+		log.Fatal(err)
+	}
 
 	/*
 	 * Construct listen address
@@ -92,6 +95,16 @@ func main() {
 		Eye.Daemon.url.Scheme = "http"
 	}
 
+	/*
+	 * Setup client authentication
+	 */
+	if err = Eye.Auth.setup(Eye.Soma.url); err != nil {
+		log.Fatal(err)
+	}
+	if Eye.Auth.ClientCA != `` && !Eye.Daemon.Tls {
+		log.Fatal("config/authentication/client.ca.file requires TLS")
+	}
+
 	/*
 	 * Initialize database
 	 */
@@ -110,27 +123,54 @@ func main() {
 	defer Eye.run.item_count.Close()
 	defer Eye.run.retrieve.Close()
 	defer Eye.run.update_item.Close()
+	defer Eye.run.ins_deletion.Close()
+	defer Eye.run.clr_deletion.Close()
+	defer Eye.run.get_revision.Close()
+	defer Eye.run.get_changed.Close()
+	defer Eye.run.get_deleted.Close()
+	defer Eye.run.get_pruned.Close()
+	defer Eye.run.prune.Close()
+	defer Eye.run.lock_lookup.Close()
+	defer Eye.run.fb_enqueue.Close()
+	defer Eye.run.fb_due.Close()
+	defer Eye.run.fb_delete.Close()
+	defer Eye.run.fb_retry.Close()
+	defer Eye.run.fb_list.Close()
+	if Eye.run.token != nil {
+		defer Eye.run.token.Close()
+	}
 	go pingDatabase()
+	go pruneDeletions()
+	Eye.run.fb_wake = make(chan struct{}, 1)
+	go deliverFeedback()
 
 	/*
 	 * Register http handlers
 	 */
 	router := httprouter.New()
-	router.GET("/api/v1/configuration/:lookup", RetrieveConfigurationItems)
-	router.GET("/api/v1/item/", ListConfigurationItems)
-	router.POST("/api/v1/item/", AddConfigurationItem)
-	router.GET("/api/v1/item/:item", GetConfigurationItem)
-	router.PUT("/api/v1/item/:item", UpdateConfigurationItem)
-	router.DELETE("/api/v1/item/:item", DeleteConfigurationItem)
-	router.POST("/api/v1/notify/", FetchConfigurationItems)
-	router.POST("/api/v1/notify", FetchConfigurationItems)
+	router.GET("/api/v1/configuration/:lookup", Authenticated(roleRead, RetrieveConfigurationItems))
+	router.GET("/api/v1/item/", Authenticated(roleRead, ListConfigurationItems))
+	router.POST("/api/v1/item/", Authenticated(roleWrite, AddConfigurationItem))
+	router.GET("/api/v1/item/:item", Authenticated(roleRead, GetConfigurationItem))
+	router.PUT("/api/v1/item/:item", Authenticated(roleWrite, UpdateConfigurationItem))
+	router.DELETE("/api/v1/item/:item", Authenticated(roleWrite, DeleteConfigurationItem))
+	router.GET("/api/v1/feedback/", Authenticated(roleRead, ListFeedback))
+	router.POST("/api/v1/notify/", NotifySource(FetchConfigurationItems))
+	router.POST("/api/v1/notify", NotifySource(FetchConfigurationItems))
 
 	if Eye.Daemon.Tls {
-		log.Fatal(http.ListenAndServeTLS(
-			Eye.Daemon.url.Host,
+		tlsConfig, err := Eye.Auth.tlsConfig()
+		if err != nil {
+			log.Fatal(err)
+		}
+		server := &http.Server{
+			Addr:      Eye.Daemon.url.Host,
+			Handler:   router,
+			TLSConfig: tlsConfig,
+		}
+		log.Fatal(server.ListenAndServeTLS(
 			Eye.Daemon.Cert,
-			Eye.Daemon.Key,
-			router))
+			Eye.Daemon.Key))
 	} else {
 		log.Fatal(http.ListenAndServe(Eye.Daemon.url.Host, router))
 	}
@@ -29,19 +29,70 @@ package main
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 )
 
+const (
+	// maxLookupWait is the longest time a long-poll request blocks
+	maxLookupWait = 300 * time.Second
+	// lookupRecheck is the interval at which blocked long-poll
+	// requests check the database for changes made by other eye
+	// instances
+	lookupRecheck = 5 * time.Second
+)
+
+// lookupWatch holds the channels that are closed when the items of
+// a lookup id change
+var lookupWatch = struct {
+	sync.Mutex
+	m map[string]chan struct{}
+}{m: map[string]chan struct{}{}}
+
+// watchLookup returns a channel that is closed on the next change to
+// lookup
+func watchLookup(lookup string) <-chan struct{} {
+	lookupWatch.Lock()
+	defer lookupWatch.Unlock()
+	if _, ok := lookupWatch.m[lookup]; !ok {
+		lookupWatch.m[lookup] = make(chan struct{})
+	}
+	return lookupWatch.m[lookup]
+}
+
+// notifyLookup wakes up all requests waiting for changes to lookup
+func notifyLookup(lookup string) {
+	lookupWatch.Lock()
+	defer lookupWatch.Unlock()
+	if ch, ok := lookupWatch.m[lookup]; ok {
+		close(ch)
+		delete(lookupWatch.m, lookup)
+	}
+}
+
+// RetrieveConfigurationItems returns the configuration items of a
+// lookup id. It supports conditional requests via If-None-Match, the
+// ETag is the revision of the lookup id. With since=revision only
+// changes after that revision are returned, unless the deletions
+// after it have already been pruned. With wait=seconds a
+// conditional or incremental request blocks until the lookup id
+// changes or the time has passed.
 func RetrieveConfigurationItems(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	var (
-		err            error
-		reply          ConfigurationData
-		jsonb          []byte
-		lookup, config string
-		rows           *sql.Rows
+		err             error
+		reply           ConfigurationData
+		jsonb           []byte
+		lookup          string
+		since, revision uint64
+		wait            time.Duration
+		incremental     bool
 	)
 
 	lookup = params.ByName("lookup")
@@ -53,40 +104,91 @@ func RetrieveConfigurationItems(w http.ResponseWriter, r *http.Request, params h
 		return
 	}
 
-	reply.Configurations = []ConfigurationItem{}
+	if s := r.URL.Query().Get(`since`); s != `` {
+		if since, err = strconv.ParseUint(s, 10, 64); err != nil {
+			dispatchBadRequest(&w, fmt.Sprintf("Invalid since revision: %s", s))
+			return
+		}
+		incremental = true
+	}
+	if s := r.URL.Query().Get(`wait`); s != `` {
+		var secs uint64
+		if secs, err = strconv.ParseUint(s, 10, 32); err != nil {
+			dispatchBadRequest(&w, fmt.Sprintf("Invalid wait duration: %s", s))
+			return
+		}
+		wait = time.Duration(secs) * time.Second
+		if wait > maxLookupWait {
+			wait = maxLookupWait
+		}
+	}
+	match := r.Header.Get(`If-None-Match`)
 
-	if rows, err = Eye.run.retrieve.Query(lookup); err != nil {
-		if err == sql.ErrNoRows {
-			dispatchNotFound(&w)
-		} else {
-			dispatchInternalServerError(&w, err.Error())
+	// unchanged reports if the client already has revision
+	unchanged := func(revision uint64) bool {
+		if incremental && revision <= since {
+			return true
 		}
-		return
+		return match != `` && etagMatch(match, lookupETag(revision))
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		if err = rows.Scan(&config); err != nil {
-			if err == sql.ErrNoRows {
-				dispatchNotFound(&w)
-			} else {
+	watch := watchLookup(lookup)
+	if revision, err = lookupRevision(lookup); err != nil {
+		dispatchInternalServerError(&w, err.Error())
+		return
+	}
+	if unchanged(revision) && wait > 0 {
+		deadline := time.After(wait)
+		recheck := time.NewTicker(lookupRecheck)
+		defer recheck.Stop()
+	poll:
+		for {
+			select {
+			case <-watch:
+				watch = watchLookup(lookup)
+			case <-recheck.C:
+			case <-deadline:
+				break poll
+			}
+			if revision, err = lookupRevision(lookup); err != nil {
 				dispatchInternalServerError(&w, err.Error())
+				return
+			}
+			if !unchanged(revision) {
+				break poll
 			}
-			return
 		}
-		c := ConfigurationItem{}
-		if err = json.Unmarshal([]byte(config), &c); err != nil {
+	}
+
+	w.Header().Set(`ETag`, lookupETag(revision))
+	if unchanged(revision) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	reply.Revision = revision
+	if incremental {
+		// deletions after since have been pruned
+		var pruned int64
+		if err = Eye.run.get_pruned.QueryRow(lookup).Scan(&pruned); err != nil {
 			dispatchInternalServerError(&w, err.Error())
 			return
 		}
-		reply.Configurations = append(reply.Configurations, c)
+		if since < uint64(pruned) {
+			incremental = false
+		}
 	}
-	if err = rows.Err(); err != nil {
-		if err == sql.ErrNoRows {
-			dispatchNotFound(&w)
-		} else {
-			dispatchInternalServerError(&w, err.Error())
+	reply.Full = !incremental
+	if incremental {
+		reply.Configurations, err = retrieveItems(Eye.run.get_changed, lookup, int64(since))
+		if err == nil {
+			reply.Deleted, err = retrieveDeletions(lookup, since)
 		}
+	} else {
+		reply.Configurations, err = retrieveItems(Eye.run.retrieve, lookup)
+	}
+	if err != nil {
+		dispatchInternalServerError(&w, err.Error())
 		return
 	}
 
@@ -98,4 +200,81 @@ func RetrieveConfigurationItems(w http.ResponseWriter, r *http.Request, params h
 	dispatchJsonOK(&w, &jsonb)
 }
 
+// lookupRevision returns the current revision of lookup
+func lookupRevision(lookup string) (uint64, error) {
+	var revision int64
+	if err := Eye.run.get_revision.QueryRow(lookup).Scan(&revision); err != nil {
+		return 0, err
+	}
+	return uint64(revision), nil
+}
+
+// lookupETag returns the ETag for revision
+func lookupETag(revision uint64) string {
+	return fmt.Sprintf("\"%d\"", revision)
+}
+
+// etagMatch checks if header, the value of an If-None-Match header,
+// matches etag
+func etagMatch(header, etag string) bool {
+	for _, tag := range strings.Split(header, `,`) {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), `W/`)
+		if tag == etag || tag == `*` {
+			return true
+		}
+	}
+	return false
+}
+
+// retrieveItems returns the configuration items selected by query
+func retrieveItems(query *sql.Stmt, args ...interface{}) ([]ConfigurationItem, error) {
+	var (
+		err    error
+		rows   *sql.Rows
+		config string
+	)
+	items := []ConfigurationItem{}
+
+	if rows, err = query.Query(args...); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = rows.Scan(&config); err != nil {
+			return nil, err
+		}
+		c := ConfigurationItem{}
+		if err = json.Unmarshal([]byte(config), &c); err != nil {
+			return nil, err
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
+
+// retrieveDeletions returns the IDs of all items removed from lookup
+// after revision since
+func retrieveDeletions(lookup string, since uint64) ([]string, error) {
+	var (
+		err  error
+		rows *sql.Rows
+		id   string
+	)
+	deleted := []string{}
+
+	if rows, err = Eye.run.get_deleted.Query(lookup, int64(since)); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, id)
+	}
+	return deleted, rows.Err()
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
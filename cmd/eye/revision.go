@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2016, 1&1 Internet SE
+ * All rights reserved.
+ */
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+const (
+	// deletionRetention is the time recorded deletions are kept for
+	// incremental requests
+	deletionRetention = 7 * 24 * time.Hour
+	// deletionPruneInterval is the interval at which expired
+	// deletions are pruned
+	deletionPruneInterval = time.Hour
+)
+
+// beginLookupChange starts a transaction and locks the lookup ids
+// in lookups. All changes to the items of a lookup id must be made
+// inside such a transaction, so that the revisions of a lookup id
+// are committed in order. sql.ErrNoRows is returned if one of the
+// lookup ids does not exist.
+func beginLookupChange(lookups ...string) (*sql.Tx, error) {
+	var (
+		err  error
+		tx   *sql.Tx
+		look string
+	)
+
+	// lock in a fixed order to avoid deadlocks between changes that
+	// move items in opposite directions
+	sorted := append([]string{}, lookups...)
+	sort.Strings(sorted)
+
+	if tx, err = Eye.run.conn.Begin(); err != nil {
+		return nil, err
+	}
+	lock := tx.Stmt(Eye.run.lock_lookup)
+	for i, lookup := range sorted {
+		if i > 0 && lookup == sorted[i-1] {
+			continue
+		}
+		if err = lock.QueryRow(lookup).Scan(&look); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+// pruneDeletions periodically removes recorded deletions older than
+// deletionRetention
+func pruneDeletions() {
+	ticker := time.NewTicker(deletionPruneInterval).C
+	retention := fmt.Sprintf("%d seconds",
+		int64(deletionRetention/time.Second))
+
+	for {
+		if _, err := Eye.run.prune.Exec(retention); err != nil {
+			log.Printf("Failed to prune deletions: %s\n", err.Error())
+		}
+		<-ticker
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
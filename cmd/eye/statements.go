@@ -43,9 +43,16 @@ WHERE NOT EXISTS (
       FROM   eye.configuration_items
       WHERE  configuration_item_id = $1::uuid);`
 
+// stmtUpdateConfigurationItem only assigns a new revision if the
+// item was changed
 const stmtUpdateConfigurationItem = `
 UPDATE eye.configuration_items
-SET    lookup_id = $2::varchar,
+SET    revision = CASE WHEN lookup_id = $2::varchar
+                        AND configuration = $3::jsonb
+                       THEN revision
+                       ELSE nextval('eye.configuration_revision')
+                  END,
+       lookup_id = $2::varchar,
        configuration = $3::jsonb
 WHERE  configuration_item_id = $1::uuid;`
 
@@ -81,4 +88,177 @@ SELECT configuration
 FROM   eye.configuration_items
 WHERE  lookup_id = $1::varchar;`
 
+// Every change to a configuration item assigns it a new revision
+// from eye.configuration_revision. Removing an item from a lookup id
+// records the removal with its own revision in
+// eye.configuration_deletions, so that clients can synchronize
+// incrementally.
+// Changes hold the row lock of their lookup id from before the new
+// revision is drawn until they commit, see stmtLockLookup. Otherwise
+// a change could commit with a lower revision than one that already
+// committed, and clients that have seen the higher revision would
+// never receive it.
+const stmtCreateRevisionSequence = `
+CREATE SEQUENCE IF NOT EXISTS eye.configuration_revision;`
+
+const stmtAddItemRevision = `
+ALTER TABLE eye.configuration_items
+ADD COLUMN IF NOT EXISTS revision bigint NOT NULL
+DEFAULT nextval('eye.configuration_revision');`
+
+const stmtCreateConfigurationDeletions = `
+CREATE TABLE IF NOT EXISTS eye.configuration_deletions (
+    configuration_item_id       uuid            NOT NULL,
+    lookup_id                   varchar(64)     NOT NULL,
+    revision                    bigint          NOT NULL DEFAULT nextval('eye.configuration_revision'),
+    PRIMARY KEY (configuration_item_id, lookup_id)
+);`
+
+const stmtAddDeletionTimestamp = `
+ALTER TABLE eye.configuration_deletions
+ADD COLUMN IF NOT EXISTS deleted_at timestamptz(3) NOT NULL
+DEFAULT NOW();`
+
+// eye.configuration_pruned holds per lookup id the highest revision
+// of the pruned deletions. Incremental requests for older revisions
+// can not be answered and receive all items instead.
+const stmtCreateConfigurationPruned = `
+CREATE TABLE IF NOT EXISTS eye.configuration_pruned (
+    lookup_id                   varchar(64)     PRIMARY KEY,
+    revision                    bigint          NOT NULL
+);`
+
+const stmtLockLookup = `
+SELECT lookup_id
+FROM   eye.configuration_lookup
+WHERE  lookup_id = $1::varchar
+FOR UPDATE;`
+
+const stmtInsertItemDeletion = `
+INSERT INTO eye.configuration_deletions (
+            configuration_item_id,
+            lookup_id)
+VALUES      ($1::uuid,
+             $2::varchar)
+ON CONFLICT (configuration_item_id, lookup_id) DO UPDATE
+SET         revision = nextval('eye.configuration_revision'),
+            deleted_at = NOW();`
+
+const stmtClearItemDeletion = `
+DELETE FROM eye.configuration_deletions
+WHERE       configuration_item_id = $1::uuid
+AND         lookup_id = $2::varchar;`
+
+const stmtGetLookupRevision = `
+SELECT COALESCE(MAX(revision), 0)
+FROM   ( SELECT revision
+         FROM   eye.configuration_items
+         WHERE  lookup_id = $1::varchar
+         UNION ALL
+         SELECT revision
+         FROM   eye.configuration_deletions
+         WHERE  lookup_id = $1::varchar ) r;`
+
+const stmtRetrieveChangedConfigurations = `
+SELECT configuration
+FROM   eye.configuration_items
+WHERE  lookup_id = $1::varchar
+AND    revision > $2::bigint;`
+
+const stmtRetrieveDeletedConfigurations = `
+SELECT configuration_item_id
+FROM   eye.configuration_deletions
+WHERE  lookup_id = $1::varchar
+AND    revision > $2::bigint;`
+
+const stmtGetPrunedRevision = `
+SELECT COALESCE(MAX(revision), 0)
+FROM   eye.configuration_pruned
+WHERE  lookup_id = $1::varchar;`
+
+const stmtPruneDeletions = `
+WITH pruned AS (
+     DELETE FROM eye.configuration_deletions
+     WHERE       deleted_at < NOW() - $1::interval
+     RETURNING   lookup_id,
+                 revision)
+INSERT INTO eye.configuration_pruned (
+            lookup_id,
+            revision)
+SELECT   lookup_id,
+         MAX(revision)
+FROM     pruned
+GROUP BY lookup_id
+ON CONFLICT (lookup_id) DO UPDATE
+SET      revision = GREATEST(eye.configuration_pruned.revision,
+                             EXCLUDED.revision);`
+
+// stmtCreateFeedbackOutbox creates the table holding deployment
+// feedback that has not yet been delivered to SOMA. There is only one
+// pending feedback per deployment, newer results replace older ones.
+const stmtCreateFeedbackOutbox = `
+CREATE TABLE IF NOT EXISTS eye.feedback_outbox (
+    deployment_id               uuid            PRIMARY KEY,
+    result                      varchar(16)     NOT NULL,
+    queued_at                   timestamptz(3)  NOT NULL DEFAULT NOW(),
+    attempts                    integer         NOT NULL DEFAULT 0,
+    next_attempt                timestamptz(3)  NOT NULL DEFAULT NOW(),
+    last_error                  text            NOT NULL DEFAULT ''
+);`
+
+const stmtFeedbackEnqueue = `
+INSERT INTO eye.feedback_outbox (
+            deployment_id,
+            result)
+VALUES      ($1::uuid,
+             $2::varchar)
+ON CONFLICT (deployment_id) DO UPDATE
+SET         result = EXCLUDED.result,
+            queued_at = NOW(),
+            attempts = 0,
+            next_attempt = NOW(),
+            last_error = '';`
+
+const stmtFeedbackDue = `
+SELECT   deployment_id,
+         result,
+         queued_at,
+         attempts
+FROM     eye.feedback_outbox
+WHERE    next_attempt <= NOW()
+ORDER BY next_attempt
+LIMIT    64;`
+
+const stmtFeedbackDelete = `
+DELETE FROM eye.feedback_outbox
+WHERE       deployment_id = $1::uuid
+AND         queued_at = $2::timestamptz;`
+
+const stmtFeedbackRetry = `
+UPDATE eye.feedback_outbox
+SET    attempts = attempts + 1,
+       next_attempt = $3::timestamptz,
+       last_error = $4::text
+WHERE  deployment_id = $1::uuid
+AND    queued_at = $2::timestamptz;`
+
+const stmtFeedbackList = `
+SELECT   deployment_id,
+         result,
+         queued_at,
+         attempts,
+         next_attempt,
+         last_error
+FROM     eye.feedback_outbox
+ORDER BY queued_at;`
+
+// stmtSelectToken reads SOMA issued tokens. It requires read access
+// to the SOMA token table auth.tokens.
+const stmtSelectToken = `
+SELECT salt,
+       valid_from,
+       valid_until
+FROM   auth.tokens
+WHERE  token = $1::varchar;`
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -27,6 +27,7 @@ OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package main
 
 import (
+	"database/sql"
 	"encoding/json"
 	"net/http"
 
@@ -86,9 +87,10 @@ func UpdateConfigurationItem(w http.ResponseWriter, r *http.Request, params http
 
 func updateItem(item *ConfigurationItem, lookupID string) error {
 	var (
-		itemID string
-		err    error
-		jsonb  []byte
+		itemID, oldLookupID, lockedLookupID string
+		err                                 error
+		jsonb                               []byte
+		tx                                  *sql.Tx
 	)
 
 	if jsonb, err = json.Marshal(item); err != nil {
@@ -104,12 +106,56 @@ func updateItem(item *ConfigurationItem, lookupID string) error {
 		panic(`Database corrupted`)
 	}
 
-	_, err = Eye.run.update_item.Exec(
+lookupcheck:
+	if err = Eye.run.get_lookup.QueryRow(itemID).Scan(&oldLookupID); err != nil {
+		return err
+	}
+	if err = ensureLookup(item, lookupID); err != nil {
+		return err
+	}
+	if tx, err = beginLookupChange(oldLookupID, lookupID); err == sql.ErrNoRows {
+		goto lookupcheck
+	} else if err != nil {
+		return err
+	}
+	// the item could have been moved before the lock was taken
+	if err = tx.Stmt(Eye.run.get_lookup).QueryRow(itemID).Scan(&lockedLookupID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if lockedLookupID != oldLookupID {
+		tx.Rollback()
+		goto lookupcheck
+	}
+
+	if _, err = tx.Stmt(Eye.run.update_item).Exec(
 		item.ConfigurationItemId.String(),
 		lookupID,
 		jsonb,
-	)
-	return err
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// the item was moved to a different lookup id
+	if oldLookupID != lookupID {
+		if _, err = tx.Stmt(Eye.run.ins_deletion).Exec(itemID, oldLookupID); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err = tx.Stmt(Eye.run.clr_deletion).Exec(itemID, lookupID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	if oldLookupID != lookupID {
+		notifyLookup(oldLookupID)
+	}
+	notifyLookup(lookupID)
+	return nil
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
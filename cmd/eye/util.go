@@ -33,6 +33,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/1and1/soma/lib/proto"
 	"github.com/asaskevich/govalidator"
@@ -49,10 +50,7 @@ func CalculateLookupId(id uint64, metric string) string {
 }
 
 func Itemize(details *proto.Deployment) (string, *ConfigurationItem, error) {
-	var (
-		fqdn, dns_zone string
-		err            error
-	)
+	var err error
 	lookupID := CalculateLookupId(details.Node.AssetId, details.Metric.Path)
 
 	item := &ConfigurationItem{
@@ -69,47 +67,40 @@ func Itemize(details *proto.Deployment) (string, *ConfigurationItem, error) {
 		return "", nil, err
 	}
 
-	switch item.Metric {
-	case `disk.write.per.second`:
-		fallthrough
-	case `disk.read.per.second`:
-		fallthrough
-	case `disk.free`:
-		fallthrough
-	case `disk.usage.percent`:
-		mpt := GetServiceAttributeValue(details, `filesystem`)
-		if mpt == `` {
-			return ``, nil, fmt.Errorf(`Disk metric is missing filesystem service attribute`)
+	rule := Eye.run.rules.match(item.Metric)
+	if rule != nil && len(rule.SuffixAttributes) > 0 {
+		suffix := []string{item.Metric}
+		for _, attr := range rule.SuffixAttributes {
+			val := GetServiceAttributeValue(details, attr)
+			if val == `` {
+				return ``, nil, fmt.Errorf("Metric %s is missing %s service attribute",
+					item.Metric, attr)
+			}
+			suffix = append(suffix, val)
 		}
-		item.Metric = fmt.Sprintf("%s:%s", item.Metric, mpt)
+		item.Metric = strings.Join(suffix, `:`)
 		// recalculate lookupID
 		lookupID = CalculateLookupId(details.Node.AssetId, item.Metric)
 	}
+	if rule != nil {
+		for _, attr := range rule.TagAttributes {
+			if val := GetServiceAttributeValue(details, attr); val != `` {
+				item.Tags = append(item.Tags, fmt.Sprintf("%s:%s", attr, val))
+			}
+		}
+		for _, prop := range rule.TagProperties {
+			if val := GetSystemPropertyValue(details, prop); val != `` {
+				item.Tags = append(item.Tags, fmt.Sprintf("%s:%s", prop, val))
+			}
+		}
+	}
 
 	// set oncall duty if available
 	if details.Oncall != nil && details.Oncall.Id != "" {
 		item.Oncall = fmt.Sprintf("%s (%s)", details.Oncall.Name, details.Oncall.Number)
 	}
 
-	// construct item.Metadata.Targethost with help of system properties
-	if details.Properties != nil {
-		for _, prop := range *details.Properties {
-			switch prop.Name {
-			case "fqdn":
-				fqdn = prop.Value
-			case "dns_zone":
-				dns_zone = prop.Value
-			}
-		}
-	}
-	switch {
-	case len(fqdn) > 0:
-		item.Metadata.Targethost = fqdn
-	case len(dns_zone) > 0:
-		item.Metadata.Targethost = fmt.Sprintf("%s.%s", details.Node.Name, dns_zone)
-	default:
-		item.Metadata.Targethost = details.Node.Name
-	}
+	item.Metadata.Targethost = Eye.run.rules.targetHost(rule, details)
 
 	// construct item.Metadata.Source
 	if details.Service != nil {
@@ -151,6 +142,18 @@ func GetServiceAttributeValue(details *proto.Deployment, attribute string) strin
 	return ``
 }
 
+func GetSystemPropertyValue(details *proto.Deployment, property string) string {
+	if details.Properties == nil {
+		return ``
+	}
+	for _, prop := range *details.Properties {
+		if prop.Name == property {
+			return prop.Value
+		}
+	}
+	return ``
+}
+
 func abortOnError(err error) {
 	if err != nil {
 		log.Fatal(err)
@@ -176,6 +179,19 @@ func dispatchBadRequest(w *http.ResponseWriter, err string) {
 	log.Println(err)
 }
 
+// 401
+func dispatchUnauthorized(w *http.ResponseWriter, err string) {
+	(*w).Header().Set("WWW-Authenticate", `Basic realm="eye"`)
+	http.Error(*w, err, http.StatusUnauthorized)
+	log.Println(err)
+}
+
+// 403
+func dispatchForbidden(w *http.ResponseWriter, err string) {
+	http.Error(*w, err, http.StatusForbidden)
+	log.Println(err)
+}
+
 // 404
 func dispatchNotFound(w *http.ResponseWriter) {
 	http.Error(*w, "No items found", http.StatusNotFound)
@@ -194,6 +210,12 @@ func dispatchPrecondition(w *http.ResponseWriter, err string) {
 	log.Println(err)
 }
 
+// 413
+func dispatchTooLarge(w *http.ResponseWriter, err string) {
+	http.Error(*w, err, http.StatusRequestEntityTooLarge)
+	log.Println(err)
+}
+
 // 422
 func dispatchUnprocessable(w *http.ResponseWriter, err string) {
 	http.Error(*w, err, 422)
@@ -209,4 +231,11 @@ func dispatchInternalServerError(w *http.ResponseWriter, err string) {
 	log.Println(err)
 }
 
+// 503
+func dispatchUnavailable(w *http.ResponseWriter, err string) {
+	(*w).Header().Set(`Retry-After`, `1`)
+	http.Error(*w, err, http.StatusServiceUnavailable)
+	log.Println(err)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1and1/soma/lib/proto"
+	"github.com/julienschmidt/httprouter"
+	"github.com/satori/go.uuid"
+)
+
+// auditBodyLimit is the number of request body bytes recorded in the
+// audit trail
+const auditBodyLimit = 64 * 1024
+
+// auditRecord collects the audit information of one request while
+// it is served
+type auditRecord struct {
+	sync.Mutex
+	entry  proto.AuditEntry
+	time   time.Time
+	denied bool
+}
+
+// auditRecords maps the AuditId parameter of running requests to
+// their record, IsAuthorized reports its verdicts here
+var auditRecords = struct {
+	sync.Mutex
+	m map[string]*auditRecord
+}{m: make(map[string]*auditRecord)}
+
+// auditResponseWriter records the status code sent to the client
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (a *auditResponseWriter) WriteHeader(code int) {
+	a.status = code
+	a.ResponseWriter.WriteHeader(code)
+}
+
+func (a *auditResponseWriter) Write(b []byte) (int, error) {
+	if a.status == 0 {
+		a.status = http.StatusOK
+	}
+	return a.ResponseWriter.Write(b)
+}
+
+// auditBody is a request body whose recorded prefix has already
+// been read from the original body
+type auditBody struct {
+	io.Reader
+	io.Closer
+}
+
+// Audit records the wrapped request in the audit trail. It must be
+// wrapped by BasicAuth, which provides the authenticated user.
+func Audit(h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request,
+		ps httprouter.Params) {
+		id := uuid.NewV4().String()
+		rec := &auditRecord{
+			time: time.Now().UTC(),
+			entry: proto.AuditEntry{
+				Id:            id,
+				User:          ps.ByName(`AuthenticatedUser`),
+				RemoteAddress: extractAddress(r.RemoteAddr),
+				Method:        r.Method,
+				Route:         auditRoute(r.URL.Path, ps),
+				Path:          r.URL.Path,
+				Permissions:   []string{},
+			},
+		}
+		if r.Body != nil {
+			// only the recorded prefix is buffered, the handler
+			// reads it followed by the untouched rest of the body
+			body, _ := ioutil.ReadAll(io.LimitReader(r.Body, auditBodyLimit))
+			r.Body = auditBody{
+				Reader: io.MultiReader(bytes.NewReader(body), r.Body),
+				Closer: r.Body,
+			}
+			rec.entry.Body = string(body)
+		}
+
+		auditRecords.Lock()
+		auditRecords.m[id] = rec
+		auditRecords.Unlock()
+
+		aw := &auditResponseWriter{ResponseWriter: w}
+		h(aw, r, append(ps, httprouter.Param{
+			Key:   `AuditId`,
+			Value: id,
+		}))
+
+		auditRecords.Lock()
+		delete(auditRecords.m, id)
+		auditRecords.Unlock()
+
+		rec.Lock()
+		rec.entry.StatusCode = aw.status
+		if rec.entry.StatusCode == 0 {
+			rec.entry.StatusCode = http.StatusOK
+		}
+		// there is no verdict if the handler did not check the
+		// authorization of the request
+		if len(rec.entry.Permissions) > 0 {
+			authorized := !rec.denied
+			rec.entry.Authorized = &authorized
+		}
+		rec.Unlock()
+		if handler, ok := handlerMap.Get(`audit_w`).(*auditWrite); ok {
+			handler.input <- rec
+		}
+	}
+}
+
+// auditAuthorization records the verdict of an authorization check
+// made while serving an audited request
+func auditAuthorization(ps httprouter.Params, action string, ok bool) {
+	auditRecords.Lock()
+	rec, found := auditRecords.m[ps.ByName(`AuditId`)]
+	auditRecords.Unlock()
+	if !found {
+		return
+	}
+
+	rec.Lock()
+	rec.entry.Permissions = append(rec.entry.Permissions, action)
+	if !ok {
+		rec.denied = true
+	}
+	rec.Unlock()
+}
+
+// auditRoute replaces the parameter values within path with the
+// parameter names of the route
+func auditRoute(path string, ps httprouter.Params) string {
+	segments := strings.Split(path, `/`)
+	replaced := make([]bool, len(segments))
+
+paramloop:
+	for _, p := range ps {
+		if p.Value == `` || p.Key == `AuthenticatedUser` {
+			continue
+		}
+		for i := range segments {
+			if !replaced[i] && segments[i] == p.Value {
+				segments[i] = `:` + p.Key
+				replaced[i] = true
+				continue paramloop
+			}
+		}
+	}
+	return strings.Join(segments, `/`)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
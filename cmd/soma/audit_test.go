@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestAuditBodyLimit(t *testing.T) {
+	writer := &auditWrite{input: make(chan *auditRecord, 1)}
+	handlerMap.Set(`audit_w`, writer)
+	defer handlerMap.Del(`audit_w`)
+
+	body := bytes.Repeat([]byte(`x`), 2*auditBodyLimit+1)
+	var received []byte
+	h := Audit(func(w http.ResponseWriter, r *http.Request,
+		_ httprouter.Params) {
+		received, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	req := httptest.NewRequest(`POST`, `/repository/`,
+		bytes.NewReader(body))
+	h(httptest.NewRecorder(), req, httprouter.Params{})
+
+	if !bytes.Equal(received, body) {
+		t.Errorf("Handler received %d bytes, expected %d",
+			len(received), len(body))
+	}
+	rec := <-writer.input
+	if len(rec.entry.Body) != auditBodyLimit {
+		t.Errorf("Recorded %d bytes, expected %d",
+			len(rec.entry.Body), auditBodyLimit)
+	}
+	if rec.entry.StatusCode != http.StatusAccepted {
+		t.Errorf("Recorded status %d", rec.entry.StatusCode)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
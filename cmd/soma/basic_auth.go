@@ -51,7 +51,7 @@ func BasicAuth(h httprouter.Handle) httprouter.Handle {
 		const basicAuthPrefix string = "Basic "
 
 		// if the supervisor is not available, no requests are accepted
-		if _, ok := handlerMap[`supervisor`]; !ok {
+		if handlerMap.Get(`supervisor`) == nil {
 			http.Error(w, `Authentication supervisor not available`,
 				http.StatusServiceUnavailable)
 			return
@@ -77,7 +77,7 @@ func BasicAuth(h httprouter.Handle) httprouter.Handle {
 				pair := bytes.SplitN(payload, []byte(":"), 2)
 				if len(pair) == 2 {
 					returnChannel := make(chan msg.Result)
-					super := handlerMap[`supervisor`].(*supervisor)
+					super := handlerMap.Get(`supervisor`).(*supervisor)
 					super.input <- msg.Request{
 						Type:   `supervisor`,
 						Action: `basic_auth`,
@@ -128,8 +128,15 @@ func (c *SomaConfig) readConfigFile(fname string) error {
 		c.LifeCycleTick = 60
 	}
 
-	if c.PokeBatchSize != 0 {
-		log.Println(`Configuration value notify.batch.size is deprecated and no longer has any effect.`)
+	if c.PokeBatchSize == 0 {
+		log.Println(`Setting default value for notify.batch.size: 1`)
+		c.PokeBatchSize = 1
+	}
+
+	// eye rejects larger batches
+	if c.PokeBatchSize > 1024 {
+		log.Println(`Limiting notify.batch.size to maximum value: 1024`)
+		c.PokeBatchSize = 1024
 	}
 
 	if c.PokeTimeout == 0 {
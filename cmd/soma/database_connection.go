@@ -3,6 +3,7 @@ package main
 import (
 	"database/sql"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/1and1/soma/internal/stmt"
@@ -10,15 +11,10 @@ import (
 	"github.com/lib/pq"
 )
 
-func connectToDatabase(appLog, errLog *log.Logger) {
-	var err error
-	var rows *sql.Rows
-	var schema string
-	var schemaVer int64
-
-	driver := "postgres"
-
-	connect := fmt.Sprintf("dbname='%s' user='%s' password='%s' host='%s' port='%s' sslmode='%s' connect_timeout='%s'",
+// databaseConnect returns the connect string for the configured
+// database
+func databaseConnect() string {
+	return fmt.Sprintf("dbname='%s' user='%s' password='%s' host='%s' port='%s' sslmode='%s' connect_timeout='%s'",
 		SomaCfg.Database.Name,
 		SomaCfg.Database.User,
 		SomaCfg.Database.Pass,
@@ -27,6 +23,17 @@ func connectToDatabase(appLog, errLog *log.Logger) {
 		SomaCfg.Database.TlsMode,
 		SomaCfg.Database.Timeout,
 	)
+}
+
+func connectToDatabase(appLog, errLog *log.Logger) {
+	var err error
+	var rows *sql.Rows
+	var schema string
+	var schemaVer int64
+
+	driver := "postgres"
+
+	connect := databaseConnect()
 
 	// enable handling of infinity timestamps
 	pq.EnableInfinityTs(NegTimeInf, PosTimeInf)
@@ -95,15 +102,7 @@ func connectToDatabase(appLog, errLog *log.Logger) {
 func newDatabaseConnection() (*sql.DB, error) {
 	driver := "postgres"
 
-	connect := fmt.Sprintf("dbname='%s' user='%s' password='%s' host='%s' port='%s' sslmode='%s' connect_timeout='%s'",
-		SomaCfg.Database.Name,
-		SomaCfg.Database.User,
-		SomaCfg.Database.Pass,
-		SomaCfg.Database.Host,
-		SomaCfg.Database.Port,
-		SomaCfg.Database.TlsMode,
-		SomaCfg.Database.Timeout,
-	)
+	connect := databaseConnect()
 
 	dbcon, err := sql.Open(driver, connect)
 	if err != nil {
@@ -125,6 +124,13 @@ func newDatabaseConnection() (*sql.DB, error) {
 	return dbcon, nil
 }
 
+// dbPing records the outcome of the most recent database ping
+var dbPing struct {
+	sync.RWMutex
+	last time.Time
+	err  error
+}
+
 func pingDatabase(errLog *log.Logger) {
 	ticker := time.NewTicker(time.Second).C
 
@@ -134,6 +140,10 @@ func pingDatabase(errLog *log.Logger) {
 		if err != nil {
 			errLog.Print(err)
 		}
+		dbPing.Lock()
+		dbPing.last = time.Now()
+		dbPing.err = err
+		dbPing.Unlock()
 	}
 }
 
@@ -0,0 +1,68 @@
+package main
+
+import "sync"
+
+// handlerRegistry is the lookup table for the running handlers.
+// Treekeepers are started, renamed and stopped at runtime, so every
+// access goes through its lock.
+type handlerRegistry struct {
+	sync.RWMutex
+	handlers map[string]interface{}
+}
+
+func newHandlerRegistry() *handlerRegistry {
+	return &handlerRegistry{
+		handlers: make(map[string]interface{}),
+	}
+}
+
+// Get returns the handler registered as name, or nil
+func (r *handlerRegistry) Get(name string) interface{} {
+	r.RLock()
+	defer r.RUnlock()
+	return r.handlers[name]
+}
+
+// Set registers h as name
+func (r *handlerRegistry) Set(name string, h interface{}) {
+	r.Lock()
+	defer r.Unlock()
+	r.handlers[name] = h
+}
+
+// Del removes the handler registered as name
+func (r *handlerRegistry) Del(name string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.handlers, name)
+}
+
+// Rename moves the handler registered as oldName to newName in one
+// step, so that it is always found under one of the two names
+func (r *handlerRegistry) Rename(oldName, newName string) {
+	r.Lock()
+	defer r.Unlock()
+	if h, ok := r.handlers[oldName]; ok {
+		delete(r.handlers, oldName)
+		r.handlers[newName] = h
+	}
+}
+
+// Range calls f for every registered handler until f returns false.
+// It iterates over a copy, f may register or remove handlers.
+func (r *handlerRegistry) Range(f func(name string, h interface{}) bool) {
+	r.RLock()
+	handlers := make(map[string]interface{}, len(r.handlers))
+	for name, h := range r.handlers {
+		handlers[name] = h
+	}
+	r.RUnlock()
+
+	for name, h := range handlers {
+		if !f(name, h) {
+			return
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestHandlerRegistryRename(t *testing.T) {
+	r := newHandlerRegistry()
+	tk := &treeKeeper{}
+	r.Set(`repository_old`, tk)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r.Set(fmt.Sprintf("handler_%d", i), i)
+			r.Range(func(name string, h interface{}) bool {
+				r.Get(name)
+				return true
+			})
+		}(i)
+	}
+	r.Rename(`repository_old`, `repository_new`)
+	wg.Wait()
+
+	if r.Get(`repository_old`) != nil {
+		t.Errorf("Old name still registered")
+	}
+	if h, ok := r.Get(`repository_new`).(*treeKeeper); !ok || h != tk {
+		t.Errorf("Handler not registered under new name")
+	}
+
+	// callbacks may remove handlers while ranging
+	r.Range(func(name string, _ interface{}) bool {
+		r.Del(name)
+		return true
+	})
+	count := 0
+	r.Range(func(string, interface{}) bool {
+		count++
+		return true
+	})
+	if count != 0 {
+		t.Errorf("Expected empty registry, found %d handlers", count)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -13,14 +13,14 @@ import (
 func ListAttribute(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`attributes_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["attributeReadHandler"].(*somaAttributeReadHandler)
+	handler := handlerMap.Get("attributeReadHandler").(*somaAttributeReadHandler)
 	handler.input <- somaAttributeRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -32,14 +32,14 @@ func ListAttribute(w http.ResponseWriter, r *http.Request,
 func ShowAttribute(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`attributes_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["attributeReadHandler"].(*somaAttributeReadHandler)
+	handler := handlerMap.Get("attributeReadHandler").(*somaAttributeReadHandler)
 	handler.input <- somaAttributeRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -56,7 +56,7 @@ func ShowAttribute(w http.ResponseWriter, r *http.Request,
 func AddAttribute(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`attributes_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -70,7 +70,7 @@ func AddAttribute(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["attributeWriteHandler"].(*somaAttributeWriteHandler)
+	handler := handlerMap.Get("attributeWriteHandler").(*somaAttributeWriteHandler)
 	handler.input <- somaAttributeRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -86,14 +86,14 @@ func AddAttribute(w http.ResponseWriter, r *http.Request,
 func DeleteAttribute(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`attributes_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["attributeWriteHandler"].(*somaAttributeWriteHandler)
+	handler := handlerMap.Get("attributeWriteHandler").(*somaAttributeWriteHandler)
 	handler.input <- somaAttributeRequest{
 		action: "delete",
 		reply:  returnChannel,
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/1and1/soma/internal/msg"
+	"github.com/1and1/soma/lib/proto"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+/* Read functions
+ */
+func SearchAudit(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+	if ok, _ := IsAuthorized(params,
+		`audit_search`, ``, ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	cReq := proto.NewAuditFilter()
+	err := DecodeJsonBody(r, &cReq)
+	if err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	if cReq.Filter == nil || cReq.Filter.Audit == nil {
+		DispatchBadRequest(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan msg.Result)
+	handler := handlerMap.Get(`audit_r`).(*auditRead)
+	handler.input <- msg.Request{
+		Type:       `audit`,
+		Action:     `search`,
+		Reply:      returnChannel,
+		RemoteAddr: extractAddress(r.RemoteAddr),
+		User:       params.ByName(`AuthenticatedUser`),
+		Search: msg.Filter{
+			Audit: *cReq.Filter.Audit,
+		},
+	}
+	result := <-returnChannel
+	SendMsgResult(&w, &result)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -59,7 +59,7 @@ func AuthenticationKex(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:   `supervisor`,
 		Action: `kex_init`,
@@ -120,7 +120,7 @@ func AuthenticationEncryptedData(w *http.ResponseWriter, r *http.Request,
 	io.ReadFull(r.Body, data)
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:   `supervisor`,
 		Action: action,
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"unicode/utf8"
 
@@ -18,7 +19,7 @@ func ListBucket(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["bucketReadHandler"].(*somaBucketReadHandler)
+	handler := handlerMap.Get("bucketReadHandler").(*somaBucketReadHandler)
 	handler.input <- somaBucketRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -55,7 +56,7 @@ func ShowBucket(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["bucketReadHandler"].(*somaBucketReadHandler)
+	handler := handlerMap.Get("bucketReadHandler").(*somaBucketReadHandler)
 	handler.input <- somaBucketRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -86,12 +87,19 @@ func AddBucket(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorized(params,
+		`buckets_create`, cReq.Bucket.RepositoryId, ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "bucket",
 		Action:      "create_bucket",
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Bucket: somaBucketRequest{
 			action: "add",
@@ -135,12 +143,19 @@ func AddPropertyToBucket(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorizedBucket(params,
+		`buckets_property_add`, params.ByName(`bucket`)); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "bucket",
 		Action:      fmt.Sprintf("add_%s_property_to_bucket", params.ByName("type")),
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Bucket: somaBucketRequest{
 			action: fmt.Sprintf("%s_property_new", params.ByName("type")),
@@ -166,8 +181,14 @@ func DeletePropertyFromBucket(w http.ResponseWriter, r *http.Request,
 		},
 	}
 
+	if ok, _ := IsAuthorizedBucket(params,
+		`buckets_property_delete`, params.ByName(`bucket`)); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: `bucket`,
 		Action: fmt.Sprintf("delete_%s_property_from_bucket",
@@ -184,6 +205,158 @@ func DeletePropertyFromBucket(w http.ResponseWriter, r *http.Request,
 	SendRepositoryReply(&w, &result)
 }
 
+func UpdatePropertyOnBucket(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.Request{}
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`bucket`) != cReq.Bucket.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched bucket ids: %s, %s",
+				params.ByName(`bucket`),
+				cReq.Bucket.Id))
+		return
+	case cReq.Bucket.Properties == nil || len(*cReq.Bucket.Properties) != 1:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Expected property count 1`))
+		return
+	case params.ByName(`type`) != (*cReq.Bucket.Properties)[0].Type:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched property types: %s, %s",
+				params.ByName(`type`),
+				(*cReq.Bucket.Properties)[0].Type))
+		return
+	}
+	(*cReq.Bucket.Properties)[0].SourceInstanceId = params.ByName(`source`)
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`buckets_property_update`, params.ByName(`bucket`)); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `bucket`,
+		Action: fmt.Sprintf("update_%s_property_on_bucket",
+			params.ByName(`type`)),
+		User:   params.ByName(`AuthenticatedUser`),
+		DryRun: cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:  returnChannel,
+		Bucket: somaBucketRequest{
+			action: fmt.Sprintf("%s_property_update",
+				params.ByName(`type`)),
+			Bucket: *cReq.Bucket,
+		},
+	}
+	result := <-returnChannel
+	SendBucketReply(&w, &result)
+}
+
+func DeleteBucket(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	// the request body is optional, a plain delete marks the
+	// bucket as deleted
+	cReq := proto.NewBucketRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil && err != io.EOF {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	action := `delete_bucket`
+	if cReq.Flags != nil && cReq.Flags.Purge {
+		action = `purge_bucket`
+	}
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`buckets_delete`, params.ByName(`bucket`)); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get("guidePost").(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `bucket`,
+		Action:      action,
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:       returnChannel,
+		Bucket: somaBucketRequest{
+			action: `delete`,
+			Bucket: proto.Bucket{
+				Id: params.ByName(`bucket`),
+			},
+		},
+	}
+	result := <-returnChannel
+	SendBucketReply(&w, &result)
+}
+
+func PatchBucket(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewBucketRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+
+	bucket := proto.Bucket{
+		Id: params.ByName(`bucket`),
+	}
+	var action string
+	switch {
+	case cReq.Flags != nil && cReq.Flags.Restore:
+		action = `restore_bucket`
+	case cReq.Flags != nil && cReq.Flags.Freeze:
+		action = `freeze_bucket`
+	case cReq.Flags != nil && cReq.Flags.Thaw:
+		action = `thaw_bucket`
+	case cReq.Bucket != nil && cReq.Bucket.Name != ``:
+		nameLen := utf8.RuneCountInString(cReq.Bucket.Name)
+		if nameLen < 4 || nameLen > 512 {
+			DispatchBadRequest(&w, fmt.Errorf(`Illegal bucket name length (4 < x <= 512)`))
+			return
+		}
+		action = `rename_bucket`
+		bucket.Name = cReq.Bucket.Name
+	default:
+		DispatchBadRequest(&w, fmt.Errorf(`Unknown bucket update request`))
+		return
+	}
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`buckets_update`, params.ByName(`bucket`)); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get("guidePost").(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `bucket`,
+		Action:      action,
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:       returnChannel,
+		Bucket: somaBucketRequest{
+			action: `update`,
+			Bucket: bucket,
+		},
+	}
+	result := <-returnChannel
+	SendBucketReply(&w, &result)
+}
+
 /*
  * Utility
  */
@@ -202,6 +375,7 @@ func SendBucketReply(w *http.ResponseWriter, r *somaResult) {
 			*result.Errors = append(*result.Errors, i.ResultError.Error())
 		}
 	}
+	r.MarkActions(&result)
 
 dispatch:
 	json, err := json.Marshal(result)
@@ -15,7 +15,7 @@ func ListCapability(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["capabilityReadHandler"].(*somaCapabilityReadHandler)
+	handler := handlerMap.Get("capabilityReadHandler").(*somaCapabilityReadHandler)
 	handler.input <- somaCapabilityRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -59,7 +59,7 @@ func ShowCapability(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["capabilityReadHandler"].(*somaCapabilityReadHandler)
+	handler := handlerMap.Get("capabilityReadHandler").(*somaCapabilityReadHandler)
 	handler.input <- somaCapabilityRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -85,7 +85,7 @@ func AddCapability(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["capabilityWriteHandler"].(*somaCapabilityWriteHandler)
+	handler := handlerMap.Get("capabilityWriteHandler").(*somaCapabilityWriteHandler)
 	handler.input <- somaCapabilityRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -105,7 +105,7 @@ func DeleteCapability(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["capabilityWriteHandler"].(*somaCapabilityWriteHandler)
+	handler := handlerMap.Get("capabilityWriteHandler").(*somaCapabilityWriteHandler)
 	handler.input <- somaCapabilityRequest{
 		action: "delete",
 		reply:  returnChannel,
@@ -13,14 +13,14 @@ import (
 func ListCategory(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`category_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `category`,
@@ -38,14 +38,14 @@ func ListCategory(w http.ResponseWriter, r *http.Request,
 func ShowCategory(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`category_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `category`,
@@ -69,7 +69,7 @@ func ShowCategory(w http.ResponseWriter, r *http.Request,
 func AddCategory(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`category_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -83,7 +83,7 @@ func AddCategory(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `category`,
@@ -104,14 +104,14 @@ func AddCategory(w http.ResponseWriter, r *http.Request,
 func DeleteCategory(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`category_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `category`,
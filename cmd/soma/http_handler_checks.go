@@ -18,7 +18,7 @@ func ListCheckConfiguration(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["checkConfigurationReadHandler"].(*somaCheckConfigurationReadHandler)
+	handler := handlerMap.Get("checkConfigurationReadHandler").(*somaCheckConfigurationReadHandler)
 	handler.input <- somaCheckConfigRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -56,7 +56,7 @@ func ShowCheckConfiguration(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["checkConfigurationReadHandler"].(*somaCheckConfigurationReadHandler)
+	handler := handlerMap.Get("checkConfigurationReadHandler").(*somaCheckConfigurationReadHandler)
 	handler.input <- somaCheckConfigRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -76,19 +76,31 @@ func AddCheckConfiguration(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 
-	cReq := proto.Request{}
+	cReq := proto.NewCheckConfigRequest()
 	if err := DecodeJsonBody(r, &cReq); err != nil {
 		DispatchBadRequest(&w, err)
 		return
 	}
+	if err := validateConstraintSpecs(
+		cReq.CheckConfig.Constraints); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
 	cReq.CheckConfig.Id = uuid.Nil.String()
 
+	if ok, _ := IsAuthorized(params,
+		`checks_create`, cReq.CheckConfig.RepositoryId, ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "check",
 		Action:      fmt.Sprintf("add_check_to_%s", cReq.CheckConfig.ObjectType),
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		CheckConfig: somaCheckConfigRequest{
 			action:      "check_configuration_new",
@@ -103,8 +115,14 @@ func DeleteCheckConfiguration(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 
+	if ok, _ := IsAuthorized(params,
+		`checks_delete`, params.ByName(`repository`), ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: `check`,
 		Action:      `remove_check`,
@@ -122,6 +140,58 @@ func DeleteCheckConfiguration(w http.ResponseWriter, r *http.Request,
 	SendCheckConfigurationReply(&w, &result)
 }
 
+func UpdateCheckConfiguration(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewCheckConfigRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`check`) != cReq.CheckConfig.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched check configuration ids: %s, %s",
+				params.ByName(`check`),
+				cReq.CheckConfig.Id))
+		return
+	case params.ByName(`repository`) != cReq.CheckConfig.RepositoryId:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched repository ids: %s, %s",
+				params.ByName(`repository`),
+				cReq.CheckConfig.RepositoryId))
+		return
+	}
+	if err := validateConstraintSpecs(
+		cReq.CheckConfig.Constraints); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+
+	if ok, _ := IsAuthorized(params,
+		`checks_update`, cReq.CheckConfig.RepositoryId, ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `check`,
+		Action:      `update_check`,
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:       returnChannel,
+		CheckConfig: somaCheckConfigRequest{
+			action:      `check_configuration_update`,
+			CheckConfig: *cReq.CheckConfig,
+		},
+	}
+	result := <-returnChannel
+	SendCheckConfigurationReply(&w, &result)
+}
+
 /* Utility
  */
 func SendCheckConfigurationReply(w *http.ResponseWriter, r *somaResult) {
@@ -139,6 +209,7 @@ func SendCheckConfigurationReply(w *http.ResponseWriter, r *somaResult) {
 			*result.Errors = append(*result.Errors, i.ResultError.Error())
 		}
 	}
+	r.MarkActions(&result)
 
 dispatch:
 	result.Clean()
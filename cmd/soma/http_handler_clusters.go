@@ -18,7 +18,7 @@ func ListCluster(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["clusterReadHandler"].(*somaClusterReadHandler)
+	handler := handlerMap.Get("clusterReadHandler").(*somaClusterReadHandler)
 	handler.input <- somaClusterRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -54,7 +54,7 @@ func ShowCluster(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["clusterReadHandler"].(*somaClusterReadHandler)
+	handler := handlerMap.Get("clusterReadHandler").(*somaClusterReadHandler)
 	handler.input <- somaClusterRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -71,7 +71,7 @@ func ListClusterMembers(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["clusterReadHandler"].(*somaClusterReadHandler)
+	handler := handlerMap.Get("clusterReadHandler").(*somaClusterReadHandler)
 	handler.input <- somaClusterRequest{
 		action: "member_list",
 		reply:  returnChannel,
@@ -102,12 +102,19 @@ func AddCluster(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorizedBucket(params,
+		`clusters_create`, cReq.Cluster.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "cluster",
 		Action:      "create_cluster",
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Cluster: somaClusterRequest{
 			action:  "add",
@@ -129,12 +136,19 @@ func AddMemberToCluster(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorizedBucket(params,
+		`clusters_member_add`, cReq.Cluster.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "cluster",
 		Action:      "add_node_to_cluster",
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Cluster: somaClusterRequest{
 			action:  "member",
@@ -178,12 +192,19 @@ func AddPropertyToCluster(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorizedBucket(params,
+		`clusters_property_add`, cReq.Cluster.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "cluster",
 		Action:      fmt.Sprintf("add_%s_property_to_cluster", params.ByName("type")),
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Cluster: somaClusterRequest{
 			action:  fmt.Sprintf("%s_property_new", params.ByName("type")),
@@ -227,14 +248,21 @@ func DeletePropertyFromCluster(w http.ResponseWriter, r *http.Request,
 		},
 	}
 
+	if ok, _ := IsAuthorizedBucket(params,
+		`clusters_property_delete`, cReq.Cluster.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap[`guidePost`].(*guidePost)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: `cluster`,
 		Action: fmt.Sprintf("delete_%s_property_from_cluster",
 			params.ByName(`type`)),
-		User:  params.ByName(`AuthenticatedUser`),
-		reply: returnChannel,
+		User:   params.ByName(`AuthenticatedUser`),
+		DryRun: cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:  returnChannel,
 		Cluster: somaClusterRequest{
 			action: fmt.Sprintf("%s_property_remove",
 				params.ByName(`type`)),
@@ -245,6 +273,218 @@ func DeletePropertyFromCluster(w http.ResponseWriter, r *http.Request,
 	SendClusterReply(&w, &result)
 }
 
+func UpdatePropertyOnCluster(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.Request{}
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`cluster`) != cReq.Cluster.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched cluster ids: %s, %s",
+				params.ByName(`cluster`),
+				cReq.Cluster.Id))
+		return
+	case cReq.Cluster.BucketId == ``:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Missing bucketId in cluster property update request`))
+		return
+	case cReq.Cluster.Properties == nil || len(*cReq.Cluster.Properties) != 1:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Expected property count 1`))
+		return
+	case params.ByName(`type`) != (*cReq.Cluster.Properties)[0].Type:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched property types: %s, %s",
+				params.ByName(`type`),
+				(*cReq.Cluster.Properties)[0].Type))
+		return
+	}
+	(*cReq.Cluster.Properties)[0].SourceInstanceId = params.ByName(`source`)
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`clusters_property_update`, cReq.Cluster.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `cluster`,
+		Action: fmt.Sprintf("update_%s_property_on_cluster",
+			params.ByName(`type`)),
+		User:   params.ByName(`AuthenticatedUser`),
+		DryRun: cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:  returnChannel,
+		Cluster: somaClusterRequest{
+			action: fmt.Sprintf("%s_property_update",
+				params.ByName(`type`)),
+			Cluster: *cReq.Cluster,
+		},
+	}
+	result := <-returnChannel
+	SendClusterReply(&w, &result)
+}
+
+func DeleteCluster(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewClusterRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`cluster`) != cReq.Cluster.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched cluster ids: %s, %s",
+				params.ByName(`cluster`),
+				cReq.Cluster.Id))
+		return
+	case cReq.Cluster.BucketId == ``:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Missing bucketId in cluster delete request`))
+		return
+	}
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`clusters_delete`, cReq.Cluster.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `cluster`,
+		Action:      `delete_cluster`,
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:       returnChannel,
+		Cluster: somaClusterRequest{
+			action: `delete`,
+			Cluster: proto.Cluster{
+				Id:       params.ByName(`cluster`),
+				BucketId: cReq.Cluster.BucketId,
+			},
+		},
+	}
+	result := <-returnChannel
+	SendClusterReply(&w, &result)
+}
+
+func PatchCluster(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewClusterRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`cluster`) != cReq.Cluster.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched cluster ids: %s, %s",
+				params.ByName(`cluster`),
+				cReq.Cluster.Id))
+		return
+	case cReq.Cluster.BucketId == ``:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Missing bucketId in cluster update request`))
+		return
+	}
+
+	nameLen := utf8.RuneCountInString(cReq.Cluster.Name)
+	if nameLen < 4 || nameLen > 256 {
+		DispatchBadRequest(&w, fmt.Errorf(`Illegal cluster name length (4 < x <= 256)`))
+		return
+	}
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`clusters_update`, cReq.Cluster.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `cluster`,
+		Action:      `rename_cluster`,
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:       returnChannel,
+		Cluster: somaClusterRequest{
+			action: `update`,
+			Cluster: proto.Cluster{
+				Id:       params.ByName(`cluster`),
+				Name:     cReq.Cluster.Name,
+				BucketId: cReq.Cluster.BucketId,
+			},
+		},
+	}
+	result := <-returnChannel
+	SendClusterReply(&w, &result)
+}
+
+func DeleteMemberFromCluster(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewClusterRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`cluster`) != cReq.Cluster.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched cluster ids: %s, %s",
+				params.ByName(`cluster`),
+				cReq.Cluster.Id))
+		return
+	case cReq.Cluster.BucketId == ``:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Missing bucketId in cluster member delete request`))
+		return
+	}
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`clusters_member_delete`, cReq.Cluster.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `cluster`,
+		Action:      `remove_node_from_cluster`,
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:       returnChannel,
+		Cluster: somaClusterRequest{
+			action: `member_remove`,
+			Cluster: proto.Cluster{
+				Id:       params.ByName(`cluster`),
+				BucketId: cReq.Cluster.BucketId,
+				Members: &[]proto.Node{
+					proto.Node{Id: params.ByName(`node`)},
+				},
+			},
+		},
+	}
+	result := <-returnChannel
+	SendClusterReply(&w, &result)
+}
+
 /*
  * Utility
  */
@@ -263,6 +503,7 @@ func SendClusterReply(w *http.ResponseWriter, r *somaResult) {
 			*result.Errors = append(*result.Errors, i.ResultError.Error())
 		}
 	}
+	r.MarkActions(&result)
 
 dispatch:
 	json, err := json.Marshal(result)
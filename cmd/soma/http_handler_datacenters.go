@@ -13,14 +13,14 @@ import (
 func ListDatacenters(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`datacenters_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["datacenterReadHandler"].(*somaDatacenterReadHandler)
+	handler := handlerMap.Get("datacenterReadHandler").(*somaDatacenterReadHandler)
 	handler.input <- somaDatacenterRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -32,14 +32,14 @@ func ListDatacenters(w http.ResponseWriter, r *http.Request,
 func SyncDatacenters(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`datacenters_sync`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["datacenterReadHandler"].(*somaDatacenterReadHandler)
+	handler := handlerMap.Get("datacenterReadHandler").(*somaDatacenterReadHandler)
 	handler.input <- somaDatacenterRequest{
 		action: `sync`,
 		reply:  returnChannel,
@@ -52,7 +52,7 @@ func ListDatacenterGroups(w http.ResponseWriter, r *http.Request, _ httprouter.P
 	/*
 		returnChannel := make(chan []somaDatacenterResult)
 
-		handler := handlerMap["datacenterReadHandler"].(somaDatacenterReadHandler)
+		handler := handlerMap.Get("datacenterReadHandler").(somaDatacenterReadHandler)
 		handler.input <- somaDatacenterRequest{
 			action: "grouplist",
 			reply:  returnChannel,
@@ -82,14 +82,14 @@ func ListDatacenterGroups(w http.ResponseWriter, r *http.Request, _ httprouter.P
 
 func ShowDatacenter(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`datacenters_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["datacenterReadHandler"].(*somaDatacenterReadHandler)
+	handler := handlerMap.Get("datacenterReadHandler").(*somaDatacenterReadHandler)
 	handler.input <- somaDatacenterRequest{
 		action: "show",
 		Datacenter: proto.Datacenter{
@@ -105,7 +105,7 @@ func ShowDatacenterGroup(w http.ResponseWriter, r *http.Request, params httprout
 	/*
 		returnChannel := make(chan []somaDatacenterResult)
 
-		handler := handlerMap["datacenterReadHandler"].(somaDatacenterReadHandler)
+		handler := handlerMap.Get("datacenterReadHandler").(somaDatacenterReadHandler)
 		handler.input <- somaDatacenterRequest{
 			action:     "groupshow",
 			datacenter: params.ByName("datacentergroup"),
@@ -143,7 +143,7 @@ func ShowDatacenterGroup(w http.ResponseWriter, r *http.Request, params httprout
 func AddDatacenter(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`datacenters_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -156,7 +156,7 @@ func AddDatacenter(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["datacenterWriteHandler"].(*somaDatacenterWriteHandler)
+	handler := handlerMap.Get("datacenterWriteHandler").(*somaDatacenterWriteHandler)
 	handler.input <- somaDatacenterRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -184,7 +184,7 @@ func AddDatacenterToGroup(w http.ResponseWriter, r *http.Request, params httprou
 			return
 		}
 
-		handler := handlerMap["datacenterWriteHandler"].(somaDatacenterWriteHandler)
+		handler := handlerMap.Get("datacenterWriteHandler").(somaDatacenterWriteHandler)
 		handler.input <- somaDatacenterRequest{
 			action:     "groupadd",
 			datacenter: clientRequest.Datacenter,
@@ -231,14 +231,14 @@ func AddDatacenterToGroup(w http.ResponseWriter, r *http.Request, params httprou
 
 func DeleteDatacenter(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`datacenters_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["datacenterWriteHandler"].(*somaDatacenterWriteHandler)
+	handler := handlerMap.Get("datacenterWriteHandler").(*somaDatacenterWriteHandler)
 	handler.input <- somaDatacenterRequest{
 		action: "delete",
 		reply:  returnChannel,
@@ -266,7 +266,7 @@ func DeleteDatacenterFromGroup(w http.ResponseWriter, r *http.Request, params ht
 			return
 		}
 
-		handler := handlerMap["datacenterWriteHandler"].(somaDatacenterWriteHandler)
+		handler := handlerMap.Get("datacenterWriteHandler").(somaDatacenterWriteHandler)
 		handler.input <- somaDatacenterRequest{
 			action:     "groupdel",
 			datacenter: clientRequest.Datacenter,
@@ -313,7 +313,7 @@ func DeleteDatacenterFromGroup(w http.ResponseWriter, r *http.Request, params ht
 
 func RenameDatacenter(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`datacenters_rename`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -326,7 +326,7 @@ func RenameDatacenter(w http.ResponseWriter, r *http.Request, params httprouter.
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["datacenterWriteHandler"].(*somaDatacenterWriteHandler)
+	handler := handlerMap.Get("datacenterWriteHandler").(*somaDatacenterWriteHandler)
 	handler.input <- somaDatacenterRequest{
 		action: "rename",
 		Datacenter: proto.Datacenter{
@@ -22,7 +22,7 @@ func DeliverDeploymentDetails(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["deploymentHandler"].(*somaDeploymentHandler)
+	handler := handlerMap.Get("deploymentHandler").(*somaDeploymentHandler)
 	handler.input <- somaDeploymentRequest{
 		action:     "get",
 		reply:      returnChannel,
@@ -49,7 +49,7 @@ func DeliverMonitoringDeployments(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["deploymentHandler"].(*somaDeploymentHandler)
+	handler := handlerMap.Get("deploymentHandler").(*somaDeploymentHandler)
 	handler.input <- somaDeploymentRequest{
 		action:     action,
 		reply:      returnChannel,
@@ -77,7 +77,7 @@ func UpdateDeploymentDetails(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["deploymentHandler"].(*somaDeploymentHandler)
+	handler := handlerMap.Get("deploymentHandler").(*somaDeploymentHandler)
 	handler.input <- somaDeploymentRequest{
 		action:     fmt.Sprintf("update/%s", params.ByName("result")),
 		reply:      returnChannel,
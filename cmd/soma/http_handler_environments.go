@@ -14,14 +14,14 @@ import (
 func ListEnvironments(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`environments_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 	returnChannel := make(chan []somaEnvironmentResult)
 
-	handler := handlerMap["environmentReadHandler"].(*somaEnvironmentReadHandler)
+	handler := handlerMap.Get("environmentReadHandler").(*somaEnvironmentReadHandler)
 	handler.input <- somaEnvironmentRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -54,14 +54,14 @@ dispatch:
 func ShowEnvironment(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`environments_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 	returnChannel := make(chan []somaEnvironmentResult)
 
-	handler := handlerMap["environmentReadHandler"].(*somaEnvironmentReadHandler)
+	handler := handlerMap.Get("environmentReadHandler").(*somaEnvironmentReadHandler)
 	handler.input <- somaEnvironmentRequest{
 		action:      "show",
 		environment: params.ByName("environment"),
@@ -99,7 +99,7 @@ dispatch:
 func AddEnvironment(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`environments_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -115,7 +115,7 @@ func AddEnvironment(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	handler := handlerMap["environmentWriteHandler"].(*somaEnvironmentWriteHandler)
+	handler := handlerMap.Get("environmentWriteHandler").(*somaEnvironmentWriteHandler)
 	handler.input <- somaEnvironmentRequest{
 		action:      "add",
 		environment: clientRequest.Environment.Name,
@@ -160,14 +160,14 @@ func AddEnvironment(w http.ResponseWriter, r *http.Request,
 func DeleteEnvironment(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`environments_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 	returnChannel := make(chan []somaEnvironmentResult)
 
-	handler := handlerMap["environmentWriteHandler"].(*somaEnvironmentWriteHandler)
+	handler := handlerMap.Get("environmentWriteHandler").(*somaEnvironmentWriteHandler)
 	handler.input <- somaEnvironmentRequest{
 		action:      "delete",
 		environment: params.ByName("environment"),
@@ -197,7 +197,7 @@ func DeleteEnvironment(w http.ResponseWriter, r *http.Request,
 func RenameEnvironment(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`environments_rename`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -213,7 +213,7 @@ func RenameEnvironment(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	handler := handlerMap["environmentWriteHandler"].(*somaEnvironmentWriteHandler)
+	handler := handlerMap.Get("environmentWriteHandler").(*somaEnvironmentWriteHandler)
 	handler.input <- somaEnvironmentRequest{
 		action:      "rename",
 		environment: params.ByName("environment"),
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/1and1/soma/lib/proto"
+	"github.com/julienschmidt/httprouter"
+)
+
+// eventKeepAlive is the interval in which comments are sent on idle
+// event streams
+const eventKeepAlive = 30 * time.Second
+
+// EventStream delivers job state changes and tree actions as
+// server-sent events. The stream can be filtered via the query
+// parameters type (job, action), repository, team and job.
+func EventStream(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+	var ok, admin bool
+	if ok, admin = IsAuthorized(params,
+		`events_subscribe`, ``, ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		DispatchInternalError(&w, fmt.Errorf(`Streaming unsupported`))
+		return
+	}
+	handler, ok := handlerMap.Get(`eventStream`).(*eventStream)
+	if !ok {
+		DispatchNotImplemented(&w, fmt.Errorf(`Event stream not available`))
+		return
+	}
+
+	query := r.URL.Query()
+	filter := eventFilter{
+		types:        map[string]bool{},
+		repositoryId: query.Get(`repository`),
+		teamId:       query.Get(`team`),
+		jobId:        query.Get(`job`),
+	}
+	if !admin {
+		filter.user = params.ByName(`AuthenticatedUser`)
+	}
+	for _, typ := range strings.Split(query.Get(`type`), `,`) {
+		switch typ {
+		case ``:
+		case `job`, `action`:
+			filter.types[typ] = true
+		default:
+			DispatchBadRequest(&w, fmt.Errorf(
+				"Unknown event type: %s", typ))
+			return
+		}
+	}
+
+	sub := &eventSubscription{
+		filter: filter,
+		events: make(chan proto.Event, 256),
+	}
+	if !handler.register(sub) {
+		DispatchNotImplemented(&w, fmt.Errorf(`Event stream not available`))
+		return
+	}
+
+	w.Header().Set(`Content-Type`, `text/event-stream`)
+	w.Header().Set(`Cache-Control`, `no-cache`)
+	w.Header().Set(`Connection`, `keep-alive`)
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepalive := time.NewTicker(eventKeepAlive)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			handler.deregister(sub)
+			return
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		case e, open := <-sub.events:
+			if !open {
+				// the stream was closed by the server
+				return
+			}
+			b, err := json.Marshal(e)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, b)
+			flusher.Flush()
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
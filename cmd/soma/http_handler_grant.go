@@ -28,7 +28,7 @@ func ListUserRepoRights(w http.ResponseWriter, r *http.Request,
 func SearchGrant(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`grant_search`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -37,7 +37,7 @@ func SearchGrant(w http.ResponseWriter, r *http.Request,
 	crq := proto.NewGrantFilter()
 	_ = DecodeJsonBody(r, &crq)
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	mr := msg.Request{
 		Type:       `supervisor`,
 		Action:     `right`,
@@ -65,7 +65,7 @@ func SearchGrant(w http.ResponseWriter, r *http.Request,
 func GrantGlobalRight(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`grant_global_right`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -82,7 +82,7 @@ func GrantGlobalRight(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `right`,
@@ -106,14 +106,14 @@ func GrantGlobalRight(w http.ResponseWriter, r *http.Request,
 func RevokeGlobalRight(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`revoke_global_right`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `right`,
@@ -138,14 +138,14 @@ func GrantLimitedRight(w http.ResponseWriter, r *http.Request,
 	scope := params.ByName(`scope`)
 	obj := params.ByName(`uuid`)
 	switch scope {
-	case `repository`:
+	case `repository`, `team`, `monitoring`:
 	default:
-		// only implement repository for now
+		// bucket, group and cluster scopes are not implemented
 		DispatchNotImplemented(&w, nil)
 		return
 	}
 
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`grant_limited_right`, obj, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -156,13 +156,15 @@ func GrantLimitedRight(w http.ResponseWriter, r *http.Request,
 	// check body is consistent with URI
 	if err != nil || crq.Grant.RecipientType != params.ByName(`rtyp`) ||
 		crq.Grant.RecipientId != params.ByName(`rid`) ||
-		crq.Grant.Category != `limited` {
+		crq.Grant.Category != `limited` ||
+		crq.Grant.ObjectType != scope ||
+		crq.Grant.ObjectId != obj {
 		DispatchBadRequest(&w, err)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `right`,
@@ -193,21 +195,21 @@ func RevokeLimitedRight(w http.ResponseWriter, r *http.Request,
 	scope := params.ByName(`scope`)
 	obj := params.ByName(`uuid`)
 	switch scope {
-	case `repository`:
+	case `repository`, `team`, `monitoring`:
 	default:
-		// only implement repository for now
+		// bucket, group and cluster scopes are not implemented
 		DispatchNotImplemented(&w, nil)
 		return
 	}
 
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`revoke_limited_right`, obj, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `right`,
@@ -218,6 +220,14 @@ func RevokeLimitedRight(w http.ResponseWriter, r *http.Request,
 			Action:  `revoke`,
 			GrantId: params.ByName(`grant`),
 		},
+		Grant: proto.Grant{
+			Id:            params.ByName(`grant`),
+			RecipientType: params.ByName(`rtyp`),
+			RecipientId:   params.ByName(`rid`),
+			Category:      `limited`,
+			ObjectType:    scope,
+			ObjectId:      obj,
+		},
 	}
 	result := <-returnChannel
 	SendMsgResult(&w, &result)
@@ -228,7 +238,7 @@ func RevokeLimitedRight(w http.ResponseWriter, r *http.Request,
 func GrantSystemRight(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`grant_system_right`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -245,7 +255,7 @@ func GrantSystemRight(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `right`,
@@ -269,14 +279,14 @@ func GrantSystemRight(w http.ResponseWriter, r *http.Request,
 func RevokeSystemRight(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`revoke_system_right`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `right`,
@@ -18,7 +18,7 @@ func ListGroup(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["groupReadHandler"].(*somaGroupReadHandler)
+	handler := handlerMap.Get("groupReadHandler").(*somaGroupReadHandler)
 	handler.input <- somaGroupRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -52,7 +52,7 @@ func ShowGroup(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["groupReadHandler"].(*somaGroupReadHandler)
+	handler := handlerMap.Get("groupReadHandler").(*somaGroupReadHandler)
 	handler.input <- somaGroupRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -69,7 +69,7 @@ func ListGroupMembers(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["groupReadHandler"].(*somaGroupReadHandler)
+	handler := handlerMap.Get("groupReadHandler").(*somaGroupReadHandler)
 	handler.input <- somaGroupRequest{
 		action: "member_list",
 		reply:  returnChannel,
@@ -100,12 +100,19 @@ func AddGroup(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorizedBucket(params,
+		`groups_create`, cReq.Group.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "group",
 		Action:      "create_group",
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Group: somaGroupRequest{
 			action: "add",
@@ -127,8 +134,14 @@ func AddMemberToGroup(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorizedBucket(params,
+		`groups_member_add`, cReq.Group.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	var rAct string
 	switch {
 	case len(*cReq.Group.MemberGroups) > 0:
@@ -142,6 +155,7 @@ func AddMemberToGroup(w http.ResponseWriter, r *http.Request,
 		RequestType: "group",
 		Action:      rAct,
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Group: somaGroupRequest{
 			action: "member",
@@ -185,12 +199,19 @@ func AddPropertyToGroup(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorizedBucket(params,
+		`groups_property_add`, cReq.Group.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "group",
 		Action:      fmt.Sprintf("add_%s_property_to_group", params.ByName("type")),
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Group: somaGroupRequest{
 			action: fmt.Sprintf("%s_property_new", params.ByName("type")),
@@ -234,14 +255,21 @@ func DeletePropertyFromGroup(w http.ResponseWriter, r *http.Request,
 		},
 	}
 
+	if ok, _ := IsAuthorizedBucket(params,
+		`groups_property_delete`, cReq.Group.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap[`guidePost`].(*guidePost)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: `group`,
 		Action: fmt.Sprintf("delete_%s_property_from_group",
 			params.ByName(`type`)),
-		User:  params.ByName(`AuthenticatedUser`),
-		reply: returnChannel,
+		User:   params.ByName(`AuthenticatedUser`),
+		DryRun: cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:  returnChannel,
 		Group: somaGroupRequest{
 			action: fmt.Sprintf("%s_property_remove",
 				params.ByName(`type`)),
@@ -252,6 +280,240 @@ func DeletePropertyFromGroup(w http.ResponseWriter, r *http.Request,
 	SendGroupReply(&w, &result)
 }
 
+func UpdatePropertyOnGroup(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.Request{}
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`group`) != cReq.Group.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched group ids: %s, %s",
+				params.ByName(`group`),
+				cReq.Group.Id))
+		return
+	case cReq.Group.BucketId == ``:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Missing bucketId in group property update request`))
+		return
+	case cReq.Group.Properties == nil || len(*cReq.Group.Properties) != 1:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Expected property count 1`))
+		return
+	case params.ByName(`type`) != (*cReq.Group.Properties)[0].Type:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched property types: %s, %s",
+				params.ByName(`type`),
+				(*cReq.Group.Properties)[0].Type))
+		return
+	}
+	(*cReq.Group.Properties)[0].SourceInstanceId = params.ByName(`source`)
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`groups_property_update`, cReq.Group.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `group`,
+		Action: fmt.Sprintf("update_%s_property_on_group",
+			params.ByName(`type`)),
+		User:   params.ByName(`AuthenticatedUser`),
+		DryRun: cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:  returnChannel,
+		Group: somaGroupRequest{
+			action: fmt.Sprintf("%s_property_update",
+				params.ByName(`type`)),
+			Group: *cReq.Group,
+		},
+	}
+	result := <-returnChannel
+	SendGroupReply(&w, &result)
+}
+
+func DeleteGroup(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewGroupRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`group`) != cReq.Group.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched group ids: %s, %s",
+				params.ByName(`group`),
+				cReq.Group.Id))
+		return
+	case cReq.Group.BucketId == ``:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Missing bucketId in group delete request`))
+		return
+	}
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`groups_delete`, cReq.Group.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `group`,
+		Action:      `delete_group`,
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:       returnChannel,
+		Group: somaGroupRequest{
+			action: `delete`,
+			Group: proto.Group{
+				Id:       params.ByName(`group`),
+				BucketId: cReq.Group.BucketId,
+			},
+		},
+	}
+	result := <-returnChannel
+	SendGroupReply(&w, &result)
+}
+
+func PatchGroup(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewGroupRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`group`) != cReq.Group.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched group ids: %s, %s",
+				params.ByName(`group`),
+				cReq.Group.Id))
+		return
+	case cReq.Group.BucketId == ``:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Missing bucketId in group update request`))
+		return
+	}
+
+	nameLen := utf8.RuneCountInString(cReq.Group.Name)
+	if nameLen < 4 || nameLen > 256 {
+		DispatchBadRequest(&w, fmt.Errorf(`Illegal group name length (4 < x <= 256)`))
+		return
+	}
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`groups_update`, cReq.Group.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `group`,
+		Action:      `rename_group`,
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:       returnChannel,
+		Group: somaGroupRequest{
+			action: `update`,
+			Group: proto.Group{
+				Id:       params.ByName(`group`),
+				Name:     cReq.Group.Name,
+				BucketId: cReq.Group.BucketId,
+			},
+		},
+	}
+	result := <-returnChannel
+	SendGroupReply(&w, &result)
+}
+
+func DeleteMemberFromGroup(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewGroupRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`group`) != cReq.Group.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched group ids: %s, %s",
+				params.ByName(`group`),
+				cReq.Group.Id))
+		return
+	case cReq.Group.BucketId == ``:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Missing bucketId in group member delete request`))
+		return
+	}
+
+	group := proto.Group{
+		Id:       params.ByName(`group`),
+		BucketId: cReq.Group.BucketId,
+	}
+	var rAct string
+	switch params.ByName(`type`) {
+	case `group`:
+		rAct = `remove_group_from_group`
+		group.MemberGroups = &[]proto.Group{
+			proto.Group{Id: params.ByName(`id`)},
+		}
+	case `cluster`:
+		rAct = `remove_cluster_from_group`
+		group.MemberClusters = &[]proto.Cluster{
+			proto.Cluster{Id: params.ByName(`id`)},
+		}
+	case `node`:
+		rAct = `remove_node_from_group`
+		group.MemberNodes = &[]proto.Node{
+			proto.Node{Id: params.ByName(`id`)},
+		}
+	default:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Unknown group member type: %s",
+				params.ByName(`type`)))
+		return
+	}
+
+	if ok, _ := IsAuthorizedBucket(params,
+		`groups_member_delete`, cReq.Group.BucketId); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `group`,
+		Action:      rAct,
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:       returnChannel,
+		Group: somaGroupRequest{
+			action: `member_remove`,
+			Group:  group,
+		},
+	}
+	result := <-returnChannel
+	SendGroupReply(&w, &result)
+}
+
 /*
  * Utility
  */
@@ -266,6 +528,7 @@ func SendGroupReply(w *http.ResponseWriter, r *somaResult) {
 			*result.Errors = append(*result.Errors, i.ResultError.Error())
 		}
 	}
+	r.MarkActions(&result)
 
 dispatch:
 	result.Clean()
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type healthReport struct {
+	Healthy            bool             `json:"healthy"`
+	Mode               string           `json:"mode"`
+	Version            string           `json:"version"`
+	ShutdownInProgress bool             `json:"shutdownInProgress"`
+	Database           healthDatabase   `json:"database"`
+	Supervisor         healthSupervisor `json:"supervisor"`
+	LifeCycle          healthLifeCycle  `json:"lifeCycle"`
+	TreeKeepers        []healthKeeper   `json:"treeKeepers"`
+}
+
+type healthDatabase struct {
+	Ok       bool   `json:"ok"`
+	LastPing string `json:"lastPing,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type healthSupervisor struct {
+	Started bool `json:"started"`
+}
+
+type healthLifeCycle struct {
+	LastTick   string  `json:"lastTick,omitempty"`
+	TickLagSec float64 `json:"tickLagSeconds"`
+}
+
+type healthKeeper struct {
+	Repository   string `json:"repository"`
+	RepositoryId string `json:"repositoryId"`
+	Ready        bool   `json:"ready"`
+	Broken       bool   `json:"broken"`
+	Frozen       bool   `json:"frozen"`
+	Stopped      bool   `json:"stopped"`
+}
+
+// Health reports the state of the database connection, the
+// supervisor, the lifecycle manager and all treekeepers. The
+// instance is reported as unhealthy with 503 Service Unavailable
+// while any repository is broken or still loading.
+func Health(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	defer PanicCatcher(w)
+
+	report := healthReport{
+		Mode:               `Master`,
+		Version:            SomaVersion,
+		ShutdownInProgress: ShutdownInProgress,
+		TreeKeepers:        []healthKeeper{},
+	}
+	switch {
+	case SomaCfg.Observer:
+		report.Mode = `Observer`
+	case SomaCfg.ReadOnly:
+		report.Mode = `ReadOnly`
+	}
+
+	dbPing.RLock()
+	if !dbPing.last.IsZero() {
+		report.Database.Ok = dbPing.err == nil
+		report.Database.LastPing = dbPing.last.UTC().Format(rfc3339Milli)
+		if dbPing.err != nil {
+			report.Database.Error = dbPing.err.Error()
+		}
+	}
+	dbPing.RUnlock()
+
+	// take a snapshot of the handlers, treekeepers are started and
+	// stopped at runtime
+	names := []string{}
+	keepers := map[string]*treeKeeper{}
+	sv, _ := handlerMap.Get(`supervisor`).(*supervisor)
+	lc, _ := handlerMap.Get(`lifeCycle`).(*lifeCycle)
+	handlerMap.Range(func(name string, h interface{}) bool {
+		if handler, ok := h.(*treeKeeper); ok {
+			names = append(names, name)
+			keepers[name] = handler
+		}
+		return true
+	})
+
+	if sv != nil {
+		report.Supervisor.Started = sv.isStarted()
+	}
+
+	// the lifecycle manager does not tick in observer mode
+	if lc != nil && !SomaCfg.Observer {
+		if lastTick := lc.lastTicked(); !lastTick.IsZero() {
+			report.LifeCycle.LastTick = lastTick.UTC().Format(
+				rfc3339Milli)
+			lag := time.Since(lastTick) -
+				time.Duration(SomaCfg.LifeCycleTick)*time.Second
+			if lag > 0 {
+				report.LifeCycle.TickLagSec = lag.Seconds()
+			}
+		}
+	}
+
+	report.Healthy = report.Database.Ok && report.Supervisor.Started &&
+		!report.ShutdownInProgress
+	sort.Strings(names)
+	for _, name := range names {
+		handler := keepers[name]
+		keeper := healthKeeper{
+			Repository:   handler.name(),
+			RepositoryId: handler.repoId,
+			Ready:        handler.isReady(),
+			Broken:       handler.isBroken(),
+			Frozen:       handler.isFrozen(),
+			Stopped:      handler.isStopped(),
+		}
+		// stopped treekeepers were shut down on purpose
+		if keeper.Broken || (!keeper.Ready && !keeper.Stopped) {
+			report.Healthy = false
+		}
+		report.TreeKeepers = append(report.TreeKeepers, keeper)
+	}
+
+	json, err := json.Marshal(report)
+	if err != nil {
+		DispatchInternalError(&w, err)
+		return
+	}
+	w.Header().Set(`Content-Type`, `application/json`)
+	w.Header().Set(`Cache-Control`, `no-cache`)
+	if report.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(json)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
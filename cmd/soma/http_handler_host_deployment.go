@@ -32,7 +32,7 @@ func GetHostDeployment(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["hostDeploymentHandler"].(*somaHostDeploymentHandler)
+	handler := handlerMap.Get("hostDeploymentHandler").(*somaHostDeploymentHandler)
 	handler.input <- somaHostDeploymentRequest{
 		action:  "get",
 		reply:   returnChannel,
@@ -72,7 +72,7 @@ func AssembleHostUpdate(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["hostDeploymentHandler"].(*somaHostDeploymentHandler)
+	handler := handlerMap.Get("hostDeploymentHandler").(*somaHostDeploymentHandler)
 	handler.input <- somaHostDeploymentRequest{
 		action:  "assemble",
 		reply:   returnChannel,
@@ -21,16 +21,14 @@ func InstanceShow(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 
-	/* repository scope is currently not checked
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
-		`instance_show`, ``, ``, ``); !ok {
+	if ok, _ := IsAuthorizedInstance(params,
+		`instance_show`, params.ByName(`instance`)); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
-	*/
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`instance_r`].(*instance)
+	handler := handlerMap.Get(`instance_r`).(*instance)
 	handler.input <- msg.Request{
 		Type:       `instance`,
 		Action:     `show`,
@@ -52,16 +50,14 @@ func InstanceVersions(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 
-	/* repository scope is currently not checked
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
-		`instance_show`, ``, ``, ``); !ok {
+	if ok, _ := IsAuthorizedInstance(params,
+		`instance_show`, params.ByName(`instance`)); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
-	*/
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`instance_r`].(*instance)
+	handler := handlerMap.Get(`instance_r`).(*instance)
 	handler.input <- msg.Request{
 		Type:       `instance`,
 		Action:     `versions`,
@@ -84,14 +80,6 @@ func InstanceList(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 
-	/* repository scope is currently not checked
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
-		`instance_list`, ``, ``, ``); !ok {
-		DispatchForbidden(&w, nil)
-		return
-	}
-	*/
-
 	listT := ``
 	switch {
 	case params.ByName(`repository`) != ``:
@@ -110,8 +98,22 @@ func InstanceList(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	var ok bool
+	switch listT {
+	case `repository`:
+		ok, _ = IsAuthorized(params,
+			`instance_list`, params.ByName(`repository`), ``, ``)
+	case `bucket`:
+		ok, _ = IsAuthorizedBucket(params,
+			`instance_list`, params.ByName(`bucket`))
+	}
+	if !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`instance_r`].(*instance)
+	handler := handlerMap.Get(`instance_r`).(*instance)
 	handler.input <- msg.Request{
 		Type:       `instance`,
 		Action:     `list`,
@@ -135,14 +137,14 @@ func InstanceListAll(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 
-	if ok, isAdmin := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, isAdmin := IsAuthorized(params,
 		`instance_list_all`, ``, ``, ``); !(ok && isAdmin) {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`instance_r`].(*instance)
+	handler := handlerMap.Get(`instance_r`).(*instance)
 	handler.input <- msg.Request{
 		Type:       `instance`,
 		Action:     `list_all`,
@@ -14,7 +14,7 @@ func JobDelay(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan bool)
-	handler := handlerMap[`jobDelay`].(*jobDelay)
+	handler := handlerMap.Get(`jobDelay`).(*jobDelay)
 	handler.input <- waitSpec{
 		JobId: params.ByName(`jobid`),
 		Reply: returnChannel,
@@ -30,14 +30,14 @@ func ListJobs(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 	var ok, admin bool
-	if ok, admin = IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, admin = IsAuthorized(params,
 		`jobs_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`jobs_r`].(*jobsRead)
+	handler := handlerMap.Get(`jobs_r`).(*jobsRead)
 	handler.input <- msg.Request{
 		Type:       `job`,
 		Action:     `list`,
@@ -54,14 +54,14 @@ func ShowJob(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 	var ok, admin bool
-	if ok, admin = IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, admin = IsAuthorized(params,
 		`jobs_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`jobs_r`].(*jobsRead)
+	handler := handlerMap.Get(`jobs_r`).(*jobsRead)
 	handler.input <- msg.Request{
 		Type:       `job`,
 		Action:     `show`,
@@ -79,7 +79,7 @@ func SearchJob(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 	var ok, admin bool
-	if ok, admin = IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, admin = IsAuthorized(params,
 		`jobs_search`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -93,7 +93,7 @@ func SearchJob(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`jobs_r`].(*jobsRead)
+	handler := handlerMap.Get(`jobs_r`).(*jobsRead)
 	handler.input <- msg.Request{
 		Type:       `job`,
 		Action:     `search/idlist`,
@@ -112,4 +112,31 @@ func SearchJob(w http.ResponseWriter, r *http.Request,
 	SendMsgResult(&w, &result)
 }
 
+/* Write functions
+ */
+func CancelJob(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+	var ok, admin bool
+	if ok, admin = IsAuthorized(params,
+		`jobs_cancel`, ``, ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan msg.Result)
+	handler := handlerMap.Get(`jobs_w`).(*jobsWrite)
+	handler.input <- msg.Request{
+		Type:       `job`,
+		Action:     `cancel`,
+		Reply:      returnChannel,
+		RemoteAddr: extractAddress(r.RemoteAddr),
+		User:       params.ByName(`AuthenticatedUser`),
+		IsAdmin:    admin,
+		Job:        proto.Job{Id: params.ByName(`jobid`)},
+	}
+	result := <-returnChannel
+	SendMsgResult(&w, &result)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
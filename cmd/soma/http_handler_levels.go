@@ -13,14 +13,14 @@ import (
 func ListLevel(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`levels_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["levelReadHandler"].(*somaLevelReadHandler)
+	handler := handlerMap.Get("levelReadHandler").(*somaLevelReadHandler)
 	handler.input <- somaLevelRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -52,14 +52,14 @@ skip:
 func ShowLevel(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`levels_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["levelReadHandler"].(*somaLevelReadHandler)
+	handler := handlerMap.Get("levelReadHandler").(*somaLevelReadHandler)
 	handler.input <- somaLevelRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -76,7 +76,7 @@ func ShowLevel(w http.ResponseWriter, r *http.Request,
 func AddLevel(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`levels_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -90,7 +90,7 @@ func AddLevel(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["levelWriteHandler"].(*somaLevelWriteHandler)
+	handler := handlerMap.Get("levelWriteHandler").(*somaLevelWriteHandler)
 	handler.input <- somaLevelRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -107,14 +107,14 @@ func AddLevel(w http.ResponseWriter, r *http.Request,
 func DeleteLevel(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`levels_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["levelWriteHandler"].(*somaLevelWriteHandler)
+	handler := handlerMap.Get("levelWriteHandler").(*somaLevelWriteHandler)
 	handler.input <- somaLevelRequest{
 		action: "delete",
 		reply:  returnChannel,
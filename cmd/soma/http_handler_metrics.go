@@ -13,14 +13,14 @@ import (
 func ListMetric(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`metrics_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["metricReadHandler"].(*somaMetricReadHandler)
+	handler := handlerMap.Get("metricReadHandler").(*somaMetricReadHandler)
 	handler.input <- somaMetricRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -32,14 +32,14 @@ func ListMetric(w http.ResponseWriter, r *http.Request,
 func ShowMetric(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`metrics_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["metricReadHandler"].(*somaMetricReadHandler)
+	handler := handlerMap.Get("metricReadHandler").(*somaMetricReadHandler)
 	handler.input <- somaMetricRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -56,7 +56,7 @@ func ShowMetric(w http.ResponseWriter, r *http.Request,
 func AddMetric(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`metrics_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -70,7 +70,7 @@ func AddMetric(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["metricWriteHandler"].(*somaMetricWriteHandler)
+	handler := handlerMap.Get("metricWriteHandler").(*somaMetricWriteHandler)
 	handler.input <- somaMetricRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -83,14 +83,14 @@ func AddMetric(w http.ResponseWriter, r *http.Request,
 func DeleteMetric(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`metrics_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["metricWriteHandler"].(*somaMetricWriteHandler)
+	handler := handlerMap.Get("metricWriteHandler").(*somaMetricWriteHandler)
 	handler.input <- somaMetricRequest{
 		action: "delete",
 		reply:  returnChannel,
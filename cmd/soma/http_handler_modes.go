@@ -13,14 +13,14 @@ import (
 func ListMode(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`modes_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["modeReadHandler"].(*somaModeReadHandler)
+	handler := handlerMap.Get("modeReadHandler").(*somaModeReadHandler)
 	handler.input <- somaModeRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -32,14 +32,14 @@ func ListMode(w http.ResponseWriter, r *http.Request,
 func ShowMode(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`modes_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["modeReadHandler"].(*somaModeReadHandler)
+	handler := handlerMap.Get("modeReadHandler").(*somaModeReadHandler)
 	handler.input <- somaModeRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -56,7 +56,7 @@ func ShowMode(w http.ResponseWriter, r *http.Request,
 func AddMode(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`modes_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -70,7 +70,7 @@ func AddMode(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["modeWriteHandler"].(*somaModeWriteHandler)
+	handler := handlerMap.Get("modeWriteHandler").(*somaModeWriteHandler)
 	handler.input <- somaModeRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -85,14 +85,14 @@ func AddMode(w http.ResponseWriter, r *http.Request,
 func DeleteMode(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`modes_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["modeWriteHandler"].(*somaModeWriteHandler)
+	handler := handlerMap.Get("modeWriteHandler").(*somaModeWriteHandler)
 	handler.input <- somaModeRequest{
 		action: "delete",
 		reply:  returnChannel,
@@ -16,14 +16,14 @@ func ListMonitoring(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 	var ok, admin bool
-	if ok, admin = IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, admin = IsAuthorized(params,
 		`monitoring_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["monitoringReadHandler"].(*somaMonitoringReadHandler)
+	handler := handlerMap.Get("monitoringReadHandler").(*somaMonitoringReadHandler)
 	handler.input <- somaMonitoringRequest{
 		action: "list",
 		admin:  admin,
@@ -56,14 +56,15 @@ skip:
 func ShowMonitoring(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
-		`monitoring_show`, ``, ``, ``); !ok {
+	if ok, _ := IsAuthorized(params,
+		`monitoring_show`, ``, params.ByName(`monitoring`),
+		``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["monitoringReadHandler"].(*somaMonitoringReadHandler)
+	handler := handlerMap.Get("monitoringReadHandler").(*somaMonitoringReadHandler)
 	handler.input <- somaMonitoringRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -80,7 +81,7 @@ func ShowMonitoring(w http.ResponseWriter, r *http.Request,
 func AddMonitoring(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`monitoring_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -98,7 +99,7 @@ func AddMonitoring(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["monitoringWriteHandler"].(*somaMonitoringWriteHandler)
+	handler := handlerMap.Get("monitoringWriteHandler").(*somaMonitoringWriteHandler)
 	handler.input <- somaMonitoringRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -117,14 +118,14 @@ func AddMonitoring(w http.ResponseWriter, r *http.Request,
 func DeleteMonitoring(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`monitoring_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["monitoringWriteHandler"].(*somaMonitoringWriteHandler)
+	handler := handlerMap.Get("monitoringWriteHandler").(*somaMonitoringWriteHandler)
 	handler.input <- somaMonitoringRequest{
 		action: "delete",
 		reply:  returnChannel,
@@ -16,7 +16,7 @@ func ListNode(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["nodeReadHandler"].(*somaNodeReadHandler)
+	handler := handlerMap.Get("nodeReadHandler").(*somaNodeReadHandler)
 	handler.input <- somaNodeRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -49,7 +49,7 @@ func ShowNode(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["nodeReadHandler"].(*somaNodeReadHandler)
+	handler := handlerMap.Get("nodeReadHandler").(*somaNodeReadHandler)
 	handler.input <- somaNodeRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -66,7 +66,7 @@ func ShowNodeConfig(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["nodeReadHandler"].(*somaNodeReadHandler)
+	handler := handlerMap.Get("nodeReadHandler").(*somaNodeReadHandler)
 	handler.input <- somaNodeRequest{
 		action: "get_config",
 		reply:  returnChannel,
@@ -81,14 +81,14 @@ func ShowNodeConfig(w http.ResponseWriter, r *http.Request,
 func SyncNode(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`node_sync`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["nodeReadHandler"].(*somaNodeReadHandler)
+	handler := handlerMap.Get("nodeReadHandler").(*somaNodeReadHandler)
 	handler.input <- somaNodeRequest{
 		action: `sync`,
 		reply:  returnChannel,
@@ -102,7 +102,7 @@ func SyncNode(w http.ResponseWriter, r *http.Request,
 func AddNode(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`node_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -116,7 +116,7 @@ func AddNode(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["nodeWriteHandler"].(*somaNodeWriteHandler)
+	handler := handlerMap.Get("nodeWriteHandler").(*somaNodeWriteHandler)
 	handler.input <- somaNodeRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -139,7 +139,7 @@ func AddNode(w http.ResponseWriter, r *http.Request,
 func UpdateNode(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`node_update`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -153,7 +153,7 @@ func UpdateNode(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["nodeWriteHandler"].(*somaNodeWriteHandler)
+	handler := handlerMap.Get("nodeWriteHandler").(*somaNodeWriteHandler)
 	handler.input <- somaNodeRequest{
 		action: `update`,
 		reply:  returnChannel,
@@ -181,12 +181,19 @@ func AssignNode(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorized(params,
+		`node_assign`, cReq.Node.Config.RepositoryId, ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "node",
 		Action:      "assign_node",
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Node: somaNodeRequest{
 			action: "assign",
@@ -197,10 +204,56 @@ func AssignNode(w http.ResponseWriter, r *http.Request,
 	SendNodeReply(&w, &result)
 }
 
+func RelocateNode(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewNodeRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	if cReq.Node.Id != params.ByName(`node`) {
+		DispatchBadRequest(&w, fmt.Errorf(
+			"Mismatched node ids: %s, %s",
+			params.ByName(`node`),
+			cReq.Node.Id))
+		return
+	}
+
+	// relocation writes to both the source and the target repository
+	if ok, _ := IsAuthorized(params,
+		`node_relocate`, ``, ``, cReq.Node.Id); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+	if ok, _ := IsAuthorized(params,
+		`node_relocate`, cReq.Node.Config.RepositoryId, ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get("guidePost").(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: "node",
+		Action:      "relocate_node",
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:       returnChannel,
+		Node: somaNodeRequest{
+			action: "relocate",
+			Node:   *cReq.Node,
+		},
+	}
+	result := <-returnChannel
+	SendNodeReply(&w, &result)
+}
+
 func DeleteNode(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`node_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -218,7 +271,7 @@ func DeleteNode(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["nodeWriteHandler"].(*somaNodeWriteHandler)
+	handler := handlerMap.Get("nodeWriteHandler").(*somaNodeWriteHandler)
 	handler.input <- somaNodeRequest{
 		action: action,
 		reply:  returnChannel,
@@ -263,12 +316,19 @@ func AddPropertyToNode(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorized(params,
+		`node_property_add`, ``, ``, params.ByName(`node`)); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "node",
 		Action:      fmt.Sprintf("add_%s_property_to_node", params.ByName("type")),
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Node: somaNodeRequest{
 			action: fmt.Sprintf("%s_property_new", params.ByName("type")),
@@ -324,14 +384,21 @@ func DeletePropertyFromNode(w http.ResponseWriter, r *http.Request,
 		},
 	}
 
+	if ok, _ := IsAuthorized(params,
+		`node_property_delete`, ``, ``, params.ByName(`node`)); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap[`guidePost`].(*guidePost)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: `node`,
 		Action: fmt.Sprintf("delete_%s_property_from_node",
 			params.ByName(`type`)),
-		User:  params.ByName(`AuthenticatedUser`),
-		reply: returnChannel,
+		User:   params.ByName(`AuthenticatedUser`),
+		DryRun: cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:  returnChannel,
 		Node: somaNodeRequest{
 			action: fmt.Sprintf("%s_property_remove",
 				params.ByName(`type`)),
@@ -342,6 +409,71 @@ func DeletePropertyFromNode(w http.ResponseWriter, r *http.Request,
 	SendNodeReply(&w, &result)
 }
 
+func UpdatePropertyOnNode(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewNodeRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`node`) != cReq.Node.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched node ids: %s, %s",
+				params.ByName(`node`),
+				cReq.Node.Id))
+		return
+	case cReq.Node.Config == nil:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Node configuration data missing`))
+		return
+	case cReq.Node.Properties == nil || len(*cReq.Node.Properties) != 1:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Expected property count 1`))
+		return
+	case params.ByName(`type`) != (*cReq.Node.Properties)[0].Type:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched property types: %s, %s",
+				params.ByName(`type`),
+				(*cReq.Node.Properties)[0].Type))
+		return
+	}
+	// outside switch: _after_ nil test
+	if cReq.Node.Config.RepositoryId == `` ||
+		cReq.Node.Config.BucketId == `` {
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Node configuration data incomplete`))
+		return
+	}
+	(*cReq.Node.Properties)[0].SourceInstanceId = params.ByName(`source`)
+
+	if ok, _ := IsAuthorized(params,
+		`node_property_update`, ``, ``, params.ByName(`node`)); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `node`,
+		Action: fmt.Sprintf("update_%s_property_on_node",
+			params.ByName(`type`)),
+		User:   params.ByName(`AuthenticatedUser`),
+		DryRun: cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:  returnChannel,
+		Node: somaNodeRequest{
+			action: fmt.Sprintf("%s_property_update",
+				params.ByName(`type`)),
+			Node: *cReq.Node,
+		},
+	}
+	result := <-returnChannel
+	SendNodeReply(&w, &result)
+}
+
 /* Utility
  */
 func SendNodeReply(w *http.ResponseWriter, r *somaResult) {
@@ -355,6 +487,7 @@ func SendNodeReply(w *http.ResponseWriter, r *somaResult) {
 			*result.Errors = append(*result.Errors, i.ResultError.Error())
 		}
 	}
+	r.MarkActions(&result)
 
 dispatch:
 	json, err := json.Marshal(result)
@@ -13,14 +13,14 @@ import (
 func ListObjectStates(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`states_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 	returnChannel := make(chan []somaObjectStateResult)
 
-	handler := handlerMap["objectStateReadHandler"].(*somaObjectStateReadHandler)
+	handler := handlerMap.Get("objectStateReadHandler").(*somaObjectStateReadHandler)
 	handler.input <- somaObjectStateRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -44,14 +44,14 @@ func ListObjectStates(w http.ResponseWriter, r *http.Request,
 func ShowObjectState(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`states_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 	returnChannel := make(chan []somaObjectStateResult)
 
-	handler := handlerMap["objectStateReadHandler"].(*somaObjectStateReadHandler)
+	handler := handlerMap.Get("objectStateReadHandler").(*somaObjectStateReadHandler)
 	handler.input <- somaObjectStateRequest{
 		action: "show",
 		state:  params.ByName("state"),
@@ -83,7 +83,7 @@ func ShowObjectState(w http.ResponseWriter, r *http.Request,
 func AddObjectState(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`states_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -99,7 +99,7 @@ func AddObjectState(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	handler := handlerMap["objectStateWriteHandler"].(*somaObjectStateWriteHandler)
+	handler := handlerMap.Get("objectStateWriteHandler").(*somaObjectStateWriteHandler)
 	handler.input <- somaObjectStateRequest{
 		action: "add",
 		state:  clientRequest.State.Name,
@@ -141,14 +141,14 @@ func AddObjectState(w http.ResponseWriter, r *http.Request,
 func DeleteObjectState(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`states_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 	returnChannel := make(chan []somaObjectStateResult)
 
-	handler := handlerMap["objectStateWriteHandler"].(*somaObjectStateWriteHandler)
+	handler := handlerMap.Get("objectStateWriteHandler").(*somaObjectStateWriteHandler)
 	handler.input <- somaObjectStateRequest{
 		action: "delete",
 		state:  params.ByName("state"),
@@ -190,7 +190,7 @@ func DeleteObjectState(w http.ResponseWriter, r *http.Request,
 func RenameObjectState(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`states_rename`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -206,7 +206,7 @@ func RenameObjectState(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	handler := handlerMap["objectStateWriteHandler"].(*somaObjectStateWriteHandler)
+	handler := handlerMap.Get("objectStateWriteHandler").(*somaObjectStateWriteHandler)
 	handler.input <- somaObjectStateRequest{
 		action: "rename",
 		state:  params.ByName("state"),
@@ -13,14 +13,14 @@ import (
 func ListObjectTypes(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`types_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 	returnChannel := make(chan []somaObjectTypeResult)
 
-	handler := handlerMap["objectTypeReadHandler"].(*somaObjectTypeReadHandler)
+	handler := handlerMap.Get("objectTypeReadHandler").(*somaObjectTypeReadHandler)
 	handler.input <- somaObjectTypeRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -44,14 +44,14 @@ func ListObjectTypes(w http.ResponseWriter, r *http.Request,
 func ShowObjectType(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`types_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 	returnChannel := make(chan []somaObjectTypeResult)
 
-	handler := handlerMap["objectTypeReadHandler"].(*somaObjectTypeReadHandler)
+	handler := handlerMap.Get("objectTypeReadHandler").(*somaObjectTypeReadHandler)
 	handler.input <- somaObjectTypeRequest{
 		action:     "show",
 		objectType: params.ByName("objectType"),
@@ -84,7 +84,7 @@ func ShowObjectType(w http.ResponseWriter, r *http.Request,
 func AddObjectType(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`types_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -100,7 +100,7 @@ func AddObjectType(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	handler := handlerMap["objectTypeWriteHandler"].(*somaObjectTypeWriteHandler)
+	handler := handlerMap.Get("objectTypeWriteHandler").(*somaObjectTypeWriteHandler)
 	handler.input <- somaObjectTypeRequest{
 		action:     "add",
 		objectType: clientRequest.Entity.Name,
@@ -142,14 +142,14 @@ func AddObjectType(w http.ResponseWriter, r *http.Request,
 func DeleteObjectType(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`types_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 	returnChannel := make(chan []somaObjectTypeResult)
 
-	handler := handlerMap["objectTypeWriteHandler"].(*somaObjectTypeWriteHandler)
+	handler := handlerMap.Get("objectTypeWriteHandler").(*somaObjectTypeWriteHandler)
 	handler.input <- somaObjectTypeRequest{
 		action:     "delete",
 		objectType: params.ByName("objectType"),
@@ -190,7 +190,7 @@ func DeleteObjectType(w http.ResponseWriter, r *http.Request,
 
 func RenameObjectType(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`types_rename`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -206,7 +206,7 @@ func RenameObjectType(w http.ResponseWriter, r *http.Request, params httprouter.
 		return
 	}
 
-	handler := handlerMap["objectTypeWriteHandler"].(*somaObjectTypeWriteHandler)
+	handler := handlerMap.Get("objectTypeWriteHandler").(*somaObjectTypeWriteHandler)
 	handler.input <- somaObjectTypeRequest{
 		action:     "rename",
 		objectType: params.ByName("objectType"),
@@ -13,14 +13,14 @@ import (
 func ListOncall(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`oncall_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["oncallReadHandler"].(*somaOncallReadHandler)
+	handler := handlerMap.Get("oncallReadHandler").(*somaOncallReadHandler)
 	handler.input <- somaOncallRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -51,14 +51,14 @@ skip:
 func ShowOncall(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`oncall_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["oncallReadHandler"].(*somaOncallReadHandler)
+	handler := handlerMap.Get("oncallReadHandler").(*somaOncallReadHandler)
 	handler.input <- somaOncallRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -75,7 +75,7 @@ func ShowOncall(w http.ResponseWriter, r *http.Request,
 func AddOncall(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`oncall_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -89,7 +89,7 @@ func AddOncall(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["oncallWriteHandler"].(*somaOncallWriteHandler)
+	handler := handlerMap.Get("oncallWriteHandler").(*somaOncallWriteHandler)
 	handler.input <- somaOncallRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -105,7 +105,7 @@ func AddOncall(w http.ResponseWriter, r *http.Request,
 func UpdateOncall(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`oncall_update`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -119,7 +119,7 @@ func UpdateOncall(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["oncallWriteHandler"].(*somaOncallWriteHandler)
+	handler := handlerMap.Get("oncallWriteHandler").(*somaOncallWriteHandler)
 	handler.input <- somaOncallRequest{
 		action: "update",
 		reply:  returnChannel,
@@ -136,14 +136,14 @@ func UpdateOncall(w http.ResponseWriter, r *http.Request,
 func DeleteOncall(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`oncall_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["oncallWriteHandler"].(*somaOncallWriteHandler)
+	handler := handlerMap.Get("oncallWriteHandler").(*somaOncallWriteHandler)
 	handler.input <- somaOncallRequest{
 		action: "delete",
 		reply:  returnChannel,
@@ -13,14 +13,14 @@ import (
 func ListPermission(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`permission_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `permission`,
@@ -38,14 +38,14 @@ func ListPermission(w http.ResponseWriter, r *http.Request,
 func ShowPermission(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`permission_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `permission`,
@@ -66,7 +66,7 @@ func ShowPermission(w http.ResponseWriter, r *http.Request,
 func SearchPermission(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`permission_search`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -75,7 +75,7 @@ func SearchPermission(w http.ResponseWriter, r *http.Request,
 	crq := proto.NewPermissionFilter()
 	_ = DecodeJsonBody(r, &crq)
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	mr := msg.Request{
 		Type:       `supervisor`,
 		Action:     `permission`,
@@ -101,7 +101,7 @@ func SearchPermission(w http.ResponseWriter, r *http.Request,
 func AddPermission(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`permission_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -115,7 +115,7 @@ func AddPermission(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `permission`,
@@ -138,14 +138,14 @@ func AddPermission(w http.ResponseWriter, r *http.Request,
 func DeletePermission(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`permission_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`supervisor`].(*supervisor)
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:       `supervisor`,
 		Action:     `permission`,
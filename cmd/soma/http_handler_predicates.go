@@ -13,14 +13,14 @@ import (
 func ListPredicate(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`predicates_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["predicateReadHandler"].(*somaPredicateReadHandler)
+	handler := handlerMap.Get("predicateReadHandler").(*somaPredicateReadHandler)
 	handler.input <- somaPredicateRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -32,14 +32,14 @@ func ListPredicate(w http.ResponseWriter, r *http.Request,
 func ShowPredicate(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`predicates_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["predicateReadHandler"].(*somaPredicateReadHandler)
+	handler := handlerMap.Get("predicateReadHandler").(*somaPredicateReadHandler)
 	handler.input <- somaPredicateRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -56,7 +56,7 @@ func ShowPredicate(w http.ResponseWriter, r *http.Request,
 func AddPredicate(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`predicates_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -70,7 +70,7 @@ func AddPredicate(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["predicateWriteHandler"].(*somaPredicateWriteHandler)
+	handler := handlerMap.Get("predicateWriteHandler").(*somaPredicateWriteHandler)
 	handler.input <- somaPredicateRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -85,14 +85,14 @@ func AddPredicate(w http.ResponseWriter, r *http.Request,
 func DeletePredicate(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`predicates_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["predicateWriteHandler"].(*somaPredicateWriteHandler)
+	handler := handlerMap.Get("predicateWriteHandler").(*somaPredicateWriteHandler)
 	handler.input <- somaPredicateRequest{
 		action: "delete",
 		reply:  returnChannel,
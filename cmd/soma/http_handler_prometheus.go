@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/1and1/soma/internal/msg"
+	"github.com/julienschmidt/httprouter"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// characters not allowed in prometheus metric names
+var promIllegal = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+
+// quantiles exported for histograms and timers
+var promQuantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999}
+
+// PrometheusMetrics renders the metrics registries, the handler
+// queue depths and the workflow summary in the prometheus text
+// exposition format
+func PrometheusMetrics(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	if ok, _ := IsAuthorized(params,
+		`runtime_metrics`, ``, ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	registries := []string{}
+	for name := range Metrics {
+		registries = append(registries, name)
+	}
+	sort.Strings(registries)
+	for _, name := range registries {
+		promRegistry(buf, Metrics[name])
+	}
+	promQueues(buf)
+	promWorkflow(buf, extractAddress(r.RemoteAddr),
+		params.ByName(`AuthenticatedUser`))
+
+	b := buf.Bytes()
+	DispatchPrometheusReply(&w, &b)
+}
+
+// promRegistry writes all metrics of registry reg
+func promRegistry(buf *bytes.Buffer, reg metrics.Registry) {
+	names := []string{}
+	collected := map[string]interface{}{}
+	reg.Each(func(name string, i interface{}) {
+		names = append(names, name)
+		collected[name] = i
+	})
+	sort.Strings(names)
+
+	for _, name := range names {
+		n := promIllegal.ReplaceAllString(name, `_`)
+		switch m := collected[name].(type) {
+		case metrics.Counter:
+			fmt.Fprintf(buf, "# TYPE %s counter\n", n)
+			fmt.Fprintf(buf, "%s %d\n", n, m.Count())
+		case metrics.Gauge:
+			fmt.Fprintf(buf, "# TYPE %s gauge\n", n)
+			fmt.Fprintf(buf, "%s %d\n", n, m.Value())
+		case metrics.GaugeFloat64:
+			fmt.Fprintf(buf, "# TYPE %s gauge\n", n)
+			fmt.Fprintf(buf, "%s %g\n", n, m.Value())
+		case metrics.Meter:
+			s := m.Snapshot()
+			fmt.Fprintf(buf, "# TYPE %s_total counter\n", n)
+			fmt.Fprintf(buf, "%s_total %d\n", n, s.Count())
+			promRates(buf, n, s.Rate1(), s.Rate5(), s.Rate15(),
+				s.RateMean())
+		case metrics.Histogram:
+			s := m.Snapshot()
+			promSummary(buf, n, s.Percentiles(promQuantiles),
+				s.Sum(), s.Count())
+		case metrics.Timer:
+			s := m.Snapshot()
+			promSummary(buf, n, s.Percentiles(promQuantiles),
+				s.Sum(), s.Count())
+			promRates(buf, n, s.Rate1(), s.Rate5(), s.Rate15(),
+				s.RateMean())
+		}
+	}
+}
+
+// promSummary writes a summary metric
+func promSummary(buf *bytes.Buffer, n string, ps []float64,
+	sum, count int64) {
+	fmt.Fprintf(buf, "# TYPE %s summary\n", n)
+	for i, q := range promQuantiles {
+		fmt.Fprintf(buf, "%s{quantile=\"%g\"} %g\n", n, q, ps[i])
+	}
+	fmt.Fprintf(buf, "%s_sum %d\n", n, sum)
+	fmt.Fprintf(buf, "%s_count %d\n", n, count)
+}
+
+// promRates writes the moving average rates of a meter
+func promRates(buf *bytes.Buffer, n string, r1, r5, r15, mean float64) {
+	fmt.Fprintf(buf, "# TYPE %s_rate gauge\n", n)
+	fmt.Fprintf(buf, "%s_rate{window=\"1m\"} %g\n", n, r1)
+	fmt.Fprintf(buf, "%s_rate{window=\"5m\"} %g\n", n, r5)
+	fmt.Fprintf(buf, "%s_rate{window=\"15m\"} %g\n", n, r15)
+	fmt.Fprintf(buf, "%s_rate{window=\"mean\"} %g\n", n, mean)
+}
+
+// promQueues writes the length and capacity of the input channel
+// of every registered handler
+func promQueues(buf *bytes.Buffer) {
+	queues := map[string]Queuer{}
+	names := []string{}
+	handlerMap.Range(func(name string, h interface{}) bool {
+		if q, ok := h.(Queuer); ok {
+			queues[name] = q
+			names = append(names, name)
+		}
+		return true
+	})
+	sort.Strings(names)
+
+	depth := &bytes.Buffer{}
+	capacity := &bytes.Buffer{}
+	for _, name := range names {
+		fmt.Fprintf(depth, "soma_handler_queue_depth{handler=%q} %d\n",
+			name, queues[name].queueLen())
+		fmt.Fprintf(capacity, "soma_handler_queue_capacity{handler=%q} %d\n",
+			name, queues[name].queueCap())
+	}
+	buf.WriteString("# TYPE soma_handler_queue_depth gauge\n")
+	buf.Write(depth.Bytes())
+	buf.WriteString("# TYPE soma_handler_queue_capacity gauge\n")
+	buf.Write(capacity.Bytes())
+}
+
+// promWorkflow writes the number of check instances per workflow
+// status
+func promWorkflow(buf *bytes.Buffer, addr, user string) {
+	handler, ok := handlerMap.Get(`workflow_r`).(*workflowRead)
+	if !ok {
+		return
+	}
+	returnChannel := make(chan msg.Result)
+	handler.input <- msg.Request{
+		Type:       `workflow`,
+		Action:     `summary`,
+		Reply:      returnChannel,
+		RemoteAddr: addr,
+		User:       user,
+	}
+	result := <-returnChannel
+	if result.Error != nil || len(result.Workflow) == 0 ||
+		result.Workflow[0].Summary == nil {
+		return
+	}
+
+	s := result.Workflow[0].Summary
+	buf.WriteString("# TYPE soma_workflow_instances gauge\n")
+	for _, status := range []struct {
+		name  string
+		count uint64
+	}{
+		{`awaiting_computation`, s.AwaitingComputation},
+		{`computed`, s.Computed},
+		{`awaiting_rollout`, s.AwaitingRollout},
+		{`rollout_in_progress`, s.RolloutInProgress},
+		{`rollout_failed`, s.RolloutFailed},
+		{`active`, s.Active},
+		{`awaiting_deprovision`, s.AwaitingDeprovision},
+		{`deprovision_in_progress`, s.DeprovisionInProgress},
+		{`deprovision_failed`, s.DeprovisionFailed},
+		{`deprovisioned`, s.Deprovisioned},
+		{`awaiting_deletion`, s.AwaitingDeletion},
+		{`blocked`, s.Blocked},
+	} {
+		fmt.Fprintf(buf, "soma_workflow_instances{status=%q} %d\n",
+			status.name, status.count)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/1and1/soma/internal/tree"
 	"github.com/1and1/soma/lib/proto"
 	"github.com/julienschmidt/httprouter"
 )
@@ -21,7 +22,7 @@ func ListProperty(w http.ResponseWriter, r *http.Request,
 	case `custom`:
 	case `service`:
 	default:
-		if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+		if ok, _ := IsAuthorized(params,
 			pa, ``, ``, ``); !ok {
 			DispatchForbidden(&w, nil)
 			return
@@ -50,7 +51,7 @@ func ListProperty(w http.ResponseWriter, r *http.Request,
 		SendPropertyReply(&w, &somaResult{})
 	}
 
-	handler := handlerMap["propertyReadHandler"].(*somaPropertyReadHandler)
+	handler := handlerMap.Get("propertyReadHandler").(*somaPropertyReadHandler)
 	handler.input <- req
 	result := <-returnChannel
 
@@ -114,7 +115,7 @@ func ShowProperty(w http.ResponseWriter, r *http.Request,
 	case `custom`:
 	case `service`:
 	default:
-		if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+		if ok, _ := IsAuthorized(params,
 			pa, ``, ``, ``); !ok {
 			DispatchForbidden(&w, nil)
 			return
@@ -148,7 +149,7 @@ func ShowProperty(w http.ResponseWriter, r *http.Request,
 		SendPropertyReply(&w, &somaResult{})
 	}
 
-	handler := handlerMap["propertyReadHandler"].(*somaPropertyReadHandler)
+	handler := handlerMap.Get("propertyReadHandler").(*somaPropertyReadHandler)
 	handler.input <- req
 	result := <-returnChannel
 	SendPropertyReply(&w, &result)
@@ -165,7 +166,7 @@ func AddProperty(w http.ResponseWriter, r *http.Request,
 	case `custom`:
 	case `service`:
 	default:
-		if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+		if ok, _ := IsAuthorized(params,
 			pa, ``, ``, ``); !ok {
 			DispatchForbidden(&w, nil)
 			return
@@ -188,6 +189,11 @@ func AddProperty(w http.ResponseWriter, r *http.Request,
 		req.prType = prType
 		req.Native = *cReq.Property.Native
 	case "system":
+		if err = tree.ValidatePropertySchema(
+			cReq.Property.System.Schema); err != nil {
+			DispatchBadRequest(&w, err)
+			return
+		}
 		req.prType = prType
 		req.System = *cReq.Property.System
 	case "custom":
@@ -195,6 +201,11 @@ func AddProperty(w http.ResponseWriter, r *http.Request,
 			DispatchBadRequest(&w, errors.New("Body and URL repositories do not match"))
 			return
 		}
+		if err = tree.ValidatePropertySchema(
+			cReq.Property.Custom.Schema); err != nil {
+			DispatchBadRequest(&w, err)
+			return
+		}
 		req.prType = prType
 		req.Custom = *cReq.Property.Custom
 		req.Custom.RepositoryId = params.ByName("repository")
@@ -213,7 +224,73 @@ func AddProperty(w http.ResponseWriter, r *http.Request,
 		SendPropertyReply(&w, &somaResult{})
 	}
 
-	handler := handlerMap["propertyWriteHandler"].(*somaPropertyWriteHandler)
+	handler := handlerMap.Get("propertyWriteHandler").(*somaPropertyWriteHandler)
+	handler.input <- req
+	result := <-returnChannel
+	SendPropertyReply(&w, &result)
+}
+
+func UpdateProperty(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+	prType, _ := GetPropertyTypeFromUrl(r.URL)
+	pa := fmt.Sprintf("property_%s_update", prType)
+	switch prType {
+	case `custom`:
+	default:
+		if ok, _ := IsAuthorized(params,
+			pa, ``, ``, ``); !ok {
+			DispatchForbidden(&w, nil)
+			return
+		}
+	}
+
+	cReq := proto.NewPropertyRequest()
+	err := DecodeJsonBody(r, &cReq)
+	if err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	returnChannel := make(chan somaResult)
+	req := somaPropertyRequest{
+		action: "update",
+		reply:  returnChannel,
+	}
+	switch prType {
+	case "system":
+		if cReq.Property.System == nil ||
+			params.ByName("system") != cReq.Property.System.Name {
+			DispatchBadRequest(&w, errors.New("Body and URL properties do not match"))
+			return
+		}
+		if err = tree.ValidatePropertySchema(
+			cReq.Property.System.Schema); err != nil {
+			DispatchBadRequest(&w, err)
+			return
+		}
+		req.prType = prType
+		req.System = *cReq.Property.System
+	case "custom":
+		if cReq.Property.Custom == nil ||
+			params.ByName("custom") != cReq.Property.Custom.Id ||
+			params.ByName("repository") != cReq.Property.Custom.RepositoryId {
+			DispatchBadRequest(&w, errors.New("Body and URL properties do not match"))
+			return
+		}
+		if err = tree.ValidatePropertySchema(
+			cReq.Property.Custom.Schema); err != nil {
+			DispatchBadRequest(&w, err)
+			return
+		}
+		req.prType = prType
+		req.Custom = *cReq.Property.Custom
+	default:
+		DispatchBadRequest(&w, fmt.Errorf(
+			"Property type %s has no value schema", prType))
+		return
+	}
+
+	handler := handlerMap.Get("propertyWriteHandler").(*somaPropertyWriteHandler)
 	handler.input <- req
 	result := <-returnChannel
 	SendPropertyReply(&w, &result)
@@ -228,7 +305,7 @@ func DeleteProperty(w http.ResponseWriter, r *http.Request,
 	case `custom`:
 	case `service`:
 	default:
-		if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+		if ok, _ := IsAuthorized(params,
 			pa, ``, ``, ``); !ok {
 			DispatchForbidden(&w, nil)
 			return
@@ -262,7 +339,7 @@ func DeleteProperty(w http.ResponseWriter, r *http.Request,
 		SendPropertyReply(&w, &somaResult{})
 	}
 
-	handler := handlerMap["propertyWriteHandler"].(*somaPropertyWriteHandler)
+	handler := handlerMap.Get("propertyWriteHandler").(*somaPropertyWriteHandler)
 	handler.input <- req
 	result := <-returnChannel
 	SendPropertyReply(&w, &result)
@@ -280,8 +357,9 @@ func SendPropertyReply(w *http.ResponseWriter, r *somaResult) {
 		case "system":
 			*result.Properties = append(*result.Properties, proto.Property{Type: "system",
 				System: &proto.PropertySystem{
-					Name:  i.System.Name,
-					Value: i.System.Value,
+					Name:   i.System.Name,
+					Value:  i.System.Value,
+					Schema: i.System.Schema,
 				}})
 		case "native":
 			*result.Properties = append(*result.Properties, proto.Property{Type: "native",
@@ -296,6 +374,7 @@ func SendPropertyReply(w *http.ResponseWriter, r *somaResult) {
 					Name:         i.Custom.Name,
 					Value:        i.Custom.Value,
 					RepositoryId: i.Custom.RepositoryId,
+					Schema:       i.Custom.Schema,
 				}})
 		case "service":
 			prop := proto.Property{
@@ -13,14 +13,14 @@ import (
 func ListProvider(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`providers_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["providerReadHandler"].(*somaProviderReadHandler)
+	handler := handlerMap.Get("providerReadHandler").(*somaProviderReadHandler)
 	handler.input <- somaProviderRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -32,14 +32,14 @@ func ListProvider(w http.ResponseWriter, r *http.Request,
 func ShowProvider(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`providers_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["providerReadHandler"].(*somaProviderReadHandler)
+	handler := handlerMap.Get("providerReadHandler").(*somaProviderReadHandler)
 	handler.input <- somaProviderRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -56,7 +56,7 @@ func ShowProvider(w http.ResponseWriter, r *http.Request,
 func AddProvider(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`providers_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -70,7 +70,7 @@ func AddProvider(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["providerWriteHandler"].(*somaProviderWriteHandler)
+	handler := handlerMap.Get("providerWriteHandler").(*somaProviderWriteHandler)
 	handler.input <- somaProviderRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -85,14 +85,14 @@ func AddProvider(w http.ResponseWriter, r *http.Request,
 func DeleteProvider(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`providers_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["providerWriteHandler"].(*somaProviderWriteHandler)
+	handler := handlerMap.Get("providerWriteHandler").(*somaProviderWriteHandler)
 	handler.input <- somaProviderRequest{
 		action: "delete",
 		reply:  returnChannel,
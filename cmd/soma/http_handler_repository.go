@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"unicode/utf8"
 
@@ -18,7 +19,7 @@ func ListRepository(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["repositoryReadHandler"].(*somaRepositoryReadHandler)
+	handler := handlerMap.Get("repositoryReadHandler").(*somaRepositoryReadHandler)
 	handler.input <- somaRepositoryRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -51,7 +52,7 @@ func ShowRepository(w http.ResponseWriter, r *http.Request,
 	defer PanicCatcher(w)
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["repositoryReadHandler"].(*somaRepositoryReadHandler)
+	handler := handlerMap.Get("repositoryReadHandler").(*somaRepositoryReadHandler)
 	handler.input <- somaRepositoryRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -68,7 +69,7 @@ func ShowRepository(w http.ResponseWriter, r *http.Request,
 func AddRepository(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`repository_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -88,7 +89,7 @@ func AddRepository(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["forestCustodian"].(*forestCustodian)
+	handler := handlerMap.Get("forestCustodian").(*forestCustodian)
 	handler.input <- somaRepositoryRequest{
 		action:     "add",
 		reply:      returnChannel,
@@ -105,6 +106,107 @@ func AddRepository(w http.ResponseWriter, r *http.Request,
 	SendRepositoryReply(&w, &result)
 }
 
+func DeleteRepository(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	// the request body is optional, a plain delete marks the
+	// repository as deleted
+	cReq := proto.NewRepositoryRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil && err != io.EOF {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	action := `delete_repository`
+	if cReq.Flags != nil && cReq.Flags.Purge {
+		action = `purge_repository`
+	}
+
+	if ok, _ := IsAuthorized(params,
+		`repository_delete`, params.ByName(`repository`), ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	sendRepositoryTreeRequest(&w, params, action, proto.Repository{
+		Id: params.ByName(`repository`),
+	}, cReq.Flags != nil && cReq.Flags.DryRun)
+}
+
+func PatchRepository(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewRepositoryRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+
+	repo := proto.Repository{
+		Id: params.ByName(`repository`),
+	}
+	var action, permission string
+	switch {
+	case cReq.Flags != nil && cReq.Flags.Restore:
+		action = `restore_repository`
+		permission = `repository_delete`
+	case cReq.Flags != nil && cReq.Flags.Activate:
+		action = `activate_repository`
+		permission = `repository_update`
+	case cReq.Repository != nil && cReq.Repository.Name != ``:
+		nameLen := utf8.RuneCountInString(cReq.Repository.Name)
+		if nameLen < 4 || nameLen > 128 {
+			DispatchBadRequest(&w, fmt.Errorf(`Illegal repository name length (4 < x <= 128)`))
+			return
+		}
+		action = `rename_repository`
+		permission = `repository_update`
+		repo.Name = cReq.Repository.Name
+	case cReq.Repository != nil && cReq.Repository.TeamId != ``:
+		action = `repossess_repository`
+		permission = `repository_repossess`
+		repo.TeamId = cReq.Repository.TeamId
+	default:
+		DispatchBadRequest(&w, fmt.Errorf(`Unknown repository update request`))
+		return
+	}
+
+	if ok, _ := IsAuthorized(params,
+		permission, repo.Id, ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	sendRepositoryTreeRequest(&w, params, action, repo,
+		cReq.Flags != nil && cReq.Flags.DryRun)
+}
+
+func PutRepository(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewRepositoryRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	if cReq.Flags == nil || !cReq.Flags.Clear {
+		DispatchBadRequest(&w, fmt.Errorf(`Unknown repository replace request`))
+		return
+	}
+
+	if ok, _ := IsAuthorized(params,
+		`repository_clear`, params.ByName(`repository`), ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	sendRepositoryTreeRequest(&w, params, `clear_repository`, proto.Repository{
+		Id: params.ByName(`repository`),
+	}, cReq.Flags != nil && cReq.Flags.DryRun)
+}
+
 func AddPropertyToRepository(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
@@ -138,12 +240,19 @@ func AddPropertyToRepository(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if ok, _ := IsAuthorized(params,
+		`repository_property_add`, params.ByName(`repository`), ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "repository",
 		Action:      fmt.Sprintf("add_%s_property_to_repository", params.ByName("type")),
 		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      cReq.Flags != nil && cReq.Flags.DryRun,
 		reply:       returnChannel,
 		Repository: somaRepositoryRequest{
 			action:     fmt.Sprintf("%s_property_new", params.ByName("type")),
@@ -169,8 +278,14 @@ func DeletePropertyFromRepository(w http.ResponseWriter, r *http.Request,
 		},
 	}
 
+	if ok, _ := IsAuthorized(params,
+		`repository_property_delete`, params.ByName(`repository`), ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["guidePost"].(*guidePost)
+	handler := handlerMap.Get("guidePost").(*guidePost)
 	handler.input <- treeRequest{
 		RequestType: "repository",
 		Action: fmt.Sprintf("delete_%s_property_from_repository",
@@ -187,9 +302,83 @@ func DeletePropertyFromRepository(w http.ResponseWriter, r *http.Request,
 	SendRepositoryReply(&w, &result)
 }
 
+func UpdatePropertyOnRepository(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer PanicCatcher(w)
+
+	cReq := proto.NewRepositoryRequest()
+	if err := DecodeJsonBody(r, &cReq); err != nil {
+		DispatchBadRequest(&w, err)
+		return
+	}
+	switch {
+	case params.ByName(`repository`) != cReq.Repository.Id:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched repository ids: %s, %s",
+				params.ByName(`repository`),
+				cReq.Repository.Id))
+		return
+	case cReq.Repository.Properties == nil || len(*cReq.Repository.Properties) != 1:
+		DispatchBadRequest(&w,
+			fmt.Errorf(`Expected property count 1`))
+		return
+	case params.ByName(`type`) != (*cReq.Repository.Properties)[0].Type:
+		DispatchBadRequest(&w,
+			fmt.Errorf("Mismatched property types: %s, %s",
+				params.ByName(`type`),
+				(*cReq.Repository.Properties)[0].Type))
+		return
+	}
+	(*cReq.Repository.Properties)[0].SourceInstanceId = params.ByName(`source`)
+
+	if ok, _ := IsAuthorized(params,
+		`repository_property_update`, params.ByName(`repository`), ``, ``); !ok {
+		DispatchForbidden(&w, nil)
+		return
+	}
+
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get(`guidePost`).(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `repository`,
+		Action: fmt.Sprintf("update_%s_property_on_repository",
+			params.ByName(`type`)),
+		User:   params.ByName(`AuthenticatedUser`),
+		DryRun: cReq.Flags != nil && cReq.Flags.DryRun,
+		reply:  returnChannel,
+		Repository: somaRepositoryRequest{
+			action: fmt.Sprintf("%s_property_update",
+				params.ByName(`type`)),
+			Repository: *cReq.Repository,
+		},
+	}
+	result := <-returnChannel
+	SendRepositoryReply(&w, &result)
+}
+
 /*
  * Utility
  */
+func sendRepositoryTreeRequest(w *http.ResponseWriter,
+	params httprouter.Params, action string, repo proto.Repository,
+	dryRun bool) {
+	returnChannel := make(chan somaResult)
+	handler := handlerMap.Get("guidePost").(*guidePost)
+	handler.input <- treeRequest{
+		RequestType: `repository`,
+		Action:      action,
+		User:        params.ByName(`AuthenticatedUser`),
+		DryRun:      dryRun,
+		reply:       returnChannel,
+		Repository: somaRepositoryRequest{
+			action:     action,
+			Repository: repo,
+		},
+	}
+	result := <-returnChannel
+	SendRepositoryReply(w, &result)
+}
+
 func SendRepositoryReply(w *http.ResponseWriter, r *somaResult) {
 	result := proto.NewRepositoryResult()
 	if r.MarkErrors(&result) {
@@ -201,6 +390,7 @@ func SendRepositoryReply(w *http.ResponseWriter, r *somaResult) {
 			*result.Errors = append(*result.Errors, i.ResultError.Error())
 		}
 	}
+	r.MarkActions(&result)
 
 dispatch:
 	json, err := json.Marshal(result)
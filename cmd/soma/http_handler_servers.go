@@ -14,14 +14,14 @@ import (
 func ListServer(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`servers_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["serverReadHandler"].(*somaServerReadHandler)
+	handler := handlerMap.Get("serverReadHandler").(*somaServerReadHandler)
 	handler.input <- somaServerRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -34,14 +34,14 @@ func ListServer(w http.ResponseWriter, r *http.Request,
 func SyncServer(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`servers_sync`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["serverReadHandler"].(*somaServerReadHandler)
+	handler := handlerMap.Get("serverReadHandler").(*somaServerReadHandler)
 	handler.input <- somaServerRequest{
 		action: "sync",
 		reply:  returnChannel,
@@ -54,14 +54,14 @@ func SyncServer(w http.ResponseWriter, r *http.Request,
 func ShowServer(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`servers_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["serverReadHandler"].(*somaServerReadHandler)
+	handler := handlerMap.Get("serverReadHandler").(*somaServerReadHandler)
 	handler.input <- somaServerRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -76,14 +76,14 @@ func ShowServer(w http.ResponseWriter, r *http.Request,
 func SearchServer(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`servers_search`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["serverReadHandler"].(*somaServerReadHandler)
+	handler := handlerMap.Get("serverReadHandler").(*somaServerReadHandler)
 	ssr := somaServerRequest{
 		reply: returnChannel,
 	}
@@ -113,7 +113,7 @@ func SearchServer(w http.ResponseWriter, r *http.Request,
 func AddServer(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`servers_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -127,7 +127,7 @@ func AddServer(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["serverWriteHandler"].(*somaServerWriteHandler)
+	handler := handlerMap.Get("serverWriteHandler").(*somaServerWriteHandler)
 	handler.input <- somaServerRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -148,7 +148,7 @@ func DeleteServer(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 	action := "delete"
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`servers_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -161,7 +161,7 @@ func DeleteServer(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["serverWriteHandler"].(*somaServerWriteHandler)
+	handler := handlerMap.Get("serverWriteHandler").(*somaServerWriteHandler)
 	handler.input <- somaServerRequest{
 		action: action,
 		reply:  returnChannel,
@@ -176,7 +176,7 @@ func DeleteServer(w http.ResponseWriter, r *http.Request,
 func UpdateServer(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`servers_update`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -195,7 +195,7 @@ func UpdateServer(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["serverWriteHandler"].(*somaServerWriteHandler)
+	handler := handlerMap.Get("serverWriteHandler").(*somaServerWriteHandler)
 	handler.input <- somaServerRequest{
 		action: "update",
 		reply:  returnChannel,
@@ -216,7 +216,7 @@ func UpdateServer(w http.ResponseWriter, r *http.Request,
 func InsertNullServer(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`servers_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -236,7 +236,7 @@ func InsertNullServer(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["serverWriteHandler"].(*somaServerWriteHandler)
+	handler := handlerMap.Get("serverWriteHandler").(*somaServerWriteHandler)
 	handler.input <- somaServerRequest{
 		action: "insert-null",
 		reply:  returnChannel,
@@ -13,14 +13,14 @@ import (
 func ListStatus(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`status_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["statusReadHandler"].(*somaStatusReadHandler)
+	handler := handlerMap.Get("statusReadHandler").(*somaStatusReadHandler)
 	handler.input <- somaStatusRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -32,14 +32,14 @@ func ListStatus(w http.ResponseWriter, r *http.Request,
 func ShowStatus(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`status_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["statusReadHandler"].(*somaStatusReadHandler)
+	handler := handlerMap.Get("statusReadHandler").(*somaStatusReadHandler)
 	handler.input <- somaStatusRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -56,7 +56,7 @@ func ShowStatus(w http.ResponseWriter, r *http.Request,
 func AddStatus(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`status_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -70,7 +70,7 @@ func AddStatus(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["statusWriteHandler"].(*somaStatusWriteHandler)
+	handler := handlerMap.Get("statusWriteHandler").(*somaStatusWriteHandler)
 	handler.input <- somaStatusRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -85,14 +85,14 @@ func AddStatus(w http.ResponseWriter, r *http.Request,
 func DeleteStatus(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`status_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["statusWriteHandler"].(*somaStatusWriteHandler)
+	handler := handlerMap.Get("statusWriteHandler").(*somaStatusWriteHandler)
 	handler.input <- somaStatusRequest{
 		action: "delete",
 		reply:  returnChannel,
@@ -12,7 +12,7 @@ import (
 func SystemOperation(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`system_operation`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -44,6 +44,18 @@ func SystemOperation(w http.ResponseWriter, r *http.Request,
 			Request:      cReq.SystemOperation.Request,
 			RepositoryId: cReq.SystemOperation.RepositoryId,
 		}
+	case `export_repository`:
+		sys = &proto.SystemOperation{
+			Request:      cReq.SystemOperation.Request,
+			RepositoryId: cReq.SystemOperation.RepositoryId,
+		}
+	case `import_repository`:
+		sys = &proto.SystemOperation{
+			Request:         cReq.SystemOperation.Request,
+			RepositoryId:    cReq.SystemOperation.RepositoryId,
+			KeepInstanceIds: cReq.SystemOperation.KeepInstanceIds,
+			Export:          cReq.SystemOperation.Export,
+		}
 	case `shutdown`:
 	default:
 		DispatchBadRequest(&w, fmt.Errorf("%s %s",
@@ -54,7 +66,7 @@ func SystemOperation(w http.ResponseWriter, r *http.Request,
 	returnChannel := make(chan msg.Result)
 	switch cReq.SystemOperation.Request {
 	case `stop_repository`:
-		handler := handlerMap[`guidePost`].(*guidePost)
+		handler := handlerMap.Get(`guidePost`).(*guidePost)
 		handler.system <- msg.Request{
 			Type:       `guidepost`,
 			Action:     `systemoperation`,
@@ -63,8 +75,9 @@ func SystemOperation(w http.ResponseWriter, r *http.Request,
 			User:       params.ByName(`AuthenticatedUser`),
 			System:     *sys,
 		}
-	case `rebuild_repository`, `restart_repository`:
-		handler := handlerMap[`forestCustodian`].(*forestCustodian)
+	case `rebuild_repository`, `restart_repository`,
+		`export_repository`, `import_repository`:
+		handler := handlerMap.Get(`forestCustodian`).(*forestCustodian)
 		handler.system <- msg.Request{
 			Type:       `forestcustodian`,
 			Action:     `systemoperation`,
@@ -74,7 +87,7 @@ func SystemOperation(w http.ResponseWriter, r *http.Request,
 			System:     *sys,
 		}
 	case `shutdown`:
-		handler := handlerMap[`grimReaper`].(*grimReaper)
+		handler := handlerMap.Get(`grimReaper`).(*grimReaper)
 		handler.system <- msg.Request{
 			Type:       `grimReaper`,
 			Action:     `shutdown`,
@@ -14,14 +14,14 @@ import (
 func ListTeam(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`team_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["teamReadHandler"].(*somaTeamReadHandler)
+	handler := handlerMap.Get("teamReadHandler").(*somaTeamReadHandler)
 	handler.input <- somaTeamRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -52,14 +52,14 @@ skip:
 func ShowTeam(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`team_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["teamReadHandler"].(*somaTeamReadHandler)
+	handler := handlerMap.Get("teamReadHandler").(*somaTeamReadHandler)
 	handler.input <- somaTeamRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -74,14 +74,14 @@ func ShowTeam(w http.ResponseWriter, r *http.Request,
 func SyncTeam(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`team_sync`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["teamReadHandler"].(*somaTeamReadHandler)
+	handler := handlerMap.Get("teamReadHandler").(*somaTeamReadHandler)
 	handler.input <- somaTeamRequest{
 		action: "sync",
 		reply:  returnChannel,
@@ -96,7 +96,7 @@ func SyncTeam(w http.ResponseWriter, r *http.Request,
 func AddTeam(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`team_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -110,7 +110,7 @@ func AddTeam(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["teamWriteHandler"].(*somaTeamWriteHandler)
+	handler := handlerMap.Get("teamWriteHandler").(*somaTeamWriteHandler)
 	handler.input <- somaTeamRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -127,7 +127,7 @@ func AddTeam(w http.ResponseWriter, r *http.Request,
 func UpdateTeam(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`team_update`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -141,7 +141,7 @@ func UpdateTeam(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["teamWriteHandler"].(*somaTeamWriteHandler)
+	handler := handlerMap.Get("teamWriteHandler").(*somaTeamWriteHandler)
 	handler.input <- somaTeamRequest{
 		action: `update`,
 		reply:  returnChannel,
@@ -159,14 +159,14 @@ func UpdateTeam(w http.ResponseWriter, r *http.Request,
 func DeleteTeam(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`team_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["teamWriteHandler"].(*somaTeamWriteHandler)
+	handler := handlerMap.Get("teamWriteHandler").(*somaTeamWriteHandler)
 	handler.input <- somaTeamRequest{
 		action: "delete",
 		reply:  returnChannel,
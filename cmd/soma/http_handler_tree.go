@@ -60,7 +60,7 @@ func OutputTree(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`tree_r`].(*outputTree)
+	handler := handlerMap.Get(`tree_r`).(*outputTree)
 	handler.input <- msg.Request{
 		Type:       `tree`,
 		Action:     `output_tree`,
@@ -13,14 +13,14 @@ import (
 func ListUnit(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`units_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["unitReadHandler"].(*somaUnitReadHandler)
+	handler := handlerMap.Get("unitReadHandler").(*somaUnitReadHandler)
 	handler.input <- somaUnitRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -32,14 +32,14 @@ func ListUnit(w http.ResponseWriter, r *http.Request,
 func ShowUnit(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`units_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["unitReadHandler"].(*somaUnitReadHandler)
+	handler := handlerMap.Get("unitReadHandler").(*somaUnitReadHandler)
 	handler.input <- somaUnitRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -56,7 +56,7 @@ func ShowUnit(w http.ResponseWriter, r *http.Request,
 func AddUnit(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`units_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -70,7 +70,7 @@ func AddUnit(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["unitWriteHandler"].(*somaUnitWriteHandler)
+	handler := handlerMap.Get("unitWriteHandler").(*somaUnitWriteHandler)
 	handler.input <- somaUnitRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -86,14 +86,14 @@ func AddUnit(w http.ResponseWriter, r *http.Request,
 func DeleteUnit(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`units_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["unitWriteHandler"].(*somaUnitWriteHandler)
+	handler := handlerMap.Get("unitWriteHandler").(*somaUnitWriteHandler)
 	handler.input <- somaUnitRequest{
 		action: "delete",
 		reply:  returnChannel,
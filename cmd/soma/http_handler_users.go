@@ -15,14 +15,14 @@ import (
 func ListUser(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`users_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["userReadHandler"].(*somaUserReadHandler)
+	handler := handlerMap.Get("userReadHandler").(*somaUserReadHandler)
 	handler.input <- somaUserRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -53,14 +53,14 @@ skip:
 func ShowUser(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`users_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["userReadHandler"].(*somaUserReadHandler)
+	handler := handlerMap.Get("userReadHandler").(*somaUserReadHandler)
 	handler.input <- somaUserRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -75,14 +75,14 @@ func ShowUser(w http.ResponseWriter, r *http.Request,
 func SyncUser(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`users_sync`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["userReadHandler"].(*somaUserReadHandler)
+	handler := handlerMap.Get("userReadHandler").(*somaUserReadHandler)
 	handler.input <- somaUserRequest{
 		action: `sync`,
 		reply:  returnChannel,
@@ -96,7 +96,7 @@ func SyncUser(w http.ResponseWriter, r *http.Request,
 func AddUser(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`users_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -114,7 +114,7 @@ func AddUser(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["userWriteHandler"].(*somaUserWriteHandler)
+	handler := handlerMap.Get("userWriteHandler").(*somaUserWriteHandler)
 	handler.input <- somaUserRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -137,7 +137,7 @@ func AddUser(w http.ResponseWriter, r *http.Request,
 func UpdateUser(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`users_update`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -159,7 +159,7 @@ func UpdateUser(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["userWriteHandler"].(*somaUserWriteHandler)
+	handler := handlerMap.Get("userWriteHandler").(*somaUserWriteHandler)
 	handler.input <- somaUserRequest{
 		action: "update",
 		reply:  returnChannel,
@@ -181,7 +181,7 @@ func UpdateUser(w http.ResponseWriter, r *http.Request,
 func DeleteUser(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`users_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -195,7 +195,7 @@ func DeleteUser(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["userWriteHandler"].(*somaUserWriteHandler)
+	handler := handlerMap.Get("userWriteHandler").(*somaUserWriteHandler)
 	handler.input <- somaUserRequest{
 		action: action,
 		reply:  returnChannel,
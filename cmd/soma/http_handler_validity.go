@@ -14,14 +14,14 @@ import (
 func ListValidity(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`validity_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["validityReadHandler"].(*somaValidityReadHandler)
+	handler := handlerMap.Get("validityReadHandler").(*somaValidityReadHandler)
 	handler.input <- somaValidityRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -33,14 +33,14 @@ func ListValidity(w http.ResponseWriter, r *http.Request,
 func ShowValidity(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`validity_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["validityReadHandler"].(*somaValidityReadHandler)
+	handler := handlerMap.Get("validityReadHandler").(*somaValidityReadHandler)
 	handler.input <- somaValidityRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -57,7 +57,7 @@ func ShowValidity(w http.ResponseWriter, r *http.Request,
 func AddValidity(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`validity_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -71,7 +71,7 @@ func AddValidity(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["validityWriteHandler"].(*somaValidityWriteHandler)
+	handler := handlerMap.Get("validityWriteHandler").(*somaValidityWriteHandler)
 	handler.input <- somaValidityRequest{
 		action:   "add",
 		reply:    returnChannel,
@@ -84,14 +84,14 @@ func AddValidity(w http.ResponseWriter, r *http.Request,
 func DeleteValidity(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`validity_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["validityWriteHandler"].(*somaValidityWriteHandler)
+	handler := handlerMap.Get("validityWriteHandler").(*somaValidityWriteHandler)
 	handler.input <- somaValidityRequest{
 		action: "delete",
 		reply:  returnChannel,
@@ -15,14 +15,14 @@ import (
 func ListView(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`view_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["viewReadHandler"].(*somaViewReadHandler)
+	handler := handlerMap.Get("viewReadHandler").(*somaViewReadHandler)
 	handler.input <- somaViewRequest{
 		action: "list",
 		reply:  returnChannel,
@@ -34,14 +34,14 @@ func ListView(w http.ResponseWriter, r *http.Request,
 func ShowView(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`view_show`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["viewReadHandler"].(*somaViewReadHandler)
+	handler := handlerMap.Get("viewReadHandler").(*somaViewReadHandler)
 	handler.input <- somaViewRequest{
 		action: "show",
 		reply:  returnChannel,
@@ -58,7 +58,7 @@ func ShowView(w http.ResponseWriter, r *http.Request,
 func AddView(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`view_create`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -76,7 +76,7 @@ func AddView(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["viewWriteHandler"].(*somaViewWriteHandler)
+	handler := handlerMap.Get("viewWriteHandler").(*somaViewWriteHandler)
 	handler.input <- somaViewRequest{
 		action: "add",
 		reply:  returnChannel,
@@ -91,14 +91,14 @@ func AddView(w http.ResponseWriter, r *http.Request,
 func DeleteView(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`view_delete`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["viewWriteHandler"].(*somaViewWriteHandler)
+	handler := handlerMap.Get("viewWriteHandler").(*somaViewWriteHandler)
 	handler.input <- somaViewRequest{
 		action: "delete",
 		reply:  returnChannel,
@@ -113,7 +113,7 @@ func DeleteView(w http.ResponseWriter, r *http.Request,
 func RenameView(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`view_rename`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -127,7 +127,7 @@ func RenameView(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan somaResult)
-	handler := handlerMap["viewWriteHandler"].(*somaViewWriteHandler)
+	handler := handlerMap.Get("viewWriteHandler").(*somaViewWriteHandler)
 	handler.input <- somaViewRequest{
 		action: "rename",
 		reply:  returnChannel,
@@ -23,14 +23,14 @@ func WorkflowSummary(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`workflow_summary`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`workflow_r`].(*workflowRead)
+	handler := handlerMap.Get(`workflow_r`).(*workflowRead)
 	handler.input <- msg.Request{
 		Type:       `workflow`,
 		Action:     `summary`,
@@ -47,7 +47,7 @@ func WorkflowList(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`workflow_list`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -65,7 +65,7 @@ func WorkflowList(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`workflow_r`].(*workflowRead)
+	handler := handlerMap.Get(`workflow_r`).(*workflowRead)
 	handler.input <- msg.Request{
 		Type:       `workflow`,
 		Action:     `list`,
@@ -85,7 +85,7 @@ func WorkflowRetry(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`workflow_retry`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -103,7 +103,7 @@ func WorkflowRetry(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`workflow_w`].(*workflowWrite)
+	handler := handlerMap.Get(`workflow_w`).(*workflowWrite)
 	handler.input <- msg.Request{
 		Type:       `workflow`,
 		Action:     `retry`,
@@ -123,7 +123,7 @@ func WorkflowSet(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer PanicCatcher(w)
 
-	if ok, _ := IsAuthorized(params.ByName(`AuthenticatedUser`),
+	if ok, _ := IsAuthorized(params,
 		`workflow_set`, ``, ``, ``); !ok {
 		DispatchForbidden(&w, nil)
 		return
@@ -148,7 +148,7 @@ func WorkflowSet(w http.ResponseWriter, r *http.Request,
 	}
 
 	returnChannel := make(chan msg.Result)
-	handler := handlerMap[`workflow_w`].(*workflowWrite)
+	handler := handlerMap.Get(`workflow_w`).(*workflowWrite)
 	handler.input <- msg.Request{
 		Type:       `workflow`,
 		Action:     `set`,
@@ -83,6 +83,10 @@ func SendMsgResult(w *http.ResponseWriter, r *msg.Result) {
 			DispatchUnauthorized(w, nil)
 			return
 		} // end supervisor
+	case `audit`:
+		result = proto.NewAuditResult()
+		*result.Audit = append(*result.Audit, r.Audit...)
+		goto UnmaskedReply
 	case `job`:
 		result = proto.NewJobResult()
 		*result.Jobs = append(*result.Jobs, r.Job...)
@@ -0,0 +1,10 @@
+package main
+
+// Queuer is implemented by handlers that report the fill level of
+// their input channel
+type Queuer interface {
+	queueLen() int
+	queueCap() int
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -11,20 +11,69 @@ package main
 import (
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/1and1/soma/internal/msg"
 	log "github.com/Sirupsen/logrus"
+	"github.com/client9/reopen"
 )
 
+// logFileRegistry is the lookup table of logfile handles for
+// logrotate. Treekeepers register and remove their logfiles at
+// runtime, so every access goes through its lock.
+type logFileRegistry struct {
+	sync.RWMutex
+	files map[string]*reopen.FileWriter
+}
+
+func newLogFileRegistry() *logFileRegistry {
+	return &logFileRegistry{
+		files: make(map[string]*reopen.FileWriter),
+	}
+}
+
+// Set registers fh as name
+func (r *logFileRegistry) Set(name string, fh *reopen.FileWriter) {
+	r.Lock()
+	defer r.Unlock()
+	r.files[name] = fh
+}
+
+// Del removes the logfile registered as name and returns its handle,
+// or nil
+func (r *logFileRegistry) Del(name string) *reopen.FileWriter {
+	r.Lock()
+	defer r.Unlock()
+	fh := r.files[name]
+	delete(r.files, name)
+	return fh
+}
+
+// Range calls f for every registered logfile until f returns false.
+// It iterates over a copy.
+func (r *logFileRegistry) Range(f func(name string, fh *reopen.FileWriter) bool) {
+	r.RLock()
+	files := make(map[string]*reopen.FileWriter, len(r.files))
+	for name, fh := range r.files {
+		files[name] = fh
+	}
+	r.RUnlock()
+
+	for name, fh := range files {
+		if !f(name, fh) {
+			return
+		}
+	}
+}
+
 func logrotate(sigChan chan os.Signal) {
 	for {
 		select {
 		case <-sigChan:
-		fileloop:
-			for name, lfHandle := range logFileMap {
+			logFileMap.Range(func(name string, lfHandle *reopen.FileWriter) bool {
 				// treekeeper startup logs do not get rotated
 				if strings.HasPrefix(name, `startup_`) {
-					continue
+					return true
 				}
 				err := lfHandle.Reopen()
 				if err != nil {
@@ -32,7 +81,7 @@ func logrotate(sigChan chan os.Signal) {
 					log.Println(`Shutting down system`)
 
 					returnChannel := make(chan msg.Result)
-					handler := handlerMap[`grimReaper`].(*grimReaper)
+					handler := handlerMap.Get(`grimReaper`).(*grimReaper)
 					handler.system <- msg.Request{
 						Type:       `grimReaper`,
 						Action:     `shutdown`,
@@ -41,9 +90,10 @@ func logrotate(sigChan chan os.Signal) {
 						User:       `root`,
 					}
 					<-returnChannel
-					break fileloop
+					return false
 				}
-			}
+				return true
+			})
 		}
 	}
 }
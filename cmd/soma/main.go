@@ -24,7 +24,7 @@ var (
 	// main database connection pool
 	conn *sql.DB
 	// lookup table for go routine input channels
-	handlerMap = make(map[string]interface{})
+	handlerMap = newHandlerRegistry()
 	// config file runtime configuration
 	SomaCfg SomaConfig
 	// this offset influences the biggest date representable in
@@ -42,7 +42,7 @@ var (
 	// Orderly shutdown of the system has been called. GrimReaper is active
 	ShutdownInProgress bool = false
 	// lookup table of logfile handles for logrotate reopen
-	logFileMap = make(map[string]*reopen.FileWriter)
+	logFileMap = newLogFileRegistry()
 	// Global metrics registry
 	Metrics = make(map[string]metrics.Registry)
 )
@@ -101,7 +101,7 @@ func main() {
 		log.Fatalf("Unable to open global output log: %s", err)
 	}
 	log.SetOutput(lfhGlobal)
-	logFileMap[`global`] = lfhGlobal
+	logFileMap.Set(`global`, lfhGlobal)
 
 	appLog = log.New()
 	if lfhApp, err = reopen.NewFileWriter(
@@ -110,7 +110,7 @@ func main() {
 		log.Fatalf("Unable to open application log: %s", err)
 	}
 	appLog.Out = lfhApp
-	logFileMap[`application`] = lfhApp
+	logFileMap.Set(`application`, lfhApp)
 
 	reqLog = log.New()
 	if lfhReq, err = reopen.NewFileWriter(
@@ -119,7 +119,7 @@ func main() {
 		log.Fatalf("Unable to open request log: %s", err)
 	}
 	reqLog.Out = lfhReq
-	logFileMap[`request`] = lfhReq
+	logFileMap.Set(`request`, lfhReq)
 
 	errLog = log.New()
 	if lfhErr, err = reopen.NewFileWriter(
@@ -128,7 +128,7 @@ func main() {
 		log.Fatalf("Unable to open error log: %s", err)
 	}
 	errLog.Out = lfhErr
-	logFileMap[`error`] = lfhErr
+	logFileMap.Set(`error`, lfhErr)
 
 	// signal handler will reopen all logfiles on USR2
 	sigChanLogRotate := make(chan os.Signal, 1)
@@ -212,6 +212,7 @@ func main() {
 	router := httprouter.New()
 
 	router.HEAD(`/`, Check(Ping))
+	router.GET(`/health`, Check(Health))
 
 	router.GET(`/attributes/:attribute`, Check(BasicAuth(ShowAttribute)))
 	router.GET(`/attributes/`, Check(BasicAuth(ListAttribute)))
@@ -287,6 +288,7 @@ func main() {
 	router.GET(`/repository/:repository/tree/:tree`, Check(BasicAuth(OutputTree)))
 	router.GET(`/repository/:repository`, Check(BasicAuth(ShowRepository)))
 	router.GET(`/repository/`, Check(BasicAuth(ListRepository)))
+	router.GET(`/runtime/metrics`, Check(BasicAuth(PrometheusMetrics)))
 	router.GET(`/servers/:server`, Check(BasicAuth(ShowServer)))
 	router.GET(`/servers/`, Check(BasicAuth(ListServer)))
 	router.GET(`/status/:status`, Check(BasicAuth(ShowStatus)))
@@ -307,6 +309,7 @@ func main() {
 	router.GET(`/views/:view`, Check(BasicAuth(ShowView)))
 	router.GET(`/views/`, Check(BasicAuth(ListView)))
 	router.GET(`/workflow/summary`, Check(BasicAuth(WorkflowSummary)))
+	router.POST(`/filter/audit/`, Check(BasicAuth(SearchAudit)))
 	router.POST(`/filter/buckets/`, Check(BasicAuth(ListBucket)))
 	router.POST(`/filter/capability/`, Check(BasicAuth(ListCapability)))
 	router.POST(`/filter/checks/:repository/`, Check(BasicAuth(ListCheckConfiguration)))
@@ -332,112 +335,134 @@ func main() {
 
 	if !SomaCfg.ReadOnly {
 		if !SomaCfg.Observer {
-			router.DELETE(`/attributes/:attribute`, Check(BasicAuth(DeleteAttribute)))
-			router.DELETE(`/buckets/:bucket/property/:type/:source`, Check(BasicAuth(DeletePropertyFromBucket)))
-			router.DELETE(`/capability/:capability`, Check(BasicAuth(DeleteCapability)))
-			router.DELETE(`/category/:category`, Check(BasicAuth(DeleteCategory)))
-			router.DELETE(`/checks/:repository/:check`, Check(BasicAuth(DeleteCheckConfiguration)))
-			router.DELETE(`/clusters/:cluster/property/:type/:source`, Check(BasicAuth(DeletePropertyFromCluster)))
-			router.DELETE(`/datacentergroups/:datacentergroup`, Check(BasicAuth(DeleteDatacenterFromGroup)))
-			router.DELETE(`/datacenters/:datacenter`, Check(BasicAuth(DeleteDatacenter)))
-			router.DELETE(`/environments/:environment`, Check(BasicAuth(DeleteEnvironment)))
-			router.DELETE(`/grant/global/:rtyp/:rid/:grant`, Check(BasicAuth(RevokeGlobalRight)))
-			router.DELETE(`/grant/limited/:rtyp/:rid/:scope/:uuid/:grant`, Check(BasicAuth(RevokeLimitedRight)))
-			router.DELETE(`/grant/system/:rtyp/:rid/:grant`, Check(BasicAuth(RevokeSystemRight)))
-			router.DELETE(`/groups/:group/property/:type/:source`, Check(BasicAuth(DeletePropertyFromGroup)))
-			router.DELETE(`/levels/:level`, Check(BasicAuth(DeleteLevel)))
-			router.DELETE(`/metrics/:metric`, Check(BasicAuth(DeleteMetric)))
-			router.DELETE(`/modes/:mode`, Check(BasicAuth(DeleteMode)))
-			router.DELETE(`/monitoring/:monitoring`, Check(BasicAuth(DeleteMonitoring)))
-			router.DELETE(`/nodes/:node/property/:type/:source`, Check(BasicAuth(DeletePropertyFromNode)))
-			router.DELETE(`/nodes/:node`, Check(BasicAuth(DeleteNode)))
-			router.DELETE(`/objstates/:state`, Check(BasicAuth(DeleteObjectState)))
-			router.DELETE(`/objtypes/:type`, Check(BasicAuth(DeleteObjectType)))
-			router.DELETE(`/oncall/:oncall`, Check(BasicAuth(DeleteOncall)))
-			router.DELETE(`/permission/:permission`, Check(BasicAuth(DeletePermission)))
-			router.DELETE(`/predicates/:predicate`, Check(BasicAuth(DeletePredicate)))
-			router.DELETE(`/property/custom/:repository/:custom`, Check(BasicAuth(DeleteProperty)))
-			router.DELETE(`/property/native/:native`, Check(BasicAuth(DeleteProperty)))
-			router.DELETE(`/property/service/global/:service`, Check(BasicAuth(DeleteProperty)))
-			router.DELETE(`/property/service/team/:team/:service`, Check(BasicAuth(DeleteProperty)))
-			router.DELETE(`/property/system/:system`, Check(BasicAuth(DeleteProperty)))
-			router.DELETE(`/providers/:provider`, Check(BasicAuth(DeleteProvider)))
-			router.DELETE(`/repository/:repository/property/:type/:source`, Check(BasicAuth(DeletePropertyFromRepository)))
-			router.DELETE(`/servers/:server`, Check(BasicAuth(DeleteServer)))
-			router.DELETE(`/status/:status`, Check(BasicAuth(DeleteStatus)))
-			router.DELETE(`/teams/:team`, Check(BasicAuth(DeleteTeam)))
-			router.DELETE(`/units/:unit`, Check(BasicAuth(DeleteUnit)))
-			router.DELETE(`/users/:user`, Check(BasicAuth(DeleteUser)))
-			router.DELETE(`/validity/:property`, Check(BasicAuth(DeleteValidity)))
-			router.DELETE(`/views/:view`, Check(BasicAuth(DeleteView)))
+			router.DELETE(`/attributes/:attribute`, Check(BasicAuth(Audit(DeleteAttribute))))
+			router.DELETE(`/buckets/:bucket/property/:type/:source`, Check(BasicAuth(Audit(DeletePropertyFromBucket))))
+			router.DELETE(`/buckets/:bucket`, Check(BasicAuth(Audit(DeleteBucket))))
+			router.DELETE(`/capability/:capability`, Check(BasicAuth(Audit(DeleteCapability))))
+			router.DELETE(`/category/:category`, Check(BasicAuth(Audit(DeleteCategory))))
+			router.DELETE(`/checks/:repository/:check`, Check(BasicAuth(Audit(DeleteCheckConfiguration))))
+			router.DELETE(`/clusters/:cluster/members/:node`, Check(BasicAuth(Audit(DeleteMemberFromCluster))))
+			router.DELETE(`/clusters/:cluster/property/:type/:source`, Check(BasicAuth(Audit(DeletePropertyFromCluster))))
+			router.DELETE(`/clusters/:cluster`, Check(BasicAuth(Audit(DeleteCluster))))
+			router.DELETE(`/datacentergroups/:datacentergroup`, Check(BasicAuth(Audit(DeleteDatacenterFromGroup))))
+			router.DELETE(`/datacenters/:datacenter`, Check(BasicAuth(Audit(DeleteDatacenter))))
+			router.DELETE(`/environments/:environment`, Check(BasicAuth(Audit(DeleteEnvironment))))
+			router.DELETE(`/grant/global/:rtyp/:rid/:grant`, Check(BasicAuth(Audit(RevokeGlobalRight))))
+			router.DELETE(`/grant/limited/:rtyp/:rid/:scope/:uuid/:grant`, Check(BasicAuth(Audit(RevokeLimitedRight))))
+			router.DELETE(`/grant/system/:rtyp/:rid/:grant`, Check(BasicAuth(Audit(RevokeSystemRight))))
+			router.DELETE(`/groups/:group/members/:type/:id`, Check(BasicAuth(Audit(DeleteMemberFromGroup))))
+			router.DELETE(`/groups/:group/property/:type/:source`, Check(BasicAuth(Audit(DeletePropertyFromGroup))))
+			router.DELETE(`/groups/:group`, Check(BasicAuth(Audit(DeleteGroup))))
+			router.DELETE(`/jobs/:jobid`, Check(BasicAuth(Audit(CancelJob))))
+			router.DELETE(`/levels/:level`, Check(BasicAuth(Audit(DeleteLevel))))
+			router.DELETE(`/metrics/:metric`, Check(BasicAuth(Audit(DeleteMetric))))
+			router.DELETE(`/modes/:mode`, Check(BasicAuth(Audit(DeleteMode))))
+			router.DELETE(`/monitoring/:monitoring`, Check(BasicAuth(Audit(DeleteMonitoring))))
+			router.DELETE(`/nodes/:node/property/:type/:source`, Check(BasicAuth(Audit(DeletePropertyFromNode))))
+			router.DELETE(`/nodes/:node`, Check(BasicAuth(Audit(DeleteNode))))
+			router.DELETE(`/objstates/:state`, Check(BasicAuth(Audit(DeleteObjectState))))
+			router.DELETE(`/objtypes/:type`, Check(BasicAuth(Audit(DeleteObjectType))))
+			router.DELETE(`/oncall/:oncall`, Check(BasicAuth(Audit(DeleteOncall))))
+			router.DELETE(`/permission/:permission`, Check(BasicAuth(Audit(DeletePermission))))
+			router.DELETE(`/predicates/:predicate`, Check(BasicAuth(Audit(DeletePredicate))))
+			router.DELETE(`/property/custom/:repository/:custom`, Check(BasicAuth(Audit(DeleteProperty))))
+			router.DELETE(`/property/native/:native`, Check(BasicAuth(Audit(DeleteProperty))))
+			router.DELETE(`/property/service/global/:service`, Check(BasicAuth(Audit(DeleteProperty))))
+			router.DELETE(`/property/service/team/:team/:service`, Check(BasicAuth(Audit(DeleteProperty))))
+			router.DELETE(`/property/system/:system`, Check(BasicAuth(Audit(DeleteProperty))))
+			router.DELETE(`/providers/:provider`, Check(BasicAuth(Audit(DeleteProvider))))
+			router.DELETE(`/repository/:repository/property/:type/:source`, Check(BasicAuth(Audit(DeletePropertyFromRepository))))
+			router.DELETE(`/repository/:repository`, Check(BasicAuth(Audit(DeleteRepository))))
+			router.DELETE(`/servers/:server`, Check(BasicAuth(Audit(DeleteServer))))
+			router.DELETE(`/status/:status`, Check(BasicAuth(Audit(DeleteStatus))))
+			router.DELETE(`/teams/:team`, Check(BasicAuth(Audit(DeleteTeam))))
+			router.DELETE(`/units/:unit`, Check(BasicAuth(Audit(DeleteUnit))))
+			router.DELETE(`/users/:user`, Check(BasicAuth(Audit(DeleteUser))))
+			router.DELETE(`/validity/:property`, Check(BasicAuth(Audit(DeleteValidity))))
+			router.DELETE(`/views/:view`, Check(BasicAuth(Audit(DeleteView))))
 			router.GET(`/deployments/id/:uuid`, Check(DeliverDeploymentDetails))
 			router.GET(`/deployments/monitoring/:uuid/:all`, Check(DeliverMonitoringDeployments))
 			router.GET(`/deployments/monitoring/:uuid`, Check(DeliverMonitoringDeployments))
+			router.GET(`/events/`, Check(BasicAuth(EventStream)))
 			router.PATCH(`/authenticate/user/password/:uuid`, Check(AuthenticationChangeUserPassword))
-			router.PATCH(`/datacentergroups/:datacentergroup`, Check(BasicAuth(AddDatacenterToGroup)))
+			router.PATCH(`/buckets/:bucket`, Check(BasicAuth(Audit(PatchBucket))))
+			router.PATCH(`/clusters/:cluster`, Check(BasicAuth(Audit(PatchCluster))))
+			router.PATCH(`/datacentergroups/:datacentergroup`, Check(BasicAuth(Audit(AddDatacenterToGroup))))
 			router.PATCH(`/deployments/id/:uuid/:result`, Check(UpdateDeploymentDetails))
-			router.PATCH(`/oncall/:oncall`, Check(BasicAuth(UpdateOncall)))
-			router.PATCH(`/views/:view`, Check(BasicAuth(RenameView)))
-			router.PATCH(`/workflow/retry`, Check(BasicAuth(WorkflowRetry)))
-			router.PATCH(`/workflow/instanceconfig/:instanceconfig`, Check(BasicAuth(WorkflowSet)))
-			router.POST(`/attributes/`, Check(BasicAuth(AddAttribute)))
-			router.POST(`/buckets/:bucket/property/:type/`, Check(BasicAuth(AddPropertyToBucket)))
-			router.POST(`/buckets/`, Check(BasicAuth(AddBucket)))
-			router.POST(`/capability/`, Check(BasicAuth(AddCapability)))
-			router.POST(`/category/`, Check(BasicAuth(AddCategory)))
-			router.POST(`/checks/:repository/`, Check(BasicAuth(AddCheckConfiguration)))
-			router.POST(`/clusters/:cluster/members/`, Check(BasicAuth(AddMemberToCluster)))
-			router.POST(`/clusters/:cluster/property/:type/`, Check(BasicAuth(AddPropertyToCluster)))
-			router.POST(`/clusters/`, Check(BasicAuth(AddCluster)))
-			router.POST(`/datacenters/`, Check(BasicAuth(AddDatacenter)))
-			router.POST(`/environments/`, Check(BasicAuth(AddEnvironment)))
-			router.POST(`/grant/global/:rtyp/:rid/`, Check(BasicAuth(GrantGlobalRight)))
-			router.POST(`/grant/limited/:rtyp/:rid/:scope/:uuid/`, Check(BasicAuth(GrantLimitedRight)))
-			router.POST(`/grant/system/:rtyp/:rid/`, Check(BasicAuth(GrantSystemRight)))
-			router.POST(`/groups/:group/members/`, Check(BasicAuth(AddMemberToGroup)))
-			router.POST(`/groups/:group/property/:type/`, Check(BasicAuth(AddPropertyToGroup)))
-			router.POST(`/groups/`, Check(BasicAuth(AddGroup)))
-			router.POST(`/levels/`, Check(BasicAuth(AddLevel)))
-			router.POST(`/metrics/`, Check(BasicAuth(AddMetric)))
-			router.POST(`/modes/`, Check(BasicAuth(AddMode)))
-			router.POST(`/monitoring/`, Check(BasicAuth(AddMonitoring)))
-			router.POST(`/nodes/:node/property/:type/`, Check(BasicAuth(AddPropertyToNode)))
-			router.POST(`/nodes/`, Check(BasicAuth(AddNode)))
-			router.POST(`/objstates/`, Check(BasicAuth(AddObjectState)))
-			router.POST(`/objtypes/`, Check(BasicAuth(AddObjectType)))
-			router.POST(`/oncall/`, Check(BasicAuth(AddOncall)))
-			router.POST(`/permission/`, Check(BasicAuth(AddPermission)))
-			router.POST(`/predicates/`, Check(BasicAuth(AddPredicate)))
-			router.POST(`/property/custom/:repository/`, Check(BasicAuth(AddProperty)))
-			router.POST(`/property/native/`, Check(BasicAuth(AddProperty)))
-			router.POST(`/property/service/global/`, Check(BasicAuth(AddProperty)))
-			router.POST(`/property/service/team/:team/`, Check(BasicAuth(AddProperty)))
-			router.POST(`/property/system/`, Check(BasicAuth(AddProperty)))
-			router.POST(`/providers/`, Check(BasicAuth(AddProvider)))
-			router.POST(`/repository/:repository/property/:type/`, Check(BasicAuth(AddPropertyToRepository)))
-			router.POST(`/repository/`, Check(BasicAuth(AddRepository)))
-			router.POST(`/servers/:server`, Check(BasicAuth(InsertNullServer)))
-			router.POST(`/servers/`, Check(BasicAuth(AddServer)))
-			router.POST(`/status/`, Check(BasicAuth(AddStatus)))
-			router.POST(`/system/`, Check(BasicAuth(SystemOperation)))
-			router.POST(`/teams/`, Check(BasicAuth(AddTeam)))
-			router.POST(`/units/`, Check(BasicAuth(AddUnit)))
-			router.POST(`/users/`, Check(BasicAuth(AddUser)))
-			router.POST(`/validity/`, Check(BasicAuth(AddValidity)))
-			router.POST(`/views/`, Check(BasicAuth(AddView)))
+			router.PATCH(`/groups/:group`, Check(BasicAuth(Audit(PatchGroup))))
+			router.PATCH(`/nodes/:node/config`, Check(BasicAuth(Audit(RelocateNode))))
+			router.PATCH(`/oncall/:oncall`, Check(BasicAuth(Audit(UpdateOncall))))
+			router.PATCH(`/repository/:repository`, Check(BasicAuth(Audit(PatchRepository))))
+			router.PATCH(`/views/:view`, Check(BasicAuth(Audit(RenameView))))
+			router.PATCH(`/workflow/retry`, Check(BasicAuth(Audit(WorkflowRetry))))
+			router.PATCH(`/workflow/instanceconfig/:instanceconfig`, Check(BasicAuth(Audit(WorkflowSet))))
+			router.POST(`/attributes/`, Check(BasicAuth(Audit(AddAttribute))))
+			router.POST(`/buckets/:bucket/property/:type/`, Check(BasicAuth(Audit(AddPropertyToBucket))))
+			router.POST(`/buckets/`, Check(BasicAuth(Audit(AddBucket))))
+			router.POST(`/capability/`, Check(BasicAuth(Audit(AddCapability))))
+			router.POST(`/category/`, Check(BasicAuth(Audit(AddCategory))))
+			router.POST(`/checks/:repository/`, Check(BasicAuth(Audit(AddCheckConfiguration))))
+			router.POST(`/clusters/:cluster/members/`, Check(BasicAuth(Audit(AddMemberToCluster))))
+			router.POST(`/clusters/:cluster/property/:type/`, Check(BasicAuth(Audit(AddPropertyToCluster))))
+			router.POST(`/clusters/`, Check(BasicAuth(Audit(AddCluster))))
+			router.POST(`/datacenters/`, Check(BasicAuth(Audit(AddDatacenter))))
+			router.POST(`/environments/`, Check(BasicAuth(Audit(AddEnvironment))))
+			router.POST(`/grant/global/:rtyp/:rid/`, Check(BasicAuth(Audit(GrantGlobalRight))))
+			router.POST(`/grant/limited/:rtyp/:rid/:scope/:uuid/`, Check(BasicAuth(Audit(GrantLimitedRight))))
+			router.POST(`/grant/system/:rtyp/:rid/`, Check(BasicAuth(Audit(GrantSystemRight))))
+			router.POST(`/groups/:group/members/`, Check(BasicAuth(Audit(AddMemberToGroup))))
+			router.POST(`/groups/:group/property/:type/`, Check(BasicAuth(Audit(AddPropertyToGroup))))
+			router.POST(`/groups/`, Check(BasicAuth(Audit(AddGroup))))
+			router.POST(`/levels/`, Check(BasicAuth(Audit(AddLevel))))
+			router.POST(`/metrics/`, Check(BasicAuth(Audit(AddMetric))))
+			router.POST(`/modes/`, Check(BasicAuth(Audit(AddMode))))
+			router.POST(`/monitoring/`, Check(BasicAuth(Audit(AddMonitoring))))
+			router.POST(`/nodes/:node/property/:type/`, Check(BasicAuth(Audit(AddPropertyToNode))))
+			router.POST(`/nodes/`, Check(BasicAuth(Audit(AddNode))))
+			router.POST(`/objstates/`, Check(BasicAuth(Audit(AddObjectState))))
+			router.POST(`/objtypes/`, Check(BasicAuth(Audit(AddObjectType))))
+			router.POST(`/oncall/`, Check(BasicAuth(Audit(AddOncall))))
+			router.POST(`/permission/`, Check(BasicAuth(Audit(AddPermission))))
+			router.POST(`/predicates/`, Check(BasicAuth(Audit(AddPredicate))))
+			router.POST(`/property/custom/:repository/`, Check(BasicAuth(Audit(AddProperty))))
+			router.POST(`/property/native/`, Check(BasicAuth(Audit(AddProperty))))
+			router.POST(`/property/service/global/`, Check(BasicAuth(Audit(AddProperty))))
+			router.POST(`/property/service/team/:team/`, Check(BasicAuth(Audit(AddProperty))))
+			router.POST(`/property/system/`, Check(BasicAuth(Audit(AddProperty))))
+			router.POST(`/providers/`, Check(BasicAuth(Audit(AddProvider))))
+			router.POST(`/repository/:repository/property/:type/`, Check(BasicAuth(Audit(AddPropertyToRepository))))
+			router.POST(`/repository/`, Check(BasicAuth(Audit(AddRepository))))
+			router.POST(`/servers/:server`, Check(BasicAuth(Audit(InsertNullServer))))
+			router.POST(`/servers/`, Check(BasicAuth(Audit(AddServer))))
+			router.POST(`/status/`, Check(BasicAuth(Audit(AddStatus))))
+			router.POST(`/system/`, Check(BasicAuth(Audit(SystemOperation))))
+			router.POST(`/teams/`, Check(BasicAuth(Audit(AddTeam))))
+			router.POST(`/units/`, Check(BasicAuth(Audit(AddUnit))))
+			router.POST(`/users/`, Check(BasicAuth(Audit(AddUser))))
+			router.POST(`/validity/`, Check(BasicAuth(Audit(AddValidity))))
+			router.POST(`/views/`, Check(BasicAuth(Audit(AddView))))
 			router.PUT(`/authenticate/activate/:uuid`, Check(AuthenticationActivateUser))
 			router.PUT(`/authenticate/bootstrap/:uuid`, Check(AuthenticationBootstrapRoot))
 			router.PUT(`/authenticate/user/password/:uuid`, Check(AuthenticationResetUserPassword))
-			router.PUT(`/datacenters/:datacenter`, Check(BasicAuth(RenameDatacenter)))
-			router.PUT(`/environments/:environment`, Check(BasicAuth(RenameEnvironment)))
+			router.PUT(`/buckets/:bucket/property/:type/:source`, Check(BasicAuth(Audit(UpdatePropertyOnBucket))))
+			router.PUT(`/checks/:repository/:check`, Check(BasicAuth(Audit(UpdateCheckConfiguration))))
+			router.PUT(`/clusters/:cluster/property/:type/:source`, Check(BasicAuth(Audit(UpdatePropertyOnCluster))))
+			router.PUT(`/datacenters/:datacenter`, Check(BasicAuth(Audit(RenameDatacenter))))
+			router.PUT(`/environments/:environment`, Check(BasicAuth(Audit(RenameEnvironment))))
+			router.PUT(`/groups/:group/property/:type/:source`, Check(BasicAuth(Audit(UpdatePropertyOnGroup))))
 			router.PUT(`/jobs/:jobid`, Check(BasicAuth(JobDelay)))
-			router.PUT(`/nodes/:node/config`, Check(BasicAuth(AssignNode)))
-			router.PUT(`/nodes/:node`, Check(BasicAuth(UpdateNode)))
-			router.PUT(`/objstates/:state`, Check(BasicAuth(RenameObjectState)))
-			router.PUT(`/objtypes/:type`, Check(BasicAuth(RenameObjectType)))
-			router.PUT(`/servers/:server`, Check(BasicAuth(UpdateServer)))
-			router.PUT(`/teams/:team`, Check(BasicAuth(UpdateTeam)))
-			router.PUT(`/users/:user`, Check(BasicAuth(UpdateUser)))
+			router.PUT(`/nodes/:node/config`, Check(BasicAuth(Audit(AssignNode))))
+			router.PUT(`/nodes/:node/property/:type/:source`, Check(BasicAuth(Audit(UpdatePropertyOnNode))))
+			router.PUT(`/nodes/:node`, Check(BasicAuth(Audit(UpdateNode))))
+			router.PUT(`/objstates/:state`, Check(BasicAuth(Audit(RenameObjectState))))
+			router.PUT(`/objtypes/:type`, Check(BasicAuth(Audit(RenameObjectType))))
+			router.PUT(`/property/custom/:repository/:custom`, Check(BasicAuth(Audit(UpdateProperty))))
+			router.PUT(`/property/system/:system`, Check(BasicAuth(Audit(UpdateProperty))))
+			router.PUT(`/repository/:repository`, Check(BasicAuth(Audit(PutRepository))))
+			router.PUT(`/repository/:repository/property/:type/:source`, Check(BasicAuth(Audit(UpdatePropertyOnRepository))))
+			router.PUT(`/servers/:server`, Check(BasicAuth(Audit(UpdateServer))))
+			router.PUT(`/teams/:team`, Check(BasicAuth(Audit(UpdateTeam))))
+			router.PUT(`/users/:user`, Check(BasicAuth(Audit(UpdateUser))))
 		}
 		router.POST(`/authenticate/`, Check(AuthenticationKex))
 		router.PUT(`/authenticate/token/:uuid`, Check(AuthenticationIssueToken))
@@ -271,7 +271,7 @@ func exportCheckConfigCustomConstraints(prepStmt *sql.Stmt,
 
 	var (
 		checkConfigId, propertyId, repositoryId string
-		name, value                             string
+		name, value, operator                   string
 		rows                                    *sql.Rows
 		err                                     error
 	)
@@ -289,12 +289,14 @@ func exportCheckConfigCustomConstraints(prepStmt *sql.Stmt,
 			&repositoryId,
 			&value,
 			&name,
+			&operator,
 		); err != nil {
 			rows.Close()
 			return nil, err
 		}
 		cstr := proto.CheckConfigConstraint{
 			ConstraintType: `custom`,
+			Operator:       operator,
 			Custom: &proto.PropertyCustom{
 				Id:           propertyId,
 				RepositoryId: repositoryId,
@@ -316,9 +318,9 @@ func exportCheckConfigSystemConstraints(prepStmt *sql.Stmt,
 	queryId string) ([]proto.CheckConfigConstraint, error) {
 
 	var (
-		checkConfigId, name, value string
-		rows                       *sql.Rows
-		err                        error
+		checkConfigId, name, value, operator string
+		rows                                 *sql.Rows
+		err                                  error
 	)
 
 	constraints := make([]proto.CheckConfigConstraint, 0)
@@ -332,12 +334,14 @@ func exportCheckConfigSystemConstraints(prepStmt *sql.Stmt,
 			&checkConfigId,
 			&name,
 			&value,
+			&operator,
 		); err != nil {
 			rows.Close()
 			return nil, err
 		}
 		cstr := proto.CheckConfigConstraint{
 			ConstraintType: `system`,
+			Operator:       operator,
 			System: &proto.PropertySystem{
 				Name:  name,
 				Value: value,
@@ -357,9 +361,9 @@ func exportCheckConfigNativeConstraints(prepStmt *sql.Stmt,
 	queryId string) ([]proto.CheckConfigConstraint, error) {
 
 	var (
-		checkConfigId, name, value string
-		rows                       *sql.Rows
-		err                        error
+		checkConfigId, name, value, operator string
+		rows                                 *sql.Rows
+		err                                  error
 	)
 
 	constraints := make([]proto.CheckConfigConstraint, 0)
@@ -373,12 +377,14 @@ func exportCheckConfigNativeConstraints(prepStmt *sql.Stmt,
 			&checkConfigId,
 			&name,
 			&value,
+			&operator,
 		); err != nil {
 			rows.Close()
 			return nil, err
 		}
 		cstr := proto.CheckConfigConstraint{
 			ConstraintType: `native`,
+			Operator:       operator,
 			Native: &proto.PropertyNative{
 				Name:  name,
 				Value: value,
@@ -439,9 +445,9 @@ func exportCheckConfigAttributeConstraints(prepStmt *sql.Stmt,
 	queryId string) ([]proto.CheckConfigConstraint, error) {
 
 	var (
-		checkConfigId, name, value string
-		rows                       *sql.Rows
-		err                        error
+		checkConfigId, name, value, operator string
+		rows                                 *sql.Rows
+		err                                  error
 	)
 
 	constraints := make([]proto.CheckConfigConstraint, 0)
@@ -455,12 +461,14 @@ func exportCheckConfigAttributeConstraints(prepStmt *sql.Stmt,
 			&checkConfigId,
 			&name,
 			&value,
+			&operator,
 		); err != nil {
 			rows.Close()
 			return nil, err
 		}
 		cstr := proto.CheckConfigConstraint{
 			ConstraintType: `attribute`,
+			Operator:       operator,
 			Attribute: &proto.ServiceAttribute{
 				Name:  name,
 				Value: value,
@@ -12,6 +12,25 @@ func (g *guidePost) extractRouting(q *treeRequest) (string, string, error, bool)
 
 	repoId, bucketId = g.extractId(q)
 
+	// relocations are routed to the repository the node is
+	// currently assigned to
+	if q.Action == `relocate_node` {
+		repoId = ``
+		if err = g.bucket_for_node.QueryRow(
+			q.Node.Node.Id,
+		).Scan(
+			&bucketId,
+		); err != nil {
+			if err == sql.ErrNoRows {
+				return ``, ``, fmt.Errorf(
+					"Node %s is not assigned to any bucket",
+					q.Node.Node.Id,
+				), true
+			}
+			return ``, ``, err, false
+		}
+	}
+
 	// lookup repository by bucket
 	if bucketId != `` {
 		if err = g.repo_stmt.QueryRow(
@@ -66,12 +85,29 @@ func (g *guidePost) extractId(q *treeRequest) (string, string) {
 		`delete_system_property_from_repository`,
 		`delete_custom_property_from_repository`,
 		`delete_oncall_property_from_repository`,
-		`delete_service_property_from_repository`:
+		`delete_service_property_from_repository`,
+		`update_system_property_on_repository`,
+		`update_custom_property_on_repository`,
+		`update_oncall_property_on_repository`,
+		`update_service_property_on_repository`,
+		`delete_repository`,
+		`restore_repository`,
+		`purge_repository`,
+		`clear_repository`,
+		`rename_repository`,
+		`repossess_repository`,
+		`activate_repository`:
 		return q.Repository.Repository.Id, ``
 	case
 		`create_bucket`:
 		return q.Bucket.Bucket.RepositoryId, ``
 	case
+		`delete_bucket`,
+		`restore_bucket`,
+		`purge_bucket`,
+		`freeze_bucket`,
+		`thaw_bucket`,
+		`rename_bucket`,
 		`add_system_property_to_bucket`,
 		`add_custom_property_to_bucket`,
 		`add_oncall_property_to_bucket`,
@@ -79,13 +115,22 @@ func (g *guidePost) extractId(q *treeRequest) (string, string) {
 		`delete_system_property_from_bucket`,
 		`delete_custom_property_from_bucket`,
 		`delete_oncall_property_from_bucket`,
-		`delete_service_property_from_bucket`:
+		`delete_service_property_from_bucket`,
+		`update_system_property_on_bucket`,
+		`update_custom_property_on_bucket`,
+		`update_oncall_property_on_bucket`,
+		`update_service_property_on_bucket`:
 		return ``, q.Bucket.Bucket.Id
 	case
 		`add_group_to_group`,
 		`add_cluster_to_group`,
 		`add_node_to_group`,
+		`remove_group_from_group`,
+		`remove_cluster_from_group`,
+		`remove_node_from_group`,
 		`create_group`,
+		`delete_group`,
+		`rename_group`,
 		`add_system_property_to_group`,
 		`add_custom_property_to_group`,
 		`add_oncall_property_to_group`,
@@ -93,11 +138,18 @@ func (g *guidePost) extractId(q *treeRequest) (string, string) {
 		`delete_system_property_from_group`,
 		`delete_custom_property_from_group`,
 		`delete_oncall_property_from_group`,
-		`delete_service_property_from_group`:
+		`delete_service_property_from_group`,
+		`update_system_property_on_group`,
+		`update_custom_property_on_group`,
+		`update_oncall_property_on_group`,
+		`update_service_property_on_group`:
 		return ``, q.Group.Group.BucketId
 	case
 		`add_node_to_cluster`,
+		`remove_node_from_cluster`,
 		`create_cluster`,
+		`delete_cluster`,
+		`rename_cluster`,
 		`add_system_property_to_cluster`,
 		`add_custom_property_to_cluster`,
 		`add_oncall_property_to_cluster`,
@@ -105,7 +157,11 @@ func (g *guidePost) extractId(q *treeRequest) (string, string) {
 		`delete_system_property_from_cluster`,
 		`delete_custom_property_from_cluster`,
 		`delete_oncall_property_from_cluster`,
-		`delete_service_property_from_cluster`:
+		`delete_service_property_from_cluster`,
+		`update_system_property_on_cluster`,
+		`update_custom_property_on_cluster`,
+		`update_oncall_property_on_cluster`,
+		`update_service_property_on_cluster`:
 		return ``, q.Cluster.Cluster.BucketId
 	case
 		`add_check_to_repository`,
@@ -113,10 +169,12 @@ func (g *guidePost) extractId(q *treeRequest) (string, string) {
 		`add_check_to_group`,
 		`add_check_to_cluster`,
 		`add_check_to_node`,
-		`remove_check`:
+		`remove_check`,
+		`update_check`:
 		return q.CheckConfig.CheckConfig.RepositoryId, ``
 	case
 		`assign_node`,
+		`relocate_node`,
 		`add_system_property_to_node`,
 		`add_custom_property_to_node`,
 		`add_oncall_property_to_node`,
@@ -124,7 +182,11 @@ func (g *guidePost) extractId(q *treeRequest) (string, string) {
 		`delete_system_property_from_node`,
 		`delete_custom_property_from_node`,
 		`delete_oncall_property_from_node`,
-		`delete_service_property_from_node`:
+		`delete_service_property_from_node`,
+		`update_system_property_on_node`,
+		`update_custom_property_on_node`,
+		`update_oncall_property_on_node`,
+		`update_service_property_on_node`:
 		return q.Node.Node.Config.RepositoryId, q.Node.Node.Config.BucketId
 	}
 	return ``, ``
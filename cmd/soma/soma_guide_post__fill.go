@@ -16,13 +16,18 @@ func (g *guidePost) fillReqData(q *treeRequest) (error, bool) {
 	switch {
 	case strings.Contains(q.Action, "add_service_property_to_"):
 		return g.fillServiceAttributes(q)
-	case q.Action == `assign_node`:
+	case q.Action == `assign_node`, q.Action == `relocate_node`:
 		return g.fillNode(q)
 	case q.Action == `remove_check`:
 		return g.fillCheckDeleteInfo(q)
+	case q.Action == `update_check`:
+		return g.fillCheckUpdateInfo(q)
 	case strings.HasPrefix(q.Action, `delete_`) &&
 		strings.Contains(q.Action, `_property_from_`):
 		return g.fillPropertyDeleteInfo(q)
+	case strings.HasPrefix(q.Action, `update_`) &&
+		strings.Contains(q.Action, `_property_on_`):
+		return g.fillPropertyUpdateInfo(q)
 	case strings.HasPrefix(q.Action, `add_check_to_`):
 		return g.fillCheckConfigId(q)
 	default:
@@ -42,6 +47,7 @@ func (g *guidePost) fillNode(q *treeRequest) (error, bool) {
 	var (
 		err                      error
 		ndName, ndTeam, ndServer string
+		ndServerName             string
 		ndAsset                  int64
 		ndOnline, ndDeleted      bool
 	)
@@ -52,6 +58,7 @@ func (g *guidePost) fillNode(q *treeRequest) (error, bool) {
 		&ndServer,
 		&ndOnline,
 		&ndDeleted,
+		&ndServerName,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("Node not found: %s", q.Node.Node.Id), true
@@ -64,6 +71,13 @@ func (g *guidePost) fillNode(q *treeRequest) (error, bool) {
 	q.Node.Node.ServerId = ndServer
 	q.Node.Node.IsOnline = ndOnline
 	q.Node.Node.IsDeleted = ndDeleted
+	if q.Node.Node.Details == nil {
+		q.Node.Node.Details = &proto.Details{}
+	}
+	q.Node.Node.Details.Server = proto.Server{
+		Id:   ndServer,
+		Name: ndServerName,
+	}
 	return nil, false
 }
 
@@ -172,6 +186,29 @@ func (g *guidePost) fillCheckDeleteInfo(q *treeRequest) (error, bool) {
 	return nil, false
 }
 
+// if the request is a check update, populate the source check id
+func (g *guidePost) fillCheckUpdateInfo(q *treeRequest) (error, bool) {
+	var updObjId, updObjTyp, updSrcChkId string
+
+	if err := g.cdel_stmt.QueryRow(
+		q.CheckConfig.CheckConfig.Id,
+		q.CheckConfig.CheckConfig.RepositoryId,
+	).Scan(
+		&updObjId,
+		&updObjTyp,
+		&updSrcChkId,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf(
+				"Failed to find source check for config %s",
+				q.CheckConfig.CheckConfig.Id), true
+		}
+		return err, false
+	}
+	q.CheckConfig.CheckConfig.ExternalId = updSrcChkId
+	return nil, false
+}
+
 // if the request is a property deletion, populate required IDs
 func (g *guidePost) fillPropertyDeleteInfo(q *treeRequest) (error, bool) {
 	var (
@@ -362,4 +399,167 @@ func (g *guidePost) fillPropertyDeleteInfo(q *treeRequest) (error, bool) {
 	return nil, false
 }
 
+// if the request is a property update, verify the source property
+// exists and populate the parts of it that can not be changed
+func (g *guidePost) fillPropertyUpdateInfo(q *treeRequest) (error, bool) {
+	var (
+		err                                             error
+		prop                                            *proto.Property
+		queryStmt, view, sysProp, value, cstId, cstProp string
+		svcProp, oncId, oncName, oncNumber              string
+		num                                             int
+	)
+
+	switch q.RequestType {
+	case `repository`:
+		prop = &(*q.Repository.Repository.Properties)[0]
+	case `bucket`:
+		prop = &(*q.Bucket.Bucket.Properties)[0]
+	case `group`:
+		prop = &(*q.Group.Group.Properties)[0]
+	case `cluster`:
+		prop = &(*q.Cluster.Cluster.Properties)[0]
+	case `node`:
+		prop = &(*q.Node.Node.Properties)[0]
+	}
+
+	// select SQL statement, the source property is looked up the
+	// same way as for deletes
+	switch q.Action {
+	case `update_system_property_on_repository`:
+		queryStmt = stmt.RepoSystemPropertyForDelete
+	case `update_custom_property_on_repository`:
+		queryStmt = stmt.RepoCustomPropertyForDelete
+	case `update_service_property_on_repository`:
+		queryStmt = stmt.RepoServicePropertyForDelete
+	case `update_oncall_property_on_repository`:
+		queryStmt = stmt.RepoOncallPropertyForDelete
+	case `update_system_property_on_bucket`:
+		queryStmt = stmt.BucketSystemPropertyForDelete
+	case `update_custom_property_on_bucket`:
+		queryStmt = stmt.BucketCustomPropertyForDelete
+	case `update_service_property_on_bucket`:
+		queryStmt = stmt.BucketServicePropertyForDelete
+	case `update_oncall_property_on_bucket`:
+		queryStmt = stmt.BucketOncallPropertyForDelete
+	case `update_system_property_on_group`:
+		queryStmt = stmt.GroupSystemPropertyForDelete
+	case `update_custom_property_on_group`:
+		queryStmt = stmt.GroupCustomPropertyForDelete
+	case `update_service_property_on_group`:
+		queryStmt = stmt.GroupServicePropertyForDelete
+	case `update_oncall_property_on_group`:
+		queryStmt = stmt.GroupOncallPropertyForDelete
+	case `update_system_property_on_cluster`:
+		queryStmt = stmt.ClusterSystemPropertyForDelete
+	case `update_custom_property_on_cluster`:
+		queryStmt = stmt.ClusterCustomPropertyForDelete
+	case `update_service_property_on_cluster`:
+		queryStmt = stmt.ClusterServicePropertyForDelete
+	case `update_oncall_property_on_cluster`:
+		queryStmt = stmt.ClusterOncallPropertyForDelete
+	case `update_system_property_on_node`:
+		queryStmt = stmt.NodeSystemPropertyForDelete
+	case `update_custom_property_on_node`:
+		queryStmt = stmt.NodeCustomPropertyForDelete
+	case `update_service_property_on_node`:
+		queryStmt = stmt.NodeServicePropertyForDelete
+	case `update_oncall_property_on_node`:
+		queryStmt = stmt.NodeOncallPropertyForDelete
+	}
+
+	// execute and scan
+	row := g.conn.QueryRow(queryStmt, prop.SourceInstanceId)
+	switch prop.Type {
+	case `system`:
+		err = row.Scan(&view, &sysProp, &value)
+	case `custom`:
+		err = row.Scan(&view, &cstId, &value, &cstProp)
+	case `service`:
+		err = row.Scan(&view, &svcProp)
+	case `oncall`:
+		err = row.Scan(&view, &oncId, &oncName, &num)
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf(
+				"Failed to find source property for %s",
+				prop.SourceInstanceId), true
+		}
+		return err, false
+	}
+
+	// the view and the property key are fixed, only the value and
+	// the inheritance flags can be updated
+	if prop.View != `` && prop.View != view {
+		return fmt.Errorf("Property view can not be updated: %s, %s",
+			view, prop.View), false
+	}
+	prop.View = view
+
+	switch prop.Type {
+	case `system`:
+		if prop.System == nil {
+			return fmt.Errorf(`Missing system property specification`),
+				false
+		}
+		if prop.System.Name != `` && prop.System.Name != sysProp {
+			return fmt.Errorf(
+				"System property name can not be updated: %s, %s",
+				sysProp, prop.System.Name), false
+		}
+		prop.System.Name = sysProp
+	case `custom`:
+		if prop.Custom == nil {
+			return fmt.Errorf(`Missing custom property specification`),
+				false
+		}
+		if prop.Custom.Id != `` && prop.Custom.Id != cstId {
+			return fmt.Errorf(
+				"Custom property id can not be updated: %s, %s",
+				cstId, prop.Custom.Id), false
+		}
+		prop.Custom.Id = cstId
+		prop.Custom.Name = cstProp
+	case `service`:
+		if prop.Service == nil {
+			return fmt.Errorf(`Missing service property specification`),
+				false
+		}
+		if prop.Service.Name != svcProp {
+			return fmt.Errorf(
+				"Service property name can not be updated: %s, %s",
+				svcProp, prop.Service.Name), false
+		}
+		// reload the attributes, they may have changed since the
+		// service was attached
+		return g.fillServiceAttributes(q)
+	case `oncall`:
+		if prop.Oncall == nil {
+			return fmt.Errorf(`Missing oncall property specification`),
+				false
+		}
+		// the oncall duty can be replaced, load the details of the
+		// requested one
+		if prop.Oncall.Id == `` {
+			prop.Oncall.Id = oncId
+		}
+		if err = g.conn.QueryRow(stmt.OncallShow, prop.Oncall.Id).Scan(
+			&oncId,
+			&oncName,
+			&oncNumber,
+		); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("Oncall duty not found: %s",
+					prop.Oncall.Id), true
+			}
+			return err, false
+		}
+		prop.Oncall.Name = oncName
+		prop.Oncall.Number = oncNumber
+	}
+	// the new value has to be accepted by the value schema
+	return g.validatePropertyValue(q)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
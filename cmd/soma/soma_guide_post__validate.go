@@ -4,13 +4,24 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+
+	"github.com/1and1/soma/internal/stmt"
+	"github.com/1and1/soma/internal/tree"
+	"github.com/1and1/soma/lib/proto"
 )
 
 func (g *guidePost) validateRequest(q *treeRequest) (error, bool) {
 	switch q.RequestType {
 	case `check`:
-		if err, nf := g.validateCheckObjectInBucket(q); err != nil {
-			return err, nf
+		switch q.Action {
+		case `update_check`:
+			if err, nf := g.validateCheckUpdate(q); err != nil {
+				return err, nf
+			}
+		default:
+			if err, nf := g.validateCheckObjectInBucket(q); err != nil {
+				return err, nf
+			}
 		}
 	case `node`:
 		if err, nf := g.validateNodeConfig(q); err != nil {
@@ -22,11 +33,23 @@ func (g *guidePost) validateRequest(q *treeRequest) (error, bool) {
 			return err, nf
 		}
 	case `bucket`:
-		if err, nf := g.validateBucketInRepository(
-			q.Bucket.Bucket.RepositoryId,
-			q.Bucket.Bucket.Id,
-		); err != nil {
-			return err, nf
+		switch q.Action {
+		case
+			`delete_bucket`,
+			`restore_bucket`,
+			`purge_bucket`,
+			`freeze_bucket`,
+			`thaw_bucket`,
+			`rename_bucket`:
+			// the bucket id is the routing information for these
+			// requests
+		default:
+			if err, nf := g.validateBucketInRepository(
+				q.Bucket.Bucket.RepositoryId,
+				q.Bucket.Bucket.Id,
+			); err != nil {
+				return err, nf
+			}
 		}
 	case `repository`:
 		// since repository ids are the routing information,
@@ -36,6 +59,14 @@ func (g *guidePost) validateRequest(q *treeRequest) (error, bool) {
 		return fmt.Errorf("Invalid request type %s", q.RequestType), false
 	}
 
+	if err, nf := g.validateRepositoryState(q); err != nil {
+		return err, nf
+	}
+
+	if err, nf := g.validateBucketState(q); err != nil {
+		return err, nf
+	}
+
 	switch q.Action {
 	case
 		`add_node_to_cluster`,
@@ -43,22 +74,47 @@ func (g *guidePost) validateRequest(q *treeRequest) (error, bool) {
 		`add_cluster_to_group`,
 		`add_group_to_group`:
 		return g.validateObjectMatch(q)
+	case
+		`remove_cluster_from_group`,
+		`remove_group_from_group`,
+		`remove_node_from_cluster`,
+		`remove_node_from_group`:
+		return g.validateMembership(q)
 	case
 		`add_check_to_bucket`,
 		`add_check_to_cluster`,
 		`add_check_to_group`,
 		`add_check_to_node`,
-		`add_check_to_repository`:
+		`add_check_to_repository`,
+		`update_check`:
+		if err, nf := g.validateCheckConstraints(q); err != nil {
+			return err, nf
+		}
 		return g.validateCheckThresholds(q)
 	case
-		`create_bucket`:
+		`create_bucket`,
+		`rename_bucket`:
 		return g.validateBucketName(q)
+	case
+		`repossess_repository`:
+		return g.validateRepositoryTeamBound(q)
+	case
+		`relocate_node`:
+		return g.validateNodeRelocation(q)
 	case
 		`add_custom_property_to_bucket`,
 		`add_custom_property_to_cluster`,
 		`add_custom_property_to_group`,
 		`add_custom_property_to_node`,
 		`add_custom_property_to_repository`,
+		`add_system_property_to_bucket`,
+		`add_system_property_to_cluster`,
+		`add_system_property_to_group`,
+		`add_system_property_to_node`,
+		`add_system_property_to_repository`:
+		return g.validatePropertyValue(q)
+	case
+		`activate_repository`,
 		`add_oncall_property_to_bucket`,
 		`add_oncall_property_to_cluster`,
 		`add_oncall_property_to_group`,
@@ -69,24 +125,24 @@ func (g *guidePost) validateRequest(q *treeRequest) (error, bool) {
 		`add_service_property_to_group`,
 		`add_service_property_to_node`,
 		`add_service_property_to_repository`,
-		`add_system_property_to_bucket`,
-		`add_system_property_to_cluster`,
-		`add_system_property_to_group`,
-		`add_system_property_to_node`,
-		`add_system_property_to_repository`,
 		`assign_node`,
+		`clear_repository`,
 		`create_cluster`,
 		`create_group`,
+		`delete_bucket`,
+		`delete_cluster`,
 		`delete_custom_property_from_bucket`,
 		`delete_custom_property_from_cluster`,
 		`delete_custom_property_from_group`,
 		`delete_custom_property_from_node`,
 		`delete_custom_property_from_repository`,
+		`delete_group`,
 		`delete_oncall_property_from_bucket`,
 		`delete_oncall_property_from_cluster`,
 		`delete_oncall_property_from_group`,
 		`delete_oncall_property_from_node`,
 		`delete_oncall_property_from_repository`,
+		`delete_repository`,
 		`delete_service_property_from_bucket`,
 		`delete_service_property_from_cluster`,
 		`delete_service_property_from_group`,
@@ -97,7 +153,36 @@ func (g *guidePost) validateRequest(q *treeRequest) (error, bool) {
 		`delete_system_property_from_group`,
 		`delete_system_property_from_node`,
 		`delete_system_property_from_repository`,
-		`remove_check`:
+		`freeze_bucket`,
+		`purge_bucket`,
+		`purge_repository`,
+		`remove_check`,
+		`rename_cluster`,
+		`rename_group`,
+		`rename_repository`,
+		`restore_bucket`,
+		`restore_repository`,
+		`thaw_bucket`,
+		`update_custom_property_on_bucket`,
+		`update_custom_property_on_cluster`,
+		`update_custom_property_on_group`,
+		`update_custom_property_on_node`,
+		`update_custom_property_on_repository`,
+		`update_oncall_property_on_bucket`,
+		`update_oncall_property_on_cluster`,
+		`update_oncall_property_on_group`,
+		`update_oncall_property_on_node`,
+		`update_oncall_property_on_repository`,
+		`update_service_property_on_bucket`,
+		`update_service_property_on_cluster`,
+		`update_service_property_on_group`,
+		`update_service_property_on_node`,
+		`update_service_property_on_repository`,
+		`update_system_property_on_bucket`,
+		`update_system_property_on_cluster`,
+		`update_system_property_on_group`,
+		`update_system_property_on_node`,
+		`update_system_property_on_repository`:
 		// actions are accepted, but require no further validation
 		return nil, false
 	default:
@@ -105,6 +190,53 @@ func (g *guidePost) validateRequest(q *treeRequest) (error, bool) {
 	}
 }
 
+// Verify that the object to be removed is a direct member of the
+// group or cluster
+func (g *guidePost) validateMembership(q *treeRequest) (error, bool) {
+	var (
+		isMember           bool
+		err                error
+		parentId, memberId string
+	)
+
+	switch q.Action {
+	case `remove_node_from_cluster`:
+		parentId = q.Cluster.Cluster.Id
+		memberId = (*q.Cluster.Cluster.Members)[0].Id
+		err = g.cluster_has_member.QueryRow(
+			parentId,
+			memberId,
+		).Scan(&isMember)
+	case `remove_node_from_group`:
+		parentId = q.Group.Group.Id
+		memberId = (*q.Group.Group.MemberNodes)[0].Id
+	case `remove_cluster_from_group`:
+		parentId = q.Group.Group.Id
+		memberId = (*q.Group.Group.MemberClusters)[0].Id
+	case `remove_group_from_group`:
+		parentId = q.Group.Group.Id
+		memberId = (*q.Group.Group.MemberGroups)[0].Id
+	default:
+		return fmt.Errorf("Incorrect validation attempted for %s",
+			q.Action), false
+	}
+
+	if q.RequestType == `group` {
+		err = g.group_has_member.QueryRow(
+			parentId,
+			memberId,
+		).Scan(&isMember)
+	}
+	if err != nil {
+		return err, false
+	}
+	if !isMember {
+		return fmt.Errorf("%s is not a member of %s %s",
+			memberId, q.RequestType, parentId), true
+	}
+	return nil, false
+}
+
 func (g *guidePost) validateObjectMatch(q *treeRequest) (error, bool) {
 	var (
 		nodeId, clusterId, groupId, childGroupId              string
@@ -208,6 +340,9 @@ func (g *guidePost) validateCorrectBucket(q *treeRequest) (error, bool) {
 	switch q.Action {
 	case `assign_node`:
 		return g.validateNodeUnassigned(q)
+	case `relocate_node`:
+		// validated by validateNodeRelocation
+		return nil, false
 	case `create_cluster`, `create_group`:
 		return nil, false
 	}
@@ -360,6 +495,37 @@ func (g *guidePost) validateBucketInRepository(
 	return nil, false
 }
 
+// Verify that the check configuration exists and that the update
+// does not change the object or capability of the check.
+func (g *guidePost) validateCheckUpdate(q *treeRequest) (error, bool) {
+	var objId, objType, capId string
+
+	if err := g.cupd_stmt.QueryRow(
+		q.CheckConfig.CheckConfig.Id,
+		q.CheckConfig.CheckConfig.RepositoryId,
+	).Scan(
+		&objId,
+		&objType,
+		&capId,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("Check configuration %s not found",
+				q.CheckConfig.CheckConfig.Id), true
+		}
+		return err, false
+	}
+	switch {
+	case objId != q.CheckConfig.CheckConfig.ObjectId,
+		objType != q.CheckConfig.CheckConfig.ObjectType:
+		return fmt.Errorf("Check configuration %s can not be moved"+
+			" to a different object", q.CheckConfig.CheckConfig.Id), false
+	case capId != q.CheckConfig.CheckConfig.CapabilityId:
+		return fmt.Errorf("Check configuration %s can not change"+
+			" its capability", q.CheckConfig.CheckConfig.Id), false
+	}
+	return nil, false
+}
+
 // check the check configuration to contain fewer thresholds than
 // the limit for the capability
 func (g *guidePost) validateCheckThresholds(q *treeRequest) (error, bool) {
@@ -387,6 +553,166 @@ func (g *guidePost) validateCheckThresholds(q *treeRequest) (error, bool) {
 	return nil, false
 }
 
+// Verify that the constraint operators are supported for the
+// constraint types and that their patterns and values are valid.
+// The operator == is stored as exact match.
+func (g *guidePost) validateCheckConstraints(q *treeRequest) (error, bool) {
+	constraints := q.CheckConfig.CheckConfig.Constraints
+	for i := range constraints {
+		value, err := checkConstraintValue(constraints[i])
+		if err != nil {
+			return err, false
+		}
+		if err = tree.ValidateConstraintOperator(
+			constraints[i].ConstraintType,
+			constraints[i].Operator,
+			value,
+		); err != nil {
+			return err, false
+		}
+		if constraints[i].Operator == `==` {
+			constraints[i].Operator = ``
+		}
+	}
+	return nil, false
+}
+
+// checkConstraintValue returns the value of check constraint c. It
+// fails if the specification for the type of c is missing.
+func checkConstraintValue(c proto.CheckConfigConstraint) (string, error) {
+	missing := fmt.Errorf("Missing %s constraint specification",
+		c.ConstraintType)
+	switch c.ConstraintType {
+	case `native`:
+		if c.Native == nil {
+			return ``, missing
+		}
+		return c.Native.Value, nil
+	case `system`:
+		if c.System == nil {
+			return ``, missing
+		}
+		return c.System.Value, nil
+	case `custom`:
+		if c.Custom == nil {
+			return ``, missing
+		}
+		return c.Custom.Value, nil
+	case `attribute`:
+		if c.Attribute == nil {
+			return ``, missing
+		}
+		return c.Attribute.Value, nil
+	case `service`:
+		if c.Service == nil {
+			return ``, missing
+		}
+	case `oncall`:
+		if c.Oncall == nil {
+			return ``, missing
+		}
+	default:
+		return ``, fmt.Errorf("Unknown constraint type: %s",
+			c.ConstraintType)
+	}
+	return ``, nil
+}
+
+// validateConstraintSpecs checks that all constraints carry the
+// specification for their type
+func validateConstraintSpecs(constraints []proto.CheckConfigConstraint) error {
+	for i := range constraints {
+		if _, err := checkConstraintValue(constraints[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Verify that the value of an attached system or custom property is
+// accepted by the value schema of the property. Updates are validated
+// once fillPropertyUpdateInfo has loaded the property from the
+// source instance.
+func (g *guidePost) validatePropertyValue(q *treeRequest) (error, bool) {
+	var (
+		prop      *proto.Property
+		rawSchema sql.NullString
+		name, id  string
+		repoId    string
+		err       error
+	)
+
+	switch q.RequestType {
+	case `repository`:
+		prop = &(*q.Repository.Repository.Properties)[0]
+	case `bucket`:
+		prop = &(*q.Bucket.Bucket.Properties)[0]
+	case `group`:
+		prop = &(*q.Group.Group.Properties)[0]
+	case `cluster`:
+		prop = &(*q.Cluster.Cluster.Properties)[0]
+	case `node`:
+		prop = &(*q.Node.Node.Properties)[0]
+	}
+
+	switch prop.Type {
+	case `system`:
+		if prop.System == nil {
+			return fmt.Errorf(`Missing system property specification`),
+				false
+		}
+		err = g.conn.QueryRow(
+			stmt.PropertySystemShow,
+			prop.System.Name,
+		).Scan(
+			&name,
+			&rawSchema,
+		)
+	case `custom`:
+		if prop.Custom == nil {
+			return fmt.Errorf(`Missing custom property specification`),
+				false
+		}
+		if repoId, _, err, _ = g.extractRouting(q); err != nil {
+			return err, false
+		}
+		err = g.conn.QueryRow(
+			stmt.PropertyCustomShow,
+			prop.Custom.Id,
+			repoId,
+		).Scan(
+			&id,
+			&repoId,
+			&name,
+			&rawSchema,
+		)
+	default:
+		return nil, false
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("Unknown %s property", prop.Type), true
+		}
+		return err, false
+	}
+
+	schema, err := unmarshalPropertySchema(rawSchema)
+	if err != nil {
+		return err, false
+	}
+	switch prop.Type {
+	case `system`:
+		err = tree.ValidatePropertyValue(schema, prop.System.Value)
+	case `custom`:
+		err = tree.ValidatePropertyValue(schema, prop.Custom.Value)
+	}
+	if err != nil {
+		return fmt.Errorf("Invalid value for %s property %s: %s",
+			prop.Type, name, err.Error()), false
+	}
+	return nil, false
+}
+
 // check the naming schema for the bucket (global unique object)
 func (g *guidePost) validateBucketName(q *treeRequest) (error, bool) {
 	_, repoName, _, _ := g.extractRouting(q)
@@ -401,16 +727,191 @@ func (g *guidePost) validateBucketName(q *treeRequest) (error, bool) {
 	return nil, false
 }
 
+// Verify that the bucket a request is routed to is in a state that
+// allows the requested action. Deleted buckets only accept restore and
+// purge requests, frozen buckets only accept being thawed.
+func (g *guidePost) validateBucketState(q *treeRequest) (error, bool) {
+	var frozen, deleted bool
+
+	_, bucketId := g.extractId(q)
+	if bucketId == `` {
+		return nil, false
+	}
+
+	if err := g.bucket_state.QueryRow(bucketId).Scan(
+		&frozen,
+		&deleted,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("Unknown bucket %s", bucketId), true
+		}
+		return err, false
+	}
+
+	switch q.Action {
+	case `restore_bucket`, `purge_bucket`:
+		if !deleted {
+			return fmt.Errorf("Bucket %s is not deleted", bucketId), false
+		}
+	case `thaw_bucket`:
+		if !frozen {
+			return fmt.Errorf("Bucket %s is not frozen", bucketId), false
+		}
+	default:
+		if deleted {
+			return fmt.Errorf("Bucket %s is deleted", bucketId), false
+		}
+		if frozen {
+			return fmt.Errorf("Bucket %s is frozen", bucketId), false
+		}
+	}
+	return nil, false
+}
+
+// Verify that the repository a request is routed to is in a state
+// that allows the requested action. Deleted repositories only accept
+// restore and purge requests, purged repositories accept nothing.
+func (g *guidePost) validateRepositoryState(q *treeRequest) (error, bool) {
+	var active, deleted bool
+
+	repoId, _, err, nf := g.extractRouting(q)
+	if err != nil {
+		return err, nf
+	}
+
+	if err = g.repo_state.QueryRow(repoId).Scan(
+		&active,
+		&deleted,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("Unknown repository %s", repoId), true
+		}
+		return err, false
+	}
+
+	if deleted && !active {
+		return fmt.Errorf("Repository %s has been purged", repoId), true
+	}
+
+	switch q.Action {
+	case `restore_repository`, `purge_repository`:
+		if !deleted {
+			return fmt.Errorf("Repository %s is not deleted",
+				repoId), false
+		}
+	case `activate_repository`:
+		if active {
+			return fmt.Errorf("Repository %s is already active",
+				repoId), false
+		}
+		fallthrough
+	default:
+		if deleted {
+			return fmt.Errorf("Repository %s is deleted", repoId), false
+		}
+		// deleting an inactive repository would be indistinguishable
+		// from a purged one
+		if q.Action == `delete_repository` && !active {
+			return fmt.Errorf("Repository %s is not active", repoId), false
+		}
+	}
+	return nil, false
+}
+
+// Verify that no team bound objects are inside the repository. Nodes
+// and service properties are owned by their team and can not change
+// hands together with the repository.
+func (g *guidePost) validateRepositoryTeamBound(q *treeRequest) (error, bool) {
+	var bound bool
+
+	if err := g.repo_team_bound.QueryRow(
+		q.Repository.Repository.Id,
+	).Scan(&bound); err != nil {
+		return err, false
+	}
+	if bound {
+		return fmt.Errorf("Repository %s contains nodes or service"+
+			" properties and can not be repossessed",
+			q.Repository.Repository.Id), false
+	}
+	return nil, false
+}
+
+// Verify that a node can be moved from its current bucket into the
+// bucket of its NodeConfig. The target bucket and the source
+// repository have already been validated via the request routing.
+func (g *guidePost) validateNodeRelocation(q *treeRequest) (error, bool) {
+	var (
+		bucketId, repoName  string
+		frozen, deleted     bool
+		active, repoDeleted bool
+	)
+
+	if err := g.bucket_for_node.QueryRow(q.Node.Node.Id).Scan(
+		&bucketId,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("Node %s is not assigned to any bucket",
+				q.Node.Node.Id), true
+		}
+		return err, false
+	}
+	if bucketId == q.Node.Node.Config.BucketId {
+		return fmt.Errorf("Node already assigned to bucket %s",
+			bucketId), false
+	}
+
+	// the node must be allowed to leave its current bucket
+	if err := g.bucket_state.QueryRow(bucketId).Scan(
+		&frozen,
+		&deleted,
+	); err != nil {
+		return err, false
+	}
+	if deleted {
+		return fmt.Errorf("Bucket %s is deleted", bucketId), false
+	}
+	if frozen {
+		return fmt.Errorf("Bucket %s is frozen", bucketId), false
+	}
+
+	// the target repository must accept the node
+	if err := g.repo_state.QueryRow(
+		q.Node.Node.Config.RepositoryId,
+	).Scan(
+		&active,
+		&repoDeleted,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("Unknown repository %s",
+				q.Node.Node.Config.RepositoryId), true
+		}
+		return err, false
+	}
+	if repoDeleted || !active {
+		return fmt.Errorf("Repository %s is not available",
+			q.Node.Node.Config.RepositoryId), false
+	}
+	if err := g.name_stmt.QueryRow(
+		q.Node.Node.Config.RepositoryId,
+	).Scan(
+		&repoName,
+	); err != nil {
+		return err, false
+	}
+	return g.validateKeeper(repoName)
+}
+
 // validate current treekeeper state
 func (g *guidePost) validateKeeper(repoName string) (error, bool) {
 	// check we have a treekeeper for that repository
 	keeper := fmt.Sprintf("repository_%s", repoName)
-	if _, ok := handlerMap[keeper].(*treeKeeper); !ok {
+	if _, ok := handlerMap.Get(keeper).(*treeKeeper); !ok {
 		return fmt.Errorf(
 			"No handler for repository %s currently registered.",
 			repoName), true
 	}
-	handler := handlerMap[keeper].(*treeKeeper)
+	handler := handlerMap.Get(keeper).(*treeKeeper)
 
 	// check the treekeeper has not been stopped
 	if handler.isStopped() {
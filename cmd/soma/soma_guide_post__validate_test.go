@@ -0,0 +1,52 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/1and1/soma/lib/proto"
+)
+
+func TestValidateConstraintSpecs(t *testing.T) {
+	tests := []struct {
+		constraint proto.CheckConfigConstraint
+		valid      bool
+	}{
+		{proto.CheckConfigConstraint{ConstraintType: `native`}, false},
+		{proto.CheckConfigConstraint{ConstraintType: `system`}, false},
+		{proto.CheckConfigConstraint{ConstraintType: `custom`}, false},
+		{proto.CheckConfigConstraint{ConstraintType: `attribute`}, false},
+		{proto.CheckConfigConstraint{ConstraintType: `service`}, false},
+		{proto.CheckConfigConstraint{ConstraintType: `oncall`}, false},
+		{proto.CheckConfigConstraint{ConstraintType: `unknown`}, false},
+		{proto.CheckConfigConstraint{
+			ConstraintType: `system`,
+			System:         &proto.PropertySystem{Name: `fqdn`},
+		}, true},
+		{proto.CheckConfigConstraint{
+			ConstraintType: `service`,
+			Service:        &proto.PropertyService{Name: `nginx`},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		err := validateConstraintSpecs(
+			[]proto.CheckConfigConstraint{tt.constraint})
+		if tt.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s",
+				tt.constraint.ConstraintType, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: missing specification accepted",
+				tt.constraint.ConstraintType)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -215,8 +215,24 @@ func (r *somaAttributeReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaAttributeReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaAttributeReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaAttributeWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaAttributeWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaAttributeWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/1and1/soma/internal/msg"
+	"github.com/1and1/soma/internal/stmt"
+	"github.com/1and1/soma/lib/proto"
+	log "github.com/Sirupsen/logrus"
+	"github.com/lib/pq"
+)
+
+// auditWrite persists the audit records of mutating requests
+type auditWrite struct {
+	input    chan *auditRecord
+	shutdown chan bool
+	conn     *sql.DB
+	add_stmt *sql.Stmt
+	appLog   *log.Logger
+	reqLog   *log.Logger
+	errLog   *log.Logger
+}
+
+func (a *auditWrite) run() {
+	var err error
+
+	if a.add_stmt, err = a.conn.Prepare(stmt.AuditInsert); err != nil {
+		a.errLog.Fatal(`audit`, err, stmt.Name(stmt.AuditInsert))
+	}
+	defer a.add_stmt.Close()
+
+runloop:
+	for {
+		select {
+		case <-a.shutdown:
+			break runloop
+		case rec := <-a.input:
+			a.process(rec)
+		}
+	}
+}
+
+func (a *auditWrite) process(rec *auditRecord) {
+	rec.Lock()
+	defer rec.Unlock()
+
+	authorized := sql.NullBool{}
+	if rec.entry.Authorized != nil {
+		authorized.Bool = *rec.entry.Authorized
+		authorized.Valid = true
+	}
+
+	if _, err := a.add_stmt.Exec(
+		rec.entry.Id,
+		rec.time,
+		rec.entry.User,
+		rec.entry.RemoteAddress,
+		rec.entry.Method,
+		rec.entry.Route,
+		rec.entry.Path,
+		pq.Array(rec.entry.Permissions),
+		authorized,
+		rec.entry.StatusCode,
+		rec.entry.Body,
+	); err != nil {
+		// the request itself has already been served
+		a.errLog.Printf(LogStrErr, `audit`, `insert`, 0,
+			fmt.Sprintf("%s: %s", rec.entry.Id, err.Error()))
+	}
+}
+
+/* Ops Access
+ */
+func (a *auditWrite) shutdownNow() {
+	a.shutdown <- true
+}
+
+func (a *auditWrite) queueLen() int {
+	return len(a.input)
+}
+
+func (a *auditWrite) queueCap() int {
+	return cap(a.input)
+}
+
+// auditRead searches the audit trail
+type auditRead struct {
+	input       chan msg.Request
+	shutdown    chan bool
+	conn        *sql.DB
+	search_stmt *sql.Stmt
+	appLog      *log.Logger
+	reqLog      *log.Logger
+	errLog      *log.Logger
+}
+
+func (a *auditRead) run() {
+	var err error
+
+	if a.search_stmt, err = a.conn.Prepare(stmt.AuditSearch); err != nil {
+		a.errLog.Fatal(`audit`, err, stmt.Name(stmt.AuditSearch))
+	}
+	defer a.search_stmt.Close()
+
+runloop:
+	for {
+		select {
+		case <-a.shutdown:
+			break runloop
+		case req := <-a.input:
+			go func() {
+				a.process(&req)
+			}()
+		}
+	}
+}
+
+func (a *auditRead) process(q *msg.Request) {
+	result := msg.Result{Type: q.Type, Action: q.Action,
+		Audit: []proto.AuditEntry{}}
+	var (
+		err                                 error
+		rows                                *sql.Rows
+		since, until                        interface{}
+		auditTime                           time.Time
+		id, user, addr, method, route, path string
+		body                                string
+		authorized                          sql.NullBool
+		status                              int
+		permissions                         []string
+	)
+
+	switch q.Action {
+	case `search`:
+		a.reqLog.Printf(LogStrArg, q.Type, q.Action, q.User,
+			q.RemoteAddr, fmt.Sprintf("%+v", q.Search.Audit))
+
+		if since, err = auditTimestamp(q.Search.Audit.Since); err != nil {
+			result.BadRequest(err)
+			goto dispatch
+		}
+		if until, err = auditTimestamp(q.Search.Audit.Until); err != nil {
+			result.BadRequest(err)
+			goto dispatch
+		}
+
+		if rows, err = a.search_stmt.Query(
+			q.Search.Audit.User,
+			q.Search.Audit.Object,
+			since,
+			until,
+		); err != nil {
+			result.ServerError(err)
+			goto dispatch
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if err = rows.Scan(
+				&id,
+				&auditTime,
+				&user,
+				&addr,
+				&method,
+				&route,
+				&path,
+				pq.Array(&permissions),
+				&authorized,
+				&status,
+				&body,
+			); err != nil {
+				result.ServerError(err)
+				result.Clear(q.Type)
+				goto dispatch
+			}
+			entry := proto.AuditEntry{
+				Id:            id,
+				Time:          auditTime.Format(rfc3339Milli),
+				User:          user,
+				RemoteAddress: addr,
+				Method:        method,
+				Route:         route,
+				Path:          path,
+				Permissions:   permissions,
+				StatusCode:    status,
+				Body:          body,
+			}
+			if authorized.Valid {
+				verdict := authorized.Bool
+				entry.Authorized = &verdict
+			}
+			result.Audit = append(result.Audit, entry)
+		}
+		if err = rows.Err(); err != nil {
+			result.ServerError(err)
+			result.Clear(q.Type)
+			goto dispatch
+		}
+		result.OK()
+	default:
+		result.NotImplemented(fmt.Errorf("Unknown requested action: %s/%s", q.Type, q.Action))
+	}
+
+dispatch:
+	q.Reply <- result
+}
+
+// auditTimestamp parses an optional RFC3339 search timestamp, empty
+// strings search without limit
+func auditTimestamp(s string) (interface{}, error) {
+	if s == `` {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return t.UTC(), nil
+}
+
+/* Ops Access
+ */
+func (a *auditRead) shutdownNow() {
+	a.shutdown <- true
+}
+
+func (a *auditRead) queueLen() int {
+	return len(a.input)
+}
+
+func (a *auditRead) queueCap() int {
+	return cap(a.input)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -293,4 +293,12 @@ func (r *somaBucketReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaBucketReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaBucketReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -282,8 +282,24 @@ func (r *somaCapabilityReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaCapabilityReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaCapabilityReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaCapabilityWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaCapabilityWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaCapabilityWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -222,6 +222,7 @@ func (r *somaCheckConfigurationReadHandler) process(q *somaCheckConfigRequest) {
 		for _, tp := range []string{"custom", "system", "native", "service", "attribute", "oncall"} {
 			var (
 				configId, propertyId, repoId, property, value string
+				operator                                      string
 				rows                                          *sql.Rows
 			)
 
@@ -241,9 +242,11 @@ func (r *somaCheckConfigurationReadHandler) process(q *somaCheckConfigRequest) {
 						&repoId,
 						&value,
 						&property,
+						&operator,
 					)
 					constr := proto.CheckConfigConstraint{
 						ConstraintType: tp,
+						Operator:       operator,
 						Custom: &proto.PropertyCustom{
 							Id:           propertyId,
 							RepositoryId: repoId,
@@ -266,9 +269,11 @@ func (r *somaCheckConfigurationReadHandler) process(q *somaCheckConfigRequest) {
 						&configId,
 						&property,
 						&value,
+						&operator,
 					)
 					constr := proto.CheckConfigConstraint{
 						ConstraintType: tp,
+						Operator:       operator,
 						System: &proto.PropertySystem{
 							Name:  property,
 							Value: value,
@@ -289,9 +294,11 @@ func (r *somaCheckConfigurationReadHandler) process(q *somaCheckConfigRequest) {
 						&configId,
 						&property,
 						&value,
+						&operator,
 					)
 					constr := proto.CheckConfigConstraint{
 						ConstraintType: tp,
+						Operator:       operator,
 						Native: &proto.PropertyNative{
 							Name:  property,
 							Value: value,
@@ -335,9 +342,11 @@ func (r *somaCheckConfigurationReadHandler) process(q *somaCheckConfigRequest) {
 						&configId,
 						&property,
 						&value,
+						&operator,
 					)
 					constr := proto.CheckConfigConstraint{
 						ConstraintType: tp,
+						Operator:       operator,
 						Attribute: &proto.ServiceAttribute{
 							Name:  property,
 							Value: value,
@@ -426,4 +435,12 @@ func (r *somaCheckConfigurationReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaCheckConfigurationReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaCheckConfigurationReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
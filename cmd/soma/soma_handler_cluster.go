@@ -331,4 +331,12 @@ func (r *somaClusterReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaClusterReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaClusterReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
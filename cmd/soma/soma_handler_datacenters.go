@@ -277,8 +277,24 @@ func (r *somaDatacenterReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaDatacenterReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaDatacenterReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaDatacenterWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaDatacenterWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaDatacenterWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
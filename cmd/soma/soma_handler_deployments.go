@@ -215,6 +215,19 @@ func (self *somaDeploymentHandler) process(q *somaDeploymentRequest) {
 					Task: "deprovision",
 				},
 			})
+		case "active":
+			// repeated feedback, the update has already been applied
+			result.Append(nil, &somaDeploymentResult{
+				Deployment: proto.Deployment{
+					Task: "rollout",
+				},
+			})
+		case "deprovisioned":
+			result.Append(nil, &somaDeploymentResult{
+				Deployment: proto.Deployment{
+					Task: "deprovision",
+				},
+			})
 		default:
 			result.SetRequestError(fmt.Errorf("Illegal current state for state update"))
 		}
@@ -257,6 +270,19 @@ func (self *somaDeploymentHandler) process(q *somaDeploymentRequest) {
 					Task: "deprovision",
 				},
 			})
+		case "rollout_failed":
+			// repeated feedback, the update has already been applied
+			result.Append(nil, &somaDeploymentResult{
+				Deployment: proto.Deployment{
+					Task: "rollout",
+				},
+			})
+		case "deprovision_failed":
+			result.Append(nil, &somaDeploymentResult{
+				Deployment: proto.Deployment{
+					Task: "deprovision",
+				},
+			})
 		default:
 			result.SetRequestError(fmt.Errorf("Illegal current state for state update"))
 		}
@@ -327,4 +353,12 @@ func (dh *somaDeploymentHandler) shutdownNow() {
 	dh.shutdown <- true
 }
 
+func (dh *somaDeploymentHandler) queueLen() int {
+	return len(dh.input)
+}
+
+func (dh *somaDeploymentHandler) queueCap() int {
+	return cap(dh.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
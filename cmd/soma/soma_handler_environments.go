@@ -213,8 +213,24 @@ func (r *somaEnvironmentReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaEnvironmentReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaEnvironmentReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaEnvironmentWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaEnvironmentWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaEnvironmentWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
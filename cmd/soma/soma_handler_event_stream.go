@@ -0,0 +1,145 @@
+package main
+
+import (
+	"time"
+
+	"github.com/1and1/soma/lib/proto"
+	log "github.com/Sirupsen/logrus"
+)
+
+// eventStream distributes job state changes and tree actions to the
+// subscribed event stream clients
+type eventStream struct {
+	input       chan proto.Event
+	subscribe   chan *eventSubscription
+	unsubscribe chan *eventSubscription
+	shutdown    chan bool
+	// closed once the stream has shut down
+	stopped     chan struct{}
+	subscribers map[*eventSubscription]bool
+	appLog      *log.Logger
+	reqLog      *log.Logger
+	errLog      *log.Logger
+}
+
+type eventSubscription struct {
+	filter eventFilter
+	events chan proto.Event
+}
+
+// eventFilter selects the events delivered to a subscription. Empty
+// fields match every event.
+type eventFilter struct {
+	types        map[string]bool
+	repositoryId string
+	teamId       string
+	jobId        string
+	// non-admin users only receive events of their own jobs
+	user string
+}
+
+func (f *eventFilter) match(e *proto.Event) bool {
+	switch {
+	case len(f.types) > 0 && !f.types[e.Type]:
+		return false
+	case f.repositoryId != `` && f.repositoryId != e.RepositoryId:
+		return false
+	case f.teamId != `` && f.teamId != e.TeamId:
+		return false
+	case f.jobId != `` && f.jobId != e.JobId:
+		return false
+	case f.user != `` && f.user != e.User:
+		return false
+	}
+	return true
+}
+
+func (s *eventStream) run() {
+	s.subscribers = make(map[*eventSubscription]bool)
+	defer close(s.stopped)
+
+runloop:
+	for {
+		select {
+		case <-s.shutdown:
+			for sub := range s.subscribers {
+				close(sub.events)
+				delete(s.subscribers, sub)
+			}
+			break runloop
+		case sub := <-s.subscribe:
+			s.subscribers[sub] = true
+		case sub := <-s.unsubscribe:
+			if s.subscribers[sub] {
+				close(sub.events)
+				delete(s.subscribers, sub)
+			}
+		case e := <-s.input:
+			for sub := range s.subscribers {
+				if !sub.filter.match(&e) {
+					continue
+				}
+				select {
+				case sub.events <- e:
+				default:
+					// the client does not keep up, its stream is
+					// closed instead of silently losing events
+					s.appLog.Println(`eventStream: dropping slow subscriber`)
+					close(sub.events)
+					delete(s.subscribers, sub)
+				}
+			}
+		}
+	}
+}
+
+// register adds sub to the stream. It returns false if the stream
+// has already shut down.
+func (s *eventStream) register(sub *eventSubscription) bool {
+	select {
+	case s.subscribe <- sub:
+		return true
+	case <-s.stopped:
+		return false
+	}
+}
+
+// deregister removes sub from the stream. Subscriptions of a stream
+// that has shut down are already closed.
+func (s *eventStream) deregister(sub *eventSubscription) {
+	select {
+	case s.unsubscribe <- sub:
+	case <-s.stopped:
+	}
+}
+
+// publishEvent hands e to the event stream. It never blocks the
+// caller, events are discarded if the stream is not running or
+// overloaded.
+func publishEvent(e proto.Event) {
+	handler, ok := handlerMap.Get(`eventStream`).(*eventStream)
+	if !ok {
+		return
+	}
+	e.Time = time.Now().UTC().Format(rfc3339Milli)
+	select {
+	case handler.input <- e:
+	default:
+	}
+}
+
+/* Ops Access
+ */
+func (s *eventStream) shutdownNow() {
+	s.shutdown <- true
+}
+
+func (s *eventStream) queueLen() int {
+	return len(s.input)
+}
+
+func (s *eventStream) queueCap() int {
+	return cap(s.input)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
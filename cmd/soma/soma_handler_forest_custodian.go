@@ -4,7 +4,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/1and1/soma/internal/msg"
 	"github.com/1and1/soma/internal/stmt"
@@ -16,18 +18,20 @@ import (
 )
 
 type forestCustodian struct {
-	input     chan somaRepositoryRequest
-	system    chan msg.Request
-	shutdown  chan bool
-	conn      *sql.DB
-	add_stmt  *sql.Stmt
-	load_stmt *sql.Stmt
-	name_stmt *sql.Stmt
-	rbck_stmt *sql.Stmt
-	rbci_stmt *sql.Stmt
-	appLog    *log.Logger
-	reqLog    *log.Logger
-	errLog    *log.Logger
+	input      chan somaRepositoryRequest
+	system     chan msg.Request
+	shutdown   chan bool
+	conn       *sql.DB
+	add_stmt   *sql.Stmt
+	load_stmt  *sql.Stmt
+	name_stmt  *sql.Stmt
+	rbck_stmt  *sql.Stmt
+	rbci_stmt  *sql.Stmt
+	empty_stmt *sql.Stmt
+	cust_stmt  *sql.Stmt
+	appLog     *log.Logger
+	reqLog     *log.Logger
+	errLog     *log.Logger
 }
 
 func (f *forestCustodian) run() {
@@ -46,6 +50,20 @@ func (f *forestCustodian) run() {
 		defer prepStmt.Close()
 	}
 
+	if f.empty_stmt, err = f.conn.Prepare(
+		stmt.ForestRepositoryIsEmpty); err != nil {
+		f.errLog.Fatal(`forestcustodian`, err,
+			stmt.Name(stmt.ForestRepositoryIsEmpty))
+	}
+	defer f.empty_stmt.Close()
+
+	if f.cust_stmt, err = f.conn.Prepare(
+		stmt.PropertyCustomList); err != nil {
+		f.errLog.Fatal(`forestcustodian`, err,
+			stmt.Name(stmt.PropertyCustomList))
+	}
+	defer f.cust_stmt.Close()
+
 	f.initialLoad()
 
 	if SomaCfg.Observer {
@@ -178,7 +196,9 @@ func (f *forestCustodian) process(q *somaRepositoryRequest) {
 func (f *forestCustodian) sysprocess(q *msg.Request) {
 	var (
 		repoId, repoName, teamId, keeper string
-		err                              error
+		err, importErr                   error
+		empty                            bool
+		repo                             proto.Repository
 	)
 	result := msg.Result{
 		Type:   `forestcustodian`,
@@ -191,6 +211,16 @@ func (f *forestCustodian) sysprocess(q *msg.Request) {
 		repoId = q.System.RepositoryId
 	case `restart_repository`:
 		repoId = q.System.RepositoryId
+	case `export_repository`:
+		repoId = q.System.RepositoryId
+	case `import_repository`:
+		repoId = q.System.RepositoryId
+		// the export is not returned
+		result.System[0].Export = nil
+		if q.System.Export == nil {
+			result.BadRequest(fmt.Errorf(`Missing repository export`))
+			goto exit
+		}
 	default:
 		result.NotImplemented(
 			fmt.Errorf("Unknown requested system operation: %s",
@@ -210,11 +240,44 @@ func (f *forestCustodian) sysprocess(q *msg.Request) {
 		goto exit
 	}
 
+	repo = proto.Repository{
+		Id:        repoId,
+		Name:      repoName,
+		TeamId:    teamId,
+		IsDeleted: false,
+		IsActive:  true,
+	}
+
+	// exports run next to the active treekeeper
+	if q.System.Request == `export_repository` {
+		if result.System[0].Export, err = f.exportRepository(
+			repo); err != nil {
+			result.ServerError(err)
+			goto exit
+		}
+		result.OK()
+		goto exit
+	}
+
+	// imports require a repository without content
+	if q.System.Request == `import_repository` {
+		if err = f.empty_stmt.QueryRow(repoId).Scan(
+			&empty); err != nil {
+			result.ServerError(err)
+			goto exit
+		}
+		if !empty {
+			result.Conflict(fmt.Errorf(
+				"Repository %s is not empty", repoName))
+			goto exit
+		}
+	}
+
 	// get the treekeeper for the repository
 	keeper = fmt.Sprintf("repository_%s", repoName)
-	if handler, ok := handlerMap[keeper].(*treeKeeper); ok {
+	if handler, ok := handlerMap.Get(keeper).(*treeKeeper); ok {
 		// remove handler from lookup table
-		delete(handlerMap, keeper)
+		handlerMap.Del(keeper)
 
 		// stop the handler before shut down to give it a chance to
 		// drain the input channel
@@ -256,6 +319,10 @@ func (f *forestCustodian) sysprocess(q *msg.Request) {
 		}
 	}
 
+	if q.System.Request == `import_repository` {
+		importErr = f.importRepository(repo, q)
+	}
+
 	// rebuild has finished, restart the tree. If the rebuild did not
 	// work, this will simply be a broken tree once more
 	if err = f.loadSomaTree(&somaRepositoryRequest{
@@ -272,12 +339,134 @@ func (f *forestCustodian) sysprocess(q *msg.Request) {
 		result.ServerError(err)
 		goto exit
 	}
+	if importErr != nil {
+		result.ServerError(importErr)
+		goto exit
+	}
 	result.OK()
 
 exit:
 	q.Reply <- result
 }
 
+// exportRepository loads repo with the treekeeper startup loaders
+// and returns the recorded query results. All queries of the export
+// run inside the read-only transaction of the recording snapshot.
+func (f *forestCustodian) exportRepository(repo proto.Repository) (
+	*proto.RepositoryExport, error) {
+	var (
+		err       error
+		snap      *snapshot
+		rows      *sql.Rows
+		rawSchema sql.NullString
+	)
+
+	if snap, err = newRecordingSnapshot(); err != nil {
+		return nil, err
+	}
+	defer snap.close()
+
+	tK := f.newSnapshotKeeper(repo, snap)
+	tK.tree.SwitchLogger(tK.startLog)
+	tK.startupLoad()
+	if tK.broken {
+		return nil, fmt.Errorf("Repository %s could not be loaded",
+			repo.Name)
+	}
+
+	export := &proto.RepositoryExport{
+		RepositoryId:     repo.Id,
+		RepositoryName:   repo.Name,
+		TeamId:           repo.TeamId,
+		ExportedAt:       time.Now().UTC().Format(rfc3339Milli),
+		CustomProperties: []proto.PropertyCustom{},
+		Queries:          snap.export(),
+	}
+
+	// the custom properties are read after the queries were
+	// exported, so that they are not part of the recorded queries
+	if rows, err = snap.db.Query(stmt.PropertyCustomList,
+		repo.Id); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		prop := proto.PropertyCustom{}
+		if err = rows.Scan(
+			&prop.Id,
+			&prop.RepositoryId,
+			&prop.Name,
+			&rawSchema,
+		); err != nil {
+			return nil, err
+		}
+		if prop.Schema, err = unmarshalPropertySchema(
+			rawSchema); err != nil {
+			return nil, err
+		}
+		export.CustomProperties = append(export.CustomProperties, prop)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// importRepository loads the export in q into repo with the
+// treekeeper startup loaders and saves the loaded tree. The
+// treekeeper of repo must be stopped.
+func (f *forestCustodian) importRepository(repo proto.Repository,
+	q *msg.Request) error {
+	var (
+		err                  error
+		id, name, repository string
+		rawSchema            sql.NullString
+		rows                 *sql.Rows
+		snap                 *snapshot
+		db                   *sql.DB
+	)
+
+	// custom properties that already exist in repo
+	custom := map[string]string{}
+	if rows, err = f.cust_stmt.Query(repo.Id); err != nil {
+		return err
+	}
+	for rows.Next() {
+		if err = rows.Scan(&id, &repository, &name,
+			&rawSchema); err != nil {
+			rows.Close()
+			return err
+		}
+		custom[name] = id
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	queries, props, instances := prepareImport(q.System.Export, repo,
+		custom)
+	if snap, err = newReplaySnapshot(queries); err != nil {
+		return err
+	}
+	defer snap.close()
+
+	if db, err = newDatabaseConnection(); err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tK := f.newSnapshotKeeper(repo, snap)
+	tK.conn = db
+	tK.importing = true
+	tK.importConfigs = map[string]proto.CheckConfig{}
+	tK.importCustom = props
+	if q.System.KeepInstanceIds {
+		tK.importInstances = instances
+	}
+	return tK.importSnapshot(q.User)
+}
+
 func (f *forestCustodian) initialLoad() {
 	var (
 		rows                     *sql.Rows
@@ -326,21 +515,28 @@ treeloop:
 }
 
 func (f *forestCustodian) loadSomaTree(q *somaRepositoryRequest) error {
+	sTree, errChan, actionChan := f.newSomaTree(q.Repository)
+	return f.spawnTreeKeeper(q, sTree, errChan, actionChan, q.Repository.TeamId)
+}
+
+// newSomaTree returns a tree that only contains the repository
+func (f *forestCustodian) newSomaTree(repo proto.Repository) (
+	*tree.Tree, chan *tree.Error, chan *tree.Action) {
 	actionChan := make(chan *tree.Action, 1024000)
 	errChan := make(chan *tree.Error, 1024000)
 
 	sTree := tree.New(tree.TreeSpec{
 		Id:     uuid.NewV4().String(),
-		Name:   fmt.Sprintf("root_%s", q.Repository.Name),
+		Name:   fmt.Sprintf("root_%s", repo.Name),
 		Action: actionChan,
 		Log:    f.appLog,
 	})
 	tree.NewRepository(tree.RepositorySpec{
-		Id:      q.Repository.Id,
-		Name:    q.Repository.Name,
-		Team:    q.Repository.TeamId,
-		Deleted: q.Repository.IsDeleted,
-		Active:  q.Repository.IsActive,
+		Id:      repo.Id,
+		Name:    repo.Name,
+		Team:    repo.TeamId,
+		Deleted: repo.IsDeleted,
+		Active:  repo.IsActive,
 	}).Attach(tree.AttachRequest{
 		Root:       sTree,
 		ParentType: "root",
@@ -355,7 +551,28 @@ func (f *forestCustodian) loadSomaTree(q *somaRepositoryRequest) error {
 		// discard actions on initial load
 		<-errChan
 	}
-	return f.spawnTreeKeeper(q, sTree, errChan, actionChan, q.Repository.TeamId)
+	return sTree, errChan, actionChan
+}
+
+// newSnapshotKeeper returns a treekeeper for repo that loads the
+// tree from snapshot snap. It is not registered as handler.
+func (f *forestCustodian) newSnapshotKeeper(repo proto.Repository,
+	snap *snapshot) *treeKeeper {
+	sTree, errChan, actionChan := f.newSomaTree(repo)
+
+	tK := new(treeKeeper)
+	tK.tree = sTree
+	tK.errChan = errChan
+	tK.actionChan = actionChan
+	tK.snapshot = snap
+	tK.repoId = repo.Id
+	tK.repoName = repo.Name
+	tK.team = repo.TeamId
+	tK.appLog = f.appLog
+	tK.log = f.appLog
+	tK.startLog = log.New()
+	tK.startLog.Out = ioutil.Discard
+	return tK
 }
 
 func (f *forestCustodian) spawnTreeKeeper(q *somaRepositoryRequest, s *tree.Tree,
@@ -414,15 +631,16 @@ func (f *forestCustodian) spawnTreeKeeper(q *somaRepositoryRequest, s *tree.Tree
 	tK.startLog.Out = sfh
 	// startup logs are not rotated, the logrotate map is just used
 	// to keep acccess to the filehandle
-	logFileMap[fmt.Sprintf("%s", keeperName)] = lfh
-	logFileMap[fmt.Sprintf("startup_%s", keeperName)] = sfh
+	logFileMap.Set(keeperName, lfh)
+	logFileMap.Set(fmt.Sprintf("startup_%s", keeperName), sfh)
 
 	// during rebuild the treekeeper will not run in background
 	if tK.rebuild {
 		tK.run()
 	} else {
 		// non-rebuild, register TK and detach
-		handlerMap[keeperName] = tK
+		tK.publishState()
+		handlerMap.Set(keeperName, tK)
 		go tK.run()
 	}
 	return nil
@@ -434,4 +652,12 @@ func (f *forestCustodian) shutdownNow() {
 	f.shutdown <- true
 }
 
+func (f *forestCustodian) queueLen() int {
+	return len(f.input)
+}
+
+func (f *forestCustodian) queueCap() int {
+	return cap(f.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
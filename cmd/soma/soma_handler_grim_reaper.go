@@ -82,66 +82,71 @@ func (grim *grimReaper) process(q *msg.Request) bool {
 	grim.appLog.Println(`GRIM REAPER ACTIVATED. SYSTEM SHUTDOWN INITIATED`)
 
 	// stop all treeKeeper       : /^repository_.*/
-	for handler, _ := range handlerMap {
+	handlerMap.Range(func(handler string, h interface{}) bool {
 		if strings.HasPrefix(handler, `repository_`) {
-			handlerMap[handler].(Stopper).stopNow()
+			h.(Stopper).stopNow()
 		}
-	}
+		return true
+	})
 	// shutdown all treeKeeper   : /^repository_.*/
-	for handler, _ := range handlerMap {
+	handlerMap.Range(func(handler string, h interface{}) bool {
 		if strings.HasPrefix(handler, `repository_`) {
-			handlerMap[handler].(Downer).shutdownNow()
-			delete(handlerMap, handler)
+			h.(Downer).shutdownNow()
+			handlerMap.Del(handler)
 			grim.appLog.Printf("grimReaper: shut down %s", handler)
 		}
-	}
+		return true
+	})
 	// shutdown all write handler: /WriteHandler$/
-	for handler, _ := range handlerMap {
+	handlerMap.Range(func(handler string, h interface{}) bool {
 		if !(strings.HasSuffix(handler, `WriteHandler`) ||
 			strings.HasSuffix(handler, `_w`)) {
-			continue
+			return true
 		}
-		handlerMap[handler].(Downer).shutdownNow()
-		delete(handlerMap, handler)
+		h.(Downer).shutdownNow()
+		handlerMap.Del(handler)
 		grim.appLog.Printf("grimReaper: shut down %s", handler)
-	}
+		return true
+	})
 	// shutdown all read handler : /ReadHandler$/
-	for handler, _ := range handlerMap {
+	handlerMap.Range(func(handler string, h interface{}) bool {
 		if !(strings.HasSuffix(handler, `ReadHandler`) ||
 			strings.HasSuffix(handler, `_r`)) {
-			continue
+			return true
 		}
-		handlerMap[handler].(Downer).shutdownNow()
-		delete(handlerMap, handler)
+		h.(Downer).shutdownNow()
+		handlerMap.Del(handler)
 		grim.appLog.Printf("grimReaper: shut down %s", handler)
-	}
+		return true
+	})
 	// shutdown special handlers
 	for _, h := range []string{
 		`jobDelay`,
+		`eventStream`,
 		`forestCustodian`,
 		`guidePost`,
 		`lifeCycle`,
 		`deploymentHandler`,
 		`hostDeploymentHandler`,
 	} {
-		handlerMap[h].(Downer).shutdownNow()
-		delete(handlerMap, h)
+		handlerMap.Get(h).(Downer).shutdownNow()
+		handlerMap.Del(h)
 		grim.appLog.Printf("grimReaper: shut down %s", h)
 	}
 
 	// shutdown supervisor -- needs handling in BasicAuth()
-	handlerMap[`supervisor`].(Downer).shutdownNow()
-	delete(handlerMap, `supervisor`)
+	handlerMap.Get(`supervisor`).(Downer).shutdownNow()
+	handlerMap.Del(`supervisor`)
 	grim.appLog.Println(`grimReaper: shut down the supervisor`)
 
 	// log what we have missed
 	grim.appLog.Println(`grimReaper: checking for still running handlers`)
-	for name, _ := range handlerMap {
-		if name == `grimReaper` {
-			continue
+	handlerMap.Range(func(name string, _ interface{}) bool {
+		if name != `grimReaper` {
+			grim.appLog.Printf("grimReaper: %s is still running\n", name)
 		}
-		grim.appLog.Printf("grimReaper: %s is still running\n", name)
-	}
+		return true
+	})
 
 	return true
 }
@@ -370,4 +370,12 @@ func (r *somaGroupReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaGroupReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaGroupReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
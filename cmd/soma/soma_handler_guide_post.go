@@ -25,9 +25,15 @@ type guidePost struct {
 	attr_stmt          *sql.Stmt
 	cthr_stmt          *sql.Stmt
 	cdel_stmt          *sql.Stmt
+	cupd_stmt          *sql.Stmt
 	bucket_for_node    *sql.Stmt
 	bucket_for_cluster *sql.Stmt
 	bucket_for_group   *sql.Stmt
+	bucket_state       *sql.Stmt
+	repo_state         *sql.Stmt
+	repo_team_bound    *sql.Stmt
+	group_has_member   *sql.Stmt
+	cluster_has_member *sql.Stmt
 	appLog             *log.Logger
 	reqLog             *log.Logger
 	errLog             *log.Logger
@@ -55,6 +61,36 @@ func (g *guidePost) run() {
 		defer prepStmt.Close()
 	}
 
+	if g.bucket_state, err = g.conn.Prepare(stmt.BucketState); err != nil {
+		g.errLog.Fatal(`guidepost`, err, stmt.Name(stmt.BucketState))
+	}
+	defer g.bucket_state.Close()
+
+	if g.repo_state, err = g.conn.Prepare(stmt.RepoState); err != nil {
+		g.errLog.Fatal(`guidepost`, err, stmt.Name(stmt.RepoState))
+	}
+	defer g.repo_state.Close()
+
+	if g.repo_team_bound, err = g.conn.Prepare(stmt.RepoTeamBound); err != nil {
+		g.errLog.Fatal(`guidepost`, err, stmt.Name(stmt.RepoTeamBound))
+	}
+	defer g.repo_team_bound.Close()
+
+	if g.group_has_member, err = g.conn.Prepare(stmt.GroupHasMember); err != nil {
+		g.errLog.Fatal(`guidepost`, err, stmt.Name(stmt.GroupHasMember))
+	}
+	defer g.group_has_member.Close()
+
+	if g.cluster_has_member, err = g.conn.Prepare(stmt.ClusterHasMember); err != nil {
+		g.errLog.Fatal(`guidepost`, err, stmt.Name(stmt.ClusterHasMember))
+	}
+	defer g.cluster_has_member.Close()
+
+	if g.cupd_stmt, err = g.conn.Prepare(stmt.CheckDetailsForUpdate); err != nil {
+		g.errLog.Fatal(`guidepost`, err, stmt.Name(stmt.CheckDetailsForUpdate))
+	}
+	defer g.cupd_stmt.Close()
+
 	if SomaCfg.Observer {
 		// XXX system/stop_repository should be possible in observer
 		// mode
@@ -109,7 +145,21 @@ func (g *guidePost) process(q *treeRequest) {
 		goto bailout
 	}
 	keeper = fmt.Sprintf("repository_%s", repoName)
-	handler = handlerMap[keeper].(*treeKeeper)
+	handler = handlerMap.Get(keeper).(*treeKeeper)
+
+	// dryrun requests are not stored as job, the treekeeper
+	// replies directly. validateKeeper has refused stopped and broken
+	// treekeepers, dryruns racing a stop are answered by the drain.
+	if q.DryRun {
+		if q.Action == `relocate_node` {
+			err = fmt.Errorf("Dryrun is not supported for %s",
+				q.Action)
+			goto bailout
+		}
+		g.appLog.Printf("R: dryrun/%s", q.Action)
+		handler.input <- *q
+		return
+	}
 
 	// store job in database
 	g.appLog.Printf("R: jobsave/%s", q.Action)
@@ -136,6 +186,20 @@ func (g *guidePost) process(q *treeRequest) {
 		goto bailout
 	}
 
+	publishEvent(proto.Event{
+		Type:         `job`,
+		RepositoryId: repoId,
+		TeamId:       handler.team,
+		JobId:        q.JobId.String(),
+		User:         q.User,
+		Job: &proto.Job{
+			Id:           q.JobId.String(),
+			Status:       `queued`,
+			Result:       `pending`,
+			Type:         q.Action,
+			RepositoryId: repoId,
+		},
+	})
 	handler.input <- *q
 	result.JobId = q.JobId.String()
 	result.JobType = q.Action
@@ -210,14 +274,14 @@ func (g *guidePost) sysprocess(q *msg.Request) {
 
 	// check we have a treekeeper for that repository
 	keeper = fmt.Sprintf("repository_%s", repoName)
-	if _, ok := handlerMap[keeper].(*treeKeeper); !ok {
+	if _, ok := handlerMap.Get(keeper).(*treeKeeper); !ok {
 		// no handler running, nothing to stop
 		result.OK()
 		goto exit
 	}
 
 	// might already be stopped
-	handler = handlerMap[keeper].(*treeKeeper)
+	handler = handlerMap.Get(keeper).(*treeKeeper)
 	if handler.isStopped() {
 		result.OK()
 		goto exit
@@ -250,4 +314,12 @@ func (g *guidePost) shutdownNow() {
 	g.shutdown <- true
 }
 
+func (g *guidePost) queueLen() int {
+	return len(g.input)
+}
+
+func (g *guidePost) queueCap() int {
+	return cap(g.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -233,4 +233,12 @@ func (hd *somaHostDeploymentHandler) shutdownNow() {
 	hd.shutdown <- true
 }
 
+func (hd *somaHostDeploymentHandler) queueLen() int {
+	return len(hd.input)
+}
+
+func (hd *somaHostDeploymentHandler) queueCap() int {
+	return cap(hd.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
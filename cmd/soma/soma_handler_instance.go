@@ -290,4 +290,12 @@ func (i *instance) shutdownNow() {
 	i.shutdown <- true
 }
 
+func (i *instance) queueLen() int {
+	return len(i.input)
+}
+
+func (i *instance) queueCap() int {
+	return cap(i.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
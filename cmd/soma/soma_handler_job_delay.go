@@ -93,4 +93,12 @@ func (j *jobDelay) shutdownNow() {
 	j.shutdown <- true
 }
 
+func (j *jobDelay) queueLen() int {
+	return len(j.input)
+}
+
+func (j *jobDelay) queueCap() int {
+	return cap(j.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -215,10 +215,158 @@ dispatch:
 	q.Reply <- result
 }
 
+type jobsWrite struct {
+	input       chan msg.Request
+	shutdown    chan bool
+	conn        *sql.DB
+	cancel_stmt *sql.Stmt
+	show_stmt   *sql.Stmt
+	appLog      *log.Logger
+	reqLog      *log.Logger
+	errLog      *log.Logger
+}
+
+func (j *jobsWrite) run() {
+	var err error
+
+	if j.cancel_stmt, err = j.conn.Prepare(stmt.JobCancel); err != nil {
+		j.errLog.Fatal(`jobs`, err, stmt.Name(stmt.JobCancel))
+	}
+	defer j.cancel_stmt.Close()
+
+	if j.show_stmt, err = j.conn.Prepare(stmt.JobResultForId); err != nil {
+		j.errLog.Fatal(`jobs`, err, stmt.Name(stmt.JobResultForId))
+	}
+	defer j.show_stmt.Close()
+
+runloop:
+	for {
+		select {
+		case <-j.shutdown:
+			break runloop
+		case req := <-j.input:
+			j.process(&req)
+		}
+	}
+}
+
+func (j *jobsWrite) process(q *msg.Request) {
+	result := msg.Result{Type: q.Type, Action: q.Action, Job: []proto.Job{}}
+	var (
+		res                                                                sql.Result
+		err                                                                error
+		rowCnt                                                             int64
+		jobId, jobType, jobStatus, jobResult, repositoryId, userId, teamId string
+		jobError, jobSpec                                                  string
+		jobSerial                                                          int
+		jobQueued                                                          time.Time
+		jobStarted, jobFinished                                            pq.NullTime
+	)
+
+	switch q.Action {
+	case `cancel`:
+		j.reqLog.Printf(LogStrArg, q.Type, q.Action, q.User, q.RemoteAddr, q.Job.Id)
+		// the job is only cancelled if it is still queued and the
+		// user submitted it or is a member of the submitting team
+		if res, err = j.cancel_stmt.Exec(
+			q.Job.Id,
+			time.Now().UTC(),
+			q.IsAdmin,
+			q.User,
+		); err != nil {
+			result.ServerError(err)
+			goto dispatch
+		}
+		if rowCnt, err = res.RowsAffected(); err != nil {
+			result.ServerError(err)
+			goto dispatch
+		}
+
+		if err = j.show_stmt.QueryRow(q.Job.Id).Scan(
+			&jobId,
+			&jobStatus,
+			&jobResult,
+			&jobType,
+			&jobSerial,
+			&repositoryId,
+			&userId,
+			&teamId,
+			&jobQueued,
+			&jobStarted,
+			&jobFinished,
+			&jobError,
+			&jobSpec,
+		); err == sql.ErrNoRows {
+			result.NotFound(err)
+			goto dispatch
+		} else if err != nil {
+			result.ServerError(err)
+			goto dispatch
+		}
+
+		if rowCnt == 0 {
+			switch {
+			case jobStatus == `queued` && !jobStarted.Valid:
+				result.Forbidden(fmt.Errorf(
+					"Job %s was not submitted by you or your team",
+					q.Job.Id))
+			default:
+				result.Conflict(fmt.Errorf(
+					"Job %s can not be cancelled, it is %s",
+					q.Job.Id, jobStatus))
+			}
+			goto dispatch
+		}
+
+		job := proto.Job{
+			Id:           jobId,
+			Status:       jobStatus,
+			Result:       jobResult,
+			Type:         jobType,
+			Serial:       jobSerial,
+			RepositoryId: repositoryId,
+			UserId:       userId,
+			TeamId:       teamId,
+			Error:        jobError,
+		}
+		job.TsQueued = jobQueued.Format(rfc3339Milli)
+		if jobFinished.Valid {
+			job.TsFinished = jobFinished.Time.Format(rfc3339Milli)
+		}
+		result.Job = []proto.Job{job}
+		result.OK()
+	default:
+		result.NotImplemented(fmt.Errorf("Unknown requested action: %s/%s", q.Type, q.Action))
+	}
+
+dispatch:
+	q.Reply <- result
+}
+
 /* Ops Access
  */
 func (j *jobsRead) shutdownNow() {
 	j.shutdown <- true
 }
 
+func (j *jobsRead) queueLen() int {
+	return len(j.input)
+}
+
+func (j *jobsRead) queueCap() int {
+	return cap(j.input)
+}
+
+func (j *jobsWrite) shutdownNow() {
+	j.shutdown <- true
+}
+
+func (j *jobsWrite) queueLen() int {
+	return len(j.input)
+}
+
+func (j *jobsWrite) queueCap() int {
+	return cap(j.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -217,8 +217,24 @@ func (r *somaLevelReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaLevelReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaLevelReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaLevelWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaLevelWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaLevelWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -2,11 +2,15 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
 	"math"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/1and1/soma/internal/stmt"
 	log "github.com/Sirupsen/logrus"
+	metrics "github.com/rcrowley/go-metrics"
 	"gopkg.in/resty.v0"
 )
 
@@ -14,6 +18,8 @@ type lifeCycle struct {
 	shutdown     chan bool
 	conn         *sql.DB
 	tick         <-chan time.Time
+	lastTick     time.Time
+	tickLock     sync.RWMutex
 	stmt_unblock *sql.Stmt
 	stmt_poke    *sql.Stmt
 	stmt_clear   *sql.Stmt
@@ -28,8 +34,19 @@ type lifeCycle struct {
 	pokers       map[string]chan string
 }
 
+const (
+	// pokeBusyDelay is the minimum time to wait before notifying a
+	// monitoring system again that replied it is busy
+	pokeBusyDelay = 8 * time.Second
+	// pokeTimeoutLimit caps the request timeout of a notification,
+	// which otherwise grows with the size of the batch
+	pokeTimeoutLimit = 2 * time.Minute
+)
+
 type PokeMessage struct {
-	Uuid string `json:"uuid"`
+	Uuid string `json:"uuid,omitempty"`
+	// batched notifications list all ids in Uuids, Uuid is unset
+	Uuids []string `json:"uuids,omitempty"`
 	// path should be used to tell the client system the basepath
 	// where to get it so SOMA + path + item_id === complete_url
 	Path string `json:"path"`
@@ -40,6 +57,7 @@ func (lc *lifeCycle) run() {
 	lc.pokers = make(map[string]chan string)
 
 	lc.tick = time.NewTicker(time.Duration(SomaCfg.LifeCycleTick) * time.Second).C
+	lc.setTick(time.Now())
 
 	for statement, prepStmt := range map[string]*sql.Stmt{
 		stmt.LifecycleActiveUnblockCondition:           lc.stmt_unblock,
@@ -68,7 +86,8 @@ runloop:
 		select {
 		case <-lc.shutdown:
 			break runloop
-		case <-lc.tick:
+		case t := <-lc.tick:
+			lc.setTick(t)
 			lc.ghost()
 			if err = lc.discardDeletedBlocked(); err == nil {
 				// skip unblock steps if there was an error to discard
@@ -370,31 +389,93 @@ func (lc *lifeCycle) pokeSystem(callback string, in chan string) {
 	for {
 		select {
 		case chkId := <-in:
-			retries := 0
-		retry:
-			client.SetTimeout(
-				time.Duration(SomaCfg.PokeTimeout) * time.Millisecond,
-			)
-			if _, err := client.R().SetBody(
-				PokeMessage{
-					Uuid: chkId,
-					Path: SomaCfg.PokePath,
-				},
-			).Post(callback); err != nil {
-				// with limit 4 this implements retries with 1, 2, 4
-				// and 8 seconds sleeps between them
-				if retries < 4 {
-					timeout := math.Pow(2, float64(retries))
-					time.Sleep(time.Duration(timeout) * time.Second)
-					retries++
-					goto retry
+			// collect the ids that are already queued, up to the
+			// configured batch size
+			batch := []string{chkId}
+		collect:
+			for uint64(len(batch)) < SomaCfg.PokeBatchSize {
+				select {
+				case id := <-in:
+					batch = append(batch, id)
+				default:
+					break collect
 				}
-				lc.errLog.Println(err)
-				continue
 			}
-			lc.appLog.Printf("Poked %s (%s)", callback, chkId)
-			lc.stmt_notify.Exec(chkId)
+			lc.pokeBatch(client, callback, batch)
+		}
+	}
+}
+
+// pokeBatch notifies the monitoring system at callback about all
+// deployments in batch. Batches rejected as too large are split and
+// resent without counting against the retries after which the batch
+// is dropped. A monitoring system that is busy is given at least
+// pokeBusyDelay before it is asked again, and counts against the
+// retries like an unreachable one.
+func (lc *lifeCycle) pokeBatch(client *resty.Client, callback string,
+	batch []string) {
+	msg := PokeMessage{Path: SomaCfg.PokePath}
+	if len(batch) == 1 {
+		msg.Uuid = batch[0]
+	} else {
+		msg.Uuids = batch
+	}
+
+	// the monitoring system fetches every deployment of the
+	// batch before it replies
+	timeout := time.Duration(
+		SomaCfg.PokeTimeout*uint64(len(batch)),
+	) * time.Millisecond
+	if timeout > pokeTimeoutLimit {
+		timeout = pokeTimeoutLimit
+	}
+	client.SetTimeout(timeout)
+
+	retries := 0
+retry:
+	busy := false
+	resp, err := client.R().SetBody(msg).Post(callback)
+	if err == nil {
+		switch {
+		case resp.StatusCode() == http.StatusRequestEntityTooLarge &&
+			len(batch) > 1:
+			half := len(batch) / 2
+			lc.pokeBatch(client, callback, batch[:half])
+			lc.pokeBatch(client, callback, batch[half:])
+			return
+		case resp.StatusCode() == http.StatusServiceUnavailable:
+			busy = true
+			err = fmt.Errorf("Monitoring system %s is busy", callback)
+		case len(batch) > 1 && resp.StatusCode() > 299:
+			// batches are acknowledged as a whole, a rejected
+			// batch is retried like an unreachable system
+			err = fmt.Errorf("Batch rejected by %s: %s",
+				callback, resp.Status())
+		}
+	}
+	if err != nil {
+		// with limit 4 this implements retries with 1, 2, 4
+		// and 8 seconds sleeps between them
+		if retries < 4 {
+			delay := time.Duration(math.Pow(2, float64(retries))) *
+				time.Second
+			if busy && delay < pokeBusyDelay {
+				delay = pokeBusyDelay
+			}
+			time.Sleep(delay)
+			retries++
+			goto retry
 		}
+		lc.errLog.Println(err)
+		metrics.GetOrRegisterCounter(`.lifecycle.poke.failure`,
+			Metrics[`soma`]).Inc(int64(len(batch)))
+		return
+	}
+	metrics.GetOrRegisterCounter(`.lifecycle.poke.success`,
+		Metrics[`soma`]).Inc(int64(len(batch)))
+	for _, id := range batch {
+		lc.appLog.Printf("Poked %s (%s)", callback, id)
+		lc.stmt_notify.Exec(id)
 	}
 }
 
@@ -439,4 +520,17 @@ func (lc *lifeCycle) shutdownNow() {
 	lc.shutdown <- true
 }
 
+func (lc *lifeCycle) setTick(t time.Time) {
+	lc.tickLock.Lock()
+	defer lc.tickLock.Unlock()
+	lc.lastTick = t
+}
+
+// lastTicked returns the time the lifecycle manager last ran
+func (lc *lifeCycle) lastTicked() time.Time {
+	lc.tickLock.RLock()
+	defer lc.tickLock.RUnlock()
+	return lc.lastTick
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
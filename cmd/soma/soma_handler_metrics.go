@@ -308,8 +308,24 @@ func (r *somaMetricReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaMetricReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaMetricReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaMetricWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaMetricWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaMetricWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -210,8 +210,24 @@ func (r *somaModeReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaModeReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaModeReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaModeWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaModeWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaModeWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -259,8 +259,24 @@ func (r *somaMonitoringReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaMonitoringReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaMonitoringReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaMonitoringWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaMonitoringWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaMonitoringWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/1and1/soma/internal/msg"
 	"github.com/1and1/soma/internal/stmt"
 	"github.com/1and1/soma/lib/proto"
 	log "github.com/Sirupsen/logrus"
@@ -443,6 +444,12 @@ func (w *somaNodeWriteHandler) process(q *somaNodeRequest) {
 	var res sql.Result
 	var err error
 	result := somaResult{}
+	super := handlerMap.Get(`supervisor`).(*supervisor)
+	notify := msg.Request{Type: `supervisor`, Action: `update_map`,
+		Super: &msg.Supervisor{
+			Object: `node`,
+		},
+	}
 
 	switch q.action {
 	case "add":
@@ -463,6 +470,7 @@ func (w *somaNodeWriteHandler) process(q *somaNodeRequest) {
 			q.user,
 		)
 		q.Node.Id = id.String()
+		notify.Super.Action = `add`
 	case `update`:
 		w.reqLog.Printf("R: node/update for %s", q.Node.Id)
 		res, err = w.upd_stmt.Exec(
@@ -474,12 +482,14 @@ func (w *somaNodeWriteHandler) process(q *somaNodeRequest) {
 			q.Node.IsDeleted,
 			q.Node.Id,
 		)
+		notify.Super.Action = `update`
 		// TODO what has to be done for this undeployment?
 	case "delete":
 		w.reqLog.Printf("R: node/delete for %s", q.Node.Id)
 		res, err = w.del_stmt.Exec(
 			q.Node.Id,
 		)
+		notify.Super.Action = `delete`
 		// TODO trigger undeployment
 	case "purge":
 		w.reqLog.Printf("R: node/purge for %s", q.Node.Id)
@@ -508,6 +518,11 @@ func (w *somaNodeWriteHandler) process(q *somaNodeRequest) {
 		result.Append(nil, &somaNodeResult{
 			Node: q.Node,
 		})
+		// send update to supervisor
+		if notify.Super.Action != `` {
+			notify.Super.Node = q.Node
+			super.input <- notify
+		}
 	}
 	q.reply <- result
 }
@@ -518,8 +533,24 @@ func (r *somaNodeReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaNodeReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaNodeReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaNodeWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaNodeWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaNodeWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -213,8 +213,24 @@ func (r *somaObjectStateReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaObjectStateReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaObjectStateReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaObjectStateWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaObjectStateWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaObjectStateWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -213,8 +213,24 @@ func (r *somaObjectTypeReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaObjectTypeReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaObjectTypeReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaObjectTypeWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaObjectTypeWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaObjectTypeWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
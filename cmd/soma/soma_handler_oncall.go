@@ -251,8 +251,24 @@ func (r *somaOncallReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaOncallReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaOncallReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaOncallWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaOncallWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaOncallWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -645,4 +645,12 @@ func (o *outputTree) shutdownNow() {
 	o.shutdown <- true
 }
 
+func (o *outputTree) queueLen() int {
+	return len(o.input)
+}
+
+func (o *outputTree) queueCap() int {
+	return cap(o.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
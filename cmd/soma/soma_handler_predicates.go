@@ -210,8 +210,24 @@ func (r *somaPredicateReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaPredicateReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaPredicateReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaPredicateWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaPredicateWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaPredicateWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -98,6 +98,8 @@ runloop:
 func (r *somaPropertyReadHandler) process(q *somaPropertyRequest) {
 	var (
 		property, team, repository, id, attribute, value string
+		rawSchema                                        sql.NullString
+		schema                                           *proto.PropertySchema
 		rows                                             *sql.Rows
 		err                                              error
 	)
@@ -164,13 +166,17 @@ func (r *somaPropertyReadHandler) process(q *somaPropertyRequest) {
 					},
 				})
 			case "custom":
-				err = rows.Scan(&id, &repository, &property)
+				if err = rows.Scan(&id, &repository, &property,
+					&rawSchema); err == nil {
+					schema, err = unmarshalPropertySchema(rawSchema)
+				}
 				result.Append(err, &somaPropertyResult{
 					prType: q.prType,
 					Custom: proto.PropertyCustom{
 						Id:           id,
 						RepositoryId: repository,
 						Name:         property,
+						Schema:       schema,
 					},
 				})
 			}
@@ -181,6 +187,7 @@ func (r *somaPropertyReadHandler) process(q *somaPropertyRequest) {
 			r.reqLog.Printf("R: property/show-system for %s", q.System.Name)
 			err = r.show_sys_stmt.QueryRow(q.System.Name).Scan(
 				&property,
+				&rawSchema,
 			)
 		case "native":
 			r.reqLog.Printf("R: property/show-native for %s", q.Native.Name)
@@ -196,6 +203,7 @@ func (r *somaPropertyReadHandler) process(q *somaPropertyRequest) {
 				&id,
 				&repository,
 				&property,
+				&rawSchema,
 			)
 		case "service":
 			r.reqLog.Printf("R: property/show-service for %s/%s", q.Service.TeamId, q.Service.Name)
@@ -220,13 +228,19 @@ func (r *somaPropertyReadHandler) process(q *somaPropertyRequest) {
 			q.reply <- result
 			return
 		}
+		if schema, err = unmarshalPropertySchema(rawSchema); err != nil {
+			_ = result.SetRequestError(err)
+			q.reply <- result
+			return
+		}
 
 		switch q.prType {
 		case "system":
 			result.Append(err, &somaPropertyResult{
 				prType: q.prType,
 				System: proto.PropertySystem{
-					Name: property,
+					Name:   property,
+					Schema: schema,
 				},
 			})
 		case "native":
@@ -243,6 +257,7 @@ func (r *somaPropertyReadHandler) process(q *somaPropertyRequest) {
 					Id:           id,
 					RepositoryId: repository,
 					Name:         property,
+					Schema:       schema,
 				},
 			})
 		case "service":
@@ -316,6 +331,8 @@ type somaPropertyWriteHandler struct {
 	add_tpl_stmt      *sql.Stmt
 	add_srv_attr_stmt *sql.Stmt
 	add_tpl_attr_stmt *sql.Stmt
+	upd_sys_stmt      *sql.Stmt
+	upd_cst_stmt      *sql.Stmt
 	del_sys_stmt      *sql.Stmt
 	del_nat_stmt      *sql.Stmt
 	del_cst_stmt      *sql.Stmt
@@ -353,6 +370,16 @@ func (w *somaPropertyWriteHandler) run() {
 		defer prepStmt.Close()
 	}
 
+	if w.upd_sys_stmt, err = w.conn.Prepare(stmt.PropertySystemSchemaUpdate); err != nil {
+		w.errLog.Fatal(`property`, err, stmt.Name(stmt.PropertySystemSchemaUpdate))
+	}
+	defer w.upd_sys_stmt.Close()
+
+	if w.upd_cst_stmt, err = w.conn.Prepare(stmt.PropertyCustomSchemaUpdate); err != nil {
+		w.errLog.Fatal(`property`, err, stmt.Name(stmt.PropertyCustomSchemaUpdate))
+	}
+	defer w.upd_cst_stmt.Close()
+
 runloop:
 	for {
 		select {
@@ -371,6 +398,7 @@ func (w *somaPropertyWriteHandler) process(q *somaPropertyRequest) {
 		tx     *sql.Tx
 		attr   proto.ServiceAttribute
 		rowCnt int64
+		schema interface{}
 	)
 	result := somaResult{}
 
@@ -379,8 +407,12 @@ func (w *somaPropertyWriteHandler) process(q *somaPropertyRequest) {
 		switch q.prType {
 		case "system":
 			w.reqLog.Printf("R: property/add-system for %s", q.System.Name)
+			if schema, err = marshalPropertySchema(q.System.Schema); err != nil {
+				goto bailout
+			}
 			res, err = w.add_sys_stmt.Exec(
 				q.System.Name,
+				schema,
 			)
 			rowCnt, _ = res.RowsAffected()
 		case "native":
@@ -392,10 +424,14 @@ func (w *somaPropertyWriteHandler) process(q *somaPropertyRequest) {
 		case "custom":
 			q.Custom.Id = uuid.NewV4().String()
 			w.reqLog.Printf("R: property/add-custom for %s", q.Custom.Name)
+			if schema, err = marshalPropertySchema(q.Custom.Schema); err != nil {
+				goto bailout
+			}
 			res, err = w.add_cst_stmt.Exec(
 				q.Custom.Id,
 				q.Custom.RepositoryId,
 				q.Custom.Name,
+				schema,
 			)
 			rowCnt, _ = res.RowsAffected()
 		case "service":
@@ -469,6 +505,35 @@ func (w *somaPropertyWriteHandler) process(q *somaPropertyRequest) {
 
 			err = tx.Commit()
 		}
+	case "update":
+		switch q.prType {
+		case "system":
+			w.reqLog.Printf("R: property/update-system for %s", q.System.Name)
+			if schema, err = marshalPropertySchema(q.System.Schema); err != nil {
+				goto bailout
+			}
+			res, err = w.upd_sys_stmt.Exec(
+				q.System.Name,
+				schema,
+			)
+			rowCnt, _ = res.RowsAffected()
+		case "custom":
+			w.reqLog.Printf("R: property/update-custom for %s", q.Custom.Id)
+			if schema, err = marshalPropertySchema(q.Custom.Schema); err != nil {
+				goto bailout
+			}
+			res, err = w.upd_cst_stmt.Exec(
+				q.Custom.RepositoryId,
+				q.Custom.Id,
+				schema,
+			)
+			rowCnt, _ = res.RowsAffected()
+		default:
+			w.reqLog.Printf("R: unimplemented property/update-%s", q.prType)
+			result.SetNotImplemented()
+			q.reply <- result
+			return
+		}
 	case "delete":
 		switch q.prType {
 		case "system":
@@ -598,8 +663,24 @@ func (r *somaPropertyReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaPropertyReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaPropertyReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaPropertyWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaPropertyWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaPropertyWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -209,8 +209,24 @@ func (r *somaProviderReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaProviderReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaProviderReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaProviderWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaProviderWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaProviderWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
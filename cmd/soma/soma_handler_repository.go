@@ -286,4 +286,12 @@ func (r *somaRepositoryReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaRepositoryReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaRepositoryReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
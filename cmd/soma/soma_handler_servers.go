@@ -350,8 +350,24 @@ func (r *somaServerReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaServerReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaServerReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaServerWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaServerWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaServerWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -209,8 +209,24 @@ func (r *somaStatusReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaStatusReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaStatusReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaStatusWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaStatusWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaStatusWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
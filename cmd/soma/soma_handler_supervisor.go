@@ -31,6 +31,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/1and1/soma/internal/msg"
@@ -52,17 +53,23 @@ type supervisor struct {
 	activation          string
 	root_disabled       bool
 	root_restricted     bool
+	started             bool
+	startLock           sync.RWMutex
 	kex                 *svKexMap
 	tokens              *svTokenMap
 	credentials         *svCredMap
 	global_permissions  *svPermMapGlobal
 	global_grants       *svGrantMapGlobal
 	limited_permissions *svPermMapLimited
+	team_permissions    *svPermMapLimited
+	monitor_permissions *svPermMapLimited
+	limited_grants      *svGrantMapLimited
 	id_user             *svLockMap
 	id_user_rev         *svLockMap
 	id_team             *svLockMap
 	id_permission       *svLockMap
 	id_userteam         *svLockMap
+	id_nodeteam         *svLockMap
 	stmt_FToken         *sql.Stmt
 	stmt_FindUser       *sql.Stmt
 	stmt_CheckUser      *sql.Stmt
@@ -96,12 +103,16 @@ func (s *supervisor) run() {
 	s.id_team = s.newLockMap()
 	s.id_permission = s.newLockMap()
 	s.id_userteam = s.newLockMap()
+	s.id_nodeteam = s.newLockMap()
 	s.tokens = s.newTokenMap()
 	s.credentials = s.newCredentialMap()
 	s.kex = s.newKexMap()
 	s.global_permissions = s.newGlobalPermMap()
 	s.global_grants = s.newGlobalGrantMap()
 	s.limited_permissions = s.newLimitedPermMap()
+	s.team_permissions = s.newLimitedPermMap()
+	s.monitor_permissions = s.newLimitedPermMap()
+	s.limited_grants = s.newLimitedGrantMap()
 
 	// load from datbase
 	s.startupLoad()
@@ -138,6 +149,9 @@ func (s *supervisor) run() {
 			defer prepStmt.Close()
 		}
 	}
+	s.startLock.Lock()
+	s.started = true
+	s.startLock.Unlock()
 
 runloop:
 	for {
@@ -243,6 +257,12 @@ func (s *supervisor) newLimitedPermMap() *svPermMapLimited {
 	return &l
 }
 
+func (s *supervisor) newLimitedGrantMap() *svGrantMapLimited {
+	g := svGrantMapLimited{}
+	g.GMap = make(map[string][]string)
+	return &g
+}
+
 func (s *supervisor) fetchTokenFromDB(token string) bool {
 	var (
 		err                       error
@@ -309,10 +329,25 @@ func (s *supervisor) checkIV(iv string) error {
 	return nil
 }
 
+// isStarted reports if the supervisor has finished its startup
+func (s *supervisor) isStarted() bool {
+	s.startLock.RLock()
+	defer s.startLock.RUnlock()
+	return s.started
+}
+
 /* Ops Access
  */
 func (s *supervisor) shutdownNow() {
 	s.shutdown <- true
 }
 
+func (s *supervisor) queueLen() int {
+	return len(s.input)
+}
+
+func (s *supervisor) queueCap() int {
+	return cap(s.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -415,7 +415,7 @@ func (g *svGrantMapGlobal) runlock() {
 //
 // read/write locked map of limited permissions
 type svPermMapLimited struct {
-	// user(uuid.string) -> permission(uuid.string) -> repository(uuid.string)
+	// recipient(uuid.string) -> permission(uuid.string) -> object(uuid.string)
 	LMap  map[string]map[string][]string
 	mutex sync.RWMutex
 }
@@ -424,6 +424,12 @@ func (l *svPermMapLimited) grant(user, permission, repository string) {
 	l.lock()
 	defer l.unlock()
 
+	l.load(user, permission, repository)
+}
+
+// load is an unlocked grant for bulk loading at startup. The
+// bulk loading mechanism must handle the locking itself
+func (l *svPermMapLimited) load(user, permission, repository string) {
 	// zero value for maps is nil
 	if m, ok := l.LMap[user]; !ok {
 		l.LMap[user] = make(map[string][]string)
@@ -507,6 +513,15 @@ func (l *svPermMapLimited) assess(user, permission, repository string) (verdict
 	return
 }
 
+// assessAny reports whether user holds a grant of permission on any
+// object
+func (l *svPermMapLimited) assessAny(user, permission string) bool {
+	l.rlock()
+	defer l.runlock()
+
+	return len(l.LMap[user][permission]) > 0
+}
+
 func (l *svPermMapLimited) lock() {
 	l.mutex.Lock()
 }
@@ -523,6 +538,59 @@ func (l *svPermMapLimited) runlock() {
 	l.mutex.RUnlock()
 }
 
+//
+//
+// read/write locked map of limited grants
+type svGrantMapLimited struct {
+	// grant(uuid.string) -> [scope, recipient(uuid.string),
+	// permission(uuid.string), object(uuid.string)]
+	GMap  map[string][]string
+	mutex sync.RWMutex
+}
+
+func (g *svGrantMapLimited) record(scope, recipient, permission, object, id string) {
+	g.lock()
+	defer g.unlock()
+
+	g.load(scope, recipient, permission, object, id)
+}
+
+func (g *svGrantMapLimited) load(scope, recipient, permission, object, id string) {
+	// record grant
+	g.GMap[id] = []string{scope, recipient, permission, object}
+}
+
+func (g *svGrantMapLimited) discard(id string) {
+	g.lock()
+	defer g.unlock()
+
+	delete(g.GMap, id)
+}
+
+func (g *svGrantMapLimited) get(id string) []string {
+	g.rlock()
+	defer g.runlock()
+
+	// it is okay to return nil
+	return g.GMap[id]
+}
+
+func (g *svGrantMapLimited) lock() {
+	g.mutex.Lock()
+}
+
+func (g *svGrantMapLimited) rlock() {
+	g.mutex.RLock()
+}
+
+func (g *svGrantMapLimited) unlock() {
+	g.mutex.Unlock()
+}
+
+func (g *svGrantMapLimited) runlock() {
+	g.mutex.RUnlock()
+}
+
 //
 //
 // read/write locked map[string]string
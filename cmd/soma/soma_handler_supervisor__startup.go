@@ -40,6 +40,7 @@ func (s *supervisor) startupLoad() {
 	s.startupRoot()
 
 	s.startupUsersAndTeams()
+	s.startupNodeTeams()
 	s.startupPermissions()
 
 	if !s.readonly {
@@ -49,6 +50,7 @@ func (s *supervisor) startupLoad() {
 	s.startupTokens()
 
 	s.startupGrants()
+	s.startupLimitedGrants()
 }
 
 func (s *supervisor) startupRoot() {
@@ -226,6 +228,38 @@ func (s *supervisor) startupUsersAndTeams() {
 	}
 }
 
+func (s *supervisor) startupNodeTeams() {
+	var (
+		err                error
+		nodeUUID, teamUUID string
+		rows               *sql.Rows
+	)
+
+	rows, err = s.conn.Query(stmt.LoadNodeTeamMapping)
+	if err != nil {
+		s.errLog.Fatal(`supervisor/load-node-team-mapping,query: `, err)
+	}
+	defer rows.Close()
+
+	// reduce lock overhead by locking here once and then using the
+	// unlocked bulk interface
+	s.id_nodeteam.lock()
+	defer s.id_nodeteam.unlock()
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&nodeUUID,
+			&teamUUID,
+		); err != nil {
+			s.errLog.Fatal(`supervisor/load-node-team-mapping,scan: `, err)
+		}
+		s.id_nodeteam.load(nodeUUID, teamUUID)
+	}
+	if err = rows.Err(); err != nil {
+		s.errLog.Fatal(`supervisor/load-node-team-mapping,next: `, err)
+	}
+}
+
 func (s *supervisor) startupPermissions() {
 	var (
 		err                error
@@ -294,4 +328,54 @@ func (s *supervisor) startupGrants() {
 	}
 }
 
+func (s *supervisor) startupLimitedGrants() {
+	// reduce lock overhead by locking here once and then using the
+	// unlocked load method
+	s.limited_grants.lock()
+	defer s.limited_grants.unlock()
+
+	for scope, statement := range map[string]string{
+		`repository`: stmt.LoadLimitedRepoGrants,
+		`team`:       stmt.LoadLimitedTeamGrants,
+		`monitoring`: stmt.LoadLimitedMonitoringGrants,
+	} {
+		s.startupLimitedScope(scope, statement)
+	}
+}
+
+func (s *supervisor) startupLimitedScope(scope, statement string) {
+	var (
+		err                                         error
+		grantUUID, recipientUUID, permUUID, objUUID string
+		rows                                        *sql.Rows
+		perms                                       *svPermMapLimited
+	)
+
+	perms = s.limitedPermMap(scope)
+	perms.lock()
+	defer perms.unlock()
+
+	rows, err = s.conn.Query(statement)
+	if err != nil {
+		s.errLog.Fatalf("supervisor/load-limited-%s-grants,query: %s", scope, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&grantUUID,
+			&recipientUUID,
+			&permUUID,
+			&objUUID,
+		); err != nil {
+			s.errLog.Fatalf("supervisor/load-limited-%s-grants,scan: %s", scope, err)
+		}
+		perms.load(recipientUUID, permUUID, objUUID)
+		s.limited_grants.load(scope, recipientUUID, permUUID, objUUID, grantUUID)
+	}
+	if err = rows.Err(); err != nil {
+		s.errLog.Fatalf("supervisor/load-limited-%s-grants,next: %s", scope, err)
+	}
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
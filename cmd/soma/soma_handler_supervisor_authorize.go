@@ -1,11 +1,14 @@
 package main
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
 
 	"github.com/1and1/soma/internal/msg"
+	"github.com/1and1/soma/internal/stmt"
 	log "github.com/Sirupsen/logrus"
+	"github.com/julienschmidt/httprouter"
 )
 
 func (s *supervisor) authorize(q *msg.Request) {
@@ -14,8 +17,10 @@ func (s *supervisor) authorize(q *msg.Request) {
 	switch svPermissionActionScopeMap[q.Super.PermAction] {
 	case `global`:
 		result.Super.Verdict, result.Super.VerdictAdmin = s.authorize_global(q)
-	case `repository`:
-	case `team`:
+	case `repository`, `team`, `monitoring`, `any`:
+		result.Super.Verdict, result.Super.VerdictAdmin = s.authorize_limited(q)
+	case `user`:
+		result.Super.Verdict, result.Super.VerdictAdmin = s.authorize_user(q)
 	default:
 		goto unauthorized
 	}
@@ -58,28 +63,202 @@ func (s *supervisor) authorize_global(q *msg.Request) (uint16, bool) {
 	return 403, false
 }
 
-func IsAuthorized(user, action, repository, monitoring, node string) (bool, bool) {
+// authorize_user permits actions every known user may perform on
+// the objects they own, ownership is checked by the handler. A global
+// grant of the required permissions gives access to the objects of
+// all users.
+func (s *supervisor) authorize_user(q *msg.Request) (uint16, bool) {
+	// unknown user
+	if _, ok := s.id_user_rev.get(q.User); !ok {
+		return 403, false
+	}
+	if verdict, admin := s.authorize_global(q); verdict == 200 {
+		return verdict, admin
+	}
+	return 200, false
+}
+
+func (s *supervisor) authorize_limited(q *msg.Request) (uint16, bool) {
+	var (
+		userUUID, teamUUID, permUUID string
+		ok                           bool
+	)
+	// unknown user
+	if userUUID, ok = s.id_user_rev.get(q.User); !ok {
+		return 403, false
+	}
+	// user has omnipotence
+	if s.global_permissions.assess(userUUID,
+		`00000000-0000-0000-0000-000000000000`) {
+		return 200, true
+	}
+	// limited grants can also be given to the team of the user
+	teamUUID, _ = s.id_userteam.get(userUUID)
+
+	for _, perm := range svLimitedRequiredPermission[q.Super.PermAction] {
+		// incomplete permission schema -> denied
+		if permUUID, ok = s.id_permission.get(perm); !ok {
+			return 403, false
+		}
+		// a global grant of the permission covers every object
+		if s.global_permissions.assess(userUUID, permUUID) {
+			if strings.HasPrefix(perm, `system_`) {
+				return 200, true
+			}
+			return 200, false
+		}
+		if s.assess_limited(q, userUUID, teamUUID, permUUID) {
+			return 200, false
+		}
+	}
+	return 403, false
+}
+
+// assess_limited checks if the user or the team of the user
+// hold a limited grant of permission on the object addressed
+// by the scope of the requested action
+func (s *supervisor) assess_limited(q *msg.Request, user, team, permission string) bool {
+	var (
+		scope, object string
+		ok            bool
+	)
+
+	scope = svPermissionActionScopeMap[q.Super.PermAction]
+	switch scope {
+	case `any`:
+		// actions that are not bound to one object, a grant on any
+		// repository or team is sufficient
+		return s.limited_permissions.assessAny(user, permission) ||
+			s.team_permissions.assessAny(user, permission) ||
+			(team != `` &&
+				(s.limited_permissions.assessAny(team, permission) ||
+					s.team_permissions.assessAny(team, permission)))
+	case `repository`:
+		if object, ok = s.lookupRepository(q); !ok {
+			return false
+		}
+	case `monitoring`:
+		object = q.Super.PermMonitoring
+	case `team`:
+		// team scoped actions address nodes, which are owned by
+		// the team the grant has been given for
+		if object, ok = s.id_nodeteam.get(q.Super.PermNode); !ok {
+			return false
+		}
+	}
+	if object == `` {
+		return false
+	}
+
+	perms := s.limitedPermMap(scope)
+	if perms.assess(user, permission, object) {
+		return true
+	}
+	if team != `` && perms.assess(team, permission, object) {
+		return true
+	}
+	return false
+}
+
+// lookupRepository returns the repository a repository scoped
+// action addresses. Requests that only carry the id of a bucket, a
+// node or a check instance are resolved to the repository the object
+// is in.
+func (s *supervisor) lookupRepository(q *msg.Request) (string, bool) {
+	var (
+		repository, name string
+		err              error
+	)
+
+	switch {
+	case q.Super.PermRepository != ``:
+		return q.Super.PermRepository, true
+	case q.Super.PermBucket != ``:
+		err = s.conn.QueryRow(stmt.RepoByBucketId,
+			q.Super.PermBucket).Scan(&repository, &name)
+	case q.Super.PermInstance != ``:
+		err = s.conn.QueryRow(stmt.SupervisorRepoByInstance,
+			q.Super.PermInstance).Scan(&repository)
+	case q.Super.PermNode != ``:
+		err = s.conn.QueryRow(stmt.SupervisorRepoByNode,
+			q.Super.PermNode).Scan(&repository)
+	default:
+		return ``, false
+	}
+	if err != nil {
+		if err != sql.ErrNoRows {
+			s.errLog.Printf(LogStrErr, `supervisor`, `authorize`, 0,
+				err.Error())
+		}
+		return ``, false
+	}
+	return repository, true
+}
+
+func (s *supervisor) limitedPermMap(scope string) *svPermMapLimited {
+	switch scope {
+	case `team`:
+		return s.team_permissions
+	case `monitoring`:
+		return s.monitor_permissions
+	default:
+		return s.limited_permissions
+	}
+}
+
+// IsAuthorized checks if the authenticated user of the request may
+// perform action. The verdict is recorded in the audit trail of
+// audited requests.
+func IsAuthorized(params httprouter.Params, action, repository, monitoring, node string) (bool, bool) {
+	return isAuthorized(params, &msg.Supervisor{
+		PermAction:     action,
+		PermRepository: repository,
+		PermMonitoring: monitoring,
+		PermNode:       node,
+	})
+}
+
+// IsAuthorizedBucket checks if the authenticated user of the request
+// may perform the repository scoped action on the repository bucket
+// is in
+func IsAuthorizedBucket(params httprouter.Params, action, bucket string) (bool, bool) {
+	return isAuthorized(params, &msg.Supervisor{
+		PermAction: action,
+		PermBucket: bucket,
+	})
+}
+
+// IsAuthorizedInstance checks if the authenticated user of the
+// request may perform the repository scoped action on the repository
+// of check instance
+func IsAuthorizedInstance(params httprouter.Params, action, instance string) (bool, bool) {
+	return isAuthorized(params, &msg.Supervisor{
+		PermAction:   action,
+		PermInstance: instance,
+	})
+}
+
+func isAuthorized(params httprouter.Params, super *msg.Supervisor) (bool, bool) {
+	user := params.ByName(`AuthenticatedUser`)
+	action := super.PermAction
 	returnChannel := make(chan msg.Result)
 	// honour request for sandbox environment
 	if SomaCfg.OpenInstance {
+		auditAuthorization(params, action, true)
 		return true, true
 	}
-	handler := handlerMap[`supervisor`].(*supervisor)
+	super.Action = `authorize`
+	handler := handlerMap.Get(`supervisor`).(*supervisor)
 	handler.input <- msg.Request{
 		Type:   `supervisor`,
 		Action: `authorize`,
 		User:   user,
 		Reply:  returnChannel,
-		Super: &msg.Supervisor{
-			Action:         `authorize`,
-			PermAction:     action,
-			PermRepository: repository,
-			PermMonitoring: monitoring,
-			PermNode:       node,
-		},
+		Super:  super,
 	}
 	result := <-returnChannel
 	if result.Super.Verdict == 200 {
+		auditAuthorization(params, action, true)
 		if result.Super.VerdictAdmin {
 			// authorized, admin access
 			return true, true
@@ -88,6 +267,7 @@ func IsAuthorized(user, action, repository, monitoring, node string) (bool, bool
 		return true, false
 	}
 	// not authorized
+	auditAuthorization(params, action, false)
 	log.Printf(LogStrErr, `supervisor`, `authorize`, result.Super.Verdict, fmt.Sprintf("Forbidden: %s, %s", user, action))
 	return false, false
 }
@@ -97,6 +277,7 @@ var svGlobalRequiredPermission = map[string][]string{
 	`attributes_delete`:        []string{`system_all`},
 	`attributes_list`:          []string{`system_all`, `global_schema`},
 	`attributes_show`:          []string{`system_all`, `global_schema`},
+	`audit_search`:             []string{`system_all`},
 	`category_create`:          []string{`system_all`},
 	`category_delete`:          []string{`system_all`},
 	`category_list`:            []string{`system_all`, `global_schema`},
@@ -117,6 +298,7 @@ var svGlobalRequiredPermission = map[string][]string{
 	`grant_search`:             []string{`system_all`},
 	`grant_system_right`:       []string{`system_all`},
 	`instance_list_all`:        []string{`system_all`},
+	`jobs_cancel`:              []string{`system_all`, `global_schema`},
 	`jobs_list`:                []string{`system_all`, `global_schema`},
 	`jobs_search`:              []string{`system_all`, `global_schema`},
 	`jobs_show`:                []string{`system_all`, `global_schema`},
@@ -136,9 +318,10 @@ var svGlobalRequiredPermission = map[string][]string{
 	`monitoring_create`:        []string{`system_all`},
 	`monitoring_delete`:        []string{`system_all`},
 	`monitoring_list`:          []string{`system_all`, `global_schema`},
-	`monitoring_show`:          []string{`system_all`, `global_schema`},
 	`node_create`:              []string{`system_all`},
 	`node_delete`:              []string{`system_all`},
+	`node_list`:                []string{`system_all`, `global_schema`},
+	`node_search`:              []string{`system_all`, `global_schema`},
 	`node_sync`:                []string{`system_all`},
 	`node_update`:              []string{`system_all`},
 	`oncall_create`:            []string{`system_all`},
@@ -165,6 +348,7 @@ var svGlobalRequiredPermission = map[string][]string{
 	`property_system_list`:     []string{`system_all`, `global_schema`},
 	`property_system_search`:   []string{`system_all`, `global_schema`},
 	`property_system_show`:     []string{`system_all`, `global_schema`},
+	`property_system_update`:   []string{`system_all`},
 	`property_template_create`: []string{`system_all`},
 	`property_template_delete`: []string{`system_all`},
 	`property_template_list`:   []string{`system_all`, `global_schema`},
@@ -176,9 +360,11 @@ var svGlobalRequiredPermission = map[string][]string{
 	`providers_show`:           []string{`system_all`, `global_schema`},
 	`repository_create`:        []string{`system_all`, `global_schema`},
 	`repository_delete`:        []string{`system_all`},
+	`repository_repossess`:     []string{`system_all`},
 	`revoke_global_right`:      []string{`system_all`},
 	`revoke_limited_right`:     []string{`system_all`},
 	`revoke_system_right`:      []string{`system_all`},
+	`runtime_metrics`:          []string{`system_all`},
 	`servers_create`:           []string{`system_all`},
 	`servers_delete`:           []string{`system_all`},
 	`servers_list`:             []string{`system_all`, `global_schema`},
@@ -234,11 +420,84 @@ var svGlobalRequiredPermission = map[string][]string{
 	`workflow_summary`:         []string{`system_all`},
 }
 
+var svLimitedRequiredPermission = map[string][]string{
+	`buckets_create`:               []string{`system_all`, `repository_write`},
+	`buckets_delete`:               []string{`system_all`, `repository_write`},
+	`buckets_list`:                 []string{`system_all`, `repository_read`, `repository_write`},
+	`buckets_property_add`:         []string{`system_all`, `repository_write`},
+	`buckets_property_delete`:      []string{`system_all`, `repository_write`},
+	`buckets_property_update`:      []string{`system_all`, `repository_write`},
+	`buckets_search`:               []string{`system_all`, `repository_read`, `repository_write`},
+	`buckets_show`:                 []string{`system_all`, `repository_read`, `repository_write`},
+	`buckets_update`:               []string{`system_all`, `repository_write`},
+	`checks_create`:                []string{`system_all`, `repository_write`},
+	`checks_delete`:                []string{`system_all`, `repository_write`},
+	`checks_list`:                  []string{`system_all`, `repository_read`, `repository_write`},
+	`checks_search`:                []string{`system_all`, `repository_read`, `repository_write`},
+	`checks_show`:                  []string{`system_all`, `repository_read`, `repository_write`},
+	`checks_update`:                []string{`system_all`, `repository_write`},
+	`clusters_create`:              []string{`system_all`, `repository_write`},
+	`clusters_delete`:              []string{`system_all`, `repository_write`},
+	`clusters_list`:                []string{`system_all`, `repository_read`, `repository_write`},
+	`clusters_member_add`:          []string{`system_all`, `repository_write`},
+	`clusters_member_delete`:       []string{`system_all`, `repository_write`},
+	`clusters_members_list`:        []string{`system_all`, `repository_read`, `repository_write`},
+	`clusters_property_add`:        []string{`system_all`, `repository_write`},
+	`clusters_property_delete`:     []string{`system_all`, `repository_write`},
+	`clusters_property_update`:     []string{`system_all`, `repository_write`},
+	`clusters_search`:              []string{`system_all`, `repository_read`, `repository_write`},
+	`clusters_show`:                []string{`system_all`, `repository_read`, `repository_write`},
+	`clusters_update`:              []string{`system_all`, `repository_write`},
+	`events_subscribe`:             []string{`system_all`, `global_schema`, `repository_read`, `repository_write`, `team_read`, `team_write`},
+	`groups_create`:                []string{`system_all`, `repository_write`},
+	`groups_delete`:                []string{`system_all`, `repository_write`},
+	`groups_list`:                  []string{`system_all`, `repository_read`, `repository_write`},
+	`groups_member_add`:            []string{`system_all`, `repository_write`},
+	`groups_member_delete`:         []string{`system_all`, `repository_write`},
+	`groups_members_list`:          []string{`system_all`, `repository_read`, `repository_write`},
+	`groups_property_add`:          []string{`system_all`, `repository_write`},
+	`groups_property_delete`:       []string{`system_all`, `repository_write`},
+	`groups_property_update`:       []string{`system_all`, `repository_write`},
+	`groups_search`:                []string{`system_all`, `repository_read`, `repository_write`},
+	`groups_show`:                  []string{`system_all`, `repository_read`, `repository_write`},
+	`groups_update`:                []string{`system_all`, `repository_write`},
+	`instance_list`:                []string{`system_all`, `repository_read`, `repository_write`},
+	`instance_show`:                []string{`system_all`, `repository_read`, `repository_write`},
+	`monitoring_show`:              []string{`system_all`, `global_schema`, `monitoring_read`},
+	`node_assign`:                  []string{`system_all`, `repository_write`},
+	`node_property_add`:            []string{`system_all`, `repository_write`},
+	`node_property_delete`:         []string{`system_all`, `repository_write`},
+	`node_property_update`:         []string{`system_all`, `repository_write`},
+	`node_relocate`:                []string{`system_all`, `repository_write`},
+	`node_show`:                    []string{`system_all`, `team_read`, `team_write`},
+	`node_show_config`:             []string{`system_all`, `team_read`, `team_write`},
+	`property_custom_create`:       []string{`system_all`, `repository_write`},
+	`property_custom_delete`:       []string{`system_all`, `repository_write`},
+	`property_custom_list`:         []string{`system_all`, `repository_read`, `repository_write`},
+	`property_custom_search`:       []string{`system_all`, `repository_read`, `repository_write`},
+	`property_custom_show`:         []string{`system_all`, `repository_read`, `repository_write`},
+	`property_custom_update`:       []string{`system_all`, `repository_write`},
+	`property_service_team_create`: []string{`system_all`, `team_write`},
+	`property_service_team_delete`: []string{`system_all`, `team_write`},
+	`property_service_team_list`:   []string{`system_all`, `team_read`, `team_write`},
+	`property_service_team_search`: []string{`system_all`, `team_read`, `team_write`},
+	`property_service_team_show`:   []string{`system_all`, `team_read`, `team_write`},
+	`repository_clear`:             []string{`system_all`, `repository_write`},
+	`repository_list`:              []string{`system_all`, `repository_read`, `repository_write`},
+	`repository_property_add`:      []string{`system_all`, `repository_write`},
+	`repository_property_delete`:   []string{`system_all`, `repository_write`},
+	`repository_property_update`:   []string{`system_all`, `repository_write`},
+	`repository_search`:            []string{`system_all`, `repository_read`, `repository_write`},
+	`repository_show`:              []string{`system_all`, `repository_read`, `repository_write`},
+	`repository_update`:            []string{`system_all`, `repository_write`},
+}
+
 var svPermissionActionScopeMap = map[string]string{
 	`attributes_create`:              `global`,
 	`attributes_delete`:              `global`,
 	`attributes_list`:                `global`,
 	`attributes_show`:                `global`,
+	`audit_search`:                   `global`,
 	`category_create`:                `global`,
 	`category_delete`:                `global`,
 	`category_list`:                  `global`,
@@ -278,9 +537,10 @@ var svPermissionActionScopeMap = map[string]string{
 	`monitoring_create`:              `global`,
 	`monitoring_delete`:              `global`,
 	`monitoring_list`:                `global`,
-	`monitoring_show`:                `global`,
 	`node_create`:                    `global`,
 	`node_delete`:                    `global`,
+	`node_list`:                      `global`,
+	`node_search`:                    `global`,
 	`node_sync`:                      `global`,
 	`node_update`:                    `global`,
 	`oncall_create`:                  `global`,
@@ -312,15 +572,18 @@ var svPermissionActionScopeMap = map[string]string{
 	`property_system_list`:           `global`,
 	`property_system_search`:         `global`,
 	`property_system_show`:           `global`,
+	`property_system_update`:         `global`,
 	`providers_create`:               `global`,
 	`providers_delete`:               `global`,
 	`providers_list`:                 `global`,
 	`providers_show`:                 `global`,
 	`repository_create`:              `global`,
 	`repository_delete`:              `global`,
+	`repository_repossess`:           `global`,
 	`revoke_global_right`:            `global`,
 	`revoke_limited_right`:           `global`,
 	`revoke_system_right`:            `global`,
+	`runtime_metrics`:                `global`,
 	`servers_create`:                 `global`,
 	`servers_delete`:                 `global`,
 	`servers_list`:                   `global`,
@@ -375,48 +638,75 @@ var svPermissionActionScopeMap = map[string]string{
 	`workflow_set`:                   `global`,
 	`workflow_summary`:               `global`,
 	`buckets_create`:                 `repository`,
-	`buckets_property_add`:           `repository`,
+	`buckets_delete`:                 `repository`,
 	`buckets_list`:                   `repository`,
+	`buckets_property_add`:           `repository`,
+	`buckets_property_delete`:        `repository`,
+	`buckets_property_update`:        `repository`,
 	`buckets_search`:                 `repository`,
 	`buckets_show`:                   `repository`,
+	`buckets_update`:                 `repository`,
 	`checks_create`:                  `repository`,
+	`checks_delete`:                  `repository`,
 	`checks_list`:                    `repository`,
 	`checks_search`:                  `repository`,
 	`checks_show`:                    `repository`,
+	`checks_update`:                  `repository`,
 	`clusters_create`:                `repository`,
+	`clusters_delete`:                `repository`,
 	`clusters_list`:                  `repository`,
 	`clusters_member_add`:            `repository`,
+	`clusters_member_delete`:         `repository`,
 	`clusters_members_list`:          `repository`,
 	`clusters_property_add`:          `repository`,
+	`clusters_property_delete`:       `repository`,
+	`clusters_property_update`:       `repository`,
 	`clusters_search`:                `repository`,
 	`clusters_show`:                  `repository`,
+	`clusters_update`:                `repository`,
 	`groups_create`:                  `repository`,
+	`groups_delete`:                  `repository`,
 	`groups_list`:                    `repository`,
 	`groups_member_add`:              `repository`,
+	`groups_member_delete`:           `repository`,
 	`groups_members_list`:            `repository`,
 	`groups_property_add`:            `repository`,
+	`groups_property_delete`:         `repository`,
+	`groups_property_update`:         `repository`,
 	`groups_search`:                  `repository`,
 	`groups_show`:                    `repository`,
+	`groups_update`:                  `repository`,
 	`instance_list`:                  `repository`,
 	`instance_show`:                  `repository`,
+	`node_assign`:                    `repository`,
 	`node_property_add`:              `repository`,
+	`node_property_delete`:           `repository`,
+	`node_property_update`:           `repository`,
+	`node_relocate`:                  `repository`,
 	`property_custom_create`:         `repository`,
 	`property_custom_delete`:         `repository`,
 	`property_custom_list`:           `repository`,
 	`property_custom_search`:         `repository`,
 	`property_custom_show`:           `repository`,
+	`property_custom_update`:         `repository`,
+	`repository_clear`:               `repository`,
 	`repository_list`:                `repository`,
+	`repository_property_add`:        `repository`,
+	`repository_property_delete`:     `repository`,
+	`repository_property_update`:     `repository`,
 	`repository_search`:              `repository`,
 	`repository_show`:                `repository`,
-	`node_list`:                      `team`,
-	`node_search`:                    `team`,
+	`repository_update`:              `repository`,
+	`monitoring_show`:                `monitoring`,
 	`node_show`:                      `team`,
 	`node_show_config`:               `team`,
+	`property_service_team_create`:   `team`,
+	`property_service_team_delete`:   `team`,
 	`property_service_team_list`:     `team`,
 	`property_service_team_search`:   `team`,
 	`property_service_team_show`:     `team`,
-	`property_service_team_create`:   `team`,
-	`property_service_team_delete`:   `team`,
+	`events_subscribe`:               `any`,
+	`jobs_cancel`:                    `user`,
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,157 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/1and1/soma/internal/msg"
+	log "github.com/Sirupsen/logrus"
+	"github.com/satori/go.uuid"
+)
+
+func newAuthorizeTestSupervisor() *supervisor {
+	s := &supervisor{}
+	s.id_user_rev = s.newLockMap()
+	s.id_userteam = s.newLockMap()
+	s.id_permission = s.newLockMap()
+	s.id_nodeteam = s.newLockMap()
+	s.global_permissions = s.newGlobalPermMap()
+	s.limited_permissions = s.newLimitedPermMap()
+	s.team_permissions = s.newLimitedPermMap()
+	s.monitor_permissions = s.newLimitedPermMap()
+	s.errLog = log.New()
+	s.errLog.Out = ioutil.Discard
+	return s
+}
+
+func testAuthorizeVerdict(s *supervisor, user, action,
+	repository string) uint16 {
+	reply := make(chan msg.Result, 1)
+	s.authorize(&msg.Request{
+		Type:   `supervisor`,
+		Action: `authorize`,
+		User:   user,
+		Reply:  reply,
+		Super: &msg.Supervisor{
+			Action:         `authorize`,
+			PermAction:     action,
+			PermRepository: repository,
+		},
+	})
+	result := <-reply
+	return result.Super.Verdict
+}
+
+func TestAuthorizeTeamRepositoryGrant(t *testing.T) {
+	s := newAuthorizeTestSupervisor()
+
+	userId := uuid.NewV4().String()
+	teamId := uuid.NewV4().String()
+	permId := uuid.NewV4().String()
+	repoId := uuid.NewV4().String()
+	otherRepoId := uuid.NewV4().String()
+
+	s.id_user_rev.insert(`alice`, userId)
+	s.id_userteam.insert(userId, teamId)
+	s.id_permission.insert(`system_all`, uuid.NewV4().String())
+	s.id_permission.insert(`repository_write`, permId)
+	// the grant is given to the team, not the user
+	s.limited_permissions.load(teamId, permId, repoId)
+
+	if v := testAuthorizeVerdict(s, `alice`, `buckets_create`,
+		repoId); v != 200 {
+		t.Errorf("Write to own repository: expected 200, got %d", v)
+	}
+
+	if v := testAuthorizeVerdict(s, `alice`, `buckets_create`,
+		otherRepoId); v != 403 {
+		t.Errorf("Write to foreign repository: expected 403, got %d", v)
+	}
+
+	if v := testAuthorizeVerdict(s, `alice`, `buckets_create`,
+		``); v != 403 {
+		t.Errorf("Write without repository: expected 403, got %d", v)
+	}
+
+	if v := testAuthorizeVerdict(s, `mallory`, `buckets_create`,
+		repoId); v != 403 {
+		t.Errorf("Write by unknown user: expected 403, got %d", v)
+	}
+}
+
+func TestAuthorizeEventsSubscribe(t *testing.T) {
+	s := newAuthorizeTestSupervisor()
+
+	userId := uuid.NewV4().String()
+	teamId := uuid.NewV4().String()
+	otherId := uuid.NewV4().String()
+	readId := uuid.NewV4().String()
+
+	s.id_user_rev.insert(`alice`, userId)
+	s.id_user_rev.insert(`bob`, otherId)
+	s.id_userteam.insert(userId, teamId)
+	for _, perm := range []string{`system_all`, `global_schema`,
+		`repository_write`, `team_read`, `team_write`} {
+		s.id_permission.insert(perm, uuid.NewV4().String())
+	}
+	s.id_permission.insert(`repository_read`, readId)
+	// a team grant on a single repository is sufficient
+	s.limited_permissions.load(teamId, readId, uuid.NewV4().String())
+
+	if v := testAuthorizeVerdict(s, `alice`, `events_subscribe`,
+		``); v != 200 {
+		t.Errorf("Subscribe with repository grant: expected 200, got %d", v)
+	}
+
+	if v := testAuthorizeVerdict(s, `bob`, `events_subscribe`,
+		``); v != 403 {
+		t.Errorf("Subscribe without grant: expected 403, got %d", v)
+	}
+}
+
+func TestAuthorizeJobsCancel(t *testing.T) {
+	s := newAuthorizeTestSupervisor()
+
+	userId := uuid.NewV4().String()
+	adminId := uuid.NewV4().String()
+	systemId := uuid.NewV4().String()
+
+	s.id_user_rev.insert(`alice`, userId)
+	s.id_user_rev.insert(`root`, adminId)
+	s.id_permission.insert(`system_all`, systemId)
+	s.id_permission.insert(`global_schema`, uuid.NewV4().String())
+	s.global_permissions.load(adminId, systemId,
+		uuid.NewV4().String())
+
+	reply := make(chan msg.Result, 1)
+	for _, c := range []struct {
+		user    string
+		verdict uint16
+		admin   bool
+	}{
+		// ownership of the job is checked when it is cancelled
+		{`alice`, 200, false},
+		{`root`, 200, true},
+		{`mallory`, 403, false},
+	} {
+		s.authorize(&msg.Request{
+			User:  c.user,
+			Reply: reply,
+			Super: &msg.Supervisor{PermAction: `jobs_cancel`},
+		})
+		result := <-reply
+		if result.Super.Verdict != c.verdict ||
+			result.Super.VerdictAdmin != c.admin {
+			t.Errorf("%s: expected %d/%t, got %d/%t", c.user,
+				c.verdict, c.admin, result.Super.Verdict,
+				result.Super.VerdictAdmin)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/1and1/soma/internal/msg"
+	"github.com/1and1/soma/internal/stmt"
 	"github.com/1and1/soma/lib/proto"
 	uuid "github.com/satori/go.uuid"
 )
@@ -153,9 +154,154 @@ dispatch:
 }
 
 func (s *supervisor) right_limited_modify(q *msg.Request) {
+	result := msg.Result{Type: `supervisor`, Action: `right`, Super: &msg.Supervisor{Action: q.Super.Action}}
+	userUUID, ok := s.id_user_rev.get(q.User)
+	if !ok {
+		userUUID = `00000000-0000-0000-0000-000000000000`
+	}
+
+	var (
+		res       sql.Result
+		err       error
+		data      []string
+		statement string
+	)
+
+	switch q.Super.Action {
+	case `grant`:
+		switch q.Grant.ObjectType {
+		case `repository`:
+			// repository grants are stored with the repository
+			q.Grant.RepositoryId = q.Grant.ObjectId
+			statement = stmt.GrantLimitedRepoToUser
+			if q.Grant.RecipientType == `team` {
+				statement = stmt.GrantLimitedRepoToTeam
+			}
+		case `team`:
+			statement = stmt.GrantLimitedTeamToUser
+			if q.Grant.RecipientType == `team` {
+				statement = stmt.GrantLimitedTeamToTeam
+			}
+		case `monitoring`:
+			statement = stmt.GrantLimitedMonitoringToUser
+			if q.Grant.RecipientType == `team` {
+				statement = stmt.GrantLimitedMonitoringToTeam
+			}
+		default:
+			result.NotImplemented(fmt.Errorf(
+				"Supervisor: unsupported grant scope %s",
+				q.Grant.ObjectType))
+			goto dispatch
+		}
+		switch q.Grant.RecipientType {
+		case `user`, `team`:
+		default:
+			result.NotImplemented(fmt.Errorf(
+				"Supervisor: unsupported recipient type %s",
+				q.Grant.RecipientType))
+			goto dispatch
+		}
+		q.Grant.Id = uuid.NewV4().String()
+		res, err = s.conn.Exec(
+			statement,
+			q.Grant.Id,
+			q.Grant.RecipientId,
+			q.Grant.ObjectId,
+			q.Grant.PermissionId,
+			q.Grant.Category,
+			userUUID,
+		)
+	case `revoke`:
+		if q.Grant.Id == `` {
+			q.Grant.Id = q.Super.GrantId
+		}
+		// data = []string{scope, recipientID, permissionID, objectID}
+		if data = s.limited_grants.get(q.Grant.Id); data == nil {
+			result.NotFound(fmt.Errorf(`Supervisor: unknown`))
+			goto dispatch
+		}
+		q.Grant.ObjectType = data[0]
+		q.Grant.RecipientId = data[1]
+		q.Grant.PermissionId = data[2]
+		q.Grant.ObjectId = data[3]
+
+		switch q.Grant.ObjectType {
+		case `repository`:
+			statement = stmt.RevokeLimitedRepoFromUser
+		case `team`:
+			statement = stmt.RevokeLimitedTeam
+		case `monitoring`:
+			statement = stmt.RevokeLimitedMonitoring
+		}
+		res, err = s.conn.Exec(
+			statement,
+			q.Grant.Id,
+		)
+	}
+	if err != nil {
+		result.ServerError(err)
+		goto dispatch
+	}
+	if result.RowCnt(res.RowsAffected()) {
+		result.Grant = []proto.Grant{q.Grant}
+		// keep lookup maps in sync
+		switch q.Super.Action {
+		case `grant`:
+			s.limitedPermMap(q.Grant.ObjectType).grant(
+				q.Grant.RecipientId, q.Grant.PermissionId,
+				q.Grant.ObjectId)
+			s.limited_grants.record(q.Grant.ObjectType,
+				q.Grant.RecipientId, q.Grant.PermissionId,
+				q.Grant.ObjectId, q.Grant.Id)
+		case `revoke`:
+			s.limited_grants.discard(q.Grant.Id)
+			s.limitedPermMap(q.Grant.ObjectType).revoke(
+				q.Grant.RecipientId, q.Grant.PermissionId,
+				q.Grant.ObjectId)
+		}
+	}
+
+dispatch:
+	q.Reply <- result
 }
 
 func (s *supervisor) right_limited_read(q *msg.Request) {
+	result := msg.Result{Type: `supervisor`, Action: `right`, Super: &msg.Supervisor{Action: q.Super.Action}}
+
+	switch q.Super.Action {
+	case `search`:
+		result.Grant = []proto.Grant{}
+		s.limited_grants.rlock()
+		for grantId, data := range s.limited_grants.GMap {
+			// data = []string{scope, recipientID, permissionID, objectID}
+			if (q.Grant.ObjectType != `` && q.Grant.ObjectType != data[0]) ||
+				(q.Grant.RecipientId != `` && q.Grant.RecipientId != data[1]) ||
+				(q.Grant.PermissionId != `` && q.Grant.PermissionId != data[2]) ||
+				(q.Grant.ObjectId != `` && q.Grant.ObjectId != data[3]) {
+				continue
+			}
+			result.Grant = append(result.Grant, proto.Grant{
+				Id:            grantId,
+				RecipientType: q.Grant.RecipientType,
+				RecipientId:   data[1],
+				PermissionId:  data[2],
+				Category:      q.Grant.Category,
+				ObjectType:    data[0],
+				ObjectId:      data[3],
+			})
+		}
+		s.limited_grants.runlock()
+		if len(result.Grant) == 0 {
+			result.NotFound(fmt.Errorf(`Supervisor: no matching grants`))
+			goto dispatch
+		}
+		result.OK()
+	default:
+		result.ServerError(nil)
+	}
+
+dispatch:
+	q.Reply <- result
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -35,6 +35,13 @@ func (s *supervisor) update_map(q *msg.Request) {
 			s.id_user.remove(q.Super.User.Id)
 			s.id_userteam.remove(q.Super.User.Id)
 		}
+	case `node`:
+		switch q.Super.Action {
+		case `add`, `update`:
+			s.id_nodeteam.insert(q.Super.Node.Id, q.Super.Node.TeamId)
+		case `delete`:
+			s.id_nodeteam.remove(q.Super.Node.Id)
+		}
 	}
 
 }
@@ -211,7 +211,7 @@ func (w *somaTeamWriteHandler) process(q *somaTeamRequest) {
 		notify msg.Request
 	)
 	result := somaResult{}
-	super = handlerMap[`supervisor`].(*supervisor)
+	super = handlerMap.Get(`supervisor`).(*supervisor)
 	notify = msg.Request{Type: `supervisor`, Action: `update_map`,
 		Super: &msg.Supervisor{
 			Object: `team`,
@@ -280,8 +280,24 @@ func (r *somaTeamReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaTeamReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaTeamReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaTeamWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaTeamWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaTeamWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -8,11 +8,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/1and1/soma/internal/stmt"
 	"github.com/1and1/soma/internal/tree"
+	"github.com/1and1/soma/lib/proto"
 	log "github.com/Sirupsen/logrus"
+	"github.com/client9/reopen"
 	metrics "github.com/rcrowley/go-metrics"
 	uuid "github.com/satori/go.uuid"
 )
@@ -29,6 +32,8 @@ type treeRequest struct {
 	Cluster     somaClusterRequest
 	Node        somaNodeRequest
 	CheckConfig somaCheckConfigRequest
+	DryRun      bool
+	relocation  *treeRelocation
 }
 
 type treeResult struct {
@@ -48,15 +53,23 @@ type treeKeeper struct {
 	ready                bool
 	stopped              bool
 	frozen               bool
+	state                treeKeeperState
 	rebuild              bool
+	importing            bool
+	snapshot             *snapshot
+	importConfigs        map[string]proto.CheckConfig
+	importCustom         []proto.PropertyCustom
+	importInstances      map[string]string
 	input                chan treeRequest
 	shutdown             chan bool
 	stopchan             chan bool
 	errChan              chan *tree.Error
 	actionChan           chan *tree.Action
+	events               []proto.Action
 	conn                 *sql.DB
 	tree                 *tree.Tree
 	get_view             *sql.Stmt
+	job_cancelled        *sql.Stmt
 	start_job            *sql.Stmt
 	stmt_CapMonMetric    *sql.Stmt
 	stmt_Check           *sql.Stmt
@@ -91,6 +104,17 @@ type treeKeeper struct {
 	startLog             *log.Logger
 }
 
+// treeKeeperState is the copy of the treekeeper state that is
+// published to other go-routines
+type treeKeeperState struct {
+	sync.RWMutex
+	repoName string
+	ready    bool
+	broken   bool
+	stopped  bool
+	frozen   bool
+}
+
 // run() is the method a treeKeeper executes in its background
 // go-routine. It checks and handles the input channels and reacts
 // appropriately.
@@ -112,16 +136,16 @@ func (tk *treeKeeper) run() {
 	tk.startLog.Out = ioutil.Discard
 
 	// close the startup logfile
-	func() {
-		fh := logFileMap[fmt.Sprintf("startup_repository_%s", tk.repoName)]
-		delete(logFileMap, fmt.Sprintf("startup_repository_%s", tk.repoName))
+	if fh := logFileMap.Del(fmt.Sprintf("startup_repository_%s",
+		tk.repoName)); fh != nil {
 		fh.Close()
-	}()
+	}
 	// deferred close the regular logfile
 	defer func() {
-		fh := logFileMap[fmt.Sprintf("repository_%s", tk.repoName)]
-		delete(logFileMap, fmt.Sprintf("repository_%s", tk.repoName))
-		fh.Close()
+		if fh := logFileMap.Del(fmt.Sprintf("repository_%s",
+			tk.repoName)); fh != nil {
+			fh.Close()
+		}
 	}()
 
 	var err error
@@ -153,6 +177,7 @@ func (tk *treeKeeper) run() {
 	// considered broken.
 broken:
 	if tk.broken {
+		tk.publishState()
 		tickTack := time.NewTicker(time.Second * 10).C
 	hoverloop:
 		for {
@@ -165,55 +190,60 @@ broken:
 			case <-tk.stopchan:
 				tk.stop()
 				goto stopsign
+			case req := <-tk.input:
+				tk.refuse(&req)
 			}
 		}
 		return
 	}
 
-	// prepare statements
-	for statement, prepStmt := range map[string]*sql.Stmt{
-		stmt.TreekeeperDeleteDuplicateDetails:          tk.stmt_DelDuplicate,
-		stmt.TxDeployDetailClusterCustProp:             tk.stmt_ClusterCustProp,
-		stmt.TxDeployDetailClusterSysProp:              tk.stmt_ClusterSysProp,
-		stmt.TxDeployDetailDefaultDatacenter:           tk.stmt_DefaultDC,
-		stmt.TxDeployDetailNodeCustProp:                tk.stmt_NodeCustProp,
-		stmt.TxDeployDetailNodeSysProp:                 tk.stmt_NodeSysProp,
-		stmt.TxDeployDetailsCapabilityMonitoringMetric: tk.stmt_CapMonMetric,
-		stmt.TxDeployDetailsCheck:                      tk.stmt_Check,
-		stmt.TxDeployDetailsCheckConfig:                tk.stmt_CheckConfig,
-		stmt.TxDeployDetailsCheckConfigThreshold:       tk.stmt_Threshold,
-		stmt.TxDeployDetailsCheckInstance:              tk.stmt_CheckInstance,
-		stmt.TxDeployDetailsCluster:                    tk.stmt_Cluster,
-		stmt.TxDeployDetailsClusterOncall:              tk.stmt_ClusterOncall,
-		stmt.TxDeployDetailsClusterService:             tk.stmt_ClusterService,
-		stmt.TxDeployDetailsComputeList:                tk.stmt_List,
-		stmt.TxDeployDetailsGroup:                      tk.stmt_Group,
-		stmt.TxDeployDetailsGroupCustProp:              tk.stmt_GroupCustProp,
-		stmt.TxDeployDetailsGroupOncall:                tk.stmt_GroupOncall,
-		stmt.TxDeployDetailsGroupService:               tk.stmt_GroupService,
-		stmt.TxDeployDetailsGroupSysProp:               tk.stmt_GroupSysProp,
-		stmt.TxDeployDetailsNode:                       tk.stmt_Node,
-		stmt.TxDeployDetailsNodeOncall:                 tk.stmt_NodeOncall,
-		stmt.TxDeployDetailsNodeService:                tk.stmt_NodeService,
-		stmt.TxDeployDetailsProviders:                  tk.stmt_Pkgs,
-		stmt.TxDeployDetailsTeam:                       tk.stmt_Team,
-		stmt.TxDeployDetailsUpdate:                     tk.stmt_Update,
-		stmt.TreekeeperGetComputedDeployments:          tk.stmt_GetComputed,
-		stmt.TreekeeperGetPreviousDeployment:           tk.stmt_GetPrevious,
-		stmt.TreekeeperGetViewFromCapability:           tk.get_view,
-		stmt.TreekeeperStartJob:                        tk.start_job,
+	// prepare statements, the map holds the addresses of the
+	// statement fields to assign them
+	for statement, prepStmt := range map[string]**sql.Stmt{
+		stmt.TreekeeperDeleteDuplicateDetails:          &tk.stmt_DelDuplicate,
+		stmt.TxDeployDetailClusterCustProp:             &tk.stmt_ClusterCustProp,
+		stmt.TxDeployDetailClusterSysProp:              &tk.stmt_ClusterSysProp,
+		stmt.TxDeployDetailDefaultDatacenter:           &tk.stmt_DefaultDC,
+		stmt.TxDeployDetailNodeCustProp:                &tk.stmt_NodeCustProp,
+		stmt.TxDeployDetailNodeSysProp:                 &tk.stmt_NodeSysProp,
+		stmt.TxDeployDetailsCapabilityMonitoringMetric: &tk.stmt_CapMonMetric,
+		stmt.TxDeployDetailsCheck:                      &tk.stmt_Check,
+		stmt.TxDeployDetailsCheckConfig:                &tk.stmt_CheckConfig,
+		stmt.TxDeployDetailsCheckConfigThreshold:       &tk.stmt_Threshold,
+		stmt.TxDeployDetailsCheckInstance:              &tk.stmt_CheckInstance,
+		stmt.TxDeployDetailsCluster:                    &tk.stmt_Cluster,
+		stmt.TxDeployDetailsClusterOncall:              &tk.stmt_ClusterOncall,
+		stmt.TxDeployDetailsClusterService:             &tk.stmt_ClusterService,
+		stmt.TxDeployDetailsComputeList:                &tk.stmt_List,
+		stmt.TxDeployDetailsGroup:                      &tk.stmt_Group,
+		stmt.TxDeployDetailsGroupCustProp:              &tk.stmt_GroupCustProp,
+		stmt.TxDeployDetailsGroupOncall:                &tk.stmt_GroupOncall,
+		stmt.TxDeployDetailsGroupService:               &tk.stmt_GroupService,
+		stmt.TxDeployDetailsGroupSysProp:               &tk.stmt_GroupSysProp,
+		stmt.TxDeployDetailsNode:                       &tk.stmt_Node,
+		stmt.TxDeployDetailsNodeOncall:                 &tk.stmt_NodeOncall,
+		stmt.TxDeployDetailsNodeService:                &tk.stmt_NodeService,
+		stmt.TxDeployDetailsProviders:                  &tk.stmt_Pkgs,
+		stmt.TxDeployDetailsTeam:                       &tk.stmt_Team,
+		stmt.TxDeployDetailsUpdate:                     &tk.stmt_Update,
+		stmt.TreekeeperGetComputedDeployments:          &tk.stmt_GetComputed,
+		stmt.TreekeeperGetPreviousDeployment:           &tk.stmt_GetPrevious,
+		stmt.TreekeeperGetViewFromCapability:           &tk.get_view,
+		stmt.TreekeeperJobCancelled:                    &tk.job_cancelled,
+		stmt.TreekeeperStartJob:                        &tk.start_job,
 	} {
-		if prepStmt, err = tk.conn.Prepare(statement); err != nil {
+		if *prepStmt, err = tk.conn.Prepare(statement); err != nil {
 			tk.log.Println("Error preparing SQL statement: ", err)
 			tk.log.Println("Failed statement: ", statement)
 			tk.broken = true
 			goto broken
 		}
-		defer prepStmt.Close()
+		defer (*prepStmt).Close()
 	}
 
 	tk.appLog.Printf("TK[%s]: ready for service!\n", tk.repoName)
 	tk.ready = true
+	tk.publishState()
 
 	if SomaCfg.Observer {
 		// XXX should listen on stopchan
@@ -230,7 +260,8 @@ stopsign:
 		// the handlerMap)
 	drain:
 		for i := len(tk.input); i > 0; i-- {
-			<-tk.input
+			req := <-tk.input
+			tk.refuse(&req)
 		}
 		if len(tk.input) > 0 {
 			// there were blocked writers on a full buffered channel
@@ -243,6 +274,9 @@ stopsign:
 			case <-tk.shutdown:
 				goto exit
 			case <-tk.stopchan:
+			case req := <-tk.input:
+				// writers that raced the stop
+				tk.refuse(&req)
 			}
 		}
 	}
@@ -255,8 +289,18 @@ runloop:
 			tk.stop()
 			goto stopsign
 		case req := <-tk.input:
-			tk.process(&req)
-			handlerMap[`jobDelay`].(*jobDelay).notify <- req.JobId.String()
+			if req.DryRun {
+				// dryrun requests leave the tree unchanged
+				tk.dryRun(&req)
+				continue runloop
+			}
+			if req.Action == `assign_relocated_node` {
+				// the job belongs to the source treekeeper
+				tk.processRelocation(&req)
+			} else {
+				tk.process(&req)
+				handlerMap.Get(`jobDelay`).(*jobDelay).notify <- req.JobId.String()
+			}
 			if !tk.frozen {
 				// buildDeploymentDetails and orderDeploymentDetails can
 				// both mark the tree as broken if there was an error
@@ -270,41 +314,108 @@ runloop:
 					goto broken
 				}
 			}
+			if tk.stopped {
+				goto stopsign
+			}
 		}
 	}
 exit:
 }
 
+// publishState copies the state of the treekeeper for other
+// go-routines to read. It must only be called from the treekeeper
+// itself.
+func (tk *treeKeeper) publishState() {
+	tk.state.Lock()
+	defer tk.state.Unlock()
+	tk.state.repoName = tk.repoName
+	tk.state.ready = tk.ready
+	tk.state.broken = tk.broken
+	tk.state.stopped = tk.stopped
+	tk.state.frozen = tk.frozen
+}
+
 func (tk *treeKeeper) isReady() bool {
-	return tk.ready
+	tk.state.RLock()
+	defer tk.state.RUnlock()
+	return tk.state.ready
 }
 
 func (tk *treeKeeper) isBroken() bool {
-	return tk.broken
+	tk.state.RLock()
+	defer tk.state.RUnlock()
+	return tk.state.broken
+}
+
+func (tk *treeKeeper) isFrozen() bool {
+	tk.state.RLock()
+	defer tk.state.RUnlock()
+	return tk.state.frozen
 }
 
 func (tk *treeKeeper) stop() {
 	tk.stopped = true
 	tk.ready = false
 	tk.broken = false
+	tk.publishState()
 }
 
 func (tk *treeKeeper) isStopped() bool {
-	return tk.stopped
+	tk.state.RLock()
+	defer tk.state.RUnlock()
+	return tk.state.stopped
+}
+
+func (tk *treeKeeper) name() string {
+	tk.state.RLock()
+	defer tk.state.RUnlock()
+	return tk.state.repoName
+}
+
+// rename switches the treekeeper to a new repository name. The
+// handler and logfile registrations as well as the logfile itself
+// are moved to the new name.
+func (tk *treeKeeper) rename(name string) {
+	oldKeeper := fmt.Sprintf("repository_%s", tk.repoName)
+	newKeeper := fmt.Sprintf("repository_%s", name)
+
+	lfh, err := reopen.NewFileWriter(filepath.Join(
+		SomaCfg.LogPath,
+		`repository`,
+		fmt.Sprintf("%s.log", newKeeper),
+	))
+	if err != nil {
+		// keep logging to the old logfile
+		tk.log.Printf("TK[%s]: failed to open new logfile: %s",
+			tk.repoName, err)
+	} else {
+		tk.log.Printf("TK[%s]: renamed to %s", tk.repoName, name)
+		tk.log.Out = lfh
+		if fh := logFileMap.Del(oldKeeper); fh != nil {
+			fh.Close()
+		}
+		logFileMap.Set(newKeeper, lfh)
+	}
+
+	handlerMap.Rename(oldKeeper, newKeeper)
+	tk.repoName = name
+	tk.tree.Name = fmt.Sprintf("root_%s", name)
+	tk.publishState()
 }
 
 func (tk *treeKeeper) process(q *treeRequest) {
 	var (
-		err                                   error
-		hasErrors, hasJobLog, jobNeverStarted bool
-		tx                                    *sql.Tx
-		stm                                   map[string]*sql.Stmt
-		jobLog                                *log.Logger
-		lfh                                   *os.File
+		err                        error
+		hasJobLog, jobNeverStarted bool
+		tx                         *sql.Tx
+		res                        sql.Result
+		stm                        map[string]*sql.Stmt
+		jobLog                     *log.Logger
+		lfh                        *os.File
 	)
 
 	if !tk.rebuild {
-		_, err = tk.start_job.Exec(q.JobId.String(), time.Now().UTC())
+		res, err = tk.start_job.Exec(q.JobId.String(), time.Now().UTC())
 		if err != nil {
 			tk.log.Printf("Failed starting job %s: %s\n",
 				q.JobId.String(),
@@ -312,52 +423,234 @@ func (tk *treeKeeper) process(q *treeRequest) {
 			jobNeverStarted = true
 			goto bailout
 		}
+		// jobs that were cancelled while queued are not started,
+		// this includes jobs replayed by startupJobs
+		if n, _ := res.RowsAffected(); n == 0 && tk.isCancelled(q) {
+			tk.appLog.Printf("Skipping cancelled job: %s\n",
+				q.JobId.String())
+			tk.publishJob(q, `processed`, `cancelled`, ``)
+			return
+		}
 		tk.appLog.Printf("Processing job: %s\n", q.JobId.String())
+		tk.publishJob(q, `in_progress`, `pending`, ``)
 	} else {
 		tk.appLog.Printf("Processing rebuild job: %s\n", q.JobId.String())
 	}
-	if lfh, err = os.Create(filepath.Join(
-		SomaCfg.LogPath,
-		`job`,
-		fmt.Sprintf("%s_%s_%s.log",
-			time.Now().UTC().Format(rfc3339Milli),
-			tk.repoName,
-			q.JobId.String(),
-		),
-	)); err != nil {
-		tk.log.Printf("Failed opening joblog %s: %s\n",
-			q.JobId.String(),
-			err)
-	}
+	jobLog, lfh = tk.openJobLog(q)
 	defer lfh.Close()
 	defer lfh.Sync()
-	jobLog = log.New()
-	jobLog.Out = lfh
 	hasJobLog = true
 
 	tk.tree.Begin()
 
+	// apply the requested change to the tree
+	err = tk.treeAction(q)
+
+	// check if we accumulated an error in one of the switch cases
+	if err != nil {
+		goto bailout
+	}
+
+	// recalculate check instances
+	tk.tree.ComputeCheckInstances()
+
+	// open multi-statement transaction
+	if tx, stm, err = tk.startTx(); err != nil {
+		goto bailout
+	}
+	defer tx.Rollback()
+
+	// defer constraint checks
+	if _, err = tx.Exec(stmt.TxDeferAllConstraints); err != nil {
+		tk.log.Println("Failed to exec: tkStmtDeferAllConstraints")
+		goto bailout
+	}
+
+	// save the check configuration as part of the transaction before
+	// processing the action channel
+	if strings.Contains(q.Action, "add_check_to_") {
+		if err = tk.txCheckConfig(q.CheckConfig.CheckConfig,
+			stm); err != nil {
+			goto bailout
+		}
+	}
+
+	// update the check configuration in place, check instances
+	// receive a new version via the action channel
+	if q.Action == `update_check` {
+		if err = tk.txCheckConfigUpdate(q.CheckConfig.CheckConfig,
+			stm); err != nil {
+			goto bailout
+		}
+	}
+
+	// mark the check configuration as deleted
+	if strings.HasPrefix(q.Action, `remove_check_from_`) {
+		if _, err = tx.Exec(
+			stmt.TxMarkCheckConfigDeleted,
+			q.CheckConfig.CheckConfig.Id,
+		); err != nil {
+			goto bailout
+		}
+	}
+
+	// update the check configurations of a relocated node
+	if q.Action == `relocate_node` {
+		if err = tk.txRelocateChecks(q, tx, stm); err != nil {
+			goto bailout
+		}
+	}
+
+	// write the tree changes into the transaction
+	if err = tk.txActions(stm, q.User, jobLog); err != nil {
+		goto bailout
+	}
+
+	// let the target treekeeper of a relocation join the transaction
+	if q.Action == `relocate_node` {
+		if err = tk.relocateHandoff(q, tx); err != nil {
+			goto bailout
+		}
+	}
+
+	if !tk.rebuild {
+		// mark job as finished
+		if _, err = tx.Exec(
+			stmt.TxFinishJob,
+			q.JobId.String(),
+			time.Now().UTC(),
+			"success",
+			``, // empty error field
+		); err != nil {
+			goto bailout
+		}
+	}
+
+	// the target treekeeper of a relocation may have given up
+	if err = q.relocation.prepareCommit(); err != nil {
+		goto bailout
+	}
+
+	// commit transaction
+	if err = tx.Commit(); err != nil {
+		goto bailout
+	}
+	q.relocation.decide(true)
+	tk.appLog.Printf("SUCCESS - Finished job: %s\n", q.JobId.String())
+
+	// accept tree changes
+	tk.tree.Commit()
+	if !tk.rebuild {
+		tk.publishJob(q, `processed`, `success`, ``)
+		tk.publishActions(q)
+	}
+
+	// apply repository changes to the treekeeper itself
+	switch q.Action {
+	case `rename_repository`:
+		tk.rename(q.Repository.Repository.Name)
+	case `repossess_repository`:
+		tk.team = q.Repository.Repository.TeamId
+	case `purge_repository`:
+		// purged repositories are no longer served, the runloop
+		// stops after this job
+		tk.stop()
+	}
+	return
+
+bailout:
+	tk.appLog.Printf("FAILED - Finished job: %s\n", q.JobId.String())
+	tk.log.Printf("Job-Error(%s): %s\n", q.JobId.String(), err)
+	if hasJobLog {
+		jobLog.Printf("Aborting error: %s\n", err)
+	}
+
+	// if this was a rebuild, the tree will not persist and the
+	// job is faked. Also if the job never actually started, then it
+	// should never be rolled back nor attempted to mark failed.
+	if tk.rebuild || jobNeverStarted {
+		return
+	}
+
+	tk.tree.Rollback()
+	tx.Rollback()
+	q.relocation.decide(false)
+	tk.conn.Exec(
+		stmt.TxFinishJob,
+		q.JobId.String(),
+		time.Now().UTC(),
+		"failed",
+		err.Error(),
+	)
+	tk.events = nil
+	tk.publishJob(q, `processed`, `failed`, err.Error())
+	for i := len(tk.actionChan); i > 0; i-- {
+		a := <-tk.actionChan
+		jB, _ := json.Marshal(a)
+		if hasJobLog {
+			jobLog.Printf("Cleaned message: %s\n", string(jB))
+		}
+	}
+	return
+}
+
+// isCancelled checks if the job of q has been cancelled
+func (tk *treeKeeper) isCancelled(q *treeRequest) bool {
+	var cancelled bool
+	if err := tk.job_cancelled.QueryRow(
+		q.JobId.String(),
+	).Scan(&cancelled); err != nil {
+		tk.log.Printf("Failed checking job %s: %s\n",
+			q.JobId.String(),
+			err)
+		return false
+	}
+	return cancelled
+}
+
+// treeAction applies the change requested by q to the tree
+func (tk *treeKeeper) treeAction(q *treeRequest) error {
 	// q.Action == `rebuild` will fall through switch
 	switch q.Action {
 
 	//
 	// TREE MANIPULATION REQUESTS
 	case
-		`create_bucket`:
+		`delete_repository`,
+		`restore_repository`,
+		`purge_repository`,
+		`clear_repository`,
+		`rename_repository`,
+		`repossess_repository`,
+		`activate_repository`:
+		tk.treeRepository(q)
+
+	case
+		`create_bucket`,
+		`delete_bucket`,
+		`restore_bucket`,
+		`purge_bucket`,
+		`freeze_bucket`,
+		`thaw_bucket`,
+		`rename_bucket`:
 		tk.treeBucket(q)
 
 	case
 		`create_group`,
 		`delete_group`,
 		`reset_group_to_bucket`,
-		`add_group_to_group`:
+		`add_group_to_group`,
+		`remove_group_from_group`,
+		`rename_group`:
 		tk.treeGroup(q)
 
 	case
 		`create_cluster`,
 		`delete_cluster`,
 		`reset_cluster_to_bucket`,
-		`add_cluster_to_group`:
+		`add_cluster_to_group`,
+		`remove_cluster_from_group`,
+		`rename_cluster`:
 		tk.treeCluster(q)
 
 	case
@@ -365,7 +658,10 @@ func (tk *treeKeeper) process(q *treeRequest) {
 		"delete_node",
 		"reset_node_to_bucket",
 		"add_node_to_group",
-		"add_node_to_cluster":
+		"add_node_to_cluster",
+		"remove_node_from_group",
+		"remove_node_from_cluster",
+		`relocate_node`:
 		tk.treeNode(q)
 
 	//
@@ -416,6 +712,29 @@ func (tk *treeKeeper) process(q *treeRequest) {
 		`delete_custom_property_from_node`:
 		tk.rmProperty(q)
 
+	case
+		`update_system_property_on_repository`,
+		`update_system_property_on_bucket`,
+		`update_system_property_on_group`,
+		`update_system_property_on_cluster`,
+		`update_system_property_on_node`,
+		`update_service_property_on_repository`,
+		`update_service_property_on_bucket`,
+		`update_service_property_on_group`,
+		`update_service_property_on_cluster`,
+		`update_service_property_on_node`,
+		`update_oncall_property_on_repository`,
+		`update_oncall_property_on_bucket`,
+		`update_oncall_property_on_group`,
+		`update_oncall_property_on_cluster`,
+		`update_oncall_property_on_node`,
+		`update_custom_property_on_repository`,
+		`update_custom_property_on_bucket`,
+		`update_custom_property_on_group`,
+		`update_custom_property_on_cluster`,
+		`update_custom_property_on_node`:
+		tk.updateProperty(q)
+
 	//
 	// CHECK MANIPULATION REQUESTS
 	case
@@ -424,7 +743,7 @@ func (tk *treeKeeper) process(q *treeRequest) {
 		`add_check_to_group`,
 		`add_check_to_cluster`,
 		`add_check_to_node`:
-		err = tk.addCheck(&q.CheckConfig.CheckConfig)
+		return tk.addCheck(&q.CheckConfig.CheckConfig)
 
 	case
 		`remove_check_from_repository`,
@@ -432,63 +751,58 @@ func (tk *treeKeeper) process(q *treeRequest) {
 		`remove_check_from_group`,
 		`remove_check_from_cluster`,
 		`remove_check_from_node`:
-		err = tk.rmCheck(&q.CheckConfig.CheckConfig)
-	}
+		return tk.rmCheck(&q.CheckConfig.CheckConfig)
 
-	// check if we accumulated an error in one of the switch cases
-	if err != nil {
-		goto bailout
-	}
-
-	// recalculate check instances
-	tk.tree.ComputeCheckInstances()
-
-	// open multi-statement transaction
-	if tx, stm, err = tk.startTx(); err != nil {
-		goto bailout
-	}
-	defer tx.Rollback()
-
-	// defer constraint checks
-	if _, err = tx.Exec(stmt.TxDeferAllConstraints); err != nil {
-		tk.log.Println("Failed to exec: tkStmtDeferAllConstraints")
-		goto bailout
+	case `update_check`:
+		return tk.updateCheck(&q.CheckConfig.CheckConfig)
 	}
+	return nil
+}
 
-	// save the check configuration as part of the transaction before
-	// processing the action channel
-	if strings.Contains(q.Action, "add_check_to_") {
-		if err = tk.txCheckConfig(q.CheckConfig.CheckConfig,
-			stm); err != nil {
-			goto bailout
-		}
+// openJobLog opens the logfile for job q
+func (tk *treeKeeper) openJobLog(q *treeRequest) (*log.Logger, *os.File) {
+	lfh, err := os.Create(filepath.Join(
+		SomaCfg.LogPath,
+		`job`,
+		fmt.Sprintf("%s_%s_%s.log",
+			time.Now().UTC().Format(rfc3339Milli),
+			tk.repoName,
+			q.JobId.String(),
+		),
+	))
+	if err != nil {
+		tk.log.Printf("Failed opening joblog %s: %s\n",
+			q.JobId.String(),
+			err)
 	}
+	jobLog := log.New()
+	jobLog.Out = lfh
+	return jobLog, lfh
+}
 
-	// mark the check configuration as deleted
-	if strings.HasPrefix(q.Action, `remove_check_from_`) {
-		if _, err = tx.Exec(
-			stmt.TxMarkCheckConfigDeleted,
-			q.CheckConfig.CheckConfig.Id,
-		); err != nil {
-			goto bailout
-		}
-	}
+// txActions writes the actions accumulated in the tree into the
+// transaction. If the tree reported errors, the action channel is
+// ignored and the first error is returned.
+func (tk *treeKeeper) txActions(stm map[string]*sql.Stmt, user string,
+	jobLog *log.Logger) error {
+	var (
+		err       error
+		hasErrors bool
+	)
 
 	// if the error channel has entries, we can fully ignore the
 	// action channel
 	for i := len(tk.errChan); i > 0; i-- {
 		e := <-tk.errChan
-		if hasJobLog {
-			b, _ := json.Marshal(e)
-			jobLog.Println(string(b))
-		}
+		b, _ := json.Marshal(e)
+		jobLog.Println(string(b))
 		hasErrors = true
 		if err == nil {
 			err = fmt.Errorf(e.Action)
 		}
 	}
 	if hasErrors {
-		goto bailout
+		return err
 	}
 
 actionloop:
@@ -496,9 +810,11 @@ actionloop:
 		a := <-tk.actionChan
 
 		// log all actions for the job
-		if hasJobLog {
-			b, _ := json.Marshal(a)
-			jobLog.Println(string(b))
+		b, _ := json.Marshal(a)
+		jobLog.Println(string(b))
+		if !tk.rebuild {
+			// published on the event stream after the commit
+			tk.events = append(tk.events, proto.Action(*a))
 		}
 
 		// only check and check_instance actions are relevant during
@@ -516,7 +832,7 @@ actionloop:
 				}
 			}
 			switch a.Action {
-			case `property_new`, `property_delete`,
+			case `property_new`, `property_update`, `property_delete`,
 				`create`, `update`, `delete`,
 				`node_assignment`,
 				`member_new`, `member_removed`:
@@ -526,7 +842,7 @@ actionloop:
 		}
 
 		switch a.Action {
-		case `property_new`, `property_delete`:
+		case `property_new`, `property_update`, `property_delete`:
 			if err = tk.txProperty(a, stm); err != nil {
 				break actionloop
 			}
@@ -542,7 +858,7 @@ actionloop:
 			}
 		case `create`, `update`, `delete`, `node_assignment`,
 			`member_new`, `member_removed`:
-			if err = tk.txTree(a, stm, q.User); err != nil {
+			if err = tk.txTree(a, stm, user); err != nil {
 				break actionloop
 			}
 		default:
@@ -559,64 +875,7 @@ actionloop:
 			continue actionloop
 		}
 	}
-	if err != nil {
-		goto bailout
-	}
-
-	if !tk.rebuild {
-		// mark job as finished
-		if _, err = tx.Exec(
-			stmt.TxFinishJob,
-			q.JobId.String(),
-			time.Now().UTC(),
-			"success",
-			``, // empty error field
-		); err != nil {
-			goto bailout
-		}
-	}
-
-	// commit transaction
-	if err = tx.Commit(); err != nil {
-		goto bailout
-	}
-	tk.appLog.Printf("SUCCESS - Finished job: %s\n", q.JobId.String())
-
-	// accept tree changes
-	tk.tree.Commit()
-	return
-
-bailout:
-	tk.appLog.Printf("FAILED - Finished job: %s\n", q.JobId.String())
-	tk.log.Printf("Job-Error(%s): %s\n", q.JobId.String(), err)
-	if hasJobLog {
-		jobLog.Printf("Aborting error: %s\n", err)
-	}
-
-	// if this was a rebuild, the tree will not persist and the
-	// job is faked. Also if the job never actually started, then it
-	// should never be rolled back nor attempted to mark failed.
-	if tk.rebuild || jobNeverStarted {
-		return
-	}
-
-	tk.tree.Rollback()
-	tx.Rollback()
-	tk.conn.Exec(
-		stmt.TxFinishJob,
-		q.JobId.String(),
-		time.Now().UTC(),
-		"failed",
-		err.Error(),
-	)
-	for i := len(tk.actionChan); i > 0; i-- {
-		a := <-tk.actionChan
-		jB, _ := json.Marshal(a)
-		if hasJobLog {
-			jobLog.Printf("Cleaned message: %s\n", string(jB))
-		}
-	}
-	return
+	return err
 }
 
 /* Ops Access
@@ -625,6 +884,14 @@ func (tk *treeKeeper) shutdownNow() {
 	tk.shutdown <- true
 }
 
+func (tk *treeKeeper) queueLen() int {
+	return len(tk.input)
+}
+
+func (tk *treeKeeper) queueCap() int {
+	return cap(tk.input)
+}
+
 func (tk *treeKeeper) stopNow() {
 	tk.stopchan <- true
 }
@@ -22,7 +22,7 @@ func (tk *treeKeeper) startupLoad() {
 	tk.startupClusters(stMap)
 	tk.startupNodes(stMap)
 
-	if len(tk.actionChan) > 0 {
+	if !tk.importing && len(tk.actionChan) > 0 {
 		tk.startLog.Printf("TK[%s] ERROR! Stray startup actions pending in action queue!", tk.repoName)
 		tk.broken = true
 		return
@@ -31,7 +31,7 @@ func (tk *treeKeeper) startupLoad() {
 	// attach system properties
 	tk.startupSystemProperties(stMap)
 
-	if len(tk.actionChan) > 0 {
+	if !tk.importing && len(tk.actionChan) > 0 {
 		tk.startLog.Printf("TK[%s] ERROR! Stray startup actions pending in action queue!", tk.repoName)
 		tk.broken = true
 		return
@@ -40,7 +40,7 @@ func (tk *treeKeeper) startupLoad() {
 	// attach service properties
 	tk.startupServiceProperties(stMap)
 
-	if len(tk.actionChan) > 0 {
+	if !tk.importing && len(tk.actionChan) > 0 {
 		tk.startLog.Printf("TK[%s] ERROR! Stray startup actions pending in action queue!", tk.repoName)
 		tk.broken = true
 		return
@@ -49,7 +49,7 @@ func (tk *treeKeeper) startupLoad() {
 	// attach custom properties
 	tk.startupCustomProperties(stMap)
 
-	if len(tk.actionChan) > 0 {
+	if !tk.importing && len(tk.actionChan) > 0 {
 		tk.startLog.Printf("TK[%s] ERROR! Stray startup actions pending in action queue!", tk.repoName)
 		tk.broken = true
 		return
@@ -58,7 +58,7 @@ func (tk *treeKeeper) startupLoad() {
 	// attach oncall properties
 	tk.startupOncallProperties(stMap)
 
-	if len(tk.actionChan) > 0 {
+	if !tk.importing && len(tk.actionChan) > 0 {
 		tk.startLog.Printf("TK[%s] ERROR! Stray startup actions pending in action queue!", tk.repoName)
 		tk.broken = true
 		return
@@ -67,12 +67,17 @@ func (tk *treeKeeper) startupLoad() {
 	// attach checks
 	tk.startupChecks(stMap)
 
-	if !tk.rebuild && len(tk.actionChan) > 0 {
+	if !tk.rebuild && !tk.importing && len(tk.actionChan) > 0 {
 		tk.startLog.Printf("TK[%s] ERROR! Stray startup actions pending in action queue!", tk.repoName)
 		tk.broken = true
 		return
 	}
 
+	// exports and imports only load the tree from their snapshot
+	if tk.snapshot != nil {
+		return
+	}
+
 	// these run as part of a job, but not inside the job's transaction. If there are leftovers
 	// after a crash, fix them up
 	if !SomaCfg.Observer {
@@ -86,7 +91,7 @@ func (tk *treeKeeper) startupLoad() {
 		tk.startupJobs(stMap)
 	}
 
-	if !tk.rebuild && len(tk.actionChan) > 0 {
+	if !tk.rebuild && !tk.importing && len(tk.actionChan) > 0 {
 		tk.startLog.Printf("TK[%s] ERROR! Stray startup actions pending in action queue!", tk.repoName)
 		tk.broken = true
 		return
@@ -360,6 +365,7 @@ func (tk *treeKeeper) startupNodes(stMap map[string]*sql.Stmt) {
 		err                                          error
 		rows                                         *sql.Rows
 		nodeId, nodeName, teamId, serverId, bucketId string
+		serverName                                   string
 		assetId                                      int
 		nodeOnline, nodeDeleted                      bool
 		clusterId, groupId                           sql.NullString
@@ -387,6 +393,7 @@ nodeloop:
 			&bucketId,
 			&clusterId,
 			&groupId,
+			&serverName,
 		)
 		if err != nil {
 			if err == sql.ErrNoRows {
@@ -398,13 +405,14 @@ nodeloop:
 		}
 
 		node := tree.NewNode(tree.NodeSpec{
-			Id:       nodeId,
-			AssetId:  uint64(assetId),
-			Name:     nodeName,
-			Team:     teamId,
-			ServerId: serverId,
-			Online:   nodeOnline,
-			Deleted:  nodeDeleted,
+			Id:         nodeId,
+			AssetId:    uint64(assetId),
+			Name:       nodeName,
+			Team:       teamId,
+			ServerId:   serverId,
+			ServerName: serverName,
+			Online:     nodeOnline,
+			Deleted:    nodeDeleted,
 		})
 		if clusterId.Valid {
 			node.Attach(tree.AttachRequest{
@@ -535,7 +543,7 @@ func (tk *treeKeeper) prepareStartupStatements() map[string]*sql.Stmt {
 		`LoadPropNodeSvcAttr`:    stmt.TkStartLoadNodeSvcAttr,
 		`LoadPropSvcInstance`:    stmt.TkStartLoadServicePropInstances,
 	} {
-		if stMap[name], err = tk.conn.Prepare(statement); err != nil {
+		if stMap[name], err = tk.startupConn().Prepare(statement); err != nil {
 			tk.startLog.Println(`treekeeper startup`, err,
 				stmt.Name(statement))
 			tk.broken = true
@@ -545,4 +553,12 @@ func (tk *treeKeeper) prepareStartupStatements() map[string]*sql.Stmt {
 	return stMap
 }
 
+// startupConn returns the connection the startup loaders read from
+func (tk *treeKeeper) startupConn() *sql.DB {
+	if tk.snapshot != nil {
+		return tk.snapshot.db
+	}
+	return tk.conn
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -49,7 +49,7 @@ func (tk *treeKeeper) startupChecks(stMap map[string]*sql.Stmt) {
 		// instances diverge!
 		// If requested, print all encountered messages instead of
 		// simply bailing out.
-		if SomaCfg.PrintChannels {
+		if SomaCfg.PrintChannels && !tk.importing {
 			if len(tk.actionChan) > 0 {
 				tk.broken = true
 				for i := len(tk.actionChan); i > 0; i-- {
@@ -98,7 +98,7 @@ func (tk *treeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 		checkId, srcCheckId, srcObjType, srcObjId, configId          string
 		capabilityId, objId, objType, cfgName, cfgObjId, cfgObjType  string
 		externalId, predicate, threshold, levelName, levelShort      string
-		cstrType, value1, value2, value3, itemId, itemCfgId          string
+		cstrType, value1, value2, value3, value4, itemId, itemCfgId  string
 		monitoringId, cstrHash, cstrValHash, instSvc, instSvcCfgHash string
 		instSvcCfg, errLocation                                      string
 		levelNumeric, numVal, interval, version                      int64
@@ -271,7 +271,7 @@ func (tk *treeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 			// iterate over returned constraints - no rows is valid, as
 			// constraints are not mandatory
 			for cstrRows.Next() {
-				if err = cstrRows.Scan(&value1, &value2, &value3); err != nil {
+				if err = cstrRows.Scan(&value1, &value2, &value3, &value4); err != nil {
 					cstrRows.Close()
 					goto fail
 				}
@@ -280,6 +280,7 @@ func (tk *treeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 					victim.Constraints = append(victim.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							Custom: &proto.PropertyCustom{
 								Id:           value1,
 								Name:         value2,
@@ -292,6 +293,7 @@ func (tk *treeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 					victim.Constraints = append(victim.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							Native: &proto.PropertyNative{
 								Name:  value1,
 								Value: value2,
@@ -302,6 +304,7 @@ func (tk *treeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 					victim.Constraints = append(victim.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							Oncall: &proto.PropertyOncall{
 								Id:     value1,
 								Name:   value2,
@@ -313,6 +316,7 @@ func (tk *treeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 					victim.Constraints = append(victim.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							Attribute: &proto.ServiceAttribute{
 								Name:  value1,
 								Value: value2,
@@ -323,6 +327,7 @@ func (tk *treeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 					victim.Constraints = append(victim.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							Service: &proto.PropertyService{
 								Name:   value2,
 								TeamId: value1,
@@ -333,6 +338,7 @@ func (tk *treeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 					victim.Constraints = append(victim.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							System: &proto.PropertySystem{
 								Name:  value1,
 								Value: value2,
@@ -356,6 +362,10 @@ func (tk *treeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 	// required to populate groups in the correct order.
 	for checkId, _ = range cfgMap {
 		victim = cfgMap[checkId]
+		// imports write the configuration before the checks
+		if tk.importing {
+			tk.importConfigs[victim.Id] = victim
+		}
 		if ckOrder[victim.ObjectId] == nil {
 			ckOrder[victim.ObjectId] = map[string]tree.Check{}
 		}
@@ -702,7 +712,7 @@ func (tk *treeKeeper) startupScopedReapplyCheckConfig(typ string, stMap map[stri
 		err                                         error
 		configRows, threshRows, cstrRows            *sql.Rows
 		predicate, threshold, levelName, levelShort string
-		cstrType, value1, value2, value3            string
+		cstrType, value1, value2, value3, value4    string
 		levelNumeric, numVal                        int64
 		treeCheck                                   *tree.Check
 		nullBucketID                                sql.NullString
@@ -801,7 +811,7 @@ func (tk *treeKeeper) startupScopedReapplyCheckConfig(typ string, stMap map[stri
 			}
 
 			for cstrRows.Next() {
-				if err = cstrRows.Scan(&value1, &value2, &value3); err != nil {
+				if err = cstrRows.Scan(&value1, &value2, &value3, &value4); err != nil {
 					cstrRows.Close()
 					goto fail
 				}
@@ -810,6 +820,7 @@ func (tk *treeKeeper) startupScopedReapplyCheckConfig(typ string, stMap map[stri
 					conf.Constraints = append(conf.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							Custom: &proto.PropertyCustom{
 								Id:           value1,
 								Name:         value2,
@@ -822,6 +833,7 @@ func (tk *treeKeeper) startupScopedReapplyCheckConfig(typ string, stMap map[stri
 					conf.Constraints = append(conf.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							Native: &proto.PropertyNative{
 								Name:  value1,
 								Value: value2,
@@ -832,6 +844,7 @@ func (tk *treeKeeper) startupScopedReapplyCheckConfig(typ string, stMap map[stri
 					conf.Constraints = append(conf.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							Oncall: &proto.PropertyOncall{
 								Id:     value1,
 								Name:   value2,
@@ -843,6 +856,7 @@ func (tk *treeKeeper) startupScopedReapplyCheckConfig(typ string, stMap map[stri
 					conf.Constraints = append(conf.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							Attribute: &proto.ServiceAttribute{
 								Name:  value1,
 								Value: value2,
@@ -853,6 +867,7 @@ func (tk *treeKeeper) startupScopedReapplyCheckConfig(typ string, stMap map[stri
 					conf.Constraints = append(conf.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							Service: &proto.PropertyService{
 								Name:   value2,
 								TeamId: value1,
@@ -863,6 +878,7 @@ func (tk *treeKeeper) startupScopedReapplyCheckConfig(typ string, stMap map[stri
 					conf.Constraints = append(conf.Constraints,
 						proto.CheckConfigConstraint{
 							ConstraintType: cstrType,
+							Operator:       value4,
 							System: &proto.PropertySystem{
 								Name:  value1,
 								Value: value2,
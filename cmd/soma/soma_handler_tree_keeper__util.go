@@ -1,6 +1,11 @@
 package main
 
 func (tk *treeKeeper) drain(s string) (j int) {
+	// imports keep all startup actions, they are written to the
+	// database afterwards
+	if tk.importing {
+		return 0
+	}
 	switch s {
 	case "action":
 		j = len(tk.actionChan)
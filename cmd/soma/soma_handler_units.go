@@ -217,8 +217,24 @@ func (r *somaUnitReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaUnitReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaUnitReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaUnitWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaUnitWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaUnitWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
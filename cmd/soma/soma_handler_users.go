@@ -245,7 +245,7 @@ func (w *somaUserWriteHandler) process(q *somaUserRequest) {
 		notify msg.Request
 	)
 	result := somaResult{}
-	super = handlerMap[`supervisor`].(*supervisor)
+	super = handlerMap.Get(`supervisor`).(*supervisor)
 	notify = msg.Request{Type: `supervisor`, Action: `update_map`,
 		Super: &msg.Supervisor{
 			Object: `user`,
@@ -329,8 +329,24 @@ func (r *somaUserReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaUserReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaUserReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaUserWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaUserWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaUserWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
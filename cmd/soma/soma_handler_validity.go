@@ -267,8 +267,24 @@ func (r *somaValidityReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaValidityReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaValidityReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaValidityWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaValidityWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaValidityWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -224,8 +224,24 @@ func (r *somaViewReadHandler) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *somaViewReadHandler) queueLen() int {
+	return len(r.input)
+}
+
+func (r *somaViewReadHandler) queueCap() int {
+	return cap(r.input)
+}
+
 func (w *somaViewWriteHandler) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *somaViewWriteHandler) queueLen() int {
+	return len(w.input)
+}
+
+func (w *somaViewWriteHandler) queueCap() int {
+	return cap(w.input)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -216,6 +216,14 @@ func (r *workflowRead) shutdownNow() {
 	r.shutdown <- true
 }
 
+func (r *workflowRead) queueLen() int {
+	return len(r.input)
+}
+
+func (r *workflowRead) queueCap() int {
+	return cap(r.input)
+}
+
 type workflowWrite struct {
 	input       chan msg.Request
 	shutdown    chan bool
@@ -257,6 +265,14 @@ func (w *workflowWrite) shutdownNow() {
 	w.shutdown <- true
 }
 
+func (w *workflowWrite) queueLen() int {
+	return len(w.input)
+}
+
+func (w *workflowWrite) queueCap() int {
+	return cap(w.input)
+}
+
 func (w *workflowWrite) process(q *msg.Request) {
 	result := msg.Result{Type: q.Type, Action: q.Action,
 		Workflow: []proto.Workflow{}}
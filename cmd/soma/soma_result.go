@@ -1,5 +1,7 @@
 package main
 
+import "github.com/1and1/soma/lib/proto"
+
 type ErrorMarker interface {
 	ErrorMark(err error, imp bool, found bool, length int, jobid, jobtype string) bool
 }
@@ -16,6 +18,7 @@ type somaResult struct {
 	Accepted        bool
 	JobId           string
 	JobType         string
+	Actions         []proto.Action
 	Attributes      []somaAttributeResult
 	Buckets         []somaBucketResult
 	Capabilities    []somaCapabilityResult
@@ -85,4 +88,12 @@ func (r *somaResult) MarkErrors(reply ErrorMarker) bool {
 		ResultLength(r, reply), r.JobId, r.JobType)
 }
 
+// MarkActions copies the actions a dryrun request would cause
+// into the reply
+func (r *somaResult) MarkActions(reply *proto.Result) {
+	if r.Actions != nil {
+		reply.Actions = &r.Actions
+	}
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -38,6 +38,18 @@ func (tk *treeKeeper) rmCheck(config *proto.CheckConfig) error {
 	}
 }
 
+func (tk *treeKeeper) updateCheck(config *proto.CheckConfig) error {
+	if chk, err := tk.convertCheckForUpdate(config); err == nil {
+		tk.tree.Find(tree.FindRequest{
+			ElementType: config.ObjectType,
+			ElementId:   config.ObjectId,
+		}, true).UpdateCheck(*chk)
+		return nil
+	} else {
+		return err
+	}
+}
+
 func (tk *treeKeeper) convertCheck(conf *proto.CheckConfig) (*tree.Check, error) {
 	treechk := &tree.Check{
 		Id:            uuid.Nil,
@@ -66,7 +78,8 @@ func (tk *treeKeeper) convertCheck(conf *proto.CheckConfig) (*tree.Check, error)
 	treechk.Constraints = make([]tree.CheckConstraint, len(conf.Constraints))
 	for i, constr := range conf.Constraints {
 		ncon := tree.CheckConstraint{
-			Type: constr.ConstraintType,
+			Type:     constr.ConstraintType,
+			Operator: constr.Operator,
 		}
 		switch constr.ConstraintType {
 		case "native":
@@ -108,4 +121,16 @@ func (tk *treeKeeper) convertCheckForDelete(conf *proto.CheckConfig) (*tree.Chec
 	return treechk, nil
 }
 
+func (tk *treeKeeper) convertCheckForUpdate(conf *proto.CheckConfig) (*tree.Check, error) {
+	var err error
+	var treechk *tree.Check
+	if treechk, err = tk.convertCheck(conf); err != nil {
+		return nil, err
+	}
+	if treechk.SourceId, err = uuid.FromString(conf.ExternalId); err != nil {
+		return nil, err
+	}
+	return treechk, nil
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,92 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/1and1/soma/lib/proto"
+)
+
+// dryRun applies the change requested by q to a snapshot of the
+// tree and replies with the resulting actions. The snapshot is
+// always rolled back, nothing is written to the database.
+func (tk *treeKeeper) dryRun(q *treeRequest) {
+	var err error
+	result := somaResult{}
+	plan := []proto.Action{}
+
+	tk.appLog.Printf("Processing dryrun request: %s\n", q.Action)
+	tk.tree.Begin()
+
+	if err = tk.treeAction(q); err == nil {
+		tk.tree.ComputeCheckInstances()
+	}
+
+	for i := len(tk.errChan); i > 0; i-- {
+		e := <-tk.errChan
+		if err == nil {
+			err = fmt.Errorf("%s", e.Action)
+		}
+	}
+	for i := len(tk.actionChan); i > 0; i-- {
+		a := <-tk.actionChan
+		plan = append(plan, proto.Action(*a))
+	}
+
+	tk.tree.Rollback()
+
+	if result.SetRequestError(err) {
+		q.reply <- result
+		return
+	}
+	result.Actions = plan
+	switch q.RequestType {
+	case `repository`:
+		result.Append(nil, &somaRepositoryResult{
+			Repository: q.Repository.Repository,
+		})
+	case `bucket`:
+		result.Append(nil, &somaBucketResult{
+			Bucket: q.Bucket.Bucket,
+		})
+	case `group`:
+		result.Append(nil, &somaGroupResult{
+			Group: q.Group.Group,
+		})
+	case `cluster`:
+		result.Append(nil, &somaClusterResult{
+			Cluster: q.Cluster.Cluster,
+		})
+	case `node`:
+		result.Append(nil, &somaNodeResult{
+			Node: q.Node.Node,
+		})
+	case `check`:
+		result.Append(nil, &somaCheckConfigResult{
+			CheckConfig: q.CheckConfig.CheckConfig,
+		})
+	}
+	q.reply <- result
+}
+
+// refuse discards a request a stopped or broken treekeeper will not
+// process. Dryrun requests are waited on and answered with an error,
+// jobs remain queued in the database.
+func (tk *treeKeeper) refuse(q *treeRequest) {
+	if !q.DryRun {
+		return
+	}
+	result := somaResult{}
+	result.SetRequestError(fmt.Errorf(
+		"Repository %s is currently unavailable", tk.repoName))
+	q.reply <- result
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
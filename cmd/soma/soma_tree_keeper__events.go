@@ -0,0 +1,52 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main
+
+import (
+	"github.com/1and1/soma/lib/proto"
+)
+
+// publishJob sends the state change of the job q to the event
+// stream
+func (tk *treeKeeper) publishJob(q *treeRequest, status, result,
+	jobErr string) {
+	publishEvent(proto.Event{
+		Type:         `job`,
+		RepositoryId: tk.repoId,
+		TeamId:       tk.team,
+		JobId:        q.JobId.String(),
+		User:         q.User,
+		Job: &proto.Job{
+			Id:           q.JobId.String(),
+			Status:       status,
+			Result:       result,
+			Type:         q.Action,
+			RepositoryId: tk.repoId,
+			Error:        jobErr,
+		},
+	})
+}
+
+// publishActions sends the tree actions committed by the job q to
+// the event stream
+func (tk *treeKeeper) publishActions(q *treeRequest) {
+	for i := range tk.events {
+		publishEvent(proto.Event{
+			Type:         `action`,
+			RepositoryId: tk.repoId,
+			TeamId:       tk.team,
+			JobId:        q.JobId.String(),
+			User:         q.User,
+			Action:       &tk.events[i],
+		})
+	}
+	tk.events = nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
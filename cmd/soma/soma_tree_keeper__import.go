@@ -0,0 +1,253 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/1and1/soma/internal/stmt"
+	"github.com/1and1/soma/lib/proto"
+	uuid "github.com/satori/go.uuid"
+)
+
+// importIdColumns are the result columns of the startup statements
+// that contain IDs local to the exported repository
+var importIdColumns = map[string]bool{
+	`bucket_id`:          true,
+	`check_id`:           true,
+	`child_group_id`:     true,
+	`cluster_id`:         true,
+	`configuration_id`:   true,
+	`custom_property_id`: true,
+	`group_id`:           true,
+	`instance_id`:        true,
+	`repository_id`:      true,
+	`source_check_id`:    true,
+	`source_instance_id`: true,
+}
+
+// prepareImport rewrites the queries of export exp for an import
+// into repository repo. All IDs local to the exported repository
+// are replaced, custom properties are mapped by name onto the
+// custom properties in custom that already exist in repo. Bucket
+// names starting with the exported repository name are renamed.
+// The recorded check instances are removed from the queries, since
+// imported check instances are computed again; their IDs are
+// returned by instanceKey.
+func prepareImport(exp *proto.RepositoryExport, repo proto.Repository,
+	custom map[string]string) ([]proto.RepositoryExportQuery,
+	[]proto.PropertyCustom, map[string]string) {
+
+	ids := map[string]string{exp.RepositoryId: repo.Id}
+	for _, cp := range exp.CustomProperties {
+		if id, ok := custom[cp.Name]; ok {
+			ids[cp.Id] = id
+		}
+	}
+	for _, q := range exp.Queries {
+		for i, col := range q.Columns {
+			if !importIdColumns[col] {
+				continue
+			}
+			for _, row := range q.Rows {
+				if row[i] == nil {
+					continue
+				}
+				if _, ok := ids[*row[i]]; !ok {
+					ids[*row[i]] = uuid.NewV4().String()
+				}
+			}
+		}
+	}
+	mapId := func(s string) string {
+		if id, ok := ids[s]; ok {
+			return id
+		}
+		return s
+	}
+
+	// custom properties that do not exist in repo yet
+	props := []proto.PropertyCustom{}
+	for _, cp := range exp.CustomProperties {
+		if _, ok := custom[cp.Name]; ok {
+			continue
+		}
+		if _, ok := ids[cp.Id]; !ok {
+			ids[cp.Id] = uuid.NewV4().String()
+		}
+		props = append(props, proto.PropertyCustom{
+			Id:           ids[cp.Id],
+			Name:         cp.Name,
+			RepositoryId: repo.Id,
+			Schema:       cp.Schema,
+		})
+	}
+
+	// map check instance ID -> exported check ID
+	instanceChecks := map[string]string{}
+	for _, q := range exp.Queries {
+		if q.Statement != stmt.Name(stmt.TkStartLoadCheckInstances) ||
+			len(q.Arguments) != 1 {
+			continue
+		}
+		for _, row := range q.Rows {
+			if len(row) > 0 && row[0] != nil {
+				instanceChecks[*row[0]] = q.Arguments[0]
+			}
+		}
+	}
+
+	instances := map[string]string{}
+	queries := []proto.RepositoryExportQuery{}
+	for _, q := range exp.Queries {
+		switch q.Statement {
+		case stmt.Name(stmt.TkStartLoadCheckInstances):
+			continue
+		case stmt.Name(stmt.TkStartLoadCheckInstanceConfiguration):
+			if len(q.Arguments) != 1 || len(q.Rows) == 0 {
+				continue
+			}
+			checkId, ok := instanceChecks[q.Arguments[0]]
+			if !ok {
+				continue
+			}
+			var cstrHash, svcCfgHash string
+			for i, col := range q.Columns {
+				if q.Rows[0][i] == nil {
+					continue
+				}
+				switch col {
+				case `constraint_hash`:
+					cstrHash = *q.Rows[0][i]
+				case `instance_service_cfg_hash`:
+					svcCfgHash = *q.Rows[0][i]
+				}
+			}
+			instances[instanceKey(mapId(checkId), cstrHash,
+				svcCfgHash)] = q.Arguments[0]
+			continue
+		}
+
+		mq := proto.RepositoryExportQuery{
+			Statement: q.Statement,
+			Arguments: make([]string, len(q.Arguments)),
+			Columns:   q.Columns,
+			Rows:      make([][]*string, len(q.Rows)),
+		}
+		for i := range q.Arguments {
+			mq.Arguments[i] = mapId(q.Arguments[i])
+		}
+		for r, row := range q.Rows {
+			mq.Rows[r] = make([]*string, len(row))
+			for i := range row {
+				if row[i] == nil {
+					continue
+				}
+				v := mapId(*row[i])
+				if q.Statement == stmt.Name(stmt.TkStartLoadBuckets) &&
+					q.Columns[i] == `bucket_name` &&
+					exp.RepositoryName != repo.Name &&
+					strings.HasPrefix(v, exp.RepositoryName) {
+					v = repo.Name + strings.TrimPrefix(v,
+						exp.RepositoryName)
+				}
+				mq.Rows[r][i] = &v
+			}
+		}
+		queries = append(queries, mq)
+	}
+	return queries, props, instances
+}
+
+// instanceKey identifies a check instance by check and constraints
+func instanceKey(checkId, cstrHash, svcCfgHash string) string {
+	return strings.Join([]string{checkId, cstrHash, svcCfgHash}, "\x00")
+}
+
+// importSnapshot loads the repository from the replayed snapshot and
+// writes the loaded tree into the database as a single transaction
+func (tk *treeKeeper) importSnapshot(user string) error {
+	var (
+		err    error
+		tx     *sql.Tx
+		stm    map[string]*sql.Stmt
+		schema interface{}
+	)
+	q := &treeRequest{
+		RequestType: `import`,
+		Action:      `import`,
+		User:        user,
+		JobId:       uuid.NewV4(),
+	}
+	jobLog, lfh := tk.openJobLog(q)
+	defer lfh.Close()
+
+	tk.tree.SwitchLogger(tk.startLog)
+	tk.startupLoad()
+	tk.tree.SwitchLogger(tk.log)
+	if tk.broken {
+		return fmt.Errorf(`Repository export could not be loaded`)
+	}
+	tk.keepInstanceIds()
+
+	if tx, stm, err = tk.startTx(); err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(stmt.TxDeferAllConstraints); err != nil {
+		return err
+	}
+	for _, cp := range tk.importCustom {
+		if schema, err = marshalPropertySchema(cp.Schema); err != nil {
+			return err
+		}
+		if _, err = tx.Exec(stmt.PropertyCustomAdd, cp.Id,
+			cp.RepositoryId, cp.Name, schema); err != nil {
+			return err
+		}
+	}
+	for _, conf := range tk.importConfigs {
+		if err = tk.txCheckConfig(conf, stm); err != nil {
+			return err
+		}
+	}
+	if err = tk.txActions(stm, user, jobLog); err != nil {
+		return err
+	}
+	// the tree is loaded again after the import, nothing is
+	// published
+	tk.events = nil
+	return tx.Commit()
+}
+
+// keepInstanceIds sets the exported check instance IDs on the
+// computed check instances that match an exported instance
+func (tk *treeKeeper) keepInstanceIds() {
+	if len(tk.importInstances) == 0 {
+		return
+	}
+	for i := len(tk.actionChan); i > 0; i-- {
+		a := <-tk.actionChan
+		if a.Action == `check_instance_create` {
+			key := instanceKey(a.CheckInstance.CheckId,
+				a.CheckInstance.ConstraintHash,
+				a.CheckInstance.InstanceSvcCfgHash)
+			if id, ok := tk.importInstances[key]; ok {
+				a.CheckInstance.InstanceId = id
+				delete(tk.importInstances, key)
+			}
+		}
+		tk.actionChan <- a
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,146 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/1and1/soma/internal/stmt"
+	"github.com/1and1/soma/lib/proto"
+)
+
+func strRow(values ...string) []*string {
+	row := make([]*string, len(values))
+	for i := range values {
+		if values[i] == `NULL` {
+			continue
+		}
+		v := values[i]
+		row[i] = &v
+	}
+	return row
+}
+
+func TestPrepareImport(t *testing.T) {
+	exp := &proto.RepositoryExport{
+		RepositoryId:   `repo-exported`,
+		RepositoryName: `example`,
+		CustomProperties: []proto.PropertyCustom{
+			{Id: `custom-serial`, Name: `serial`},
+			{Id: `custom-asset`, Name: `asset`},
+		},
+		Queries: []proto.RepositoryExportQuery{
+			{
+				Statement: stmt.Name(stmt.TkStartLoadBuckets),
+				Arguments: []string{`repo-exported`},
+				Columns: []string{`bucket_id`, `bucket_name`,
+					`environment`},
+				Rows: [][]*string{
+					strRow(`bucket-1`, `example_live`, `live`),
+					strRow(`bucket-2`, `other_live`, `live`),
+				},
+			},
+			{
+				Statement: stmt.Name(stmt.TkStartLoadCustomPropInstances),
+				Arguments: []string{`repo-exported`},
+				Columns: []string{`bucket_id`, `custom_property_id`,
+					`value`},
+				Rows: [][]*string{
+					strRow(`bucket-1`, `custom-serial`, `4711`),
+					strRow(`bucket-2`, `custom-asset`, `NULL`),
+				},
+			},
+			{
+				Statement: stmt.Name(stmt.TkStartLoadChecks),
+				Arguments: []string{`repo-exported`},
+				Columns:   []string{`check_id`, `bucket_id`},
+				Rows: [][]*string{
+					strRow(`check-1`, `bucket-1`),
+				},
+			},
+			{
+				Statement: stmt.Name(stmt.TkStartLoadCheckInstances),
+				Arguments: []string{`check-1`},
+				Columns:   []string{`check_instance_id`},
+				Rows:      [][]*string{strRow(`instance-1`)},
+			},
+			{
+				Statement: stmt.Name(
+					stmt.TkStartLoadCheckInstanceConfiguration),
+				Arguments: []string{`instance-1`},
+				Columns: []string{`constraint_hash`,
+					`instance_service_cfg_hash`},
+				Rows: [][]*string{strRow(`cstr`, `svc`)},
+			},
+		},
+	}
+	repo := proto.Repository{Id: `repo-imported`, Name: `imported`}
+	custom := map[string]string{`serial`: `custom-existing`}
+
+	queries, props, instances := prepareImport(exp, repo, custom)
+
+	if len(queries) != 3 {
+		t.Fatalf("Expected 3 queries, got %d", len(queries))
+	}
+	for _, q := range queries {
+		if q.Statement == stmt.Name(stmt.TkStartLoadCheckInstances) ||
+			q.Statement == stmt.Name(
+				stmt.TkStartLoadCheckInstanceConfiguration) {
+			t.Errorf("Check instance query %s was not removed",
+				q.Statement)
+		}
+		if q.Arguments[0] != repo.Id {
+			t.Errorf("%s: repository argument not mapped: %s",
+				q.Statement, q.Arguments[0])
+		}
+	}
+
+	buckets, customs, checks := queries[0], queries[1], queries[2]
+	bucketId := *buckets.Rows[0][0]
+	if bucketId == `bucket-1` || bucketId == `` {
+		t.Errorf("Bucket id not replaced: %s", bucketId)
+	}
+	if *customs.Rows[0][0] != bucketId || *checks.Rows[0][1] != bucketId {
+		t.Errorf("Bucket id not mapped consistently")
+	}
+	if v := *buckets.Rows[0][1]; v != `imported_live` {
+		t.Errorf("Bucket not renamed: %s", v)
+	}
+	if v := *buckets.Rows[1][1]; v != `other_live` {
+		t.Errorf("Bucket without repository prefix renamed: %s", v)
+	}
+	if v := *buckets.Rows[0][2]; v != `live` {
+		t.Errorf("Non-id column modified: %s", v)
+	}
+
+	// existing custom properties are reused by name, missing ones
+	// are returned for creation
+	if v := *customs.Rows[0][1]; v != `custom-existing` {
+		t.Errorf("Existing custom property not mapped: %s", v)
+	}
+	if customs.Rows[1][2] != nil {
+		t.Errorf("NULL value not preserved")
+	}
+	if len(props) != 1 || props[0].Name != `asset` ||
+		props[0].RepositoryId != repo.Id {
+		t.Fatalf("Unexpected custom properties: %+v", props)
+	}
+	if *customs.Rows[1][1] != props[0].Id ||
+		props[0].Id == `custom-asset` {
+		t.Errorf("New custom property id not mapped: %s", props[0].Id)
+	}
+
+	// the exported check instance is keyed by the imported check id
+	checkId := *checks.Rows[0][0]
+	if id, ok := instances[instanceKey(checkId, `cstr`,
+		`svc`)]; !ok || id != `instance-1` {
+		t.Errorf("Unexpected check instances: %v", instances)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -30,6 +30,14 @@ func (tk *treeKeeper) rmProperty(q *treeRequest) {
 	}, true).(tree.Propertier).DeleteProperty(prop)
 }
 
+func (tk *treeKeeper) updateProperty(q *treeRequest) {
+	prop, id := tk.convProperty(`update`, q)
+	tk.tree.Find(tree.FindRequest{
+		ElementType: q.RequestType,
+		ElementId:   id,
+	}, true).(tree.Propertier).UpdateProperty(prop)
+}
+
 func (tk *treeKeeper) convProperty(task string, q *treeRequest) (
 	tree.Property, string) {
 
@@ -80,6 +88,17 @@ func (tk *treeKeeper) pTT(task string, pp proto.Property) tree.Property {
 				Key:      pp.Custom.Name,
 				Value:    pp.Custom.Value,
 			}
+		case `update`:
+			srcUUID, _ := uuid.FromString(pp.SourceInstanceId)
+			return &tree.PropertyCustom{
+				SourceId:     srcUUID,
+				CustomId:     customId,
+				Inheritance:  pp.Inheritance,
+				ChildrenOnly: pp.ChildrenOnly,
+				View:         pp.View,
+				Key:          pp.Custom.Name,
+				Value:        pp.Custom.Value,
+			}
 		}
 	case `oncall`:
 		oncallId, _ := uuid.FromString(pp.Oncall.Id)
@@ -103,6 +122,17 @@ func (tk *treeKeeper) pTT(task string, pp proto.Property) tree.Property {
 				Name:     pp.Oncall.Name,
 				Number:   pp.Oncall.Number,
 			}
+		case `update`:
+			srcUUID, _ := uuid.FromString(pp.SourceInstanceId)
+			return &tree.PropertyOncall{
+				SourceId:     srcUUID,
+				OncallId:     oncallId,
+				Inheritance:  pp.Inheritance,
+				ChildrenOnly: pp.ChildrenOnly,
+				View:         pp.View,
+				Name:         pp.Oncall.Name,
+				Number:       pp.Oncall.Number,
+			}
 		}
 	case `service`:
 		switch task {
@@ -122,6 +152,16 @@ func (tk *treeKeeper) pTT(task string, pp proto.Property) tree.Property {
 				View:     pp.View,
 				Service:  pp.Service.Name,
 			}
+		case `update`:
+			srcUUID, _ := uuid.FromString(pp.SourceInstanceId)
+			return &tree.PropertyService{
+				SourceId:     srcUUID,
+				Inheritance:  pp.Inheritance,
+				ChildrenOnly: pp.ChildrenOnly,
+				View:         pp.View,
+				Service:      pp.Service.Name,
+				Attributes:   pp.Service.Attributes,
+			}
 		}
 	case `system`:
 		switch task {
@@ -142,6 +182,16 @@ func (tk *treeKeeper) pTT(task string, pp proto.Property) tree.Property {
 				Key:      pp.System.Name,
 				Value:    pp.System.Value,
 			}
+		case `update`:
+			srcUUID, _ := uuid.FromString(pp.SourceInstanceId)
+			return &tree.PropertySystem{
+				SourceId:     srcUUID,
+				Inheritance:  pp.Inheritance,
+				ChildrenOnly: pp.ChildrenOnly,
+				View:         pp.View,
+				Key:          pp.System.Name,
+				Value:        pp.System.Value,
+			}
 		}
 	}
 	return nil
@@ -0,0 +1,424 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/1and1/soma/internal/stmt"
+	"github.com/1and1/soma/internal/tree"
+	"github.com/satori/go.uuid"
+)
+
+// relocationTimeout is how long the source treekeeper waits for the
+// target treekeeper to accept and stage a relocated node, and how
+// long the target waits for the source to start its commit
+const relocationTimeout = 60 * time.Second
+
+// treeRelocation coordinates the move of a node between the
+// treekeepers of two repositories. The source treekeeper unassigns
+// the node and hands its open transaction to the target treekeeper,
+// which assigns the node inside the same transaction. The target
+// only accepts its tree changes once the source has committed.
+type treeRelocation struct {
+	// name of the target treekeeper, empty if the node stays in the
+	// same repository
+	keeper string
+	// local properties and checks carried over from the source
+	Properties []tree.Property
+	Checks     []tree.Check
+	// check configuration mapping, old id -> new id
+	configs map[string]string
+	tx      *sql.Tx
+	// target -> source: node is staged or failed
+	ready chan error
+	// source -> target: transaction was committed or rolled back
+	commit  chan bool
+	decided bool
+	// guards committing and abandoned, which decide whether the
+	// source may still commit after the target stopped waiting
+	lock       sync.Mutex
+	committing bool
+	abandoned  bool
+}
+
+// prepareCommit is called by the source treekeeper before it commits
+// the transaction. It fails if the target has given up waiting and
+// rolled back its tree, afterwards the target waits for the outcome.
+func (r *treeRelocation) prepareCommit() error {
+	if r == nil || r.commit == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.abandoned {
+		return fmt.Errorf("Relocation abandoned by %s", r.keeper)
+	}
+	r.committing = true
+	return nil
+}
+
+// abandon is called by the target treekeeper once it has waited
+// relocationTimeout for the outcome. It returns false if the source
+// is already committing, then the outcome has to be awaited.
+func (r *treeRelocation) abandon() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.committing {
+		return false
+	}
+	r.abandoned = true
+	return true
+}
+
+// decide informs the target treekeeper about the outcome of the
+// source transaction. It is a no-op if the target was never
+// involved or has already been informed.
+func (r *treeRelocation) decide(commit bool) {
+	if r == nil || r.commit == nil || r.decided {
+		return
+	}
+	r.decided = true
+	r.commit <- commit
+}
+
+// relocateNode is the source half of a node relocation. The node is
+// removed from this tree, its local properties and checks are
+// recorded for the target.
+func (tk *treeKeeper) relocateNode(q *treeRequest) {
+	node := tk.tree.Find(tree.FindRequest{
+		ElementType: `node`,
+		ElementId:   q.Node.Node.Id,
+	}, true).(*tree.Node)
+
+	// the relocation state is not part of the saved job and is
+	// rebuilt here, which also covers jobs reloaded on startup
+	q.relocation = &treeRelocation{}
+	if q.Node.Node.Config.RepositoryId != tk.repoId {
+		q.relocation.keeper = relocationKeeper(
+			q.Node.Node.Config.RepositoryId,
+		)
+	}
+	r := q.relocation
+	r.Properties = node.LocalProperties()
+	r.Checks = node.LocalChecks()
+	node.Destroy()
+
+	if r.keeper != `` {
+		return
+	}
+
+	// the node stays inside this repository, the check
+	// configurations are kept and moved to the new bucket
+	target := tk.newRelocatedNode(q)
+	for _, p := range r.Properties {
+		target.SetProperty(p)
+	}
+	for _, c := range r.Checks {
+		target.SetCheck(c)
+	}
+}
+
+// relocationKeeper returns the name of the treekeeper serving the
+// repository repoId
+func relocationKeeper(repoId string) string {
+	// an unknown keeper fails the handoff
+	keeper := fmt.Sprintf("repository_%s", repoId)
+	handlerMap.Range(func(name string, h interface{}) bool {
+		if handler, ok := h.(*treeKeeper); ok && handler.repoId == repoId {
+			keeper = name
+			return false
+		}
+		return true
+	})
+	return keeper
+}
+
+// newRelocatedNode assigns a fresh copy of the node from q to its
+// target bucket
+func (tk *treeKeeper) newRelocatedNode(q *treeRequest) *tree.Node {
+	tree.NewNode(tree.NodeSpec{
+		Id:         q.Node.Node.Id,
+		AssetId:    q.Node.Node.AssetId,
+		Name:       q.Node.Node.Name,
+		Team:       q.Node.Node.TeamId,
+		ServerId:   q.Node.Node.ServerId,
+		ServerName: nodeServerName(q.Node.Node),
+		Online:     q.Node.Node.IsOnline,
+		Deleted:    q.Node.Node.IsDeleted,
+	}).Attach(tree.AttachRequest{
+		Root:       tk.tree,
+		ParentType: `bucket`,
+		ParentId:   q.Node.Node.Config.BucketId,
+	})
+	return tk.tree.Find(tree.FindRequest{
+		ElementType: `node`,
+		ElementId:   q.Node.Node.Id,
+	}, true).(*tree.Node)
+}
+
+// txRelocateChecks updates the check configurations of the checks
+// that were carried along with a relocated node
+func (tk *treeKeeper) txRelocateChecks(q *treeRequest, tx *sql.Tx,
+	stm map[string]*sql.Stmt) error {
+	var err error
+
+	for _, c := range q.relocation.Checks {
+		if q.relocation.keeper == `` {
+			_, err = stm[`MoveCheckConfigurationBucket`].Exec(
+				c.ConfigId.String(),
+				q.Node.Node.Config.BucketId,
+			)
+		} else {
+			// the target repository receives copies
+			_, err = tx.Exec(
+				stmt.TxMarkCheckConfigDeleted,
+				c.ConfigId.String(),
+			)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relocateHandoff passes the open transaction to the target
+// treekeeper and waits until the node has been staged there
+func (tk *treeKeeper) relocateHandoff(q *treeRequest, tx *sql.Tx) error {
+	r := q.relocation
+	if r == nil || r.keeper == `` {
+		return nil
+	}
+
+	handler, ok := handlerMap.Get(r.keeper).(*treeKeeper)
+	if !ok {
+		return fmt.Errorf("No handler %s currently registered", r.keeper)
+	}
+	if handler.isStopped() || handler.isBroken() || !handler.isReady() {
+		return fmt.Errorf("Handler %s is not available", r.keeper)
+	}
+
+	r.tx = tx
+	r.ready = make(chan error, 1)
+	r.commit = make(chan bool, 1)
+
+	// the target may be busy with a relocation towards this
+	// treekeeper, neither side may block without a timeout
+	timeout := time.After(relocationTimeout)
+	select {
+	case handler.input <- treeRequest{
+		RequestType: `node`,
+		Action:      `assign_relocated_node`,
+		User:        q.User,
+		JobId:       q.JobId,
+		Node:        q.Node,
+		relocation:  r,
+	}:
+	case <-timeout:
+		return fmt.Errorf("Timeout handing off to %s", r.keeper)
+	}
+
+	select {
+	case err := <-r.ready:
+		return err
+	case <-timeout:
+		return fmt.Errorf("Timeout waiting for %s", r.keeper)
+	}
+}
+
+// processRelocation is the target half of a node relocation. It runs
+// inside the transaction of the source treekeeper.
+func (tk *treeKeeper) processRelocation(q *treeRequest) {
+	var (
+		err error
+		stm map[string]*sql.Stmt
+	)
+	r := q.relocation
+
+	// the source treekeeper may have given up already
+	select {
+	case commit := <-r.commit:
+		if !commit {
+			return
+		}
+	default:
+	}
+
+	jobLog, lfh := tk.openJobLog(q)
+	defer lfh.Close()
+	defer lfh.Sync()
+	tk.appLog.Printf("Processing relocation job: %s\n", q.JobId.String())
+
+	tk.tree.Begin()
+
+	if err = tk.assignRelocatedNode(q); err != nil {
+		goto bailout
+	}
+
+	tk.tree.ComputeCheckInstances()
+
+	if stm, err = tk.prepareTx(r.tx); err != nil {
+		goto bailout
+	}
+
+	// create the copied check configurations before the checks
+	// referencing them
+	for oldId, newId := range r.configs {
+		if _, err = stm[`CopyCheckConfigurationBase`].Exec(
+			oldId,
+			newId,
+			tk.repoId,
+			q.Node.Node.Config.BucketId,
+		); err != nil {
+			goto bailout
+		}
+		if _, err = stm[`CopyCheckConfigurationDetails`].Exec(
+			oldId,
+			newId,
+			tk.repoId,
+		); err != nil {
+			goto bailout
+		}
+	}
+
+	if err = tk.txActions(stm, q.User, jobLog); err != nil {
+		goto bailout
+	}
+
+	r.ready <- nil
+	if tk.awaitRelocationCommit(r) {
+		tk.tree.Commit()
+		tk.publishActions(q)
+		tk.appLog.Printf("SUCCESS - Finished relocation job: %s\n",
+			q.JobId.String())
+		return
+	}
+	tk.tree.Rollback()
+	tk.events = nil
+	tk.appLog.Printf("FAILED - Finished relocation job: %s\n",
+		q.JobId.String())
+	return
+
+bailout:
+	tk.appLog.Printf("FAILED - Finished relocation job: %s\n",
+		q.JobId.String())
+	tk.log.Printf("Job-Error(%s): %s\n", q.JobId.String(), err)
+	jobLog.Printf("Aborting error: %s\n", err)
+	tk.tree.Rollback()
+	tk.events = nil
+	for i := len(tk.errChan); i > 0; i-- {
+		<-tk.errChan
+	}
+	for i := len(tk.actionChan); i > 0; i-- {
+		<-tk.actionChan
+	}
+	select {
+	case r.ready <- err:
+	default:
+	}
+}
+
+// awaitRelocationCommit waits for the source treekeeper to decide
+// the relocation. If the source does not start its commit within
+// relocationTimeout, the relocation is abandoned and the source will
+// roll back as well.
+func (tk *treeKeeper) awaitRelocationCommit(r *treeRelocation) bool {
+	select {
+	case commit := <-r.commit:
+		return commit
+	case <-time.After(relocationTimeout):
+	}
+	if r.abandon() {
+		tk.appLog.Printf("TK[%s]: abandoned relocation from source"+
+			" treekeeper after timeout", tk.repoName)
+		return false
+	}
+	// the source is committing, its decision follows
+	return <-r.commit
+}
+
+// assignRelocatedNode attaches the node to this tree and sets the
+// carried over properties and checks that are valid inside this
+// repository
+func (tk *treeKeeper) assignRelocatedNode(q *treeRequest) error {
+	var (
+		err     error
+		allowed bool
+		cstId   string
+	)
+	r := q.relocation
+	r.configs = map[string]string{}
+	node := tk.newRelocatedNode(q)
+
+	for _, p := range r.Properties {
+		switch p.GetType() {
+		case `custom`:
+			// custom properties are defined per repository
+			if err = r.tx.QueryRow(
+				stmt.TxRelocationCustomPropertyId,
+				p.(*tree.PropertyCustom).CustomId.String(),
+				tk.repoId,
+			).Scan(&cstId); err == sql.ErrNoRows {
+				continue
+			} else if err != nil {
+				return err
+			}
+			p.(*tree.PropertyCustom).CustomId, _ = uuid.FromString(cstId)
+		case `service`:
+			// services are only available to the repository team
+			if q.Node.Node.TeamId != tk.team {
+				continue
+			}
+		}
+		node.SetProperty(p)
+	}
+
+checkloop:
+	for _, c := range r.Checks {
+		if err = r.tx.QueryRow(
+			stmt.TxRelocationCheckConfigAllowed,
+			c.ConfigId.String(),
+			tk.repoId,
+			tk.team,
+		).Scan(&allowed); err != nil {
+			return err
+		}
+		if !allowed {
+			continue
+		}
+		for i := range c.Constraints {
+			if c.Constraints[i].Type != `custom` {
+				continue
+			}
+			if err = r.tx.QueryRow(
+				stmt.TxRelocationCustomPropertyId,
+				c.Constraints[i].Key,
+				tk.repoId,
+			).Scan(&cstId); err == sql.ErrNoRows {
+				// the constraint can never match inside this
+				// repository, the check is not carried over
+				continue checkloop
+			} else if err != nil {
+				return err
+			}
+			c.Constraints[i].Key = cstId
+		}
+		newId := uuid.NewV4()
+		r.configs[c.ConfigId.String()] = newId.String()
+		c.ConfigId = newId
+		node.SetCheck(c)
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
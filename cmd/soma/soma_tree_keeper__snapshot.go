@@ -0,0 +1,338 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1and1/soma/internal/stmt"
+	"github.com/1and1/soma/lib/proto"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+)
+
+func init() {
+	sql.Register(`somasnapshot`, &snapshotDriver{})
+}
+
+// snapshots holds all open snapshots by their data source name
+var snapshots = struct {
+	sync.Mutex
+	m map[string]*snapshot
+}{m: map[string]*snapshot{}}
+
+// snapshot is a database handle for the treekeeper startup loaders
+// that either records or replays their query results. Recording
+// snapshots pass all queries to the database, replaying snapshots
+// only return results recorded earlier.
+// All queries of a recording snapshot run on the single database
+// connection pg, inside one read-only repeatable read transaction,
+// so that the recorded results are consistent with each other.
+// pgLock serializes the use of pg.
+type snapshot struct {
+	sync.Mutex
+	name    string
+	queries map[string]*proto.RepositoryExportQuery
+	db      *sql.DB
+	pgLock  sync.Mutex
+	pg      driver.Conn
+	tx      driver.Tx
+}
+
+// newRecordingSnapshot returns a snapshot that records the results
+// of all queries run against the database
+func newRecordingSnapshot() (*snapshot, error) {
+	return openSnapshot(databaseConnect(),
+		map[string]*proto.RepositoryExportQuery{})
+}
+
+// newReplaySnapshot returns a snapshot that replays the results of
+// queries. Queries without recorded results return no rows.
+func newReplaySnapshot(queries []proto.RepositoryExportQuery) (*snapshot, error) {
+	qm := map[string]*proto.RepositoryExportQuery{}
+	for i := range queries {
+		qm[snapshotKey(queries[i].Statement,
+			queries[i].Arguments)] = &queries[i]
+	}
+	return openSnapshot(``, qm)
+}
+
+func openSnapshot(connect string,
+	queries map[string]*proto.RepositoryExportQuery) (*snapshot, error) {
+	var err error
+	s := &snapshot{
+		name:    uuid.NewV4().String(),
+		queries: queries,
+	}
+	if connect != `` {
+		if err = s.begin(connect); err != nil {
+			s.close()
+			return nil, err
+		}
+	}
+	snapshots.Lock()
+	snapshots.m[s.name] = s
+	snapshots.Unlock()
+
+	if s.db, err = sql.Open(`somasnapshot`, s.name); err != nil {
+		s.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// begin opens the database connection of a recording snapshot and
+// starts its transaction
+func (s *snapshot) begin(connect string) error {
+	var err error
+	if s.pg, err = pq.Open(connect); err != nil {
+		return err
+	}
+	if s.tx, err = s.pg.Begin(); err != nil {
+		return err
+	}
+	// SET TRANSACTION must be the first statement of the transaction
+	execer, ok := s.pg.(driver.Execer)
+	if !ok {
+		return fmt.Errorf(`snapshot: connection does not support Exec`)
+	}
+	_, err = execer.Exec(stmt.ReadOnlyRepeatableRead, nil)
+	return err
+}
+
+// close releases the database handle of the snapshot. The
+// transaction of a recording snapshot is read-only and rolled back.
+func (s *snapshot) close() {
+	if s.db != nil {
+		s.db.Close()
+	}
+	s.pgLock.Lock()
+	if s.tx != nil {
+		s.tx.Rollback()
+	}
+	if s.pg != nil {
+		s.pg.Close()
+	}
+	s.pgLock.Unlock()
+	snapshots.Lock()
+	delete(snapshots.m, s.name)
+	snapshots.Unlock()
+}
+
+// export returns the recorded query results, sorted by statement
+// and arguments
+func (s *snapshot) export() []proto.RepositoryExportQuery {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := make([]string, 0, len(s.queries))
+	for k := range s.queries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	queries := make([]proto.RepositoryExportQuery, 0, len(keys))
+	for _, k := range keys {
+		queries = append(queries, *s.queries[k])
+	}
+	return queries
+}
+
+func (s *snapshot) lookup(key string) (*proto.RepositoryExportQuery, bool) {
+	s.Lock()
+	defer s.Unlock()
+	q, ok := s.queries[key]
+	return q, ok
+}
+
+func (s *snapshot) record(key string, q *proto.RepositoryExportQuery) {
+	s.Lock()
+	defer s.Unlock()
+	s.queries[key] = q
+}
+
+// snapshotKey identifies a query by statement name and arguments
+func snapshotKey(statement string, args []string) string {
+	return strings.Join(append([]string{statement}, args...), "\x00")
+}
+
+// snapshotValue converts a database value into its exported form
+func snapshotValue(v driver.Value) *string {
+	var s string
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		s = string(t)
+	case string:
+		s = t
+	case time.Time:
+		s = t.Format(time.RFC3339Nano)
+	default:
+		s = fmt.Sprintf("%v", t)
+	}
+	return &s
+}
+
+// snapshotDriver implements database/sql/driver for snapshots
+type snapshotDriver struct{}
+
+func (d *snapshotDriver) Open(name string) (driver.Conn, error) {
+	snapshots.Lock()
+	s, ok := snapshots.m[name]
+	snapshots.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("snapshot: unknown snapshot %s", name)
+	}
+
+	return &snapshotConn{snap: s}, nil
+}
+
+type snapshotConn struct {
+	snap *snapshot
+}
+
+func (c *snapshotConn) Prepare(query string) (driver.Stmt, error) {
+	st := &snapshotStmt{
+		snap: c.snap,
+		name: stmt.Name(query),
+	}
+	if st.name == `` {
+		return nil, fmt.Errorf(`snapshot: unregistered statement`)
+	}
+	if c.snap.pg != nil {
+		var err error
+		c.snap.pgLock.Lock()
+		st.pg, err = c.snap.pg.Prepare(query)
+		c.snap.pgLock.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return st, nil
+}
+
+// Close does not close the database connection, it is shared by
+// all connections of the snapshot
+func (c *snapshotConn) Close() error {
+	return nil
+}
+
+func (c *snapshotConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf(`snapshot: transactions are not supported`)
+}
+
+type snapshotStmt struct {
+	snap *snapshot
+	name string
+	pg   driver.Stmt
+}
+
+func (st *snapshotStmt) Close() error {
+	if st.pg != nil {
+		st.snap.pgLock.Lock()
+		defer st.snap.pgLock.Unlock()
+		return st.pg.Close()
+	}
+	return nil
+}
+
+func (st *snapshotStmt) NumInput() int {
+	return -1
+}
+
+func (st *snapshotStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("snapshot: %s is read-only", st.name)
+}
+
+func (st *snapshotStmt) Query(args []driver.Value) (driver.Rows, error) {
+	arguments := make([]string, len(args))
+	for i := range args {
+		if v := snapshotValue(args[i]); v != nil {
+			arguments[i] = *v
+		}
+	}
+	key := snapshotKey(st.name, arguments)
+
+	// replay
+	if st.pg == nil {
+		if q, ok := st.snap.lookup(key); ok {
+			return &snapshotRows{query: q}, nil
+		}
+		return &snapshotRows{query: &proto.RepositoryExportQuery{}}, nil
+	}
+
+	// record
+	st.snap.pgLock.Lock()
+	defer st.snap.pgLock.Unlock()
+	rows, err := st.pg.Query(args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	q := &proto.RepositoryExportQuery{
+		Statement: st.name,
+		Arguments: arguments,
+		Columns:   rows.Columns(),
+		Rows:      [][]*string{},
+	}
+	dest := make([]driver.Value, len(q.Columns))
+	for {
+		if err = rows.Next(dest); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		row := make([]*string, len(dest))
+		for i := range dest {
+			row[i] = snapshotValue(dest[i])
+		}
+		q.Rows = append(q.Rows, row)
+	}
+	st.snap.record(key, q)
+	return &snapshotRows{query: q}, nil
+}
+
+type snapshotRows struct {
+	query *proto.RepositoryExportQuery
+	pos   int
+}
+
+func (r *snapshotRows) Columns() []string {
+	return r.query.Columns
+}
+
+func (r *snapshotRows) Close() error {
+	return nil
+}
+
+func (r *snapshotRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.query.Rows) {
+		return io.EOF
+	}
+	for i, v := range r.query.Rows[r.pos] {
+		if v == nil {
+			dest[i] = nil
+			continue
+		}
+		dest[i] = *v
+	}
+	r.pos++
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
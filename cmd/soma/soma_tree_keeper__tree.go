@@ -10,9 +10,29 @@ package main
 
 import (
 	"github.com/1and1/soma/internal/tree"
+	"github.com/1and1/soma/lib/proto"
 	"github.com/satori/go.uuid"
 )
 
+func (tk *treeKeeper) treeRepository(q *treeRequest) {
+	switch q.Action {
+	case `delete_repository`:
+		tk.tree.Child.Delete()
+	case `restore_repository`:
+		tk.tree.Child.Restore()
+	case `purge_repository`:
+		tk.tree.Child.Purge()
+	case `clear_repository`:
+		tk.tree.Child.Clear()
+	case `rename_repository`:
+		tk.tree.Child.Rename(q.Repository.Repository.Name)
+	case `repossess_repository`:
+		tk.tree.Child.Repossess(q.Repository.Repository.TeamId)
+	case `activate_repository`:
+		tk.tree.Child.Activate()
+	}
+}
+
 func (tk *treeKeeper) treeBucket(q *treeRequest) {
 	switch q.Action {
 	case `create_bucket`:
@@ -30,9 +50,30 @@ func (tk *treeKeeper) treeBucket(q *treeRequest) {
 			ParentId:   tk.repoId,
 			ParentName: tk.repoName,
 		})
+	case `delete_bucket`:
+		tk.findBucket(q.Bucket.Bucket.Id).Delete()
+	case `restore_bucket`:
+		tk.findBucket(q.Bucket.Bucket.Id).Restore()
+	case `purge_bucket`:
+		tk.findBucket(q.Bucket.Bucket.Id).Destroy()
+	case `freeze_bucket`:
+		tk.findBucket(q.Bucket.Bucket.Id).Freeze()
+	case `thaw_bucket`:
+		tk.findBucket(q.Bucket.Bucket.Id).Thaw()
+	case `rename_bucket`:
+		tk.findBucket(q.Bucket.Bucket.Id).Rename(
+			q.Bucket.Bucket.Name,
+		)
 	}
 }
 
+func (tk *treeKeeper) findBucket(bucketId string) *tree.Bucket {
+	return tk.tree.Find(tree.FindRequest{
+		ElementType: `bucket`,
+		ElementId:   bucketId,
+	}, true).(*tree.Bucket)
+}
+
 func (tk *treeKeeper) treeGroup(q *treeRequest) {
 	switch q.Action {
 	case `create_group`:
@@ -64,6 +105,18 @@ func (tk *treeKeeper) treeGroup(q *treeRequest) {
 			ParentType: `group`,
 			ParentId:   q.Group.Group.Id,
 		})
+	case `remove_group_from_group`:
+		tk.tree.Find(tree.FindRequest{
+			ElementType: `group`,
+			ElementId:   (*q.Group.Group.MemberGroups)[0].Id,
+		}, true).(tree.BucketAttacher).Detach()
+	case `rename_group`:
+		tk.tree.Find(tree.FindRequest{
+			ElementType: `group`,
+			ElementId:   q.Group.Group.Id,
+		}, true).(*tree.Group).Rename(
+			q.Group.Group.Name,
+		)
 	}
 }
 
@@ -98,6 +151,18 @@ func (tk *treeKeeper) treeCluster(q *treeRequest) {
 			ParentType: `group`,
 			ParentId:   q.Group.Group.Id,
 		})
+	case `remove_cluster_from_group`:
+		tk.tree.Find(tree.FindRequest{
+			ElementType: `cluster`,
+			ElementId:   (*q.Group.Group.MemberClusters)[0].Id,
+		}, true).(tree.BucketAttacher).Detach()
+	case `rename_cluster`:
+		tk.tree.Find(tree.FindRequest{
+			ElementType: `cluster`,
+			ElementId:   q.Cluster.Cluster.Id,
+		}, true).(*tree.Cluster).Rename(
+			q.Cluster.Cluster.Name,
+		)
 	}
 }
 
@@ -105,13 +170,14 @@ func (tk *treeKeeper) treeNode(q *treeRequest) {
 	switch q.Action {
 	case `assign_node`:
 		tree.NewNode(tree.NodeSpec{
-			Id:       q.Node.Node.Id,
-			AssetId:  q.Node.Node.AssetId,
-			Name:     q.Node.Node.Name,
-			Team:     q.Node.Node.TeamId,
-			ServerId: q.Node.Node.ServerId,
-			Online:   q.Node.Node.IsOnline,
-			Deleted:  q.Node.Node.IsDeleted,
+			Id:         q.Node.Node.Id,
+			AssetId:    q.Node.Node.AssetId,
+			Name:       q.Node.Node.Name,
+			Team:       q.Node.Node.TeamId,
+			ServerId:   q.Node.Node.ServerId,
+			ServerName: nodeServerName(q.Node.Node),
+			Online:     q.Node.Node.IsOnline,
+			Deleted:    q.Node.Node.IsDeleted,
 		}).Attach(tree.AttachRequest{
 			Root:       tk.tree,
 			ParentType: `bucket`,
@@ -145,7 +211,29 @@ func (tk *treeKeeper) treeNode(q *treeRequest) {
 			ParentType: `cluster`,
 			ParentId:   q.Cluster.Cluster.Id,
 		})
+	case `remove_node_from_group`:
+		tk.tree.Find(tree.FindRequest{
+			ElementType: `node`,
+			ElementId:   (*q.Group.Group.MemberNodes)[0].Id,
+		}, true).(tree.BucketAttacher).Detach()
+	case `remove_node_from_cluster`:
+		tk.tree.Find(tree.FindRequest{
+			ElementType: `node`,
+			ElementId:   (*q.Cluster.Cluster.Members)[0].Id,
+		}, true).(tree.BucketAttacher).Detach()
+	case `relocate_node`:
+		tk.relocateNode(q)
+	}
+}
+
+// nodeServerName returns the name of the server the node runs on.
+// Jobs saved before the guide post recorded the server carry no
+// details and yield an empty name.
+func nodeServerName(node proto.Node) string {
+	if node.Details == nil {
+		return ``
 	}
+	return node.Details.Server.Name
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -49,6 +49,33 @@ func (tk *treeKeeper) txCheckConfig(conf proto.CheckConfig,
 	); err != nil {
 		return err
 	}
+	return tk.txCheckConfigDetails(conf, stm)
+}
+
+// txCheckConfigUpdate updates a check configuration in place. The
+// thresholds and constraints are replaced.
+func (tk *treeKeeper) txCheckConfigUpdate(conf proto.CheckConfig,
+	stm map[string]*sql.Stmt) error {
+	if _, err := stm[`UpdateCheckConfigurationBase`].Exec(
+		conf.Id,
+		conf.Name,
+		int64(conf.Interval),
+		conf.Inheritance,
+		conf.ChildrenOnly,
+	); err != nil {
+		return err
+	}
+	if _, err := stm[`DeleteCheckConfigurationDetails`].Exec(
+		conf.Id,
+	); err != nil {
+		return err
+	}
+	return tk.txCheckConfigDetails(conf, stm)
+}
+
+func (tk *treeKeeper) txCheckConfigDetails(conf proto.CheckConfig,
+	stm map[string]*sql.Stmt) error {
+	var err error
 
 threshloop:
 	for _, thr := range conf.Thresholds {
@@ -73,6 +100,7 @@ constrloop:
 				conf.Id,
 				constr.Native.Name,
 				constr.Native.Value,
+				constr.Operator,
 			); err != nil {
 				break constrloop
 			}
@@ -89,6 +117,7 @@ constrloop:
 				constr.Custom.Id,
 				constr.Custom.RepositoryId,
 				constr.Custom.Value,
+				constr.Operator,
 			); err != nil {
 				break constrloop
 			}
@@ -97,6 +126,7 @@ constrloop:
 				conf.Id,
 				constr.System.Name,
 				constr.System.Value,
+				constr.Operator,
 			); err != nil {
 				break constrloop
 			}
@@ -119,6 +149,7 @@ constrloop:
 				conf.Id,
 				constr.Attribute.Name,
 				constr.Attribute.Value,
+				constr.Operator,
 			); err != nil {
 				break constrloop
 			}
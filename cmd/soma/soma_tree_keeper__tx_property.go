@@ -20,6 +20,8 @@ func (tk *treeKeeper) txProperty(a *tree.Action,
 	switch a.Action {
 	case `property_new`:
 		return tk.txPropertyNew(a, stm)
+	case `property_update`:
+		return tk.txPropertyUpdate(a, stm)
 	case `property_delete`:
 		return tk.txPropertyDelete(a, stm)
 	default:
@@ -206,6 +208,97 @@ func (tk *treeKeeper) txPropertyNewOncall(a *tree.Action,
 	return err
 }
 
+//
+// PROPERTY UPDATE
+func (tk *treeKeeper) txPropertyUpdate(a *tree.Action,
+	stm map[string]*sql.Stmt) error {
+	var (
+		err       error
+		statement *sql.Stmt
+	)
+	switch a.Property.Type {
+	case `custom`:
+		switch a.Type {
+		case `repository`:
+			statement = stm[`RepositoryPropertyCustomUpdate`]
+		case `bucket`:
+			statement = stm[`BucketPropertyCustomUpdate`]
+		case `group`:
+			statement = stm[`GroupPropertyCustomUpdate`]
+		case `cluster`:
+			statement = stm[`ClusterPropertyCustomUpdate`]
+		case `node`:
+			statement = stm[`NodePropertyCustomUpdate`]
+		}
+		_, err = statement.Exec(
+			a.Property.InstanceId,
+			a.Property.Custom.Value,
+			a.Property.Inheritance,
+			a.Property.ChildrenOnly,
+		)
+	case `system`:
+		switch a.Type {
+		case `repository`:
+			statement = stm[`RepositoryPropertySystemUpdate`]
+		case `bucket`:
+			statement = stm[`BucketPropertySystemUpdate`]
+		case `group`:
+			statement = stm[`GroupPropertySystemUpdate`]
+		case `cluster`:
+			statement = stm[`ClusterPropertySystemUpdate`]
+		case `node`:
+			statement = stm[`NodePropertySystemUpdate`]
+		}
+		_, err = statement.Exec(
+			a.Property.InstanceId,
+			a.Property.System.Value,
+			a.Property.Inheritance,
+			a.Property.ChildrenOnly,
+		)
+	case `service`:
+		// the service attributes are not stored per instance
+		switch a.Type {
+		case `repository`:
+			statement = stm[`RepositoryPropertyServiceUpdate`]
+		case `bucket`:
+			statement = stm[`BucketPropertyServiceUpdate`]
+		case `group`:
+			statement = stm[`GroupPropertyServiceUpdate`]
+		case `cluster`:
+			statement = stm[`ClusterPropertyServiceUpdate`]
+		case `node`:
+			statement = stm[`NodePropertyServiceUpdate`]
+		}
+		_, err = statement.Exec(
+			a.Property.InstanceId,
+			a.Property.Inheritance,
+			a.Property.ChildrenOnly,
+		)
+	case `oncall`:
+		switch a.Type {
+		case `repository`:
+			statement = stm[`RepositoryPropertyOncallUpdate`]
+		case `bucket`:
+			statement = stm[`BucketPropertyOncallUpdate`]
+		case `group`:
+			statement = stm[`GroupPropertyOncallUpdate`]
+		case `cluster`:
+			statement = stm[`ClusterPropertyOncallUpdate`]
+		case `node`:
+			statement = stm[`NodePropertyOncallUpdate`]
+		}
+		_, err = statement.Exec(
+			a.Property.InstanceId,
+			a.Property.Oncall.Id,
+			a.Property.Inheritance,
+			a.Property.ChildrenOnly,
+		)
+	default:
+		err = fmt.Errorf(`Impossible property type`)
+	}
+	return err
+}
+
 //
 // PROPERTY DELETE
 func (tk *treeKeeper) txPropertyDelete(a *tree.Action,
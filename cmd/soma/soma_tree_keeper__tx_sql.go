@@ -20,14 +20,39 @@ func (tk *treeKeeper) startTx() (
 
 	var err error
 	var tx *sql.Tx
+	var stMap map[string]*sql.Stmt
 	open := false
-	stMap := map[string]*sql.Stmt{}
 
 	if tx, err = tk.conn.Begin(); err != nil {
 		goto bailout
 	}
 	open = true
 
+	if stMap, err = tk.prepareTx(tx); err != nil {
+		goto bailout
+	}
+	return tx, stMap, nil
+
+bailout:
+	if open {
+		// if the transaction was opened, then tx.Rollback() will close all
+		// prepared statements. If the transaction was not opened yet, then
+		// no statements have been prepared inside it - there is nothing to
+		// close
+		defer tx.Rollback()
+	}
+	return nil, nil, err
+}
+
+// prepareTx prepares all statements used by the treekeeper inside
+// the transaction tx. This is also used to join the transaction of
+// another treekeeper during a node relocation.
+func (tk *treeKeeper) prepareTx(tx *sql.Tx) (
+	map[string]*sql.Stmt, error) {
+
+	var err error
+	stMap := map[string]*sql.Stmt{}
+
 	//
 	// PROPERTY STATEMENTS
 	for name, statement := range map[string]string{
@@ -35,44 +60,64 @@ func (tk *treeKeeper) startTx() (
 		`PropertyInstanceDelete`:          stmt.TxPropertyInstanceDelete,
 		`RepositoryPropertyOncallCreate`:  stmt.TxRepositoryPropertyOncallCreate,
 		`RepositoryPropertyOncallDelete`:  stmt.TxRepositoryPropertyOncallDelete,
+		`RepositoryPropertyOncallUpdate`:  stmt.TxRepositoryPropertyOncallUpdate,
 		`RepositoryPropertyServiceCreate`: stmt.TxRepositoryPropertyServiceCreate,
 		`RepositoryPropertyServiceDelete`: stmt.TxRepositoryPropertyServiceDelete,
+		`RepositoryPropertyServiceUpdate`: stmt.TxRepositoryPropertyServiceUpdate,
 		`RepositoryPropertySystemCreate`:  stmt.TxRepositoryPropertySystemCreate,
 		`RepositoryPropertySystemDelete`:  stmt.TxRepositoryPropertySystemDelete,
+		`RepositoryPropertySystemUpdate`:  stmt.TxRepositoryPropertySystemUpdate,
 		`RepositoryPropertyCustomCreate`:  stmt.TxRepositoryPropertyCustomCreate,
 		`RepositoryPropertyCustomDelete`:  stmt.TxRepositoryPropertyCustomDelete,
+		`RepositoryPropertyCustomUpdate`:  stmt.TxRepositoryPropertyCustomUpdate,
 		`BucketPropertyOncallCreate`:      stmt.TxBucketPropertyOncallCreate,
 		`BucketPropertyOncallDelete`:      stmt.TxBucketPropertyOncallDelete,
+		`BucketPropertyOncallUpdate`:      stmt.TxBucketPropertyOncallUpdate,
 		`BucketPropertyServiceCreate`:     stmt.TxBucketPropertyServiceCreate,
 		`BucketPropertyServiceDelete`:     stmt.TxBucketPropertyServiceDelete,
+		`BucketPropertyServiceUpdate`:     stmt.TxBucketPropertyServiceUpdate,
 		`BucketPropertySystemCreate`:      stmt.TxBucketPropertySystemCreate,
 		`BucketPropertySystemDelete`:      stmt.TxBucketPropertySystemDelete,
+		`BucketPropertySystemUpdate`:      stmt.TxBucketPropertySystemUpdate,
 		`BucketPropertyCustomCreate`:      stmt.TxBucketPropertyCustomCreate,
 		`BucketPropertyCustomDelete`:      stmt.TxBucketPropertyCustomDelete,
+		`BucketPropertyCustomUpdate`:      stmt.TxBucketPropertyCustomUpdate,
 		`GroupPropertyOncallCreate`:       stmt.TxGroupPropertyOncallCreate,
 		`GroupPropertyOncallDelete`:       stmt.TxGroupPropertyOncallDelete,
+		`GroupPropertyOncallUpdate`:       stmt.TxGroupPropertyOncallUpdate,
 		`GroupPropertyServiceCreate`:      stmt.TxGroupPropertyServiceCreate,
 		`GroupPropertyServiceDelete`:      stmt.TxGroupPropertyServiceDelete,
+		`GroupPropertyServiceUpdate`:      stmt.TxGroupPropertyServiceUpdate,
 		`GroupPropertySystemCreate`:       stmt.TxGroupPropertySystemCreate,
 		`GroupPropertySystemDelete`:       stmt.TxGroupPropertySystemDelete,
+		`GroupPropertySystemUpdate`:       stmt.TxGroupPropertySystemUpdate,
 		`GroupPropertyCustomCreate`:       stmt.TxGroupPropertyCustomCreate,
 		`GroupPropertyCustomDelete`:       stmt.TxGroupPropertyCustomDelete,
+		`GroupPropertyCustomUpdate`:       stmt.TxGroupPropertyCustomUpdate,
 		`ClusterPropertyOncallCreate`:     stmt.TxClusterPropertyOncallCreate,
 		`ClusterPropertyOncallDelete`:     stmt.TxClusterPropertyOncallDelete,
+		`ClusterPropertyOncallUpdate`:     stmt.TxClusterPropertyOncallUpdate,
 		`ClusterPropertyServiceCreate`:    stmt.TxClusterPropertyServiceCreate,
 		`ClusterPropertyServiceDelete`:    stmt.TxClusterPropertyServiceDelete,
+		`ClusterPropertyServiceUpdate`:    stmt.TxClusterPropertyServiceUpdate,
 		`ClusterPropertySystemCreate`:     stmt.TxClusterPropertySystemCreate,
 		`ClusterPropertySystemDelete`:     stmt.TxClusterPropertySystemDelete,
+		`ClusterPropertySystemUpdate`:     stmt.TxClusterPropertySystemUpdate,
 		`ClusterPropertyCustomCreate`:     stmt.TxClusterPropertyCustomCreate,
 		`ClusterPropertyCustomDelete`:     stmt.TxClusterPropertyCustomDelete,
+		`ClusterPropertyCustomUpdate`:     stmt.TxClusterPropertyCustomUpdate,
 		`NodePropertyOncallCreate`:        stmt.TxNodePropertyOncallCreate,
 		`NodePropertyOncallDelete`:        stmt.TxNodePropertyOncallDelete,
+		`NodePropertyOncallUpdate`:        stmt.TxNodePropertyOncallUpdate,
 		`NodePropertyServiceCreate`:       stmt.TxNodePropertyServiceCreate,
 		`NodePropertyServiceDelete`:       stmt.TxNodePropertyServiceDelete,
+		`NodePropertyServiceUpdate`:       stmt.TxNodePropertyServiceUpdate,
 		`NodePropertySystemCreate`:        stmt.TxNodePropertySystemCreate,
 		`NodePropertySystemDelete`:        stmt.TxNodePropertySystemDelete,
+		`NodePropertySystemUpdate`:        stmt.TxNodePropertySystemUpdate,
 		`NodePropertyCustomCreate`:        stmt.TxNodePropertyCustomCreate,
 		`NodePropertyCustomDelete`:        stmt.TxNodePropertyCustomDelete,
+		`NodePropertyCustomUpdate`:        stmt.TxNodePropertyCustomUpdate,
 	} {
 		if stMap[name], err = tx.Prepare(statement); err != nil {
 			err = fmt.Errorf("tk.Prepare(%s) error: %s",
@@ -122,6 +167,11 @@ func (tk *treeKeeper) startTx() (
 		`CreateCheckConfigurationConstraintCustom`:    stmt.TxCreateCheckConfigurationConstraintCustom,
 		`CreateCheckConfigurationConstraintService`:   stmt.TxCreateCheckConfigurationConstraintService,
 		`CreateCheckConfigurationConstraintAttribute`: stmt.TxCreateCheckConfigurationConstraintAttribute,
+		`UpdateCheckConfigurationBase`:                stmt.TxUpdateCheckConfigurationBase,
+		`DeleteCheckConfigurationDetails`:             stmt.TxDeleteCheckConfigurationDetails,
+		`CopyCheckConfigurationBase`:                  stmt.TxCopyCheckConfigurationBase,
+		`CopyCheckConfigurationDetails`:               stmt.TxCopyCheckConfigurationDetails,
+		`MoveCheckConfigurationBucket`:                stmt.TxMoveCheckConfigurationBucket,
 	} {
 		if stMap[name], err = tx.Prepare(statement); err != nil {
 			err = fmt.Errorf("tk.Prepare(%s) error: %s",
@@ -135,22 +185,34 @@ func (tk *treeKeeper) startTx() (
 	// TREE MANIPULATION STATEMENTS
 	for name, statement := range map[string]string{
 		`BucketAssignNode`:         stmt.TxBucketAssignNode,
+		`BucketDelete`:             stmt.TxBucketDelete,
+		`BucketDetachChecks`:       stmt.TxBucketDetachChecks,
+		`BucketRemoveGrants`:       stmt.TxBucketRemoveGrants,
+		`BucketUpdate`:             stmt.TxBucketUpdate,
 		`ClusterCreate`:            stmt.TxClusterCreate,
 		`ClusterDelete`:            stmt.TxClusterDelete,
+		`ClusterDetachChecks`:      stmt.TxClusterDetachChecks,
 		`ClusterMemberNew`:         stmt.TxClusterMemberNew,
 		`ClusterMemberRemove`:      stmt.TxClusterMemberRemove,
+		`ClusterRemoveGrants`:      stmt.TxClusterRemoveGrants,
 		`ClusterUpdate`:            stmt.TxClusterUpdate,
 		`CreateBucket`:             stmt.TxCreateBucket,
 		`GroupCreate`:              stmt.TxGroupCreate,
 		`GroupDelete`:              stmt.TxGroupDelete,
+		`GroupDetachChecks`:        stmt.TxGroupDetachChecks,
 		`GroupMemberNewCluster`:    stmt.TxGroupMemberNewCluster,
 		`GroupMemberNewGroup`:      stmt.TxGroupMemberNewGroup,
 		`GroupMemberNewNode`:       stmt.TxGroupMemberNewNode,
 		`GroupMemberRemoveCluster`: stmt.TxGroupMemberRemoveCluster,
 		`GroupMemberRemoveGroup`:   stmt.TxGroupMemberRemoveGroup,
 		`GroupMemberRemoveNode`:    stmt.TxGroupMemberRemoveNode,
+		`GroupRemoveGrants`:        stmt.TxGroupRemoveGrants,
 		`GroupUpdate`:              stmt.TxGroupUpdate,
 		`NodeUnassignFromBucket`:   stmt.TxNodeUnassignFromBucket,
+		`RepositoryDetachChecks`:   stmt.TxRepositoryDetachChecks,
+		`RepositoryRemoveGrants`:   stmt.TxRepositoryRemoveGrants,
+		`RepositoryUpdate`:         stmt.TxRepositoryUpdate,
+		`RepositoryUpdateTeam`:     stmt.TxRepositoryUpdateTeam,
 		`UpdateNodeState`:          stmt.TxUpdateNodeState,
 	} {
 		if stMap[name], err = tx.Prepare(statement); err != nil {
@@ -161,17 +223,10 @@ func (tk *treeKeeper) startTx() (
 		}
 	}
 
-	return tx, stMap, nil
+	return stMap, nil
 
 bailout:
-	if open {
-		// if the transaction was opened, then tx.Rollback() will close all
-		// prepared statements. If the transaction was not opened yet, then
-		// no statements have been prepared inside it - there is nothing to
-		// close
-		defer tx.Rollback()
-	}
-	return nil, nil, err
+	return nil, err
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -78,14 +78,44 @@ func (tk *treeKeeper) txTreeUpdate(a *tree.Action,
 		id, newState string
 	)
 	switch a.Type {
+	case `repository`:
+		if _, err = stm[`RepositoryUpdate`].Exec(
+			a.Repository.Id,
+			a.Repository.Name,
+			a.Repository.TeamId,
+			a.Repository.IsActive,
+			a.Repository.IsDeleted,
+		); err != nil {
+			return err
+		}
+		// buckets, groups and clusters follow the repository team
+		_, err = stm[`RepositoryUpdateTeam`].Exec(
+			a.Repository.Id,
+			a.Repository.TeamId,
+		)
+		return err
+	case `bucket`:
+		_, err = stm[`BucketUpdate`].Exec(
+			a.Bucket.Id,
+			a.Bucket.Name,
+			a.Bucket.IsFrozen,
+			a.Bucket.IsDeleted,
+		)
+		return err
 	case `group`:
-		statement = stm[`GroupUpdate`]
-		id = a.Group.Id
-		newState = a.Group.ObjectState
+		_, err = stm[`GroupUpdate`].Exec(
+			a.Group.Id,
+			a.Group.ObjectState,
+			a.Group.Name,
+		)
+		return err
 	case `cluster`:
-		statement = stm[`ClusterUpdate`]
-		id = a.Cluster.Id
-		newState = a.Cluster.ObjectState
+		_, err = stm[`ClusterUpdate`].Exec(
+			a.Cluster.Id,
+			a.Cluster.ObjectState,
+			a.Cluster.Name,
+		)
+		return err
 	case `node`:
 		statement = stm[`UpdateNodeState`]
 		id = a.Node.Id
@@ -102,14 +132,60 @@ func (tk *treeKeeper) txTreeDelete(a *tree.Action,
 	stm map[string]*sql.Stmt) error {
 	var err error
 	switch a.Type {
+	case `repository`:
+		// purged repositories remain as tombstone, since their jobs
+		// still reference them
+		for _, name := range []string{
+			`RepositoryDetachChecks`,
+			`RepositoryRemoveGrants`,
+		} {
+			if _, err = stm[name].Exec(
+				a.Repository.Id,
+			); err != nil {
+				return err
+			}
+		}
+		err = tk.txTreeUpdate(a, stm)
+	case `bucket`:
+		// checks and grants referencing the bucket are detached
+		// before the bucket itself is removed
+		for _, name := range []string{
+			`BucketDetachChecks`,
+			`BucketRemoveGrants`,
+			`BucketDelete`,
+		} {
+			if _, err = stm[name].Exec(
+				a.Bucket.Id,
+			); err != nil {
+				return err
+			}
+		}
 	case `group`:
-		_, err = stm[`GroupDelete`].Exec(
-			a.Group.Id,
-		)
+		// checks and grants referencing the group are detached
+		// before the group itself is removed
+		for _, name := range []string{
+			`GroupDetachChecks`,
+			`GroupRemoveGrants`,
+			`GroupDelete`,
+		} {
+			if _, err = stm[name].Exec(
+				a.Group.Id,
+			); err != nil {
+				return err
+			}
+		}
 	case `cluster`:
-		_, err = stm[`ClusterDelete`].Exec(
-			a.Cluster.Id,
-		)
+		for _, name := range []string{
+			`ClusterDetachChecks`,
+			`ClusterRemoveGrants`,
+			`ClusterDelete`,
+		} {
+			if _, err = stm[name].Exec(
+				a.Cluster.Id,
+			); err != nil {
+				return err
+			}
+		}
 	case `node`:
 		if _, err = stm[`NodeUnassignFromBucket`].Exec(
 			a.Node.Id,
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 
 	"github.com/1and1/soma/internal/msg"
+	"github.com/1and1/soma/lib/proto"
 	log "github.com/Sirupsen/logrus"
 )
 
@@ -40,6 +41,7 @@ func startHandlers(appLog, reqLog, errLog *log.Logger) {
 	spawnCheckConfigurationReadHandler(appLog, reqLog, errLog)
 	spawnHostDeploymentHandler(appLog, reqLog, errLog)
 	spawnJobReadHandler(appLog, reqLog, errLog)
+	spawnAuditReadHandler(appLog, reqLog, errLog)
 	spawnOutputTreeHandler(appLog, reqLog, errLog)
 	spawnInstanceReadHandler(appLog, reqLog, errLog)
 	spawnWorkflowReadHandler(appLog, reqLog, errLog)
@@ -52,6 +54,9 @@ func startHandlers(appLog, reqLog, errLog *log.Logger) {
 			spawnDeploymentHandler(appLog, reqLog, errLog)
 			spawnEnvironmentWriteHandler(appLog, reqLog, errLog)
 			spawnJobDelay(appLog, reqLog, errLog)
+			spawnJobWriteHandler(appLog, reqLog, errLog)
+			spawnEventStream(appLog, reqLog, errLog)
+			spawnAuditWriteHandler(appLog, reqLog, errLog)
 			spawnLevelWriteHandler(appLog, reqLog, errLog)
 			spawnMetricWriteHandler(appLog, reqLog, errLog)
 			spawnModeWriteHandler(appLog, reqLog, errLog)
@@ -86,7 +91,7 @@ func spawnViewReadHandler(appLog, reqLog, errLog *log.Logger) {
 	viewReadHandler.appLog = appLog
 	viewReadHandler.reqLog = reqLog
 	viewReadHandler.errLog = errLog
-	handlerMap["viewReadHandler"] = &viewReadHandler
+	handlerMap.Set("viewReadHandler", &viewReadHandler)
 	go viewReadHandler.run()
 }
 
@@ -98,7 +103,7 @@ func spawnViewWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	viewWriteHandler.appLog = appLog
 	viewWriteHandler.reqLog = reqLog
 	viewWriteHandler.errLog = errLog
-	handlerMap["viewWriteHandler"] = &viewWriteHandler
+	handlerMap.Set("viewWriteHandler", &viewWriteHandler)
 	go viewWriteHandler.run()
 }
 
@@ -110,7 +115,7 @@ func spawnEnvironmentReadHandler(appLog, reqLog, errLog *log.Logger) {
 	environmentReadHandler.appLog = appLog
 	environmentReadHandler.reqLog = reqLog
 	environmentReadHandler.errLog = errLog
-	handlerMap["environmentReadHandler"] = &environmentReadHandler
+	handlerMap.Set("environmentReadHandler", &environmentReadHandler)
 	go environmentReadHandler.run()
 }
 
@@ -122,7 +127,7 @@ func spawnEnvironmentWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	environmentWriteHandler.appLog = appLog
 	environmentWriteHandler.reqLog = reqLog
 	environmentWriteHandler.errLog = errLog
-	handlerMap["environmentWriteHandler"] = &environmentWriteHandler
+	handlerMap.Set("environmentWriteHandler", &environmentWriteHandler)
 	go environmentWriteHandler.run()
 }
 
@@ -134,7 +139,7 @@ func spawnObjectStateReadHandler(appLog, reqLog, errLog *log.Logger) {
 	objectStateReadHandler.appLog = appLog
 	objectStateReadHandler.reqLog = reqLog
 	objectStateReadHandler.errLog = errLog
-	handlerMap["objectStateReadHandler"] = &objectStateReadHandler
+	handlerMap.Set("objectStateReadHandler", &objectStateReadHandler)
 	go objectStateReadHandler.run()
 }
 
@@ -146,7 +151,7 @@ func spawnObjectStateWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	objectStateWriteHandler.appLog = appLog
 	objectStateWriteHandler.reqLog = reqLog
 	objectStateWriteHandler.errLog = errLog
-	handlerMap["objectStateWriteHandler"] = &objectStateWriteHandler
+	handlerMap.Set("objectStateWriteHandler", &objectStateWriteHandler)
 	go objectStateWriteHandler.run()
 }
 
@@ -158,7 +163,7 @@ func spawnObjectTypeReadHandler(appLog, reqLog, errLog *log.Logger) {
 	objectTypeReadHandler.appLog = appLog
 	objectTypeReadHandler.reqLog = reqLog
 	objectTypeReadHandler.errLog = errLog
-	handlerMap["objectTypeReadHandler"] = &objectTypeReadHandler
+	handlerMap.Set("objectTypeReadHandler", &objectTypeReadHandler)
 	go objectTypeReadHandler.run()
 }
 
@@ -170,7 +175,7 @@ func spawnObjectTypeWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	objectTypeWriteHandler.appLog = appLog
 	objectTypeWriteHandler.reqLog = reqLog
 	objectTypeWriteHandler.errLog = errLog
-	handlerMap["objectTypeWriteHandler"] = &objectTypeWriteHandler
+	handlerMap.Set("objectTypeWriteHandler", &objectTypeWriteHandler)
 	go objectTypeWriteHandler.run()
 }
 
@@ -182,7 +187,7 @@ func spawnDatacenterReadHandler(appLog, reqLog, errLog *log.Logger) {
 	datacenterReadHandler.appLog = appLog
 	datacenterReadHandler.reqLog = reqLog
 	datacenterReadHandler.errLog = errLog
-	handlerMap["datacenterReadHandler"] = &datacenterReadHandler
+	handlerMap.Set("datacenterReadHandler", &datacenterReadHandler)
 	go datacenterReadHandler.run()
 }
 
@@ -194,7 +199,7 @@ func spawnDatacenterWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	datacenterWriteHandler.appLog = appLog
 	datacenterWriteHandler.reqLog = reqLog
 	datacenterWriteHandler.errLog = errLog
-	handlerMap["datacenterWriteHandler"] = &datacenterWriteHandler
+	handlerMap.Set("datacenterWriteHandler", &datacenterWriteHandler)
 	go datacenterWriteHandler.run()
 }
 
@@ -206,7 +211,7 @@ func spawnLevelReadHandler(appLog, reqLog, errLog *log.Logger) {
 	levelReadHandler.appLog = appLog
 	levelReadHandler.reqLog = reqLog
 	levelReadHandler.errLog = errLog
-	handlerMap["levelReadHandler"] = &levelReadHandler
+	handlerMap.Set("levelReadHandler", &levelReadHandler)
 	go levelReadHandler.run()
 }
 
@@ -218,7 +223,7 @@ func spawnLevelWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	levelWriteHandler.appLog = appLog
 	levelWriteHandler.reqLog = reqLog
 	levelWriteHandler.errLog = errLog
-	handlerMap["levelWriteHandler"] = &levelWriteHandler
+	handlerMap.Set("levelWriteHandler", &levelWriteHandler)
 	go levelWriteHandler.run()
 }
 
@@ -230,7 +235,7 @@ func spawnPredicateReadHandler(appLog, reqLog, errLog *log.Logger) {
 	predicateReadHandler.appLog = appLog
 	predicateReadHandler.reqLog = reqLog
 	predicateReadHandler.errLog = errLog
-	handlerMap["predicateReadHandler"] = &predicateReadHandler
+	handlerMap.Set("predicateReadHandler", &predicateReadHandler)
 	go predicateReadHandler.run()
 }
 
@@ -242,7 +247,7 @@ func spawnPredicateWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	predicateWriteHandler.appLog = appLog
 	predicateWriteHandler.reqLog = reqLog
 	predicateWriteHandler.errLog = errLog
-	handlerMap["predicateWriteHandler"] = &predicateWriteHandler
+	handlerMap.Set("predicateWriteHandler", &predicateWriteHandler)
 	go predicateWriteHandler.run()
 }
 
@@ -254,7 +259,7 @@ func spawnStatusReadHandler(appLog, reqLog, errLog *log.Logger) {
 	statusReadHandler.appLog = appLog
 	statusReadHandler.reqLog = reqLog
 	statusReadHandler.errLog = errLog
-	handlerMap["statusReadHandler"] = &statusReadHandler
+	handlerMap.Set("statusReadHandler", &statusReadHandler)
 	go statusReadHandler.run()
 }
 
@@ -266,7 +271,7 @@ func spawnStatusWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	statusWriteHandler.appLog = appLog
 	statusWriteHandler.reqLog = reqLog
 	statusWriteHandler.errLog = errLog
-	handlerMap["statusWriteHandler"] = &statusWriteHandler
+	handlerMap.Set("statusWriteHandler", &statusWriteHandler)
 	go statusWriteHandler.run()
 }
 
@@ -278,7 +283,7 @@ func spawnOncallReadHandler(appLog, reqLog, errLog *log.Logger) {
 	oncallReadHandler.appLog = appLog
 	oncallReadHandler.reqLog = reqLog
 	oncallReadHandler.errLog = errLog
-	handlerMap["oncallReadHandler"] = &oncallReadHandler
+	handlerMap.Set("oncallReadHandler", &oncallReadHandler)
 	go oncallReadHandler.run()
 }
 
@@ -290,7 +295,7 @@ func spawnOncallWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	oncallWriteHandler.appLog = appLog
 	oncallWriteHandler.reqLog = reqLog
 	oncallWriteHandler.errLog = errLog
-	handlerMap["oncallWriteHandler"] = &oncallWriteHandler
+	handlerMap.Set("oncallWriteHandler", &oncallWriteHandler)
 	go oncallWriteHandler.run()
 }
 
@@ -302,7 +307,7 @@ func spawnTeamReadHandler(appLog, reqLog, errLog *log.Logger) {
 	teamReadHandler.appLog = appLog
 	teamReadHandler.reqLog = reqLog
 	teamReadHandler.errLog = errLog
-	handlerMap["teamReadHandler"] = &teamReadHandler
+	handlerMap.Set("teamReadHandler", &teamReadHandler)
 	go teamReadHandler.run()
 }
 
@@ -314,7 +319,7 @@ func spawnTeamWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	teamWriteHandler.appLog = appLog
 	teamWriteHandler.reqLog = reqLog
 	teamWriteHandler.errLog = errLog
-	handlerMap["teamWriteHandler"] = &teamWriteHandler
+	handlerMap.Set("teamWriteHandler", &teamWriteHandler)
 	go teamWriteHandler.run()
 }
 
@@ -326,7 +331,7 @@ func spawnNodeReadHandler(appLog, reqLog, errLog *log.Logger) {
 	nodeReadHandler.appLog = appLog
 	nodeReadHandler.reqLog = reqLog
 	nodeReadHandler.errLog = errLog
-	handlerMap["nodeReadHandler"] = &nodeReadHandler
+	handlerMap.Set("nodeReadHandler", &nodeReadHandler)
 	go nodeReadHandler.run()
 }
 
@@ -338,7 +343,7 @@ func spawnNodeWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	nodeWriteHandler.appLog = appLog
 	nodeWriteHandler.reqLog = reqLog
 	nodeWriteHandler.errLog = errLog
-	handlerMap["nodeWriteHandler"] = &nodeWriteHandler
+	handlerMap.Set("nodeWriteHandler", &nodeWriteHandler)
 	go nodeWriteHandler.run()
 }
 
@@ -350,7 +355,7 @@ func spawnServerReadHandler(appLog, reqLog, errLog *log.Logger) {
 	serverReadHandler.appLog = appLog
 	serverReadHandler.reqLog = reqLog
 	serverReadHandler.errLog = errLog
-	handlerMap["serverReadHandler"] = &serverReadHandler
+	handlerMap.Set("serverReadHandler", &serverReadHandler)
 	go serverReadHandler.run()
 }
 
@@ -362,7 +367,7 @@ func spawnServerWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	serverWriteHandler.appLog = appLog
 	serverWriteHandler.reqLog = reqLog
 	serverWriteHandler.errLog = errLog
-	handlerMap["serverWriteHandler"] = &serverWriteHandler
+	handlerMap.Set("serverWriteHandler", &serverWriteHandler)
 	go serverWriteHandler.run()
 }
 
@@ -374,7 +379,7 @@ func spawnUnitReadHandler(appLog, reqLog, errLog *log.Logger) {
 	unitReadHandler.appLog = appLog
 	unitReadHandler.reqLog = reqLog
 	unitReadHandler.errLog = errLog
-	handlerMap["unitReadHandler"] = &unitReadHandler
+	handlerMap.Set("unitReadHandler", &unitReadHandler)
 	go unitReadHandler.run()
 }
 
@@ -386,7 +391,7 @@ func spawnUnitWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	unitWriteHandler.appLog = appLog
 	unitWriteHandler.reqLog = reqLog
 	unitWriteHandler.errLog = errLog
-	handlerMap["unitWriteHandler"] = &unitWriteHandler
+	handlerMap.Set("unitWriteHandler", &unitWriteHandler)
 	go unitWriteHandler.run()
 }
 
@@ -398,7 +403,7 @@ func spawnProviderReadHandler(appLog, reqLog, errLog *log.Logger) {
 	providerReadHandler.appLog = appLog
 	providerReadHandler.reqLog = reqLog
 	providerReadHandler.errLog = errLog
-	handlerMap["providerReadHandler"] = &providerReadHandler
+	handlerMap.Set("providerReadHandler", &providerReadHandler)
 	go providerReadHandler.run()
 }
 
@@ -410,7 +415,7 @@ func spawnProviderWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	providerWriteHandler.appLog = appLog
 	providerWriteHandler.reqLog = reqLog
 	providerWriteHandler.errLog = errLog
-	handlerMap["providerWriteHandler"] = &providerWriteHandler
+	handlerMap.Set("providerWriteHandler", &providerWriteHandler)
 	go providerWriteHandler.run()
 }
 
@@ -422,7 +427,7 @@ func spawnMetricReadHandler(appLog, reqLog, errLog *log.Logger) {
 	metricReadHandler.appLog = appLog
 	metricReadHandler.reqLog = reqLog
 	metricReadHandler.errLog = errLog
-	handlerMap["metricReadHandler"] = &metricReadHandler
+	handlerMap.Set("metricReadHandler", &metricReadHandler)
 	go metricReadHandler.run()
 }
 
@@ -434,7 +439,7 @@ func spawnMetricWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	metricWriteHandler.appLog = appLog
 	metricWriteHandler.reqLog = reqLog
 	metricWriteHandler.errLog = errLog
-	handlerMap["metricWriteHandler"] = &metricWriteHandler
+	handlerMap.Set("metricWriteHandler", &metricWriteHandler)
 	go metricWriteHandler.run()
 }
 
@@ -446,7 +451,7 @@ func spawnModeReadHandler(appLog, reqLog, errLog *log.Logger) {
 	modeReadHandler.appLog = appLog
 	modeReadHandler.reqLog = reqLog
 	modeReadHandler.errLog = errLog
-	handlerMap["modeReadHandler"] = &modeReadHandler
+	handlerMap.Set("modeReadHandler", &modeReadHandler)
 	go modeReadHandler.run()
 }
 
@@ -458,7 +463,7 @@ func spawnModeWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	modeWriteHandler.appLog = appLog
 	modeWriteHandler.reqLog = reqLog
 	modeWriteHandler.errLog = errLog
-	handlerMap["modeWriteHandler"] = &modeWriteHandler
+	handlerMap.Set("modeWriteHandler", &modeWriteHandler)
 	go modeWriteHandler.run()
 }
 
@@ -470,7 +475,7 @@ func spawnUserReadHandler(appLog, reqLog, errLog *log.Logger) {
 	userReadHandler.appLog = appLog
 	userReadHandler.reqLog = reqLog
 	userReadHandler.errLog = errLog
-	handlerMap["userReadHandler"] = &userReadHandler
+	handlerMap.Set("userReadHandler", &userReadHandler)
 	go userReadHandler.run()
 }
 
@@ -482,7 +487,7 @@ func spawnUserWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	userWriteHandler.appLog = appLog
 	userWriteHandler.reqLog = reqLog
 	userWriteHandler.errLog = errLog
-	handlerMap["userWriteHandler"] = &userWriteHandler
+	handlerMap.Set("userWriteHandler", &userWriteHandler)
 	go userWriteHandler.run()
 }
 
@@ -494,7 +499,7 @@ func spawnMonitoringReadHandler(appLog, reqLog, errLog *log.Logger) {
 	monitoringReadHandler.appLog = appLog
 	monitoringReadHandler.reqLog = reqLog
 	monitoringReadHandler.errLog = errLog
-	handlerMap["monitoringReadHandler"] = &monitoringReadHandler
+	handlerMap.Set("monitoringReadHandler", &monitoringReadHandler)
 	go monitoringReadHandler.run()
 }
 
@@ -506,7 +511,7 @@ func spawnMonitoringWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	monitoringWriteHandler.appLog = appLog
 	monitoringWriteHandler.reqLog = reqLog
 	monitoringWriteHandler.errLog = errLog
-	handlerMap["monitoringWriteHandler"] = &monitoringWriteHandler
+	handlerMap.Set("monitoringWriteHandler", &monitoringWriteHandler)
 	go monitoringWriteHandler.run()
 }
 
@@ -518,7 +523,7 @@ func spawnCapabilityReadHandler(appLog, reqLog, errLog *log.Logger) {
 	capabilityReadHandler.appLog = appLog
 	capabilityReadHandler.reqLog = reqLog
 	capabilityReadHandler.errLog = errLog
-	handlerMap["capabilityReadHandler"] = &capabilityReadHandler
+	handlerMap.Set("capabilityReadHandler", &capabilityReadHandler)
 	go capabilityReadHandler.run()
 }
 
@@ -530,7 +535,7 @@ func spawnCapabilityWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	capabilityWriteHandler.appLog = appLog
 	capabilityWriteHandler.reqLog = reqLog
 	capabilityWriteHandler.errLog = errLog
-	handlerMap["capabilityWriteHandler"] = &capabilityWriteHandler
+	handlerMap.Set("capabilityWriteHandler", &capabilityWriteHandler)
 	go capabilityWriteHandler.run()
 }
 
@@ -542,7 +547,7 @@ func spawnPropertyReadHandler(appLog, reqLog, errLog *log.Logger) {
 	propertyReadHandler.appLog = appLog
 	propertyReadHandler.reqLog = reqLog
 	propertyReadHandler.errLog = errLog
-	handlerMap["propertyReadHandler"] = &propertyReadHandler
+	handlerMap.Set("propertyReadHandler", &propertyReadHandler)
 	go propertyReadHandler.run()
 }
 
@@ -554,7 +559,7 @@ func spawnPropertyWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	propertyWriteHandler.appLog = appLog
 	propertyWriteHandler.reqLog = reqLog
 	propertyWriteHandler.errLog = errLog
-	handlerMap["propertyWriteHandler"] = &propertyWriteHandler
+	handlerMap.Set("propertyWriteHandler", &propertyWriteHandler)
 	go propertyWriteHandler.run()
 }
 
@@ -566,7 +571,7 @@ func spawnAttributeReadHandler(appLog, reqLog, errLog *log.Logger) {
 	attributeReadHandler.appLog = appLog
 	attributeReadHandler.reqLog = reqLog
 	attributeReadHandler.errLog = errLog
-	handlerMap["attributeReadHandler"] = &attributeReadHandler
+	handlerMap.Set("attributeReadHandler", &attributeReadHandler)
 	go attributeReadHandler.run()
 }
 
@@ -578,7 +583,7 @@ func spawnAttributeWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	attributeWriteHandler.appLog = appLog
 	attributeWriteHandler.reqLog = reqLog
 	attributeWriteHandler.errLog = errLog
-	handlerMap["attributeWriteHandler"] = &attributeWriteHandler
+	handlerMap.Set("attributeWriteHandler", &attributeWriteHandler)
 	go attributeWriteHandler.run()
 }
 
@@ -590,7 +595,7 @@ func spawnRepositoryReadHandler(appLog, reqLog, errLog *log.Logger) {
 	repositoryReadHandler.appLog = appLog
 	repositoryReadHandler.reqLog = reqLog
 	repositoryReadHandler.errLog = errLog
-	handlerMap["repositoryReadHandler"] = &repositoryReadHandler
+	handlerMap.Set("repositoryReadHandler", &repositoryReadHandler)
 	go repositoryReadHandler.run()
 }
 
@@ -602,7 +607,7 @@ func spawnBucketReadHandler(appLog, reqLog, errLog *log.Logger) {
 	bucketReadHandler.appLog = appLog
 	bucketReadHandler.reqLog = reqLog
 	bucketReadHandler.errLog = errLog
-	handlerMap["bucketReadHandler"] = &bucketReadHandler
+	handlerMap.Set("bucketReadHandler", &bucketReadHandler)
 	go bucketReadHandler.run()
 }
 
@@ -614,7 +619,7 @@ func spawnGroupReadHandler(appLog, reqLog, errLog *log.Logger) {
 	groupReadHandler.appLog = appLog
 	groupReadHandler.reqLog = reqLog
 	groupReadHandler.errLog = errLog
-	handlerMap["groupReadHandler"] = &groupReadHandler
+	handlerMap.Set("groupReadHandler", &groupReadHandler)
 	go groupReadHandler.run()
 }
 
@@ -626,7 +631,7 @@ func spawnClusterReadHandler(appLog, reqLog, errLog *log.Logger) {
 	clusterReadHandler.appLog = appLog
 	clusterReadHandler.reqLog = reqLog
 	clusterReadHandler.errLog = errLog
-	handlerMap["clusterReadHandler"] = &clusterReadHandler
+	handlerMap.Set("clusterReadHandler", &clusterReadHandler)
 	go clusterReadHandler.run()
 }
 
@@ -639,7 +644,7 @@ func spawnForestCustodian(appLog, reqLog, errLog *log.Logger) {
 	fC.appLog = appLog
 	fC.reqLog = reqLog
 	fC.errLog = errLog
-	handlerMap["forestCustodian"] = &fC
+	handlerMap.Set("forestCustodian", &fC)
 	go fC.run()
 }
 
@@ -652,7 +657,7 @@ func spawnGuidePost(appLog, reqLog, errLog *log.Logger) {
 	gP.appLog = appLog
 	gP.reqLog = reqLog
 	gP.errLog = errLog
-	handlerMap["guidePost"] = &gP
+	handlerMap.Set("guidePost", &gP)
 	go gP.run()
 }
 
@@ -664,7 +669,7 @@ func spawnCheckConfigurationReadHandler(appLog, reqLog, errLog *log.Logger) {
 	checkConfigurationReadHandler.appLog = appLog
 	checkConfigurationReadHandler.reqLog = reqLog
 	checkConfigurationReadHandler.errLog = errLog
-	handlerMap["checkConfigurationReadHandler"] = &checkConfigurationReadHandler
+	handlerMap.Set("checkConfigurationReadHandler", &checkConfigurationReadHandler)
 	go checkConfigurationReadHandler.run()
 }
 
@@ -675,7 +680,7 @@ func spawnLifeCycle(appLog, reqLog, errLog *log.Logger) {
 	lifeCycleHandler.appLog = appLog
 	lifeCycleHandler.reqLog = reqLog
 	lifeCycleHandler.errLog = errLog
-	handlerMap["lifeCycle"] = &lifeCycleHandler
+	handlerMap.Set("lifeCycle", &lifeCycleHandler)
 	go lifeCycleHandler.run()
 }
 
@@ -687,7 +692,7 @@ func spawnDeploymentHandler(appLog, reqLog, errLog *log.Logger) {
 	deploymentHandler.appLog = appLog
 	deploymentHandler.reqLog = reqLog
 	deploymentHandler.errLog = errLog
-	handlerMap["deploymentHandler"] = &deploymentHandler
+	handlerMap.Set("deploymentHandler", &deploymentHandler)
 	go deploymentHandler.run()
 }
 
@@ -699,7 +704,7 @@ func spawnHostDeploymentHandler(appLog, reqLog, errLog *log.Logger) {
 	hostDeploymentHandler.appLog = appLog
 	hostDeploymentHandler.reqLog = reqLog
 	hostDeploymentHandler.errLog = errLog
-	handlerMap["hostDeploymentHandler"] = &hostDeploymentHandler
+	handlerMap.Set("hostDeploymentHandler", &hostDeploymentHandler)
 	go hostDeploymentHandler.run()
 }
 
@@ -711,7 +716,7 @@ func spawnValidityReadHandler(appLog, reqLog, errLog *log.Logger) {
 	validityReadHandler.appLog = appLog
 	validityReadHandler.reqLog = reqLog
 	validityReadHandler.errLog = errLog
-	handlerMap["validityReadHandler"] = &validityReadHandler
+	handlerMap.Set("validityReadHandler", &validityReadHandler)
 	go validityReadHandler.run()
 }
 
@@ -723,7 +728,7 @@ func spawnValidityWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	validityWriteHandler.appLog = appLog
 	validityWriteHandler.reqLog = reqLog
 	validityWriteHandler.errLog = errLog
-	handlerMap["validityWriteHandler"] = &validityWriteHandler
+	handlerMap.Set("validityWriteHandler", &validityWriteHandler)
 	go validityWriteHandler.run()
 }
 
@@ -753,7 +758,7 @@ func spawnSupervisorHandler(appLog, reqLog, errLog *log.Logger) {
 	supervisorHandler.kexExpiry = SomaCfg.Auth.KexExpirySeconds
 	supervisorHandler.credExpiry = SomaCfg.Auth.CredentialExpiryDays
 	supervisorHandler.activation = SomaCfg.Auth.Activation
-	handlerMap[`supervisor`] = &supervisorHandler
+	handlerMap.Set(`supervisor`, &supervisorHandler)
 	go supervisorHandler.run()
 }
 
@@ -765,7 +770,21 @@ func spawnJobDelay(appLog, reqLog, errLog *log.Logger) {
 	handler.appLog = appLog
 	handler.reqLog = reqLog
 	handler.errLog = errLog
-	handlerMap[`jobDelay`] = &handler
+	handlerMap.Set(`jobDelay`, &handler)
+	go handler.run()
+}
+
+func spawnEventStream(appLog, reqLog, errLog *log.Logger) {
+	var handler eventStream
+	handler.input = make(chan proto.Event, 4096)
+	handler.subscribe = make(chan *eventSubscription)
+	handler.unsubscribe = make(chan *eventSubscription)
+	handler.shutdown = make(chan bool)
+	handler.stopped = make(chan struct{})
+	handler.appLog = appLog
+	handler.reqLog = reqLog
+	handler.errLog = errLog
+	handlerMap.Set(`eventStream`, &handler)
 	go handler.run()
 }
 
@@ -777,7 +796,43 @@ func spawnJobReadHandler(appLog, reqLog, errLog *log.Logger) {
 	handler.appLog = appLog
 	handler.reqLog = reqLog
 	handler.errLog = errLog
-	handlerMap[`jobs_r`] = &handler
+	handlerMap.Set(`jobs_r`, &handler)
+	go handler.run()
+}
+
+func spawnJobWriteHandler(appLog, reqLog, errLog *log.Logger) {
+	var handler jobsWrite
+	handler.input = make(chan msg.Request, 64)
+	handler.shutdown = make(chan bool)
+	handler.conn = conn
+	handler.appLog = appLog
+	handler.reqLog = reqLog
+	handler.errLog = errLog
+	handlerMap.Set(`jobs_w`, &handler)
+	go handler.run()
+}
+
+func spawnAuditReadHandler(appLog, reqLog, errLog *log.Logger) {
+	var handler auditRead
+	handler.input = make(chan msg.Request, 64)
+	handler.shutdown = make(chan bool)
+	handler.conn = conn
+	handler.appLog = appLog
+	handler.reqLog = reqLog
+	handler.errLog = errLog
+	handlerMap.Set(`audit_r`, &handler)
+	go handler.run()
+}
+
+func spawnAuditWriteHandler(appLog, reqLog, errLog *log.Logger) {
+	var handler auditWrite
+	handler.input = make(chan *auditRecord, 1024)
+	handler.shutdown = make(chan bool)
+	handler.conn = conn
+	handler.appLog = appLog
+	handler.reqLog = reqLog
+	handler.errLog = errLog
+	handlerMap.Set(`audit_w`, &handler)
 	go handler.run()
 }
 
@@ -789,7 +844,7 @@ func spawnOutputTreeHandler(appLog, reqLog, errLog *log.Logger) {
 	handler.appLog = appLog
 	handler.reqLog = reqLog
 	handler.errLog = errLog
-	handlerMap[`tree_r`] = &handler
+	handlerMap.Set(`tree_r`, &handler)
 	go handler.run()
 }
 
@@ -800,7 +855,7 @@ func spawnGrimReaperHandler(appLog, reqLog, errLog *log.Logger) {
 	reaper.appLog = appLog
 	reaper.reqLog = reqLog
 	reaper.errLog = errLog
-	handlerMap[`grimReaper`] = &reaper
+	handlerMap.Set(`grimReaper`, &reaper)
 	go reaper.run()
 }
 
@@ -812,7 +867,7 @@ func spawnInstanceReadHandler(appLog, reqLog, errLog *log.Logger) {
 	handler.appLog = appLog
 	handler.reqLog = reqLog
 	handler.errLog = errLog
-	handlerMap[`instance_r`] = &handler
+	handlerMap.Set(`instance_r`, &handler)
 	go handler.run()
 }
 
@@ -824,7 +879,7 @@ func spawnWorkflowReadHandler(appLog, reqLog, errLog *log.Logger) {
 	handler.appLog = appLog
 	handler.reqLog = reqLog
 	handler.errLog = errLog
-	handlerMap[`workflow_r`] = &handler
+	handlerMap.Set(`workflow_r`, &handler)
 	go handler.run()
 }
 
@@ -836,7 +891,7 @@ func spawnWorkflowWriteHandler(appLog, reqLog, errLog *log.Logger) {
 	handler.appLog = appLog
 	handler.reqLog = reqLog
 	handler.errLog = errLog
-	handlerMap[`workflow_w`] = &handler
+	handlerMap.Set(`workflow_w`, &handler)
 	go handler.run()
 }
 
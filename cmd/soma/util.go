@@ -1,6 +1,7 @@
 package main
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -176,6 +177,12 @@ func DispatchOctetReply(w *http.ResponseWriter, b *[]byte) {
 	(*w).Write(*b)
 }
 
+func DispatchPrometheusReply(w *http.ResponseWriter, b *[]byte) {
+	(*w).Header().Set("Content-Type", `text/plain; version=0.0.4`)
+	(*w).WriteHeader(http.StatusOK)
+	(*w).Write(*b)
+}
+
 func GetPropertyTypeFromUrl(u *url.URL) (string, error) {
 	// strip surrounding / and skip first path element `property|filter`
 	el := strings.Split(strings.Trim(u.Path, "/"), "/")[1:]
@@ -217,4 +224,31 @@ func extractAddress(str string) string {
 	return addr
 }
 
+// marshalPropertySchema returns s as argument for a nullable jsonb
+// column
+func marshalPropertySchema(s *proto.PropertySchema) (interface{}, error) {
+	if s == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// unmarshalPropertySchema returns the schema read from a nullable
+// jsonb column
+func unmarshalPropertySchema(raw sql.NullString) (*proto.PropertySchema,
+	error) {
+	if !raw.Valid {
+		return nil, nil
+	}
+	s := &proto.PropertySchema{}
+	if err := json.Unmarshal([]byte(raw.String), s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/1and1/soma/internal/adm"
+	"github.com/1and1/soma/internal/help"
+	"github.com/codegangsta/cli"
+)
+
+func registerApply(app cli.App) *cli.App {
+	app.Commands = append(app.Commands,
+		[]cli.Command{
+			{
+				Name:        "apply",
+				Usage:       "Apply a repository manifest",
+				Description: help.Text(`Apply`),
+				Action:      runtime(cmdApply),
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "Manifest file to apply (JSON, YAML or UCL)",
+					},
+					cli.BoolFlag{
+						Name:  "plan, p",
+						Usage: "Only print the required changes",
+					},
+				},
+			},
+		}...,
+	)
+	return &app
+}
+
+func cmdApply(c *cli.Context) error {
+	if err := adm.VerifyNoArgument(c); err != nil {
+		return err
+	}
+	if c.String(`file`) == `` {
+		return fmt.Errorf(`Missing manifest file, use --file`)
+	}
+
+	manifest, err := adm.LoadManifest(c.String(`file`))
+	if err != nil {
+		return err
+	}
+	plan, err := adm.ComputePlan(manifest)
+	if err != nil {
+		return err
+	}
+
+	plan.Print(os.Stdout)
+	if c.Bool(`plan`) || plan.Empty() {
+		return nil
+	}
+	return plan.Apply(os.Stdout)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -23,6 +23,12 @@ func registerChecks(app cli.App) *cli.App {
 						Description:  help.Text(`ChecksCreate`),
 						Action:       runtime(cmdCheckAdd),
 						BashComplete: cmpl.CheckAdd,
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "dryrun, n",
+								Usage: "Show the resulting tree changes without applying them",
+							},
+						},
 					},
 					{
 						Name:         `delete`,
@@ -31,6 +37,19 @@ func registerChecks(app cli.App) *cli.App {
 						Action:       runtime(cmdCheckDelete),
 						BashComplete: cmpl.In,
 					},
+					{
+						Name:         `update`,
+						Usage:        "Update a check configuration",
+						Description:  help.Text(`ChecksUpdate`),
+						Action:       runtime(cmdCheckUpdate),
+						BashComplete: cmpl.CheckAdd,
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "dryrun, n",
+								Usage: "Show the resulting tree changes without applying them",
+							},
+						},
+					},
 					{
 						Name:         "list",
 						Usage:        "List check configurations",
@@ -53,6 +72,36 @@ func registerChecks(app cli.App) *cli.App {
 }
 
 func cmdCheckAdd(c *cli.Context) error {
+	req, err := checkConfigFromArgs(c)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/checks/%s/", req.CheckConfig.RepositoryId)
+	return adm.Perform(`postbody`, path, `command`, req, nil)
+}
+
+func cmdCheckUpdate(c *cli.Context) error {
+	req, err := checkConfigFromArgs(c)
+	if err != nil {
+		return err
+	}
+	if req.CheckConfig.Id, _, err = adm.LookupCheckConfigId(
+		req.CheckConfig.Name,
+		req.CheckConfig.RepositoryId,
+		``,
+	); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/checks/%s/%s", req.CheckConfig.RepositoryId,
+		req.CheckConfig.Id)
+	return adm.Perform(`putbody`, path, `command`, req, nil)
+}
+
+// checkConfigFromArgs builds a check configuration request from the
+// command line arguments shared by checks create and checks update
+func checkConfigFromArgs(c *cli.Context) (proto.Request, error) {
 	var (
 		err    error
 		teamId string
@@ -60,6 +109,7 @@ func cmdCheckAdd(c *cli.Context) error {
 	opts := make(map[string][]string)
 	constraints := []proto.CheckConfigConstraint{}
 	thresholds := []proto.CheckConfigThreshold{}
+	req := proto.NewCheckConfigRequest()
 
 	if err = adm.ParseVariadicCheckArguments(
 		opts,
@@ -67,37 +117,36 @@ func cmdCheckAdd(c *cli.Context) error {
 		thresholds,
 		c.Args().Tail(),
 	); err != nil {
-		return err
+		return req, err
 	}
 
-	req := proto.NewCheckConfigRequest()
 	if err = adm.ValidateLBoundUint64(opts[`interval`][0],
 		&req.CheckConfig.Interval, 1); err != nil {
-		return err
+		return req, err
 	}
 	if err = adm.ValidateRuneCount(c.Args().First(), 256); err != nil {
-		return err
+		return req, err
 	}
 	if req.CheckConfig.CapabilityId, err = adm.LookupCapabilityId(
 		opts[`with`][0]); err != nil {
-		return err
+		return req, err
 	}
 	req.CheckConfig.ObjectType = opts[`on/type`][0]
 	req.CheckConfig.Name = c.Args().First()
 	req.CheckConfig.BucketId, err = adm.LookupBucketId(opts[`in`][0])
 	if err != nil {
-		return err
+		return req, err
 	}
 	if req.CheckConfig.RepositoryId, err = adm.LookupRepoByBucket(
 		req.CheckConfig.BucketId); err != nil {
-		return err
+		return req, err
 	}
 	if req.CheckConfig.ObjectId, err = adm.LookupCheckObjectId(
 		opts[`on/type`][0],
 		opts[`on/object`][0],
 		req.CheckConfig.BucketId,
 	); err != nil {
-		return err
+		return req, err
 	}
 
 	// clear bucketid if check is on a repository
@@ -109,7 +158,7 @@ func cmdCheckAdd(c *cli.Context) error {
 	if iv, ok := opts[`inheritance`]; ok {
 		if err = adm.ValidateBool(iv[0],
 			&req.CheckConfig.Inheritance); err != nil {
-			return err
+			return req, err
 		}
 	} else {
 		// inheritance defaults to true
@@ -120,7 +169,7 @@ func cmdCheckAdd(c *cli.Context) error {
 	if co, ok := opts[`childrenonly`]; ok {
 		if err = adm.ValidateBool(co[0],
 			&req.CheckConfig.ChildrenOnly); err != nil {
-			return err
+			return req, err
 		}
 	} else {
 		// childrenonly defaults to false
@@ -130,20 +179,20 @@ func cmdCheckAdd(c *cli.Context) error {
 	// optional argument: extern
 	if ex, ok := opts[`extern`]; ok {
 		if err = adm.ValidateRuneCount(ex[0], 64); err != nil {
-			return err
+			return req, err
 		}
 		req.CheckConfig.ExternalId = ex[0]
 	}
 
 	if teamId, err = adm.LookupTeamByRepo(
 		req.CheckConfig.RepositoryId); err != nil {
-		return err
+		return req, err
 	}
 
 	if req.CheckConfig.Thresholds, err = adm.ValidateThresholds(
 		thresholds,
 	); err != nil {
-		return err
+		return req, err
 	}
 
 	if req.CheckConfig.Constraints, err = adm.ValidateCheckConstraints(
@@ -151,11 +200,11 @@ func cmdCheckAdd(c *cli.Context) error {
 		teamId,
 		constraints,
 	); err != nil {
-		return err
+		return req, err
 	}
 
-	path := fmt.Sprintf("/checks/%s/", req.CheckConfig.RepositoryId)
-	return adm.Perform(`postbody`, path, `command`, req, nil)
+	req.Flags.DryRun = c.Bool(`dryrun`)
+	return req, nil
 }
 
 func cmdCheckDelete(c *cli.Context) error {
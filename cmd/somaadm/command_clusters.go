@@ -204,8 +204,12 @@ func cmdClusterDelete(c *cli.Context) error {
 		return err
 	}
 
+	req := proto.NewClusterRequest()
+	req.Cluster.Id = clusterId
+	req.Cluster.BucketId = bucketId
+
 	path := fmt.Sprintf("/clusters/%s", clusterId)
-	return adm.Perform(`delete`, path, `command`, nil, c)
+	return adm.Perform(`deletebody`, path, `command`, req, c)
 }
 
 func cmdClusterRename(c *cli.Context) error {
@@ -235,6 +239,8 @@ func cmdClusterRename(c *cli.Context) error {
 	}
 
 	req.Cluster = &proto.Cluster{}
+	req.Cluster.Id = clusterId
+	req.Cluster.BucketId = bucketId
 	req.Cluster.Name = opts["to"][0]
 
 	path := fmt.Sprintf("/clusters/%s", clusterId)
@@ -382,9 +388,13 @@ func cmdClusterMemberDelete(c *cli.Context) error {
 		return err
 	}
 
+	req := proto.NewClusterRequest()
+	req.Cluster.Id = clusterId
+	req.Cluster.BucketId = bucketId
+
 	path := fmt.Sprintf("/clusters/%s/members/%s", clusterId,
 		nodeId)
-	return adm.Perform(`delete`, path, `command`, nil, c)
+	return adm.Perform(`deletebody`, path, `command`, req, c)
 }
 
 func cmdClusterMemberList(c *cli.Context) error {
@@ -243,13 +243,12 @@ func cmdGroupDelete(c *cli.Context) error {
 		bucketId); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/groups/%s", groupId)
+	req := proto.NewGroupRequest()
+	req.Group.Id = groupId
+	req.Group.BucketId = bucketId
 
-	if resp, err := adm.DeleteReq(path); err != nil {
-		return err
-	} else {
-		return adm.FormatOut(c, resp, ``)
-	}
+	path := fmt.Sprintf("/groups/%s", groupId)
+	return adm.Perform(`deletebody`, path, `command`, req, c)
 }
 
 func cmdGroupRename(c *cli.Context) error {
@@ -277,8 +276,9 @@ func cmdGroupRename(c *cli.Context) error {
 		return err
 	}
 
-	var req proto.Request
-	req.Group = &proto.Group{}
+	req := proto.NewGroupRequest()
+	req.Group.Id = groupId
+	req.Group.BucketId = bucketId
 	req.Group.Name = opts["to"][0]
 
 	path := fmt.Sprintf("/groups/%s", groupId)
@@ -506,9 +506,13 @@ func cmdGroupMemberDeleteGroup(c *cli.Context) error {
 		return err
 	}
 
-	path := fmt.Sprintf("/groups/%s/members/%s", groupId,
+	req := proto.NewGroupRequest()
+	req.Group.Id = groupId
+	req.Group.BucketId = bucketId
+
+	path := fmt.Sprintf("/groups/%s/members/group/%s", groupId,
 		mGroupId)
-	return adm.Perform(`delete`, path, `command`, nil, c)
+	return adm.Perform(`deletebody`, path, `command`, req, c)
 }
 
 func cmdGroupMemberDeleteCluster(c *cli.Context) error {
@@ -540,9 +544,13 @@ func cmdGroupMemberDeleteCluster(c *cli.Context) error {
 		return err
 	}
 
-	path := fmt.Sprintf("/groups/%s/members/%s", groupId,
+	req := proto.NewGroupRequest()
+	req.Group.Id = groupId
+	req.Group.BucketId = bucketId
+
+	path := fmt.Sprintf("/groups/%s/members/cluster/%s", groupId,
 		mClusterId)
-	return adm.Perform(`delete`, path, `command`, nil, c)
+	return adm.Perform(`deletebody`, path, `command`, req, c)
 }
 
 func cmdGroupMemberDeleteNode(c *cli.Context) error {
@@ -573,9 +581,13 @@ func cmdGroupMemberDeleteNode(c *cli.Context) error {
 		return err
 	}
 
-	path := fmt.Sprintf("/groups/%s/members/%s", groupId,
+	req := proto.NewGroupRequest()
+	req.Group.Id = groupId
+	req.Group.BucketId = bucketId
+
+	path := fmt.Sprintf("/groups/%s/members/node/%s", groupId,
 		mNodeId)
-	return adm.Perform(`delete`, path, `command`, nil, c)
+	return adm.Perform(`deletebody`, path, `command`, req, c)
 }
 
 func cmdGroupMemberList(c *cli.Context) error {
@@ -27,6 +27,11 @@ func registerJobs(app cli.App) *cli.App {
 						Usage:  `Show details about a job (remote)`,
 						Action: runtime(cmdJobShow),
 					},
+					{
+						Name:   `cancel`,
+						Usage:  `Cancel a queued job (remote)`,
+						Action: runtime(cmdJobCancel),
+					},
 					{
 						Name:  `local`,
 						Usage: `SUBCOMMANDS for locally saved jobs`,
@@ -88,6 +93,20 @@ func cmdJobShow(c *cli.Context) error {
 	return adm.Perform(`get`, path, `show`, nil, c)
 }
 
+func cmdJobCancel(c *cli.Context) error {
+	if err := adm.VerifySingleArgument(c); err != nil {
+		return err
+	}
+
+	if !adm.IsUUID(c.Args().First()) {
+		return fmt.Errorf("Argument is not a UUID: %s",
+			c.Args().First())
+	}
+
+	path := fmt.Sprintf("/jobs/%s", c.Args().First())
+	return adm.Perform(`delete`, path, `command`, nil, c)
+}
+
 func cmdJobLocalOutstanding(c *cli.Context) error {
 	jobs, err := store.ActiveJobs()
 	if err != nil && err != bolt.ErrBucketNotFound {
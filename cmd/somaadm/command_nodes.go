@@ -90,6 +90,12 @@ func registerNodes(app cli.App) *cli.App {
 						Action:       runtime(cmdNodeAssign),
 						BashComplete: cmpl.To,
 					},
+					{
+						Name:         "move",
+						Usage:        "Move an assigned node to a different bucket",
+						Action:       runtime(cmdNodeMoveBucket),
+						BashComplete: cmpl.To,
+					},
 					{
 						Name:   "list",
 						Usage:  "List all nodes",
@@ -523,6 +529,57 @@ func cmdNodeAssign(c *cli.Context) error {
 	return adm.Perform(`putbody`, path, `command`, req, c)
 }
 
+func cmdNodeMoveBucket(c *cli.Context) error {
+	multiple := []string{}
+	unique := []string{"to"}
+	required := []string{"to"}
+
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		multiple,
+		unique,
+		required,
+		c.Args().Tail(),
+	); err != nil {
+		return err
+	}
+	var (
+		err                      error
+		bucketId, repoId, nodeId string
+		bucketTId, nodeTId       string
+	)
+	if bucketId, err = adm.LookupBucketId(opts["to"][0]); err != nil {
+		return err
+	}
+	if repoId, err = adm.LookupRepoByBucket(bucketId); err != nil {
+		return err
+	}
+	if nodeId, err = adm.LookupNodeId(c.Args().First()); err != nil {
+		return err
+	}
+	if bucketTId, err = adm.LookupTeamByBucket(bucketId); err != nil {
+		return err
+	}
+	if nodeTId, err = adm.LookupTeamByNode(nodeId); err != nil {
+		return err
+	}
+	if bucketTId != nodeTId {
+		return fmt.Errorf(
+			`Cannot move node since node and bucket belong to` +
+				` different teams.`)
+	}
+
+	req := proto.NewNodeRequest()
+	req.Node.Id = nodeId
+	req.Node.Config = &proto.NodeConfig{}
+	req.Node.Config.RepositoryId = repoId
+	req.Node.Config.BucketId = bucketId
+
+	path := fmt.Sprintf("/nodes/%s/config", nodeId)
+	return adm.Perform(`patchbody`, path, `command`, req, c)
+}
+
 func cmdNodeList(c *cli.Context) error {
 	if err := adm.VerifyNoArgument(c); err != nil {
 		return err
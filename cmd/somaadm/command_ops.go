@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 
 	"gopkg.in/resty.v0"
@@ -63,6 +64,26 @@ func registerOps(app cli.App) *cli.App {
 								Description: help.Text(`OpsRepositoryRestart`),
 								Action:      runtime(cmdOpsRepoRestart),
 							},
+							{
+								Name:         `export`,
+								Usage:        `Export a repository into a file`,
+								Description:  help.Text(`OpsRepositoryExport`),
+								Action:       runtime(cmdOpsRepoExport),
+								BashComplete: cmpl.OpsRepoExport,
+							},
+							{
+								Name:         `import`,
+								Usage:        `Import a repository export into an empty repository`,
+								Description:  help.Text(`OpsRepositoryImport`),
+								Action:       runtime(cmdOpsRepoImport),
+								BashComplete: cmpl.OpsRepoImport,
+								Flags: []cli.Flag{
+									cli.BoolFlag{
+										Name:  `keep-instance-ids, k`,
+										Usage: `Keep the exported check instance IDs`,
+									},
+								},
+							},
 						},
 					},
 					// -> settings loglevel/opendoor/...
@@ -284,6 +305,62 @@ func cmdOpsRepoRebuild(c *cli.Context) error {
 	return cmdOpsRepo(c, req)
 }
 
+func cmdOpsRepoExport(c *cli.Context) error {
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		[]string{},     // more than once
+		[]string{`to`}, // at most once
+		[]string{`to`}, // at least once
+		c.Args().Tail()); err != nil {
+		return err
+	}
+
+	repoId, err := adm.LookupRepoId(c.Args().First())
+	if err != nil {
+		return err
+	}
+	export, err := adm.ExportRepository(repoId)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(export, ``, `  `)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(opts[`to`][0], data, 0600); err != nil {
+		return err
+	}
+	fmt.Printf("Exported repository %s with %d queries to %s\n",
+		export.RepositoryName, len(export.Queries), opts[`to`][0])
+	return nil
+}
+
+func cmdOpsRepoImport(c *cli.Context) error {
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		[]string{},       // more than once
+		[]string{`from`}, // at most once
+		[]string{`from`}, // at least once
+		c.Args().Tail()); err != nil {
+		return err
+	}
+
+	export, err := adm.LoadRepositoryExport(opts[`from`][0])
+	if err != nil {
+		return err
+	}
+
+	req := proto.NewSystemOperationRequest()
+	req.SystemOperation.Request = `import_repository`
+	req.SystemOperation.KeepInstanceIds = c.Bool(`keep-instance-ids`)
+	req.SystemOperation.Export = export
+
+	return cmdOpsRepo(c, req)
+}
+
 func cmdOpsRepo(c *cli.Context, req proto.Request) error {
 
 	// lookup requested repository
@@ -31,9 +31,10 @@ func registerProperty(app cli.App) *cli.App {
 								BashComplete: comptime(bashCompSvcCreate),
 							},
 							{
-								Name:   "system",
-								Usage:  "Create a new global system property",
-								Action: runtime(cmdPropertySystemCreate),
+								Name:         "system",
+								Usage:        "Create a new global system property",
+								Action:       runtime(cmdPropertySystemCreate),
+								BashComplete: cmpl.PropertySchema,
 							},
 							{
 								Name:   "native",
@@ -44,7 +45,7 @@ func registerProperty(app cli.App) *cli.App {
 								Name:         "custom",
 								Usage:        "Create a new per-repo custom property",
 								Action:       runtime(cmdPropertyCustomCreate),
-								BashComplete: cmpl.Repository,
+								BashComplete: cmpl.RepositoryPropertySchema,
 							},
 							{
 								Name:   "template",
@@ -53,6 +54,24 @@ func registerProperty(app cli.App) *cli.App {
 							},
 						},
 					}, // end property create
+					{
+						Name:  "update",
+						Usage: "SUBCOMMANDS for property update",
+						Subcommands: []cli.Command{
+							{
+								Name:         "system",
+								Usage:        "Update the value schema of a system property",
+								Action:       runtime(cmdPropertySystemUpdate),
+								BashComplete: cmpl.PropertySchema,
+							},
+							{
+								Name:         "custom",
+								Usage:        "Update the value schema of a custom property",
+								Action:       runtime(cmdPropertyCustomUpdate),
+								BashComplete: cmpl.RepositoryPropertySchema,
+							},
+						},
+					}, // end property update
 					{
 						Name:  "delete",
 						Usage: "SUBCOMMANDS for property delete",
@@ -162,8 +181,9 @@ func registerProperty(app cli.App) *cli.App {
 /* CREATE
  */
 func cmdPropertyCustomCreate(c *cli.Context) error {
-	multiple := []string{}
-	unique := []string{"repository"}
+	multiple := []string{"value"}
+	unique := []string{"repository", "type", "minimum", "maximum",
+		"pattern"}
 	required := []string{"repository"}
 
 	opts := map[string][]string{}
@@ -187,13 +207,27 @@ func cmdPropertyCustomCreate(c *cli.Context) error {
 	req.Property.Custom = &proto.PropertyCustom{}
 	req.Property.Custom.Name = c.Args().First()
 	req.Property.Custom.RepositoryId = repoId
+	req.Property.Custom.Schema = propertySchemaFromOpts(opts)
 
 	path := fmt.Sprintf("/property/custom/%s/", repoId)
 	return adm.Perform(`postbody`, path, `command`, req, c)
 }
 
 func cmdPropertySystemCreate(c *cli.Context) error {
-	if err := adm.VerifySingleArgument(c); err != nil {
+	multiple := []string{"value"}
+	unique := []string{"type", "minimum", "maximum", "pattern"}
+	required := []string{}
+
+	if !c.Args().Present() {
+		return fmt.Errorf(`Syntax error, command requires argument`)
+	}
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		multiple,
+		unique,
+		required,
+		c.Args().Tail()); err != nil {
 		return err
 	}
 
@@ -203,6 +237,7 @@ func cmdPropertySystemCreate(c *cli.Context) error {
 
 	req.Property.System = &proto.PropertySystem{}
 	req.Property.System.Name = c.Args().First()
+	req.Property.System.Schema = propertySchemaFromOpts(opts)
 
 	return adm.Perform(`postbody`, `/property/system/`, `command`, req, c)
 }
@@ -380,6 +415,101 @@ func bashCompSvcCreate(c *cli.Context) {
 	cmpl.GenericMulti(c, unique, multiple)
 }
 
+/* UPDATE
+ */
+func cmdPropertyCustomUpdate(c *cli.Context) error {
+	multiple := []string{"value"}
+	unique := []string{"repository", "type", "minimum", "maximum",
+		"pattern"}
+	required := []string{"repository"}
+
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		multiple,
+		unique,
+		required,
+		c.Args().Tail()); err != nil {
+		return err
+	}
+
+	repoId, err := adm.LookupRepoId(opts[`repository`][0])
+	if err != nil {
+		return err
+	}
+
+	propId, err := adm.LookupCustomPropertyId(
+		c.Args().First(), repoId)
+	if err != nil {
+		return err
+	}
+
+	req := proto.Request{}
+	req.Property = &proto.Property{}
+	req.Property.Type = "custom"
+
+	req.Property.Custom = &proto.PropertyCustom{}
+	req.Property.Custom.Id = propId
+	req.Property.Custom.Name = c.Args().First()
+	req.Property.Custom.RepositoryId = repoId
+	req.Property.Custom.Schema = propertySchemaFromOpts(opts)
+
+	path := fmt.Sprintf("/property/custom/%s/%s", repoId, propId)
+	return adm.Perform(`putbody`, path, `command`, req, c)
+}
+
+func cmdPropertySystemUpdate(c *cli.Context) error {
+	multiple := []string{"value"}
+	unique := []string{"type", "minimum", "maximum", "pattern"}
+	required := []string{}
+
+	if !c.Args().Present() {
+		return fmt.Errorf(`Syntax error, command requires argument`)
+	}
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		multiple,
+		unique,
+		required,
+		c.Args().Tail()); err != nil {
+		return err
+	}
+
+	req := proto.Request{}
+	req.Property = &proto.Property{}
+	req.Property.Type = "system"
+
+	req.Property.System = &proto.PropertySystem{}
+	req.Property.System.Name = c.Args().First()
+	req.Property.System.Schema = propertySchemaFromOpts(opts)
+
+	path := fmt.Sprintf("/property/system/%s", c.Args().First())
+	return adm.Perform(`putbody`, path, `command`, req, c)
+}
+
+// propertySchemaFromOpts returns the value schema specified in opts,
+// or nil if no schema type was given
+func propertySchemaFromOpts(opts map[string][]string) *proto.PropertySchema {
+	if _, ok := opts[`type`]; !ok {
+		return nil
+	}
+	schema := &proto.PropertySchema{
+		Type:   opts[`type`][0],
+		Values: opts[`value`],
+	}
+	if _, ok := opts[`minimum`]; ok {
+		schema.Minimum = opts[`minimum`][0]
+	}
+	if _, ok := opts[`maximum`]; ok {
+		schema.Maximum = opts[`maximum`][0]
+	}
+	if _, ok := opts[`pattern`]; ok {
+		schema.Pattern = opts[`pattern`][0]
+	}
+	return schema
+}
+
 /* DELETE
  */
 func cmdPropertyCustomDelete(c *cli.Context) error {
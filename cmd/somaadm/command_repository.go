@@ -289,7 +289,7 @@ func cmdRepositoryRepossess(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	teamId, err := adm.LookupTeamId(opts[`team`][0])
+	teamId, err := adm.LookupTeamId(opts[`to`][0])
 	if err != nil {
 		return err
 	}
@@ -17,6 +17,7 @@ func registerCommands(app cli.App) *cli.App {
 		},
 	}
 
+	app = *registerApply(app)
 	app = *registerAttributes(app)
 	app = *registerBuckets(app)
 	app = *registerCapability(app)
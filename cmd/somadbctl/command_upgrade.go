@@ -31,6 +31,17 @@ var UpgradeVersions = map[string]map[int]func(int, string, bool) int{
 		201609080001: upgrade_soma_to_201609120001,
 		201609120001: upgrade_soma_to_201610290001,
 		201610290001: upgrade_soma_to_201611060001,
+		201611060001: upgrade_soma_to_201611130001,
+		201611130001: upgrade_soma_to_201611140001,
+		201611140001: upgrade_soma_to_201611150001,
+		201611150001: upgrade_soma_to_201611160001,
+		201611160001: upgrade_soma_to_201611170001,
+		201611170001: upgrade_soma_to_201611180001,
+		201611180001: upgrade_soma_to_201611190001,
+		201611190001: upgrade_soma_to_201611200001,
+		201611200001: upgrade_soma_to_201611210001,
+		201611210001: upgrade_soma_to_201611220001,
+		201611220001: upgrade_soma_to_201611230001,
 	},
 	"root": map[int]func(int, string, bool) int{
 		000000000001: install_root_201605150001,
@@ -409,6 +420,178 @@ func upgrade_soma_to_201611060001(curr int, tool string, printOnly bool) int {
 	return 201611060001
 }
 
+func upgrade_soma_to_201611130001(curr int, tool string, printOnly bool) int {
+	if curr != 201611060001 {
+		return 0
+	}
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS soma.authorizations_team ( grant_id uuid PRIMARY KEY, user_id uuid REFERENCES inventory.users ( user_id ) DEFERRABLE, tool_id uuid REFERENCES auth.tools ( tool_id ) DEFERRABLE, organizational_team_id uuid REFERENCES inventory.organizational_teams ( organizational_team_id ) DEFERRABLE, authorized_team_id uuid NOT NULL REFERENCES inventory.organizational_teams ( organizational_team_id ) DEFERRABLE, permission_id uuid NOT NULL REFERENCES soma.permissions ( permission_id ) DEFERRABLE, permission_type varchar(32) NOT NULL REFERENCES soma.permission_types ( permission_type ) DEFERRABLE, created_by uuid NOT NULL REFERENCES inventory.users ( user_id ) DEFERRABLE, created_at timestamptz(3) NOT NULL DEFAULT NOW(), FOREIGN KEY ( permission_id, permission_type ) REFERENCES soma.permissions ( permission_id, permission_type ) DEFERRABLE, CHECK (( user_id IS NOT NULL AND tool_id IS NULL AND organizational_team_id IS NULL ) OR ( user_id IS NULL AND tool_id IS NOT NULL AND organizational_team_id IS NULL ) OR ( user_id IS NULL AND tool_id IS NULL AND organizational_team_id IS NOT NULL )), CHECK ( permission_type = 'limited' ));`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611130001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611130001
+}
+
+func upgrade_soma_to_201611140001(curr int, tool string, printOnly bool) int {
+	if curr != 201611130001 {
+		return 0
+	}
+	stmts := []string{
+		`INSERT INTO soma.job_types ( job_type ) VALUES ( 'delete_bucket' ), ( 'restore_bucket' ), ( 'purge_bucket' ), ( 'freeze_bucket' ), ( 'thaw_bucket' ), ( 'rename_bucket' );`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611140001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611140001
+}
+
+func upgrade_soma_to_201611150001(curr int, tool string, printOnly bool) int {
+	if curr != 201611140001 {
+		return 0
+	}
+	stmts := []string{
+		`INSERT INTO soma.job_types ( job_type ) VALUES ( 'delete_repository' ), ( 'restore_repository' ), ( 'purge_repository' ), ( 'clear_repository' ), ( 'rename_repository' ), ( 'repossess_repository' ), ( 'activate_repository' );`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611150001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611150001
+}
+
+func upgrade_soma_to_201611160001(curr int, tool string, printOnly bool) int {
+	if curr != 201611150001 {
+		return 0
+	}
+	stmts := []string{
+		`INSERT INTO soma.job_types ( job_type ) VALUES ( 'delete_group' ), ( 'rename_group' ), ( 'remove_group_from_group' ), ( 'remove_cluster_from_group' ), ( 'remove_node_from_group' ), ( 'delete_cluster' ), ( 'rename_cluster' ), ( 'remove_node_from_cluster' );`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611160001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611160001
+}
+
+func upgrade_soma_to_201611170001(curr int, tool string, printOnly bool) int {
+	if curr != 201611160001 {
+		return 0
+	}
+	stmts := []string{
+		`INSERT INTO soma.job_types ( job_type ) VALUES ( 'update_check' );`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611170001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611170001
+}
+
+func upgrade_soma_to_201611180001(curr int, tool string, printOnly bool) int {
+	if curr != 201611170001 {
+		return 0
+	}
+	stmts := []string{
+		`INSERT INTO soma.job_types ( job_type ) VALUES ( 'relocate_node' );`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611180001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611180001
+}
+
+func upgrade_soma_to_201611190001(curr int, tool string, printOnly bool) int {
+	if curr != 201611180001 {
+		return 0
+	}
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS soma.audit_log ( audit_id uuid PRIMARY KEY, audit_time timestamptz(3) NOT NULL DEFAULT NOW()::timestamptz(3), user_uid varchar(256) NOT NULL, remote_address varchar(64) NOT NULL, http_method varchar(16) NOT NULL, route text NOT NULL, request_path text NOT NULL, permissions varchar(128)[] NOT NULL DEFAULT '{}', authorized boolean, status_code integer NOT NULL, request_body text NOT NULL DEFAULT '' );`,
+		`CREATE INDEX _audit_by_user ON soma.audit_log ( user_uid, audit_time );`,
+		`CREATE INDEX _audit_by_time ON soma.audit_log ( audit_time );`,
+		`GRANT SELECT, INSERT ON soma.audit_log TO soma_svc;`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611190001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611190001
+}
+
+func upgrade_soma_to_201611200001(curr int, tool string, printOnly bool) int {
+	if curr != 201611190001 {
+		return 0
+	}
+	stmts := []string{
+		`INSERT INTO soma.job_results ( job_result ) VALUES ( 'cancelled' );`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611200001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611200001
+}
+
+func upgrade_soma_to_201611210001(curr int, tool string, printOnly bool) int {
+	if curr != 201611200001 {
+		return 0
+	}
+	stmts := []string{
+		`ALTER TABLE soma.constraints_custom_property ADD COLUMN constraint_operator varchar(16) NOT NULL DEFAULT '';`,
+		`ALTER TABLE soma.constraints_system_property ADD COLUMN constraint_operator varchar(16) NOT NULL DEFAULT '';`,
+		`ALTER TABLE soma.constraints_native_property ADD COLUMN constraint_operator varchar(16) NOT NULL DEFAULT '';`,
+		`ALTER TABLE soma.constraints_service_attribute ADD COLUMN constraint_operator varchar(16) NOT NULL DEFAULT '';`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611210001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611210001
+}
+
+func upgrade_soma_to_201611220001(curr int, tool string, printOnly bool) int {
+	if curr != 201611210001 {
+		return 0
+	}
+	stmts := []string{
+		`ALTER TABLE soma.system_properties ADD COLUMN value_schema jsonb;`,
+		`ALTER TABLE soma.custom_properties ADD COLUMN value_schema jsonb;`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611220001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611220001
+}
+
+func upgrade_soma_to_201611230001(curr int, tool string, printOnly bool) int {
+	if curr != 201611220001 {
+		return 0
+	}
+	stmts := []string{
+		`INSERT INTO soma.job_types ( job_type ) VALUES ( 'update_system_property_on_repository' ), ( 'update_system_property_on_bucket' ), ( 'update_system_property_on_group' ), ( 'update_system_property_on_cluster' ), ( 'update_system_property_on_node' ), ( 'update_custom_property_on_repository' ), ( 'update_custom_property_on_bucket' ), ( 'update_custom_property_on_group' ), ( 'update_custom_property_on_cluster' ), ( 'update_custom_property_on_node' ), ( 'update_oncall_property_on_repository' ), ( 'update_oncall_property_on_bucket' ), ( 'update_oncall_property_on_group' ), ( 'update_oncall_property_on_cluster' ), ( 'update_oncall_property_on_node' ), ( 'update_service_property_on_repository' ), ( 'update_service_property_on_bucket' ), ( 'update_service_property_on_group' ), ( 'update_service_property_on_cluster' ), ( 'update_service_property_on_node' );`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 201611230001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 201611230001
+}
+
 func install_root_201605150001(curr int, tool string, printOnly bool) int {
 	if curr != 000000000001 {
 		return 0
@@ -0,0 +1,45 @@
+package main
+
+func createTablesAudit(printOnly bool, verbose bool) {
+	idx := 0
+	// map for storing the SQL statements by name
+	queryMap := make(map[string]string)
+	// slice storing the required statement order so foreign keys can
+	// resolve successfully
+	queries := make([]string, 5)
+
+	queryMap["createTableAuditLog"] = `
+create table if not exists soma.audit_log (
+    audit_id                    uuid            PRIMARY KEY,
+    audit_time                  timestamptz(3)  NOT NULL DEFAULT NOW()::timestamptz(3),
+    user_uid                    varchar(256)    NOT NULL,
+    remote_address              varchar(64)     NOT NULL,
+    http_method                 varchar(16)     NOT NULL,
+    route                       text            NOT NULL,
+    request_path                text            NOT NULL,
+    permissions                 varchar(128)[]  NOT NULL DEFAULT '{}',
+    authorized                  boolean,
+    status_code                 integer         NOT NULL,
+    request_body                text            NOT NULL DEFAULT ''
+);`
+	queries[idx] = "createTableAuditLog"
+	idx++
+
+	queryMap["createIndexAuditByUser"] = `
+create index _audit_by_user
+    on soma.audit_log ( user_uid, audit_time )
+;`
+	queries[idx] = "createIndexAuditByUser"
+	idx++
+
+	queryMap["createIndexAuditByTime"] = `
+create index _audit_by_time
+    on soma.audit_log ( audit_time )
+;`
+	queries[idx] = "createIndexAuditByTime"
+	idx++
+
+	performDatabaseTask(printOnly, verbose, queries, queryMap)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -142,6 +142,7 @@ create table if not exists soma.constraints_custom_property (
     custom_property_id          uuid            NOT NULL REFERENCES soma.custom_properties ( custom_property_id ) DEFERRABLE,
     repository_id               uuid            NOT NULL REFERENCES soma.repositories ( repository_id ) DEFERRABLE,
     property_value              text            NOT NULL,
+    constraint_operator         varchar(16)     NOT NULL DEFAULT '',
     -- ensure this custom property is defined for this repository
     FOREIGN KEY ( repository_id, custom_property_id ) REFERENCES soma.custom_properties ( repository_id, custom_property_id ) DEFERRABLE,
     -- ensure the configuration_id is for the repository the custom property is defined in
@@ -154,7 +155,8 @@ create table if not exists soma.constraints_custom_property (
 create table if not exists soma.constraints_system_property (
     configuration_id            uuid            NOT NULL REFERENCES soma.check_configurations ( configuration_id ) DEFERRABLE,
     system_property             varchar(128)    NOT NULL REFERENCES soma.system_properties ( system_property ) DEFERRABLE,
-    property_value              text            NOT NULL
+    property_value              text            NOT NULL,
+    constraint_operator         varchar(16)     NOT NULL DEFAULT ''
 );`
 	queries[idx] = "createTableCheckConstraintsSystemProperty"
 	idx++
@@ -163,7 +165,8 @@ create table if not exists soma.constraints_system_property (
 create table if not exists soma.constraints_native_property (
     configuration_id            uuid            NOT NULL REFERENCES soma.check_configurations ( configuration_id ) DEFERRABLE,
     native_property             varchar(128)    NOT NULL REFERENCES soma.native_properties ( native_property ) DEFERRABLE,
-    property_value              text            NOT NULL
+    property_value              text            NOT NULL,
+    constraint_operator         varchar(16)     NOT NULL DEFAULT ''
 );`
 	queries[idx] = "createTableCheckConstraintsNativeProperty"
 	idx++
@@ -182,7 +185,8 @@ create table if not exists soma.constraints_service_property (
 create table if not exists soma.constraints_service_attribute (
     configuration_id            uuid            NOT NULL REFERENCES soma.check_configurations ( configuration_id ) DEFERRABLE,
     service_property_attribute  varchar(128)    NOT NULL REFERENCES soma.service_property_attributes ( service_property_attribute ) DEFERRABLE,
-    attribute_value             varchar(512)
+    attribute_value             varchar(512),
+    constraint_operator         varchar(16)     NOT NULL DEFAULT ''
 );`
 	queries[idx] = "createTableCheckConstraintsServiceAttributes"
 	idx++
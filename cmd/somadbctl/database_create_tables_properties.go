@@ -54,7 +54,8 @@ create table if not exists soma.team_service_property_values (
 
 	queryMap["createTableSystemProperties"] = `
 create table if not exists soma.system_properties (
-    system_property             varchar(128)    PRIMARY KEY
+    system_property             varchar(128)    PRIMARY KEY,
+    value_schema                jsonb
 );`
 	queries[idx] = "createTableSystemProperties"
 	idx++
@@ -92,6 +93,7 @@ create table if not exists soma.custom_properties (
     custom_property_id          uuid            PRIMARY KEY,
     repository_id               uuid            NOT NULL REFERENCES soma.repositories ( repository_id ) DEFERRABLE,
     custom_property             varchar(128)    NOT NULL,
+    value_schema                jsonb,
     UNIQUE( repository_id, custom_property ),
     UNIQUE( repository_id, custom_property_id )
 );`
@@ -12,6 +12,11 @@ func grantPermissions(printOnly bool, verbose bool) {
 	queries[idx] = "grantServiceUserSchemaSoma"
 	idx++
 
+	// the audit log is append-only for the service user
+	queryMap["revokeServiceUserAuditLog"] = `revoke update, delete on soma.audit_log from soma_svc;`
+	queries[idx] = "revokeServiceUserAuditLog"
+	idx++
+
 	queryMap["grantServiceUserSequencesSoma"] = `grant usage, select on all sequences in schema soma to soma_svc;`
 	queries[idx] = "grantServiceUserSequencesSoma"
 	idx++
@@ -112,7 +112,8 @@ INSERT INTO soma.job_results (
 ) VALUES
             ( 'pending' ),
             ( 'success' ),
-            ( 'failed' )
+            ( 'failed' ),
+            ( 'cancelled' )
 ;`
 	queries[idx] = "insertJobResults"
 	idx++
@@ -121,6 +122,7 @@ INSERT INTO soma.job_results (
 INSERT INTO soma.job_types (
             job_type
 ) VALUES
+            ( 'activate_repository' ),
             ( 'add_check_to_bucket' ),
             ( 'add_check_to_cluster' ),
             ( 'add_check_to_group' ),
@@ -151,19 +153,24 @@ INSERT INTO soma.job_types (
             ( 'add_system_property_to_node' ),
             ( 'add_system_property_to_repository' ),
             ( 'assign_node' ),
+            ( 'clear_repository' ),
             ( 'create_bucket' ),
             ( 'create_cluster' ),
             ( 'create_group' ),
+            ( 'delete_bucket' ),
+            ( 'delete_cluster' ),
             ( 'delete_custom_property_from_bucket' ),
             ( 'delete_custom_property_from_cluster' ),
             ( 'delete_custom_property_from_group' ),
             ( 'delete_custom_property_from_node' ),
             ( 'delete_custom_property_from_repository' ),
+            ( 'delete_group' ),
             ( 'delete_oncall_property_from_bucket' ),
             ( 'delete_oncall_property_from_cluster' ),
             ( 'delete_oncall_property_from_group' ),
             ( 'delete_oncall_property_from_node' ),
             ( 'delete_oncall_property_from_repository' ),
+            ( 'delete_repository' ),
             ( 'delete_service_property_from_bucket' ),
             ( 'delete_service_property_from_cluster' ),
             ( 'delete_service_property_from_group' ),
@@ -174,11 +181,48 @@ INSERT INTO soma.job_types (
             ( 'delete_system_property_from_group' ),
             ( 'delete_system_property_from_node' ),
             ( 'delete_system_property_from_repository' ),
+            ( 'freeze_bucket' ),
+            ( 'purge_bucket' ),
+            ( 'purge_repository' ),
+            ( 'relocate_node' ),
             ( 'remove_check_from_bucket' ),
             ( 'remove_check_from_cluster' ),
             ( 'remove_check_from_group' ),
             ( 'remove_check_from_node' ),
-            ( 'remove_check_from_repository' )
+            ( 'remove_check_from_repository' ),
+            ( 'remove_cluster_from_group' ),
+            ( 'remove_group_from_group' ),
+            ( 'remove_node_from_cluster' ),
+            ( 'remove_node_from_group' ),
+            ( 'rename_bucket' ),
+            ( 'rename_cluster' ),
+            ( 'rename_group' ),
+            ( 'rename_repository' ),
+            ( 'repossess_repository' ),
+            ( 'restore_bucket' ),
+            ( 'restore_repository' ),
+            ( 'thaw_bucket' ),
+            ( 'update_check' ),
+            ( 'update_custom_property_on_bucket' ),
+            ( 'update_custom_property_on_cluster' ),
+            ( 'update_custom_property_on_group' ),
+            ( 'update_custom_property_on_node' ),
+            ( 'update_custom_property_on_repository' ),
+            ( 'update_oncall_property_on_bucket' ),
+            ( 'update_oncall_property_on_cluster' ),
+            ( 'update_oncall_property_on_group' ),
+            ( 'update_oncall_property_on_node' ),
+            ( 'update_oncall_property_on_repository' ),
+            ( 'update_service_property_on_bucket' ),
+            ( 'update_service_property_on_cluster' ),
+            ( 'update_service_property_on_group' ),
+            ( 'update_service_property_on_node' ),
+            ( 'update_service_property_on_repository' ),
+            ( 'update_system_property_on_bucket' ),
+            ( 'update_system_property_on_cluster' ),
+            ( 'update_system_property_on_group' ),
+            ( 'update_system_property_on_node' ),
+            ( 'update_system_property_on_repository' )
 ;`
 	queries[idx] = "insertJobTypes"
 	idx++
@@ -240,7 +284,7 @@ INSERT INTO public.schema_versions (
             description
 ) VALUES (
             'soma',
-            201611060001,
+            201611230001,
             'Initial create - somadbctl %s'
 );`, version)
 	queryMap["insertSomaSchemaVersion"] = somaString
@@ -0,0 +1,263 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+// formatColumn is a column of table and csv output. Path is the dot
+// separated path of the value inside the listed object, alternative
+// paths are separated by |. An empty path uses the listed value
+// itself.
+type formatColumn struct {
+	Header string
+	Path   string
+}
+
+// formatColumns are the columns printed for the object lists of
+// proto.Result, by JSON key
+var formatColumns = map[string][]formatColumn{
+	`actions`: {
+		{`action`, `action`},
+		{`type`, `type`},
+		{`child type`, `child_type`},
+	},
+	`attributes`: {
+		{`name`, `name`},
+		{`cardinality`, `cardinality`},
+	},
+	`audit`: {
+		{`time`, `time`},
+		{`user`, `user`},
+		{`address`, `remoteAddress`},
+		{`method`, `method`},
+		{`path`, `path`},
+		{`status`, `statusCode`},
+		{`authorized`, `authorized`},
+	},
+	`buckets`: {
+		{`id`, `id`},
+		{`name`, `name`},
+		{`environment`, `environment`},
+		{`repository`, `repositoryId`},
+		{`frozen`, `isFrozen`},
+		{`deleted`, `isDeleted`},
+	},
+	`capability`: {
+		{`id`, `id`},
+		{`name`, `name`},
+		{`monitoring`, `monitoringId`},
+		{`metric`, `metric`},
+		{`view`, `view`},
+		{`thresholds`, `thresholds`},
+	},
+	`categories`: {
+		{`name`, `name`},
+	},
+	`checkConfigs`: {
+		{`id`, `id`},
+		{`name`, `name`},
+		{`object type`, `objectType`},
+		{`object`, `objectId`},
+		{`capability`, `capabilityId`},
+		{`interval`, `interval`},
+		{`inheritance`, `inheritance`},
+		{`children only`, `childrenOnly`},
+		{`active`, `isActive`},
+	},
+	`clusters`: {
+		{`id`, `id`},
+		{`name`, `name`},
+		{`bucket`, `bucketId`},
+		{`state`, `objectState`},
+		{`team`, `teamId`},
+	},
+	`datacenterGroups`: {
+		{`name`, `name`},
+	},
+	`datacenter`: {
+		{`locode`, `locode`},
+	},
+	`deployments`: {
+		{`repository`, `repository`},
+		{`bucket`, `bucket`},
+		{`object type`, `objectType`},
+		{`task`, `task`},
+		{`metric`, `metric.path`},
+		{`node`, `node.name`},
+		{`instance`, `checkInstance.instanceId`},
+	},
+	`deploymentsList`: {
+		{`id`, ``},
+	},
+	`entities`: {
+		{`entity`, `entity`},
+	},
+	`environment`: {
+		{`name`, `name`},
+	},
+	`grants`: {
+		{`id`, `id`},
+		{`recipient type`, `recipientType`},
+		{`recipient`, `recipientId`},
+		{`permission`, `permissionId`},
+		{`category`, `category`},
+		{`repository`, `repositoryId`},
+		{`object type`, `objectType`},
+		{`object`, `objectId`},
+	},
+	`groups`: {
+		{`id`, `id`},
+		{`name`, `name`},
+		{`bucket`, `bucketId`},
+		{`state`, `objectState`},
+		{`team`, `teamId`},
+	},
+	`hostDeployments`: {
+		{`instance`, `checkInstanceId`},
+		{`delete`, `deleteInstance`},
+	},
+	`instances`: {
+		{`id`, `id`},
+		{`version`, `version`},
+		{`check`, `checkId`},
+		{`object type`, `objectType`},
+		{`object`, `objectId`},
+		{`status`, `currentStatus`},
+		{`next status`, `nextStatus`},
+		{`inherited`, `isInherited`},
+	},
+	`jobs`: {
+		{`id`, `id`},
+		{`type`, `type`},
+		{`status`, `status`},
+		{`result`, `result`},
+		{`queued`, `queued`},
+		{`started`, `started`},
+		{`finished`, `finished`},
+		{`error`, `error`},
+	},
+	`levels`: {
+		{`name`, `name`},
+		{`short name`, `shortName`},
+		{`numeric`, `numeric`},
+	},
+	`metrics`: {
+		{`path`, `path`},
+		{`unit`, `unit`},
+		{`description`, `description`},
+	},
+	`modes`: {
+		{`mode`, `mode`},
+	},
+	`monitorings`: {
+		{`id`, `id`},
+		{`name`, `name`},
+		{`mode`, `mode`},
+		{`contact`, `contact`},
+		{`team`, `teamId`},
+		{`callback`, `callback`},
+	},
+	`nodes`: {
+		{`id`, `id`},
+		{`asset`, `assetId`},
+		{`name`, `name`},
+		{`team`, `teamId`},
+		{`server`, `serverId`},
+		{`state`, `state`},
+		{`online`, `isOnline`},
+		{`deleted`, `isDeleted`},
+	},
+	`oncall`: {
+		{`id`, `id`},
+		{`name`, `name`},
+		{`number`, `number`},
+	},
+	`permissions`: {
+		{`id`, `id`},
+		{`name`, `name`},
+		{`category`, `category`},
+	},
+	`predicates`: {
+		{`symbol`, `symbol`},
+	},
+	`properties`: {
+		{`type`, `type`},
+		{`name`, `custom.name|system.name|service.name|native.name|oncall.name`},
+		{`value`, `custom.value|system.value|native.value|oncall.number`},
+		{`view`, `view`},
+		{`inheritance`, `inheritance`},
+		{`children only`, `childrenOnly`},
+		{`inherited`, `isInherited`},
+	},
+	`providers`: {
+		{`name`, `name`},
+	},
+	`repositories`: {
+		{`id`, `id`},
+		{`name`, `name`},
+		{`team`, `teamId`},
+		{`active`, `isActive`},
+		{`deleted`, `isDeleted`},
+	},
+	`servers`: {
+		{`id`, `id`},
+		{`asset`, `assetId`},
+		{`name`, `name`},
+		{`datacenter`, `datacenter`},
+		{`location`, `location`},
+		{`online`, `isOnline`},
+		{`deleted`, `isDeleted`},
+	},
+	`states`: {
+		{`name`, `Name`},
+	},
+	`status`: {
+		{`name`, `name`},
+	},
+	`systemOperation`: {
+		{`request`, `request`},
+		{`repository`, `repositoryId`},
+		{`rebuild level`, `rebuildLevel`},
+	},
+	`teams`: {
+		{`id`, `id`},
+		{`name`, `name`},
+		{`ldap id`, `ldapId`},
+		{`system`, `isSystem`},
+	},
+	`units`: {
+		{`unit`, `unit`},
+		{`name`, `name`},
+	},
+	`users`: {
+		{`id`, `id`},
+		{`user name`, `userName`},
+		{`first name`, `firstName`},
+		{`last name`, `lastName`},
+		{`employee number`, `employeeNumber`},
+		{`mail`, `mailAddress`},
+		{`team`, `teamId`},
+		{`active`, `isActive`},
+		{`system`, `isSystem`},
+	},
+	`validities`: {
+		{`system property`, `systemProperty`},
+		{`object type`, `objectType`},
+		{`direct`, `direct`},
+		{`inherited`, `inherited`},
+	},
+	`views`: {
+		{`name`, `name`},
+	},
+	`workflows`: {
+		{`instance`, `instanceId`},
+		{`instance config`, `instanceConfigId`},
+		{`status`, `status`},
+		{`next status`, `nextStatus`},
+	},
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -1,31 +1,283 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
 package adm
 
 import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/codegangsta/cli"
 	"gopkg.in/resty.v0"
 )
 
+// FormatOut prints the reply resp in the output format selected via
+// the global --format flag. Replies to tree commands are rendered as
+// hierarchy by the table and csv formats.
 func FormatOut(c *cli.Context, resp *resty.Response, cmd string) error {
-	if c.GlobalBool(`json`) {
+	format := c.GlobalString(`format`)
+	if c.GlobalBool(`json`) || format == `` {
+		format = `json`
+	}
+
+	switch format {
+	case `json`:
 		fmt.Println(resp)
 		return nil
+	case `table`, `yaml`, `csv`:
+	default:
+		return fmt.Errorf("Unknown output format: %s", format)
 	}
 
-	// hardwire JSON output for now
-	fmt.Println(resp)
+	reply, err := decodeOrdered(resp.Body())
+	if err != nil {
+		return err
+	}
+	result, ok := reply.(*jsonObject)
+	if !ok {
+		return fmt.Errorf(`Reply is not a result object`)
+	}
 
-	/* TODO
-	switch cmd {
-	case `list`:
-	case `show`:
-	case `tree`:
+	switch {
+	case format == `yaml`:
+		return writeYAML(os.Stdout, result)
+	case cmd == `tree`:
+		return writeTree(os.Stdout, resp.Body(), format)
+	case format == `table`:
+		return writeTables(os.Stdout, result)
 	default:
+		return writeCSV(os.Stdout, result)
+	}
+}
+
+// writeTables prints every object list of result as table, followed
+// by the job and error information of the result
+func writeTables(w io.Writer, result *jsonObject) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	first := true
+	for _, key := range result.keys {
+		columns := resultColumns(key, result.values[key])
+		if columns == nil {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(tw)
+		}
+		first = false
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(columnHeaders(columns), "\t")))
+		for _, row := range resultRows(columns, result.values[key]) {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
 	}
-	*/
 
+	if id := formatValue(result.get(`jobId`)); id != `` {
+		fmt.Fprintf(w, "Job: %s (%s)\n", id,
+			formatValue(result.get(`jobType`)))
+	}
+	if errs, ok := result.get(`errors`).([]interface{}); ok {
+		for _, e := range errs {
+			fmt.Fprintf(w, "Error: %s\n", formatValue(e))
+		}
+	}
+	if first {
+		fmt.Fprintf(w, "%s %s\n", formatValue(result.get(`statusCode`)),
+			formatValue(result.get(`statusText`)))
+	}
 	return nil
 }
 
+// writeCSV prints every object list of result as CSV block with a
+// header line
+func writeCSV(w io.Writer, result *jsonObject) error {
+	cw := csv.NewWriter(w)
+	for _, key := range result.keys {
+		columns := resultColumns(key, result.values[key])
+		if columns == nil {
+			continue
+		}
+		if err := cw.Write(columnHeaders(columns)); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(resultRows(columns, result.values[key])); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// resultColumns returns the columns for the object list stored under
+// key in a result, or nil if key does not hold an object list
+func resultColumns(key string, value interface{}) []formatColumn {
+	list, ok := value.([]interface{})
+	if !ok || key == `errors` {
+		return nil
+	}
+	if columns, ok := formatColumns[key]; ok {
+		return columns
+	}
+	// lists without configured columns
+	if len(list) > 0 {
+		if obj, ok := list[0].(*jsonObject); ok {
+			columns := []formatColumn{}
+			for _, k := range obj.keys {
+				switch obj.values[k].(type) {
+				case *jsonObject, []interface{}:
+					continue
+				}
+				columns = append(columns, formatColumn{k, k})
+			}
+			return columns
+		}
+	}
+	return []formatColumn{{key, ``}}
+}
+
+// resultRows returns the column values of all objects in list
+func resultRows(columns []formatColumn, list interface{}) [][]string {
+	rows := [][]string{}
+	for _, item := range list.([]interface{}) {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			if col.Path == `` {
+				row[i] = formatValue(item)
+				continue
+			}
+			if obj, ok := item.(*jsonObject); ok {
+				row[i] = formatValue(obj.lookup(col.Path))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func columnHeaders(columns []formatColumn) []string {
+	headers := make([]string, len(columns))
+	for i := range columns {
+		headers[i] = columns[i].Header
+	}
+	return headers
+}
+
+// formatValue converts a decoded JSON value into a table cell
+func formatValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ``
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		if t {
+			return `true`
+		}
+		return `false`
+	case []interface{}:
+		s := make([]string, 0, len(t))
+		for _, e := range t {
+			if _, ok := e.(*jsonObject); ok {
+				return fmt.Sprintf("(%d)", len(t))
+			}
+			s = append(s, formatValue(e))
+		}
+		return strings.Join(s, `,`)
+	case *jsonObject:
+		return fmt.Sprintf("{%d}", len(t.keys))
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// jsonObject is a decoded JSON object that retains the order of its
+// keys
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (o *jsonObject) get(key string) interface{} {
+	return o.values[key]
+}
+
+// lookup returns the value at the dot separated path. Alternative
+// paths are separated by |, the first one set is used.
+func (o *jsonObject) lookup(path string) interface{} {
+alternatives:
+	for _, alt := range strings.Split(path, `|`) {
+		var v interface{} = o
+		for _, key := range strings.Split(alt, `.`) {
+			obj, ok := v.(*jsonObject)
+			if !ok {
+				continue alternatives
+			}
+			if v, ok = obj.values[key]; !ok {
+				continue alternatives
+			}
+		}
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// decodeOrdered decodes JSON data into jsonObject, []interface{},
+// json.Number, string, bool and nil values
+func decodeOrdered(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeValue(dec)
+}
+
+func decodeValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := &jsonObject{values: map[string]interface{}{}}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			val, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.keys = append(obj.keys, key.(string))
+			obj.values[key.(string)] = val
+		}
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for dec.More() {
+			val, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, val)
+		}
+		_, err = dec.Token()
+		return list, err
+	}
+	return tok, nil
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,180 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+const formatTestReply = `{
+	"statusCode": 200,
+	"statusText": "OK",
+	"buckets": [
+		{"id": "b1", "name": "foo:", "environment": "live",
+		 "repositoryId": "r1", "isFrozen": false, "isDeleted": false},
+		{"id": "b2", "name": "- bar, \"baz\"", "environment": "qa",
+		 "repositoryId": "r1", "isFrozen": true, "isDeleted": false}
+	],
+	"jobId": "j1",
+	"jobType": "create_bucket",
+	"ratio": 0.5,
+	"nothing": null,
+	"errors": ["# not a comment"]
+}`
+
+func formatTestResult(t *testing.T) *jsonObject {
+	reply, err := decodeOrdered([]byte(formatTestReply))
+	if err != nil {
+		t.Fatalf("decodeOrdered: %s", err)
+	}
+	result, ok := reply.(*jsonObject)
+	if !ok {
+		t.Fatalf("Decoded reply is %T, expected *jsonObject", reply)
+	}
+	return result
+}
+
+func TestDecodeOrderedRoundTrip(t *testing.T) {
+	result := formatTestResult(t)
+
+	keys := []string{`statusCode`, `statusText`, `buckets`, `jobId`,
+		`jobType`, `ratio`, `nothing`, `errors`}
+	if !reflect.DeepEqual(result.keys, keys) {
+		t.Errorf("Decoded keys %v, expected %v", result.keys, keys)
+	}
+	if v := formatValue(result.lookup(`buckets`)); v != `(2)` {
+		t.Errorf("Formatted buckets as %q", v)
+	}
+
+	var got, want interface{}
+	if err := json.Unmarshal([]byte(formatTestReply), &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(jsonString(t, result)), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON round trip mismatch:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestWriteYAMLRoundTrip(t *testing.T) {
+	result := formatTestResult(t)
+	buf := &bytes.Buffer{}
+	if err := writeYAML(buf, result); err != nil {
+		t.Fatalf("writeYAML: %s", err)
+	}
+	if !strings.HasPrefix(buf.String(), "---\n") {
+		t.Errorf("Missing document start:\n%s", buf)
+	}
+
+	var got interface{}
+	if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Output does not parse: %s\n%s", err, buf)
+	}
+	var want interface{}
+	if err := yaml.Unmarshal([]byte(formatTestReply), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("YAML round trip mismatch:\n got %v\nwant %v", got, want)
+	}
+
+	// keys keep the order of the reply
+	if i, j := strings.Index(buf.String(), `statusCode:`),
+		strings.Index(buf.String(), `errors:`); i < 0 || j < i {
+		t.Errorf("Key order not retained:\n%s", buf)
+	}
+}
+
+func TestWriteCSVRoundTrip(t *testing.T) {
+	result := formatTestResult(t)
+	buf := &bytes.Buffer{}
+	if err := writeCSV(buf, result); err != nil {
+		t.Fatalf("writeCSV: %s", err)
+	}
+
+	records, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Output does not parse: %s", err)
+	}
+	want := [][]string{
+		{`id`, `name`, `environment`, `repository`, `frozen`, `deleted`},
+		{`b1`, `foo:`, `live`, `r1`, `false`, `false`},
+		{`b2`, `- bar, "baz"`, `qa`, `r1`, `true`, `false`},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("CSV round trip mismatch:\n got %v\nwant %v", records, want)
+	}
+}
+
+func TestWriteTables(t *testing.T) {
+	result := formatTestResult(t)
+	buf := &bytes.Buffer{}
+	if err := writeTables(buf, result); err != nil {
+		t.Fatalf("writeTables: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	want := [][]string{
+		{`ID`, `NAME`, `ENVIRONMENT`, `REPOSITORY`, `FROZEN`, `DELETED`},
+		{`b1`, `foo:`, `live`, `r1`, `false`, `false`},
+	}
+	for i := range want {
+		if i >= len(lines) {
+			t.Fatalf("Missing table line %d:\n%s", i, buf)
+		}
+		if got := strings.Fields(lines[i]); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("Table line %d is %v, expected %v", i, got, want[i])
+		}
+	}
+	for _, s := range []string{
+		"Job: j1 (create_bucket)\n",
+		"Error: # not a comment\n",
+	} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("Table output misses %q:\n%s", s, buf)
+		}
+	}
+	if strings.Contains(buf.String(), `200 OK`) {
+		t.Errorf("Status printed despite object lists:\n%s", buf)
+	}
+}
+
+// jsonString encodes a decoded JSON value back into JSON, retaining
+// the order of object keys
+func jsonString(t *testing.T, v interface{}) string {
+	switch o := v.(type) {
+	case *jsonObject:
+		parts := make([]string, len(o.keys))
+		for i, key := range o.keys {
+			parts[i] = jsonString(t, key) + `:` + jsonString(t, o.values[key])
+		}
+		return `{` + strings.Join(parts, `,`) + `}`
+	case []interface{}:
+		parts := make([]string, len(o))
+		for i := range o {
+			parts[i] = jsonString(t, o[i])
+		}
+		return `[` + strings.Join(parts, `,`) + `]`
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
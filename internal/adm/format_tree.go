@@ -0,0 +1,129 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/1and1/soma/lib/proto"
+)
+
+// treeEntry is an object within a tree reply
+type treeEntry struct {
+	depth    int
+	kind     string
+	id       string
+	name     string
+	parentId string
+}
+
+// writeTree prints the tree contained in the reply body, either as
+// indented hierarchy or as csv with one line per object
+func writeTree(w io.Writer, body []byte, format string) error {
+	res := proto.Result{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return err
+	}
+	if res.Tree == nil {
+		return fmt.Errorf(`Reply does not contain a tree`)
+	}
+
+	entries := []treeEntry{}
+	switch {
+	case res.Tree.Repository != nil:
+		entries = walkRepository(entries, res.Tree.Repository, 0, ``)
+	case res.Tree.Bucket != nil:
+		entries = walkBucket(entries, res.Tree.Bucket, 0, ``)
+	case res.Tree.Group != nil:
+		entries = walkGroup(entries, res.Tree.Group, 0, ``)
+	case res.Tree.Cluster != nil:
+		entries = walkCluster(entries, res.Tree.Cluster, 0, ``)
+	case res.Tree.Node != nil:
+		entries = append(entries, treeEntry{0, `node`,
+			res.Tree.Node.Id, res.Tree.Node.Name, ``})
+	}
+
+	if format == `csv` {
+		cw := csv.NewWriter(w)
+		cw.Write([]string{`depth`, `type`, `id`, `name`, `parent`})
+		for _, e := range entries {
+			cw.Write([]string{fmt.Sprintf("%d", e.depth), e.kind, e.id,
+				e.name, e.parentId})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s%s %s (%s)\n", strings.Repeat(`  `, e.depth),
+			e.kind, e.name, e.id)
+	}
+	return nil
+}
+
+func walkRepository(entries []treeEntry, r *proto.Repository,
+	depth int, parent string) []treeEntry {
+	entries = append(entries, treeEntry{depth, `repository`, r.Id,
+		r.Name, parent})
+	if r.Members != nil {
+		for i := range *r.Members {
+			entries = walkBucket(entries, &(*r.Members)[i], depth+1, r.Id)
+		}
+	}
+	return entries
+}
+
+func walkBucket(entries []treeEntry, b *proto.Bucket,
+	depth int, parent string) []treeEntry {
+	entries = append(entries, treeEntry{depth, `bucket`, b.Id, b.Name,
+		parent})
+	return walkMembers(entries, b.MemberGroups, b.MemberClusters,
+		b.MemberNodes, depth+1, b.Id)
+}
+
+func walkGroup(entries []treeEntry, g *proto.Group,
+	depth int, parent string) []treeEntry {
+	entries = append(entries, treeEntry{depth, `group`, g.Id, g.Name,
+		parent})
+	return walkMembers(entries, g.MemberGroups, g.MemberClusters,
+		g.MemberNodes, depth+1, g.Id)
+}
+
+func walkCluster(entries []treeEntry, c *proto.Cluster,
+	depth int, parent string) []treeEntry {
+	entries = append(entries, treeEntry{depth, `cluster`, c.Id, c.Name,
+		parent})
+	return walkMembers(entries, nil, nil, c.Members, depth+1, c.Id)
+}
+
+func walkMembers(entries []treeEntry, groups *[]proto.Group,
+	clusters *[]proto.Cluster, nodes *[]proto.Node, depth int,
+	parent string) []treeEntry {
+	if groups != nil {
+		for i := range *groups {
+			entries = walkGroup(entries, &(*groups)[i], depth, parent)
+		}
+	}
+	if clusters != nil {
+		for i := range *clusters {
+			entries = walkCluster(entries, &(*clusters)[i], depth, parent)
+		}
+	}
+	if nodes != nil {
+		for _, n := range *nodes {
+			entries = append(entries, treeEntry{depth, `node`, n.Id,
+				n.Name, parent})
+		}
+	}
+	return entries
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,61 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v2"
+)
+
+// writeYAML prints a decoded JSON value as YAML document
+func writeYAML(w io.Writer, v interface{}) error {
+	b, err := yaml.Marshal(yamlValue(v))
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(w, "---\n"); err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// yamlValue converts a decoded JSON value into values marshalled by
+// yaml.v2. Objects keep the order of their keys.
+func yamlValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case *jsonObject:
+		m := make(yaml.MapSlice, 0, len(t.keys))
+		for _, key := range t.keys {
+			m = append(m, yaml.MapItem{
+				Key:   key,
+				Value: yamlValue(t.values[key]),
+			})
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(t))
+		for i := range t {
+			l[i] = yamlValue(t[i])
+		}
+		return l
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		if f, err := t.Float64(); err == nil {
+			return f
+		}
+		return t.String()
+	}
+	return v
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -318,6 +318,7 @@ func ValidateCheckConstraints(repoId, teamId string,
 			custom.Value = prop.Custom.Value
 			valid = append(valid, proto.CheckConfigConstraint{
 				ConstraintType: prop.ConstraintType,
+				Operator:       prop.Operator,
 				Custom:         &custom,
 			})
 		}
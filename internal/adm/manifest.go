@@ -0,0 +1,241 @@
+package adm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/nahanni/go-ucl"
+	"gopkg.in/yaml.v2"
+)
+
+// Manifest describes the desired content of a repository. All
+// scalar values are strings, so that JSON, YAML and UCL manifests
+// decode the same way.
+type Manifest struct {
+	Repository string             `json:"repository" yaml:"repository"`
+	Properties []ManifestProperty `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Buckets    []ManifestBucket   `json:"buckets,omitempty" yaml:"buckets,omitempty"`
+	Checks     []ManifestCheck    `json:"checks,omitempty" yaml:"checks,omitempty"`
+}
+
+// ManifestBucket describes a bucket and the objects inside it. Nodes
+// listed as members of a group or cluster are assigned to the bucket
+// without having to be listed under Nodes.
+type ManifestBucket struct {
+	Name        string             `json:"name" yaml:"name"`
+	Environment string             `json:"environment" yaml:"environment"`
+	Properties  []ManifestProperty `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Groups      []ManifestGroup    `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Clusters    []ManifestCluster  `json:"clusters,omitempty" yaml:"clusters,omitempty"`
+	Nodes       []ManifestNode     `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+}
+
+// ManifestGroup describes a group and the names of its members
+type ManifestGroup struct {
+	Name           string             `json:"name" yaml:"name"`
+	Properties     []ManifestProperty `json:"properties,omitempty" yaml:"properties,omitempty"`
+	MemberGroups   []string           `json:"memberGroups,omitempty" yaml:"memberGroups,omitempty"`
+	MemberClusters []string           `json:"memberClusters,omitempty" yaml:"memberClusters,omitempty"`
+	MemberNodes    []string           `json:"memberNodes,omitempty" yaml:"memberNodes,omitempty"`
+}
+
+// ManifestCluster describes a cluster and the names of its member
+// nodes
+type ManifestCluster struct {
+	Name        string             `json:"name" yaml:"name"`
+	Properties  []ManifestProperty `json:"properties,omitempty" yaml:"properties,omitempty"`
+	MemberNodes []string           `json:"memberNodes,omitempty" yaml:"memberNodes,omitempty"`
+}
+
+// ManifestNode describes a node assigned to a bucket
+type ManifestNode struct {
+	Name       string             `json:"name" yaml:"name"`
+	Properties []ManifestProperty `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// ManifestProperty describes a locally set property. Value is only
+// used for system and custom properties.
+type ManifestProperty struct {
+	Type         string `json:"type" yaml:"type"`
+	Name         string `json:"name" yaml:"name"`
+	Value        string `json:"value,omitempty" yaml:"value,omitempty"`
+	View         string `json:"view" yaml:"view"`
+	Inheritance  string `json:"inheritance,omitempty" yaml:"inheritance,omitempty"`
+	ChildrenOnly string `json:"childrenOnly,omitempty" yaml:"childrenOnly,omitempty"`
+}
+
+// ManifestCheck describes a check configuration, with the same
+// arguments as somaadm checks create
+type ManifestCheck struct {
+	Name         string               `json:"name" yaml:"name"`
+	Bucket       string               `json:"bucket" yaml:"bucket"`
+	ObjectType   string               `json:"objectType" yaml:"objectType"`
+	ObjectName   string               `json:"objectName" yaml:"objectName"`
+	Capability   string               `json:"capability" yaml:"capability"`
+	Interval     string               `json:"interval" yaml:"interval"`
+	Inheritance  string               `json:"inheritance,omitempty" yaml:"inheritance,omitempty"`
+	ChildrenOnly string               `json:"childrenOnly,omitempty" yaml:"childrenOnly,omitempty"`
+	ExternalId   string               `json:"externalId,omitempty" yaml:"externalId,omitempty"`
+	Thresholds   []ManifestThreshold  `json:"thresholds" yaml:"thresholds"`
+	Constraints  []ManifestConstraint `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+}
+
+// ManifestThreshold describes a check threshold
+type ManifestThreshold struct {
+	Predicate string `json:"predicate" yaml:"predicate"`
+	Level     string `json:"level" yaml:"level"`
+	Value     string `json:"value" yaml:"value"`
+}
+
+// ManifestConstraint describes a check constraint. For service and
+// oncall constraints only Name is used.
+type ManifestConstraint struct {
+	Type     string `json:"type" yaml:"type"`
+	Name     string `json:"name" yaml:"name"`
+	Operator string `json:"operator,omitempty" yaml:"operator,omitempty"`
+	Value    string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// LoadManifest reads the manifest from file path. Files ending in
+// .json are read as JSON, files ending in .yaml or .yml as YAML and
+// all other files as UCL.
+func LoadManifest(path string) (*Manifest, error) {
+	var (
+		err  error
+		file []byte
+		data map[string]interface{}
+	)
+	m := &Manifest{}
+
+	if file, err = ioutil.ReadFile(path); err != nil {
+		return nil, err
+	}
+
+	switch filepath.Ext(path) {
+	case `.json`:
+		if err = json.Unmarshal(file, m); err != nil {
+			return nil, err
+		}
+	case `.yaml`, `.yml`:
+		if err = yaml.Unmarshal(file, m); err != nil {
+			return nil, fmt.Errorf("YAML error: %s", err.Error())
+		}
+	default:
+		// take detour via JSON to load UCL into struct
+		parser := ucl.NewParser(bytes.NewBuffer(file))
+		if data, err = parser.Ucl(); err != nil {
+			return nil, fmt.Errorf("UCL error: %s", err.Error())
+		}
+		if file, err = json.Marshal(data); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(file, m); err != nil {
+			return nil, err
+		}
+	}
+	if err = m.validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// validate checks the manifest for missing or duplicate names
+func (m *Manifest) validate() error {
+	if m.Repository == `` {
+		return fmt.Errorf(`Manifest does not name a repository`)
+	}
+	if err := validateManifestProperties(m.Properties); err != nil {
+		return err
+	}
+
+	buckets := map[string]bool{}
+	for _, b := range m.Buckets {
+		if b.Name == `` || b.Environment == `` {
+			return fmt.Errorf(
+				`Manifest bucket requires name and environment`)
+		}
+		if buckets[b.Name] {
+			return fmt.Errorf("Duplicate bucket in manifest: %s", b.Name)
+		}
+		buckets[b.Name] = true
+		if err := validateManifestProperties(b.Properties); err != nil {
+			return err
+		}
+
+		names := map[string]bool{}
+		for _, g := range b.Groups {
+			if g.Name == `` || names[`group/`+g.Name] {
+				return fmt.Errorf("Invalid or duplicate group in"+
+					" bucket %s: '%s'", b.Name, g.Name)
+			}
+			names[`group/`+g.Name] = true
+			if err := validateManifestProperties(
+				g.Properties); err != nil {
+				return err
+			}
+		}
+		for _, c := range b.Clusters {
+			if c.Name == `` || names[`cluster/`+c.Name] {
+				return fmt.Errorf("Invalid or duplicate cluster in"+
+					" bucket %s: '%s'", b.Name, c.Name)
+			}
+			names[`cluster/`+c.Name] = true
+			if err := validateManifestProperties(
+				c.Properties); err != nil {
+				return err
+			}
+		}
+		for _, n := range b.Nodes {
+			if n.Name == `` || names[`node/`+n.Name] {
+				return fmt.Errorf("Invalid or duplicate node in"+
+					" bucket %s: '%s'", b.Name, n.Name)
+			}
+			names[`node/`+n.Name] = true
+			if err := validateManifestProperties(
+				n.Properties); err != nil {
+				return err
+			}
+		}
+	}
+
+	checks := map[string]bool{}
+	for _, c := range m.Checks {
+		if c.Name == `` || c.Bucket == `` || c.ObjectType == `` ||
+			c.ObjectName == `` || c.Capability == `` ||
+			c.Interval == `` || len(c.Thresholds) == 0 {
+			return fmt.Errorf("Incomplete check in manifest: '%s'",
+				c.Name)
+		}
+		if checks[c.Name] {
+			return fmt.Errorf("Duplicate check in manifest: %s", c.Name)
+		}
+		checks[c.Name] = true
+	}
+	return nil
+}
+
+// validateManifestProperties checks that all properties are complete
+func validateManifestProperties(props []ManifestProperty) error {
+	for _, p := range props {
+		switch p.Type {
+		case `system`, `custom`:
+			if p.Value == `` {
+				return fmt.Errorf("Property %s/%s requires a value",
+					p.Type, p.Name)
+			}
+		case `service`, `oncall`:
+		default:
+			return fmt.Errorf("Unknown property type: %s", p.Type)
+		}
+		if p.Name == `` || p.View == `` {
+			return fmt.Errorf("Property of type %s requires name"+
+				" and view", p.Type)
+		}
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
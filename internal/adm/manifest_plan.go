@@ -0,0 +1,1310 @@
+package adm
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/1and1/soma/lib/proto"
+	"gopkg.in/resty.v0"
+)
+
+// Plan is the list of changes required to bring a repository into
+// the state described by a manifest. Plans only add objects,
+// memberships, properties and check configurations; nothing is
+// deleted or moved. Existing check configurations are updated.
+// Conflicts are differences a plan can not resolve, a plan with
+// conflicts can not be applied.
+type Plan struct {
+	Steps     []PlanStep
+	Conflicts []string
+}
+
+// PlanStep is a single change of a plan
+type PlanStep struct {
+	Text   string
+	change planChange
+	apply  func() error
+}
+
+// planChange describes the change of a plan step. Parent is the
+// bucket a node is assigned to or the group or cluster an object
+// becomes a member of.
+type planChange struct {
+	op          string
+	object      planObject
+	parent      planObject
+	environment string
+	property    ManifestProperty
+	check       ManifestCheck
+	checkId     string
+}
+
+func (c planChange) String() string {
+	switch c.op {
+	case `create`:
+		if c.object.Type == `bucket` {
+			return fmt.Sprintf("create bucket %s (environment %s)",
+				c.object.Name, c.environment)
+		}
+		return fmt.Sprintf("create %s", c.object)
+	case `assign`:
+		return fmt.Sprintf("assign node %s to bucket %s",
+			c.object.Name, c.parent.Name)
+	case `member`:
+		return fmt.Sprintf("add %s %s to %s", c.object.Type,
+			c.object.Name, c.parent)
+	case `property`:
+		return fmt.Sprintf("add %s property %s to %s in view %s",
+			c.property.Type, c.property.Name, c.object, c.property.View)
+	case `check`:
+		return fmt.Sprintf("create check %s on %s", c.check.Name,
+			c.object)
+	case `update`:
+		return fmt.Sprintf("update check %s on %s", c.check.Name,
+			c.object)
+	}
+	return c.op
+}
+
+// planObject identifies an object of the repository by name. Bucket
+// is required for groups and clusters.
+type planObject struct {
+	Type   string
+	Name   string
+	Bucket string
+}
+
+func (o planObject) String() string {
+	switch o.Type {
+	case `group`, `cluster`:
+		return fmt.Sprintf("%s %s in bucket %s", o.Type, o.Name, o.Bucket)
+	}
+	return fmt.Sprintf("%s %s", o.Type, o.Name)
+}
+
+// treeObject is an object of the current repository tree. Parent is
+// either bucket or type/name of the group or cluster the object is
+// a member of. The id of objects created by the plan is empty.
+type treeObject struct {
+	id     string
+	parent string
+}
+
+// treeBucket holds the objects of a bucket, indexed by name
+type treeBucket struct {
+	id       string
+	groups   map[string]*treeObject
+	clusters map[string]*treeObject
+	nodes    map[string]*treeObject
+}
+
+func newTreeBucket(id string) *treeBucket {
+	return &treeBucket{
+		id:       id,
+		groups:   map[string]*treeObject{},
+		clusters: map[string]*treeObject{},
+		nodes:    map[string]*treeObject{},
+	}
+}
+
+// planner computes the plan for a manifest. Fetch, capabilityId
+// and levelName query the server and are replaced in tests.
+type planner struct {
+	manifest     *Manifest
+	repoId       string
+	buckets      map[string]*treeBucket
+	plan         *Plan
+	fetch        func(path string) (*proto.Result, error)
+	capabilityId func(capability string) (string, error)
+	levelName    func(level string) (string, error)
+}
+
+func newPlanner(m *Manifest, repoId string) *planner {
+	return &planner{
+		manifest: m,
+		repoId:   repoId,
+		buckets:  map[string]*treeBucket{},
+		plan: &Plan{
+			Steps:     []PlanStep{},
+			Conflicts: []string{},
+		},
+		fetch:        fetchObjList,
+		capabilityId: LookupCapabilityId,
+		levelName:    LookupLevelName,
+	}
+}
+
+// ComputePlan compares manifest m against the current tree of its
+// repository and returns the required changes
+func ComputePlan(m *Manifest) (*Plan, error) {
+	repoId, err := LookupRepoId(m.Repository)
+	if err != nil {
+		return nil, err
+	}
+	return newPlanner(m, repoId).compute()
+}
+
+// compute builds the plan
+func (p *planner) compute() (*Plan, error) {
+	var err error
+	m := p.manifest
+
+	if err = p.loadTree(); err != nil {
+		return nil, err
+	}
+	if err = p.diffProperties(planObject{
+		Type: `repository`,
+		Name: m.Repository,
+	}, p.repoId, m.Properties); err != nil {
+		return nil, err
+	}
+	for _, b := range m.Buckets {
+		if err = p.diffBucket(b); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.diffChecks(); err != nil {
+		return nil, err
+	}
+	return p.plan, nil
+}
+
+// Empty reports whether the plan contains no changes
+func (p *Plan) Empty() bool {
+	return len(p.Steps) == 0 && len(p.Conflicts) == 0
+}
+
+// Print writes the plan to w
+func (p *Plan) Print(w io.Writer) {
+	if p.Empty() {
+		fmt.Fprintln(w, `No changes. Repository matches the manifest.`)
+		return
+	}
+	for _, s := range p.Steps {
+		fmt.Fprintf(w, "  + %s\n", s.Text)
+	}
+	for _, c := range p.Conflicts {
+		fmt.Fprintf(w, "  ! %s\n", c)
+	}
+	fmt.Fprintf(w, "Plan: %d change(s), %d conflict(s)\n",
+		len(p.Steps), len(p.Conflicts))
+}
+
+// Apply executes the steps of the plan in order. Every job is waited
+// for before the next step is submitted, since later steps reference
+// objects created by earlier ones.
+func (p *Plan) Apply(w io.Writer) error {
+	if len(p.Conflicts) > 0 {
+		return fmt.Errorf("Plan has %d unresolved conflict(s)",
+			len(p.Conflicts))
+	}
+	for _, s := range p.Steps {
+		fmt.Fprintf(w, "Applying: %s\n", s.Text)
+		if err := s.apply(); err != nil {
+			return fmt.Errorf("Failed to %s: %s", s.Text, err.Error())
+		}
+	}
+	return nil
+}
+
+// add appends a step performing change c to the plan
+func (p *planner) add(c planChange, f func() error) {
+	p.plan.Steps = append(p.plan.Steps, PlanStep{
+		Text:   c.String(),
+		change: c,
+		apply:  f,
+	})
+}
+
+// conflict records a difference the plan can not resolve
+func (p *planner) conflict(format string, a ...interface{}) {
+	p.plan.Conflicts = append(p.plan.Conflicts,
+		fmt.Sprintf(format, a...))
+}
+
+// loadTree reads the current repository tree
+func (p *planner) loadTree() error {
+	res, err := p.fetch(fmt.Sprintf("/repository/%s/tree/tree",
+		p.repoId))
+	if err != nil {
+		return err
+	}
+	if res.Tree == nil || res.Tree.Repository == nil {
+		return fmt.Errorf(`Received no repository tree`)
+	}
+	if res.Tree.Repository.Members == nil {
+		return nil
+	}
+
+	for _, b := range *res.Tree.Repository.Members {
+		tb := newTreeBucket(b.Id)
+		p.buckets[b.Name] = tb
+		if b.MemberGroups != nil {
+			for _, g := range *b.MemberGroups {
+				tb.walkGroup(g, `bucket`)
+			}
+		}
+		if b.MemberClusters != nil {
+			for _, c := range *b.MemberClusters {
+				tb.walkCluster(c, `bucket`)
+			}
+		}
+		if b.MemberNodes != nil {
+			for _, n := range *b.MemberNodes {
+				tb.nodes[n.Name] = &treeObject{id: n.Id, parent: `bucket`}
+			}
+		}
+	}
+	return nil
+}
+
+// walkGroup records group g and its members
+func (tb *treeBucket) walkGroup(g proto.Group, parent string) {
+	tb.groups[g.Name] = &treeObject{id: g.Id, parent: parent}
+	self := `group/` + g.Name
+	if g.MemberGroups != nil {
+		for _, mg := range *g.MemberGroups {
+			tb.walkGroup(mg, self)
+		}
+	}
+	if g.MemberClusters != nil {
+		for _, mc := range *g.MemberClusters {
+			tb.walkCluster(mc, self)
+		}
+	}
+	if g.MemberNodes != nil {
+		for _, mn := range *g.MemberNodes {
+			tb.nodes[mn.Name] = &treeObject{id: mn.Id, parent: self}
+		}
+	}
+}
+
+// walkCluster records cluster c and its members
+func (tb *treeBucket) walkCluster(c proto.Cluster, parent string) {
+	tb.clusters[c.Name] = &treeObject{id: c.Id, parent: parent}
+	if c.Members != nil {
+		for _, mn := range *c.Members {
+			tb.nodes[mn.Name] = &treeObject{
+				id:     mn.Id,
+				parent: `cluster/` + c.Name,
+			}
+		}
+	}
+}
+
+// diffBucket compares bucket b with the current tree
+func (p *planner) diffBucket(b ManifestBucket) error {
+	var err error
+	tb, ok := p.buckets[b.Name]
+	if !ok {
+		tb = newTreeBucket(``)
+		p.buckets[b.Name] = tb
+		p.add(planChange{
+			op:          `create`,
+			object:      planObject{Type: `bucket`, Name: b.Name},
+			environment: b.Environment,
+		}, p.createBucket(b))
+	}
+
+	for _, g := range b.Groups {
+		if _, ok := tb.groups[g.Name]; !ok {
+			tb.groups[g.Name] = &treeObject{parent: `bucket`}
+			p.add(planChange{
+				op:     `create`,
+				object: planObject{`group`, g.Name, b.Name},
+			}, p.createGroup(g.Name, b.Name))
+		}
+	}
+	for _, c := range b.Clusters {
+		if _, ok := tb.clusters[c.Name]; !ok {
+			tb.clusters[c.Name] = &treeObject{parent: `bucket`}
+			p.add(planChange{
+				op:     `create`,
+				object: planObject{`cluster`, c.Name, b.Name},
+			}, p.createCluster(c.Name, b.Name))
+		}
+	}
+
+	// nodes are assigned to the bucket before they can become
+	// members of its groups and clusters
+	for _, n := range b.Nodes {
+		p.diffNode(b.Name, tb, n.Name)
+	}
+	for _, g := range b.Groups {
+		for _, n := range g.MemberNodes {
+			p.diffNode(b.Name, tb, n)
+		}
+	}
+	for _, c := range b.Clusters {
+		for _, n := range c.MemberNodes {
+			p.diffNode(b.Name, tb, n)
+		}
+	}
+
+	for _, g := range b.Groups {
+		for _, m := range g.MemberGroups {
+			p.diffMember(b.Name, tb, `group`, m, `group`, g.Name)
+		}
+		for _, m := range g.MemberClusters {
+			p.diffMember(b.Name, tb, `cluster`, m, `group`, g.Name)
+		}
+		for _, m := range g.MemberNodes {
+			p.diffMember(b.Name, tb, `node`, m, `group`, g.Name)
+		}
+	}
+	for _, c := range b.Clusters {
+		for _, m := range c.MemberNodes {
+			p.diffMember(b.Name, tb, `node`, m, `cluster`, c.Name)
+		}
+	}
+
+	if err = p.diffProperties(planObject{
+		Type: `bucket`,
+		Name: b.Name,
+	}, tb.id, b.Properties); err != nil {
+		return err
+	}
+	for _, g := range b.Groups {
+		if err = p.diffProperties(planObject{
+			Type:   `group`,
+			Name:   g.Name,
+			Bucket: b.Name,
+		}, tb.groups[g.Name].id, g.Properties); err != nil {
+			return err
+		}
+	}
+	for _, c := range b.Clusters {
+		if err = p.diffProperties(planObject{
+			Type:   `cluster`,
+			Name:   c.Name,
+			Bucket: b.Name,
+		}, tb.clusters[c.Name].id, c.Properties); err != nil {
+			return err
+		}
+	}
+	for _, n := range b.Nodes {
+		node, ok := tb.nodes[n.Name]
+		if !ok {
+			// assignment conflict
+			continue
+		}
+		if err = p.diffProperties(planObject{
+			Type:   `node`,
+			Name:   n.Name,
+			Bucket: b.Name,
+		}, node.id, n.Properties); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffNode plans the assignment of node name to bucket
+func (p *planner) diffNode(bucket string, tb *treeBucket, name string) {
+	if _, ok := tb.nodes[name]; ok {
+		return
+	}
+	for other, ob := range p.buckets {
+		if _, ok := ob.nodes[name]; ok {
+			p.conflict("node %s is assigned to bucket %s, not %s",
+				name, other, bucket)
+			return
+		}
+	}
+	tb.nodes[name] = &treeObject{parent: `bucket`}
+	p.add(planChange{
+		op:     `assign`,
+		object: planObject{Type: `node`, Name: name},
+		parent: planObject{Type: `bucket`, Name: bucket},
+	}, p.assignNode(name, bucket))
+}
+
+// diffMember plans adding object kind/name to the group or cluster
+// pType/pName
+func (p *planner) diffMember(bucket string, tb *treeBucket,
+	kind, name, pType, pName string) {
+	var obj *treeObject
+	switch kind {
+	case `group`:
+		obj = tb.groups[name]
+	case `cluster`:
+		obj = tb.clusters[name]
+	case `node`:
+		if obj = tb.nodes[name]; obj == nil {
+			// assignment conflict
+			return
+		}
+	}
+	if obj == nil {
+		p.conflict("%s %s is not part of bucket %s", kind, name, bucket)
+		return
+	}
+	if kind == pType && name == pName {
+		p.conflict("%s %s in bucket %s can not be a member of itself",
+			kind, name, bucket)
+		return
+	}
+
+	want := pType + `/` + pName
+	switch obj.parent {
+	case want:
+	case `bucket`:
+		obj.parent = want
+		p.add(planChange{
+			op:     `member`,
+			object: planObject{kind, name, bucket},
+			parent: planObject{pType, pName, bucket},
+		}, p.addMember(bucket, kind, name, pType, pName))
+	default:
+		p.conflict("%s %s in bucket %s is a member of %s, not %s/%s",
+			kind, name, bucket, obj.parent, pType, pName)
+	}
+}
+
+// diffProperties plans adding the properties of object o that are
+// not set locally. id is empty for objects created by the plan.
+func (p *planner) diffProperties(o planObject, id string,
+	props []ManifestProperty) error {
+	current := []proto.Property{}
+	if id != `` && len(props) > 0 {
+		var (
+			err  error
+			res  *proto.Result
+			path string
+		)
+		switch o.Type {
+		case `repository`:
+			path = fmt.Sprintf("/repository/%s", id)
+		default:
+			path = fmt.Sprintf("/%ss/%s", o.Type, id)
+		}
+		if res, err = p.fetch(path); err != nil {
+			return err
+		}
+		var list *[]proto.Property
+		switch {
+		case res.Repositories != nil && len(*res.Repositories) > 0:
+			list = (*res.Repositories)[0].Properties
+		case res.Buckets != nil && len(*res.Buckets) > 0:
+			list = (*res.Buckets)[0].Properties
+		case res.Groups != nil && len(*res.Groups) > 0:
+			list = (*res.Groups)[0].Properties
+		case res.Clusters != nil && len(*res.Clusters) > 0:
+			list = (*res.Clusters)[0].Properties
+		case res.Nodes != nil && len(*res.Nodes) > 0:
+			list = (*res.Nodes)[0].Properties
+		}
+		if list != nil {
+			current = *list
+		}
+	}
+
+	for _, mp := range props {
+		found := false
+		for _, cp := range current {
+			// inherited properties can not be matched
+			if cp.Type != mp.Type || cp.View != mp.View ||
+				cp.InstanceId != cp.SourceInstanceId {
+				continue
+			}
+			name, value := propertyNameValue(cp)
+			if name != mp.Name {
+				continue
+			}
+			found = true
+			switch mp.Type {
+			case `system`, `custom`:
+				if value != mp.Value {
+					p.conflict("%s property %s on %s in view %s has"+
+						" value %s, not %s", mp.Type, mp.Name, o,
+						mp.View, value, mp.Value)
+				}
+			}
+			break
+		}
+		if !found {
+			p.add(planChange{
+				op:       `property`,
+				object:   o,
+				property: mp,
+			}, p.addProperty(o, mp))
+		}
+	}
+	return nil
+}
+
+// propertyNameValue returns the name and value of property prop
+func propertyNameValue(prop proto.Property) (string, string) {
+	switch prop.Type {
+	case `system`:
+		if prop.System != nil {
+			return prop.System.Name, prop.System.Value
+		}
+	case `custom`:
+		if prop.Custom != nil {
+			return prop.Custom.Name, prop.Custom.Value
+		}
+	case `service`:
+		if prop.Service != nil {
+			return prop.Service.Name, ``
+		}
+	case `oncall`:
+		if prop.Oncall != nil {
+			return prop.Oncall.Name, ``
+		}
+	}
+	return ``, ``
+}
+
+// diffChecks compares the check configurations of the manifest with
+// those of the repository, matched by name. Missing configurations
+// are created, differing ones are updated. Object and capability of
+// a configuration can not be updated and are reported as conflict.
+func (p *planner) diffChecks() error {
+	if len(p.manifest.Checks) == 0 {
+		return nil
+	}
+	res, err := p.fetch(fmt.Sprintf("/checks/%s/", p.repoId))
+	if err != nil {
+		return err
+	}
+	existing := map[string]string{}
+	if res.CheckConfigs != nil {
+		for _, c := range *res.CheckConfigs {
+			existing[c.Name] = c.Id
+		}
+	}
+	for _, c := range p.manifest.Checks {
+		o := planObject{c.ObjectType, c.ObjectName, c.Bucket}
+		id, ok := existing[c.Name]
+		if !ok {
+			p.add(planChange{
+				op:     `check`,
+				object: o,
+				check:  c,
+			}, p.createCheck(c))
+			continue
+		}
+
+		if res, err = p.fetch(fmt.Sprintf("/checks/%s/%s", p.repoId,
+			id)); err != nil {
+			return err
+		}
+		if res.CheckConfigs == nil || len(*res.CheckConfigs) == 0 {
+			return fmt.Errorf("Check configuration %s not found", id)
+		}
+		if err = p.diffCheck(o, id, c,
+			(*res.CheckConfigs)[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffCheck compares manifest check c on object o with the existing
+// check configuration current
+func (p *planner) diffCheck(o planObject, id string, c ManifestCheck,
+	current proto.CheckConfig) error {
+	var (
+		err          error
+		capabilityId string
+		want, have   checkState
+	)
+	if capabilityId, err = p.capabilityId(c.Capability); err != nil {
+		return err
+	}
+	if current.ObjectType != o.Type ||
+		current.ObjectId != p.objectId(o) {
+		p.conflict("check %s is configured on %s %s, not on %s",
+			c.Name, current.ObjectType, current.ObjectId, o)
+		return nil
+	}
+	if current.CapabilityId != capabilityId {
+		p.conflict("check %s uses capability %s, not %s", c.Name,
+			current.CapabilityId, c.Capability)
+		return nil
+	}
+
+	if want, err = p.manifestCheckState(c); err != nil {
+		return err
+	}
+	have = configCheckState(current)
+	if want != have {
+		p.add(planChange{
+			op:      `update`,
+			object:  o,
+			check:   c,
+			checkId: id,
+		}, p.updateCheck(c, id))
+	}
+	return nil
+}
+
+// objectId returns the id of object o in the current tree, or an
+// empty string if the object does not exist yet
+func (p *planner) objectId(o planObject) string {
+	switch o.Type {
+	case `repository`:
+		return p.repoId
+	case `bucket`:
+		if tb, ok := p.buckets[o.Name]; ok {
+			return tb.id
+		}
+		return ``
+	}
+	tb, ok := p.buckets[o.Bucket]
+	if !ok {
+		return ``
+	}
+	var obj *treeObject
+	switch o.Type {
+	case `group`:
+		obj = tb.groups[o.Name]
+	case `cluster`:
+		obj = tb.clusters[o.Name]
+	case `node`:
+		obj = tb.nodes[o.Name]
+	}
+	if obj == nil {
+		return ``
+	}
+	return obj.id
+}
+
+// checkState is the part of a check configuration that can be
+// updated, in a comparable form. Thresholds and constraints are
+// sorted and joined.
+type checkState struct {
+	interval     uint64
+	inheritance  bool
+	childrenOnly bool
+	externalId   string
+	thresholds   string
+	constraints  string
+}
+
+// manifestCheckState returns the checkState of manifest check c
+func (p *planner) manifestCheckState(c ManifestCheck) (checkState,
+	error) {
+	var err error
+	st := checkState{
+		inheritance: true,
+		externalId:  c.ExternalId,
+	}
+	if err = ValidateLBoundUint64(c.Interval, &st.interval,
+		1); err != nil {
+		return st, err
+	}
+	if c.Inheritance != `` {
+		if err = ValidateBool(c.Inheritance,
+			&st.inheritance); err != nil {
+			return st, err
+		}
+	}
+	if c.ChildrenOnly != `` {
+		if err = ValidateBool(c.ChildrenOnly,
+			&st.childrenOnly); err != nil {
+			return st, err
+		}
+	}
+
+	thresholds := []string{}
+	for _, t := range c.Thresholds {
+		var (
+			level string
+			value int64
+		)
+		if level, err = p.levelName(t.Level); err != nil {
+			return st, err
+		}
+		if value, err = strconv.ParseInt(t.Value, 10, 64); err != nil {
+			return st, fmt.Errorf("Syntax error, value argument not"+
+				" numeric: %s", t.Value)
+		}
+		thresholds = append(thresholds, fmt.Sprintf("%s/%s/%d",
+			t.Predicate, level, value))
+	}
+	constraints := []string{}
+	for _, ct := range c.Constraints {
+		constraints = append(constraints, constraintKey(ct.Type,
+			ct.Name, ct.Operator, ct.Value))
+	}
+	st.thresholds = sortJoin(thresholds)
+	st.constraints = sortJoin(constraints)
+	return st, nil
+}
+
+// configCheckState returns the checkState of check configuration
+// conf as returned by the server
+func configCheckState(conf proto.CheckConfig) checkState {
+	st := checkState{
+		interval:     conf.Interval,
+		inheritance:  conf.Inheritance,
+		childrenOnly: conf.ChildrenOnly,
+		externalId:   conf.ExternalId,
+	}
+
+	thresholds := []string{}
+	for _, t := range conf.Thresholds {
+		thresholds = append(thresholds, fmt.Sprintf("%s/%s/%d",
+			t.Predicate.Symbol, t.Level.Name, t.Value))
+	}
+	constraints := []string{}
+	for _, ct := range conf.Constraints {
+		var name, value string
+		switch {
+		case ct.Service != nil:
+			name = ct.Service.Name
+		case ct.Oncall != nil:
+			name = ct.Oncall.Name
+		case ct.Attribute != nil:
+			name, value = ct.Attribute.Name, ct.Attribute.Value
+		case ct.System != nil:
+			name, value = ct.System.Name, ct.System.Value
+		case ct.Custom != nil:
+			name, value = ct.Custom.Name, ct.Custom.Value
+		case ct.Native != nil:
+			name, value = ct.Native.Name, ct.Native.Value
+		}
+		constraints = append(constraints, constraintKey(
+			ct.ConstraintType, name, ct.Operator, value))
+	}
+	st.thresholds = sortJoin(thresholds)
+	st.constraints = sortJoin(constraints)
+	return st
+}
+
+// constraintKey returns the comparable form of a constraint. The
+// server stores the == operator as empty string.
+func constraintKey(cType, name, operator, value string) string {
+	if operator == `==` {
+		operator = ``
+	}
+	switch cType {
+	case `service`, `oncall`:
+		operator, value = ``, ``
+	}
+	return strings.Join([]string{cType, name, operator, value}, "\x00")
+}
+
+// sortJoin sorts list and joins it into a single string
+func sortJoin(list []string) string {
+	sort.Strings(list)
+	return strings.Join(list, "\x01")
+}
+
+// createBucket returns the step function creating bucket b
+func (p *planner) createBucket(b ManifestBucket) func() error {
+	return func() error {
+		if err := ValidateEnvironment(b.Environment); err != nil {
+			return err
+		}
+		if err := ValidateRuneCountRange(b.Name, 4, 512); err != nil {
+			return err
+		}
+		req := proto.NewBucketRequest()
+		req.Bucket.Name = b.Name
+		req.Bucket.RepositoryId = p.repoId
+		req.Bucket.Environment = b.Environment
+		return submitRequest(`postbody`, `/buckets/`, req)
+	}
+}
+
+// createGroup returns the step function creating a group
+func (p *planner) createGroup(name, bucket string) func() error {
+	return func() error {
+		if err := ValidateRuneCountRange(name, 4, 256); err != nil {
+			return err
+		}
+		bucketId, err := LookupBucketId(bucket)
+		if err != nil {
+			return err
+		}
+		req := proto.NewGroupRequest()
+		req.Group.Name = name
+		req.Group.BucketId = bucketId
+		return submitRequest(`postbody`, `/groups/`, req)
+	}
+}
+
+// createCluster returns the step function creating a cluster
+func (p *planner) createCluster(name, bucket string) func() error {
+	return func() error {
+		if err := ValidateRuneCountRange(name, 4, 256); err != nil {
+			return err
+		}
+		bucketId, err := LookupBucketId(bucket)
+		if err != nil {
+			return err
+		}
+		req := proto.NewClusterRequest()
+		req.Cluster.Name = name
+		req.Cluster.BucketId = bucketId
+		return submitRequest(`postbody`, `/clusters/`, req)
+	}
+}
+
+// assignNode returns the step function assigning a node to a bucket
+func (p *planner) assignNode(name, bucket string) func() error {
+	return func() error {
+		var (
+			err                      error
+			nodeId, bucketId         string
+			bucketTeamId, nodeTeamId string
+		)
+		if bucketId, err = LookupBucketId(bucket); err != nil {
+			return err
+		}
+		if nodeId, err = LookupNodeId(name); err != nil {
+			return err
+		}
+		if bucketTeamId, err = LookupTeamByBucket(bucketId); err != nil {
+			return err
+		}
+		if nodeTeamId, err = LookupTeamByNode(nodeId); err != nil {
+			return err
+		}
+		if bucketTeamId != nodeTeamId {
+			return fmt.Errorf(`Cannot assign node since node and` +
+				` bucket belong to different teams.`)
+		}
+
+		req := proto.NewNodeRequest()
+		req.Node.Id = nodeId
+		req.Node.Config = &proto.NodeConfig{
+			RepositoryId: p.repoId,
+			BucketId:     bucketId,
+		}
+		return submitRequest(`putbody`,
+			fmt.Sprintf("/nodes/%s/config", nodeId), req)
+	}
+}
+
+// addMember returns the step function adding object kind/name to
+// the group or cluster pType/pName
+func (p *planner) addMember(bucket, kind, name, pType,
+	pName string) func() error {
+	return func() error {
+		var (
+			err                     error
+			bucketId, memberId, pId string
+			req                     proto.Request
+		)
+		if bucketId, err = LookupBucketId(bucket); err != nil {
+			return err
+		}
+		switch kind {
+		case `group`:
+			memberId, err = LookupGroupId(name, bucketId)
+		case `cluster`:
+			memberId, err = LookupClusterId(name, bucketId)
+		case `node`:
+			memberId, err = LookupNodeId(name)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch pType {
+		case `group`:
+			if pId, err = LookupGroupId(pName, bucketId); err != nil {
+				return err
+			}
+			req = proto.NewGroupRequest()
+			req.Group.Id = pId
+			req.Group.BucketId = bucketId
+			switch kind {
+			case `group`:
+				req.Group.MemberGroups = &[]proto.Group{
+					proto.Group{Id: memberId},
+				}
+			case `cluster`:
+				req.Group.MemberClusters = &[]proto.Cluster{
+					proto.Cluster{Id: memberId},
+				}
+			case `node`:
+				req.Group.MemberNodes = &[]proto.Node{
+					proto.Node{Id: memberId},
+				}
+			}
+		case `cluster`:
+			if pId, err = LookupClusterId(pName, bucketId); err != nil {
+				return err
+			}
+			req = proto.NewClusterRequest()
+			req.Cluster.Id = pId
+			req.Cluster.BucketId = bucketId
+			req.Cluster.Members = &[]proto.Node{
+				proto.Node{
+					Id:     memberId,
+					Config: &proto.NodeConfig{BucketId: bucketId},
+				},
+			}
+		}
+		return submitRequest(`postbody`,
+			fmt.Sprintf("/%ss/%s/members/", pType, pId), req)
+	}
+}
+
+// addProperty returns the step function setting property mp on
+// object o
+func (p *planner) addProperty(o planObject,
+	mp ManifestProperty) func() error {
+	return func() error {
+		var (
+			err                error
+			objectId, bucketId string
+			config             *proto.NodeConfig
+			req                proto.Request
+		)
+		repoId := p.repoId
+
+		// id lookup
+		switch o.Type {
+		case `repository`:
+			objectId = repoId
+		case `bucket`:
+			if bucketId, err = LookupBucketId(o.Name); err != nil {
+				return err
+			}
+			objectId = bucketId
+		case `group`:
+			if bucketId, err = LookupBucketId(o.Bucket); err != nil {
+				return err
+			}
+			if objectId, err = LookupGroupId(o.Name,
+				bucketId); err != nil {
+				return err
+			}
+		case `cluster`:
+			if bucketId, err = LookupBucketId(o.Bucket); err != nil {
+				return err
+			}
+			if objectId, err = LookupClusterId(o.Name,
+				bucketId); err != nil {
+				return err
+			}
+		case `node`:
+			if objectId, err = LookupNodeId(o.Name); err != nil {
+				return err
+			}
+			if config, err = LookupNodeConfig(objectId); err != nil {
+				return err
+			}
+			bucketId = config.BucketId
+		}
+
+		// property assembly
+		prop := proto.Property{
+			Type:         mp.Type,
+			View:         mp.View,
+			Inheritance:  true,
+			ChildrenOnly: false,
+		}
+		if mp.Inheritance != `` {
+			if err = ValidateBool(mp.Inheritance,
+				&prop.Inheritance); err != nil {
+				return err
+			}
+		}
+		if mp.ChildrenOnly != `` {
+			if err = ValidateBool(mp.ChildrenOnly,
+				&prop.ChildrenOnly); err != nil {
+				return err
+			}
+		}
+		switch mp.Type {
+		case `system`:
+			if err = ValidateSystemProperty(mp.Name); err != nil {
+				return err
+			}
+			prop.System = &proto.PropertySystem{
+				Name:  mp.Name,
+				Value: mp.Value,
+			}
+		case `service`:
+			var teamId string
+			if o.Type == `repository` {
+				teamId, err = LookupTeamByRepo(repoId)
+			} else {
+				teamId, err = LookupTeamByBucket(bucketId)
+			}
+			if err != nil {
+				return err
+			}
+			prop.Service = &proto.PropertyService{
+				Name:       mp.Name,
+				TeamId:     teamId,
+				Attributes: []proto.ServiceAttribute{},
+			}
+		case `oncall`:
+			prop.Oncall = &proto.PropertyOncall{}
+			if prop.Oncall.Id, err = LookupOncallId(mp.Name); err != nil {
+				return err
+			}
+			if prop.Oncall.Name, prop.Oncall.Number,
+				err = LookupOncallDetails(prop.Oncall.Id); err != nil {
+				return err
+			}
+		case `custom`:
+			prop.Custom = &proto.PropertyCustom{
+				Name:         mp.Name,
+				RepositoryId: repoId,
+				Value:        mp.Value,
+			}
+			if prop.Custom.Id, err = LookupCustomPropertyId(mp.Name,
+				repoId); err != nil {
+				return err
+			}
+		}
+
+		// request assembly
+		switch o.Type {
+		case `node`:
+			req = proto.NewNodeRequest()
+			req.Node.Id = objectId
+			req.Node.Config = config
+			req.Node.Properties = &[]proto.Property{prop}
+		case `cluster`:
+			req = proto.NewClusterRequest()
+			req.Cluster.Id = objectId
+			req.Cluster.BucketId = bucketId
+			req.Cluster.Properties = &[]proto.Property{prop}
+		case `group`:
+			req = proto.NewGroupRequest()
+			req.Group.Id = objectId
+			req.Group.BucketId = bucketId
+			req.Group.Properties = &[]proto.Property{prop}
+		case `bucket`:
+			req = proto.NewBucketRequest()
+			req.Bucket.Id = objectId
+			req.Bucket.Properties = &[]proto.Property{prop}
+		case `repository`:
+			req = proto.NewRepositoryRequest()
+			req.Repository.Id = repoId
+			req.Repository.Properties = &[]proto.Property{prop}
+		}
+
+		object := o.Type + `s`
+		if o.Type == `repository` {
+			object = o.Type
+		}
+		return submitRequest(`postbody`, fmt.Sprintf(
+			"/%s/%s/property/%s/", object, objectId, mp.Type), req)
+	}
+}
+
+// createCheck returns the step function creating check
+// configuration c
+func (p *planner) createCheck(c ManifestCheck) func() error {
+	return func() error {
+		req, err := p.checkRequest(c)
+		if err != nil {
+			return err
+		}
+		return submitRequest(`postbody`,
+			fmt.Sprintf("/checks/%s/", p.repoId), req)
+	}
+}
+
+// updateCheck returns the step function updating check
+// configuration id to match c
+func (p *planner) updateCheck(c ManifestCheck, id string) func() error {
+	return func() error {
+		req, err := p.checkRequest(c)
+		if err != nil {
+			return err
+		}
+		req.CheckConfig.Id = id
+		return submitRequest(`putbody`,
+			fmt.Sprintf("/checks/%s/%s", p.repoId, id), req)
+	}
+}
+
+// checkRequest builds the request for check configuration c
+func (p *planner) checkRequest(c ManifestCheck) (proto.Request, error) {
+	var (
+		err    error
+		teamId string
+	)
+	req := proto.NewCheckConfigRequest()
+	thresholds := []proto.CheckConfigThreshold{}
+	constraints := []proto.CheckConfigConstraint{}
+
+	if err = ValidateLBoundUint64(c.Interval,
+		&req.CheckConfig.Interval, 1); err != nil {
+		return req, err
+	}
+	if err = ValidateRuneCount(c.Name, 256); err != nil {
+		return req, err
+	}
+	if req.CheckConfig.CapabilityId, err = LookupCapabilityId(
+		c.Capability); err != nil {
+		return req, err
+	}
+	req.CheckConfig.Name = c.Name
+	req.CheckConfig.ObjectType = c.ObjectType
+	req.CheckConfig.RepositoryId = p.repoId
+	if req.CheckConfig.BucketId, err = LookupBucketId(
+		c.Bucket); err != nil {
+		return req, err
+	}
+	if req.CheckConfig.ObjectId, err = LookupCheckObjectId(
+		c.ObjectType,
+		c.ObjectName,
+		req.CheckConfig.BucketId,
+	); err != nil {
+		return req, err
+	}
+	// clear bucketid if check is on a repository
+	if req.CheckConfig.ObjectType == `repository` {
+		req.CheckConfig.BucketId = ``
+	}
+
+	req.CheckConfig.Inheritance = true
+	if c.Inheritance != `` {
+		if err = ValidateBool(c.Inheritance,
+			&req.CheckConfig.Inheritance); err != nil {
+			return req, err
+		}
+	}
+	if c.ChildrenOnly != `` {
+		if err = ValidateBool(c.ChildrenOnly,
+			&req.CheckConfig.ChildrenOnly); err != nil {
+			return req, err
+		}
+	}
+	if c.ExternalId != `` {
+		if err = ValidateRuneCount(c.ExternalId, 64); err != nil {
+			return req, err
+		}
+		req.CheckConfig.ExternalId = c.ExternalId
+	}
+
+	for _, t := range c.Thresholds {
+		thr := proto.CheckConfigThreshold{
+			Predicate: proto.Predicate{Symbol: t.Predicate},
+			Level:     proto.Level{Name: t.Level},
+		}
+		if thr.Value, err = strconv.ParseInt(
+			t.Value, 10, 64); err != nil {
+			return req, fmt.Errorf("Syntax error, value argument not"+
+				" numeric: %s", t.Value)
+		}
+		thresholds = append(thresholds, thr)
+	}
+	for _, ct := range c.Constraints {
+		constr := proto.CheckConfigConstraint{
+			ConstraintType: ct.Type,
+			Operator:       ct.Operator,
+		}
+		switch ct.Type {
+		case `service`:
+			constr.Service = &proto.PropertyService{Name: ct.Name}
+		case `oncall`:
+			constr.Oncall = &proto.PropertyOncall{Name: ct.Name}
+		case `attribute`:
+			constr.Attribute = &proto.ServiceAttribute{
+				Name:  ct.Name,
+				Value: ct.Value,
+			}
+		case `system`:
+			constr.System = &proto.PropertySystem{
+				Name:  ct.Name,
+				Value: ct.Value,
+			}
+		case `custom`:
+			constr.Custom = &proto.PropertyCustom{
+				Name:  ct.Name,
+				Value: ct.Value,
+			}
+		case `native`:
+			constr.Native = &proto.PropertyNative{
+				Name:  ct.Name,
+				Value: ct.Value,
+			}
+		default:
+			return req, fmt.Errorf("Unknown constraint type: %s", ct.Type)
+		}
+		constraints = append(constraints, constr)
+	}
+
+	if teamId, err = LookupTeamByRepo(p.repoId); err != nil {
+		return req, err
+	}
+	if req.CheckConfig.Thresholds, err = ValidateThresholds(
+		thresholds,
+	); err != nil {
+		return req, err
+	}
+	if req.CheckConfig.Constraints, err = ValidateCheckConstraints(
+		p.repoId,
+		teamId,
+		constraints,
+	); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// submitRequest sends req and waits for the resulting job
+func submitRequest(rqType, path string, req proto.Request) error {
+	var (
+		err  error
+		resp *resty.Response
+		res  *proto.Result
+	)
+	switch rqType {
+	case `postbody`:
+		resp, err = PostReqBody(req, path)
+	case `putbody`:
+		resp, err = PutReqBody(req, path)
+	default:
+		return fmt.Errorf("Unsupported request type: %s", rqType)
+	}
+	if err != nil {
+		return err
+	}
+	if res, err = decodeResponse(resp); err != nil {
+		return err
+	}
+	if err = checkApplicationError(res); err != nil {
+		return err
+	}
+	return waitForJob(res)
+}
+
+// waitForJob blocks until the job created by result has finished
+// and reports failed jobs as error
+func waitForJob(result *proto.Result) error {
+	if result.StatusCode != 202 || result.JobId == `` {
+		return nil
+	}
+
+	_, err := PutReq(fmt.Sprintf("/jobs/%s", result.JobId))
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	res, err := fetchObjList(fmt.Sprintf("/jobs/%s", result.JobId))
+	if err != nil {
+		return err
+	}
+	if res.Jobs == nil || len(*res.Jobs) == 0 {
+		return fmt.Errorf("Job %s not found", result.JobId)
+	}
+	job := (*res.Jobs)[0]
+	switch {
+	case job.Status != `processed`:
+		return fmt.Errorf("Job %s did not finish, status: %s",
+			job.Id, job.Status)
+	case job.Result != `success`:
+		return fmt.Errorf("Job %s failed: %s", job.Id, job.Error)
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,780 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/1and1/soma/lib/proto"
+)
+
+// fakeObject is an object of a fakeRepository. Parent is empty for
+// objects that are direct children of their bucket.
+type fakeObject struct {
+	id     string
+	parent string
+}
+
+type fakeBucket struct {
+	id       string
+	groups   map[string]*fakeObject
+	clusters map[string]*fakeObject
+	nodes    map[string]*fakeObject
+}
+
+// fakeRepository serves the requests of the planner from memory and
+// applies plan changes the way the server would
+type fakeRepository struct {
+	id         string
+	seq        int
+	buckets    map[string]*fakeBucket
+	properties map[string][]proto.Property
+	checks     map[string]proto.CheckConfig
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		id:         `repo-0`,
+		buckets:    map[string]*fakeBucket{},
+		properties: map[string][]proto.Property{},
+		checks:     map[string]proto.CheckConfig{},
+	}
+}
+
+func (f *fakeRepository) newId(kind string) string {
+	f.seq++
+	return fmt.Sprintf("%s-%d", kind, f.seq)
+}
+
+func (f *fakeRepository) bucket(name string) *fakeBucket {
+	if b, ok := f.buckets[name]; ok {
+		return b
+	}
+	b := &fakeBucket{
+		id:       f.newId(`bucket`),
+		groups:   map[string]*fakeObject{},
+		clusters: map[string]*fakeObject{},
+		nodes:    map[string]*fakeObject{},
+	}
+	f.buckets[name] = b
+	return b
+}
+
+func (f *fakeRepository) object(o planObject) *fakeObject {
+	b := f.buckets[o.Bucket]
+	if b == nil {
+		return nil
+	}
+	switch o.Type {
+	case `group`:
+		return b.groups[o.Name]
+	case `cluster`:
+		return b.clusters[o.Name]
+	case `node`:
+		return b.nodes[o.Name]
+	}
+	return nil
+}
+
+func (f *fakeRepository) objectId(o planObject) string {
+	switch o.Type {
+	case `repository`:
+		return f.id
+	case `bucket`:
+		if b := f.buckets[o.Name]; b != nil {
+			return b.id
+		}
+		return ``
+	case `node`:
+		for _, b := range f.buckets {
+			if n, ok := b.nodes[o.Name]; ok {
+				return n.id
+			}
+		}
+		return ``
+	}
+	if obj := f.object(o); obj != nil {
+		return obj.id
+	}
+	return ``
+}
+
+func (f *fakeRepository) setProperty(o planObject, mp ManifestProperty) {
+	id := f.objectId(o)
+	prop := proto.Property{
+		Type:             mp.Type,
+		View:             mp.View,
+		InstanceId:       `instance-` + id,
+		SourceInstanceId: `instance-` + id,
+	}
+	switch mp.Type {
+	case `system`:
+		prop.System = &proto.PropertySystem{Name: mp.Name, Value: mp.Value}
+	case `custom`:
+		prop.Custom = &proto.PropertyCustom{Name: mp.Name, Value: mp.Value}
+	case `service`:
+		prop.Service = &proto.PropertyService{Name: mp.Name}
+	case `oncall`:
+		prop.Oncall = &proto.PropertyOncall{Name: mp.Name}
+	}
+	f.properties[id] = append(f.properties[id], prop)
+}
+
+// setCheck stores manifest check c as the server would after
+// validation
+func (f *fakeRepository) setCheck(c ManifestCheck, id string) {
+	conf := proto.CheckConfig{
+		Id:           id,
+		Name:         c.Name,
+		RepositoryId: f.id,
+		CapabilityId: testCapabilityId(c.Capability),
+		ObjectType:   c.ObjectType,
+		ObjectId: f.objectId(planObject{
+			c.ObjectType, c.ObjectName, c.Bucket,
+		}),
+		Inheritance: c.Inheritance != `false`,
+		ExternalId:  c.ExternalId,
+	}
+	conf.ChildrenOnly, _ = strconv.ParseBool(c.ChildrenOnly)
+	conf.Interval, _ = strconv.ParseUint(c.Interval, 10, 64)
+	for _, t := range c.Thresholds {
+		level, _ := testLevelName(t.Level)
+		value, _ := strconv.ParseInt(t.Value, 10, 64)
+		conf.Thresholds = append(conf.Thresholds,
+			proto.CheckConfigThreshold{
+				Predicate: proto.Predicate{Symbol: t.Predicate},
+				Level:     proto.Level{Name: level},
+				Value:     value,
+			})
+	}
+	for _, ct := range c.Constraints {
+		constr := proto.CheckConfigConstraint{
+			ConstraintType: ct.Type,
+			Operator:       ct.Operator,
+		}
+		if constr.Operator == `==` {
+			constr.Operator = ``
+		}
+		switch ct.Type {
+		case `service`:
+			constr.Operator = ``
+			constr.Service = &proto.PropertyService{Name: ct.Name}
+		case `oncall`:
+			constr.Operator = ``
+			constr.Oncall = &proto.PropertyOncall{Name: ct.Name}
+		case `attribute`:
+			constr.Attribute = &proto.ServiceAttribute{
+				Name: ct.Name, Value: ct.Value,
+			}
+		case `system`:
+			constr.System = &proto.PropertySystem{
+				Name: ct.Name, Value: ct.Value,
+			}
+		case `custom`:
+			constr.Custom = &proto.PropertyCustom{
+				Name: ct.Name, Value: ct.Value,
+			}
+		case `native`:
+			constr.Native = &proto.PropertyNative{
+				Name: ct.Name, Value: ct.Value,
+			}
+		}
+		conf.Constraints = append(conf.Constraints, constr)
+	}
+	f.checks[c.Name] = conf
+}
+
+// apply performs change c
+func (f *fakeRepository) apply(c planChange) {
+	switch c.op {
+	case `create`:
+		switch c.object.Type {
+		case `bucket`:
+			f.bucket(c.object.Name)
+		case `group`:
+			f.bucket(c.object.Bucket).groups[c.object.Name] =
+				&fakeObject{id: f.newId(`group`)}
+		case `cluster`:
+			f.bucket(c.object.Bucket).clusters[c.object.Name] =
+				&fakeObject{id: f.newId(`cluster`)}
+		}
+	case `assign`:
+		f.bucket(c.parent.Name).nodes[c.object.Name] =
+			&fakeObject{id: f.newId(`node`)}
+	case `member`:
+		f.object(c.object).parent = c.parent.Type + `/` + c.parent.Name
+	case `property`:
+		f.setProperty(c.object, c.property)
+	case `check`:
+		f.setCheck(c.check, f.newId(`check`))
+	case `update`:
+		f.setCheck(c.check, c.checkId)
+	}
+}
+
+// fetch answers the GET requests of the planner
+func (f *fakeRepository) fetch(path string) (*proto.Result, error) {
+	res := &proto.Result{}
+	parts := strings.Split(strings.Trim(path, `/`), `/`)
+	switch {
+	case len(parts) == 4 && parts[0] == `repository`:
+		res.Tree = &proto.Tree{Repository: f.tree()}
+	case len(parts) == 2 && parts[0] == `repository`:
+		res.Repositories = &[]proto.Repository{{
+			Id:         f.id,
+			Properties: f.propertyList(parts[1]),
+		}}
+	case len(parts) == 2 && parts[0] == `buckets`:
+		res.Buckets = &[]proto.Bucket{{
+			Id:         parts[1],
+			Properties: f.propertyList(parts[1]),
+		}}
+	case len(parts) == 2 && parts[0] == `groups`:
+		res.Groups = &[]proto.Group{{
+			Id:         parts[1],
+			Properties: f.propertyList(parts[1]),
+		}}
+	case len(parts) == 2 && parts[0] == `clusters`:
+		res.Clusters = &[]proto.Cluster{{
+			Id:         parts[1],
+			Properties: f.propertyList(parts[1]),
+		}}
+	case len(parts) == 2 && parts[0] == `nodes`:
+		res.Nodes = &[]proto.Node{{
+			Id:         parts[1],
+			Properties: f.propertyList(parts[1]),
+		}}
+	case len(parts) == 2 && parts[0] == `checks`:
+		list := []proto.CheckConfig{}
+		for _, c := range f.checks {
+			list = append(list, proto.CheckConfig{
+				Id:   c.Id,
+				Name: c.Name,
+			})
+		}
+		res.CheckConfigs = &list
+	case len(parts) == 3 && parts[0] == `checks`:
+		list := []proto.CheckConfig{}
+		for _, c := range f.checks {
+			if c.Id == parts[2] {
+				list = append(list, c)
+			}
+		}
+		res.CheckConfigs = &list
+	default:
+		return nil, fmt.Errorf("Unexpected request: %s", path)
+	}
+	return res, nil
+}
+
+func (f *fakeRepository) propertyList(id string) *[]proto.Property {
+	list := append([]proto.Property{}, f.properties[id]...)
+	return &list
+}
+
+// tree returns the repository tree as served by the tree endpoint
+func (f *fakeRepository) tree() *proto.Repository {
+	repo := &proto.Repository{Id: f.id, Members: &[]proto.Bucket{}}
+	for name, b := range f.buckets {
+		bucket := proto.Bucket{
+			Id:             b.id,
+			Name:           name,
+			MemberGroups:   &[]proto.Group{},
+			MemberClusters: &[]proto.Cluster{},
+			MemberNodes:    &[]proto.Node{},
+		}
+		for gName, g := range b.groups {
+			if g.parent == `` {
+				*bucket.MemberGroups = append(*bucket.MemberGroups,
+					b.treeGroup(gName))
+			}
+		}
+		for cName, c := range b.clusters {
+			if c.parent == `` {
+				*bucket.MemberClusters = append(
+					*bucket.MemberClusters, b.treeCluster(cName))
+			}
+		}
+		for nName, n := range b.nodes {
+			if n.parent == `` {
+				*bucket.MemberNodes = append(*bucket.MemberNodes,
+					proto.Node{Id: n.id, Name: nName})
+			}
+		}
+		*repo.Members = append(*repo.Members, bucket)
+	}
+	return repo
+}
+
+func (b *fakeBucket) treeGroup(name string) proto.Group {
+	self := `group/` + name
+	g := proto.Group{
+		Id:             b.groups[name].id,
+		Name:           name,
+		MemberGroups:   &[]proto.Group{},
+		MemberClusters: &[]proto.Cluster{},
+		MemberNodes:    &[]proto.Node{},
+	}
+	for gName, mg := range b.groups {
+		if mg.parent == self {
+			*g.MemberGroups = append(*g.MemberGroups, b.treeGroup(gName))
+		}
+	}
+	for cName, mc := range b.clusters {
+		if mc.parent == self {
+			*g.MemberClusters = append(*g.MemberClusters,
+				b.treeCluster(cName))
+		}
+	}
+	for nName, mn := range b.nodes {
+		if mn.parent == self {
+			*g.MemberNodes = append(*g.MemberNodes,
+				proto.Node{Id: mn.id, Name: nName})
+		}
+	}
+	return g
+}
+
+func (b *fakeBucket) treeCluster(name string) proto.Cluster {
+	self := `cluster/` + name
+	c := proto.Cluster{
+		Id:      b.clusters[name].id,
+		Name:    name,
+		Members: &[]proto.Node{},
+	}
+	for nName, mn := range b.nodes {
+		if mn.parent == self {
+			*c.Members = append(*c.Members,
+				proto.Node{Id: mn.id, Name: nName})
+		}
+	}
+	return c
+}
+
+func testCapabilityId(capability string) string {
+	return `capability-` + capability
+}
+
+func testLevelName(level string) (string, error) {
+	switch level {
+	case `warn`, `warning`:
+		return `warning`, nil
+	case `crit`, `critical`:
+		return `critical`, nil
+	}
+	return ``, fmt.Errorf("Unknown level: %s", level)
+}
+
+// newTestPlanner returns a planner for manifest m that reads from f
+func newTestPlanner(m *Manifest, f *fakeRepository) *planner {
+	p := newPlanner(m, f.id)
+	p.fetch = f.fetch
+	p.capabilityId = func(capability string) (string, error) {
+		return testCapabilityId(capability), nil
+	}
+	p.levelName = testLevelName
+	return p
+}
+
+func planTexts(plan *Plan) []string {
+	texts := []string{}
+	for _, s := range plan.Steps {
+		texts = append(texts, s.Text)
+	}
+	return texts
+}
+
+func TestDiffNode(t *testing.T) {
+	tests := []struct {
+		name      string
+		bucket    string
+		node      string
+		steps     []string
+		conflicts int
+	}{
+		{`assigned`, `web`, `web01`, []string{}, 0},
+		{`unassigned`, `web`, `web02`,
+			[]string{`assign node web02 to bucket web`}, 0},
+		{`other bucket`, `web`, `db01`, []string{}, 1},
+	}
+
+	for _, tt := range tests {
+		f := newFakeRepository()
+		f.bucket(`web`).nodes[`web01`] = &fakeObject{id: `node-web01`}
+		f.bucket(`db`).nodes[`db01`] = &fakeObject{id: `node-db01`}
+		p := newTestPlanner(&Manifest{Repository: `test`}, f)
+		if err := p.loadTree(); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+
+		tb := p.buckets[tt.bucket]
+		p.diffNode(tt.bucket, tb, tt.node)
+		if texts := planTexts(p.plan); !reflect.DeepEqual(texts,
+			tt.steps) {
+			t.Errorf("%s: expected steps %v, got %v", tt.name,
+				tt.steps, texts)
+		}
+		if len(p.plan.Conflicts) != tt.conflicts {
+			t.Errorf("%s: expected %d conflicts, got %v", tt.name,
+				tt.conflicts, p.plan.Conflicts)
+		}
+		// a planned assignment is not planned twice
+		p.diffNode(tt.bucket, tb, tt.node)
+		if len(p.plan.Steps) != len(tt.steps) {
+			t.Errorf("%s: node planned twice", tt.name)
+		}
+	}
+}
+
+func TestDiffProperties(t *testing.T) {
+	sys := func(name, value string) ManifestProperty {
+		return ManifestProperty{
+			Type:  `system`,
+			Name:  name,
+			Value: value,
+			View:  `internal`,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		current   []ManifestProperty
+		inherited []ManifestProperty
+		manifest  []ManifestProperty
+		steps     []string
+		conflicts int
+	}{
+		{
+			name:     `missing`,
+			manifest: []ManifestProperty{sys(`fqdn`, `web01.example.com`)},
+			steps: []string{`add system property fqdn to node web01` +
+				` in view internal`},
+		},
+		{
+			name:     `equal`,
+			current:  []ManifestProperty{sys(`fqdn`, `web01.example.com`)},
+			manifest: []ManifestProperty{sys(`fqdn`, `web01.example.com`)},
+			steps:    []string{},
+		},
+		{
+			name:      `different value`,
+			current:   []ManifestProperty{sys(`fqdn`, `web01.example.org`)},
+			manifest:  []ManifestProperty{sys(`fqdn`, `web01.example.com`)},
+			steps:     []string{},
+			conflicts: 1,
+		},
+		{
+			name: `other view`,
+			current: []ManifestProperty{{
+				Type: `system`, Name: `fqdn`,
+				Value: `web01.example.com`, View: `external`,
+			}},
+			manifest: []ManifestProperty{sys(`fqdn`, `web01.example.com`)},
+			steps: []string{`add system property fqdn to node web01` +
+				` in view internal`},
+		},
+		{
+			name:      `inherited only`,
+			inherited: []ManifestProperty{sys(`fqdn`, `web01.example.com`)},
+			manifest:  []ManifestProperty{sys(`fqdn`, `web01.example.com`)},
+			steps: []string{`add system property fqdn to node web01` +
+				` in view internal`},
+		},
+		{
+			name: `service`,
+			current: []ManifestProperty{
+				{Type: `service`, Name: `nginx`, View: `internal`},
+			},
+			manifest: []ManifestProperty{
+				{Type: `service`, Name: `nginx`, View: `internal`},
+				{Type: `oncall`, Name: `web-oncall`, View: `internal`},
+			},
+			steps: []string{`add oncall property web-oncall to node` +
+				` web01 in view internal`},
+		},
+	}
+
+	for _, tt := range tests {
+		f := newFakeRepository()
+		f.bucket(`web`).nodes[`web01`] = &fakeObject{id: `node-web01`}
+		node := planObject{`node`, `web01`, `web`}
+		for _, mp := range tt.current {
+			f.setProperty(node, mp)
+		}
+		for _, mp := range tt.inherited {
+			f.setProperty(node, mp)
+			props := f.properties[`node-web01`]
+			props[len(props)-1].SourceInstanceId = `instance-bucket`
+		}
+
+		p := newTestPlanner(&Manifest{Repository: `test`}, f)
+		if err := p.diffProperties(node, `node-web01`,
+			tt.manifest); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if texts := planTexts(p.plan); !reflect.DeepEqual(texts,
+			tt.steps) {
+			t.Errorf("%s: expected steps %v, got %v", tt.name,
+				tt.steps, texts)
+		}
+		if len(p.plan.Conflicts) != tt.conflicts {
+			t.Errorf("%s: expected %d conflicts, got %v", tt.name,
+				tt.conflicts, p.plan.Conflicts)
+		}
+	}
+}
+
+func testManifestCheck() ManifestCheck {
+	return ManifestCheck{
+		Name:       `http`,
+		Bucket:     `web`,
+		ObjectType: `node`,
+		ObjectName: `web01`,
+		Capability: `nagios.http`,
+		Interval:   `60`,
+		Thresholds: []ManifestThreshold{
+			{Predicate: `>=`, Level: `warning`, Value: `500`},
+			{Predicate: `>=`, Level: `critical`, Value: `1000`},
+		},
+		Constraints: []ManifestConstraint{
+			{Type: `system`, Name: `environment`, Value: `live`},
+			{Type: `service`, Name: `nginx`},
+		},
+	}
+}
+
+func TestDiffChecks(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  func(c *ManifestCheck)
+		manifest  func(c *ManifestCheck)
+		steps     []string
+		conflicts int
+	}{
+		{
+			name:     `missing`,
+			manifest: func(c *ManifestCheck) {},
+			steps:    []string{`create check http on node web01`},
+		},
+		{
+			name:     `equal`,
+			existing: func(c *ManifestCheck) {},
+			manifest: func(c *ManifestCheck) {},
+			steps:    []string{},
+		},
+		{
+			name:     `equal after normalization`,
+			existing: func(c *ManifestCheck) {},
+			manifest: func(c *ManifestCheck) {
+				c.Inheritance = `true`
+				c.ChildrenOnly = `false`
+				c.Thresholds = []ManifestThreshold{
+					{Predicate: `>=`, Level: `crit`, Value: `1000`},
+					{Predicate: `>=`, Level: `warn`, Value: `0500`},
+				}
+				c.Constraints = []ManifestConstraint{
+					{Type: `service`, Name: `nginx`},
+					{Type: `system`, Name: `environment`,
+						Operator: `==`, Value: `live`},
+				}
+			},
+			steps: []string{},
+		},
+		{
+			name:     `interval`,
+			existing: func(c *ManifestCheck) {},
+			manifest: func(c *ManifestCheck) { c.Interval = `30` },
+			steps:    []string{`update check http on node web01`},
+		},
+		{
+			name:     `threshold`,
+			existing: func(c *ManifestCheck) {},
+			manifest: func(c *ManifestCheck) {
+				c.Thresholds = c.Thresholds[:1]
+			},
+			steps: []string{`update check http on node web01`},
+		},
+		{
+			name:     `constraint operator`,
+			existing: func(c *ManifestCheck) {},
+			manifest: func(c *ManifestCheck) {
+				c.Constraints[0].Operator = `!=`
+			},
+			steps: []string{`update check http on node web01`},
+		},
+		{
+			name:     `inheritance`,
+			existing: func(c *ManifestCheck) {},
+			manifest: func(c *ManifestCheck) { c.Inheritance = `false` },
+			steps:    []string{`update check http on node web01`},
+		},
+		{
+			name:      `capability`,
+			existing:  func(c *ManifestCheck) {},
+			manifest:  func(c *ManifestCheck) { c.Capability = `nagios.ping` },
+			steps:     []string{},
+			conflicts: 1,
+		},
+		{
+			name:      `object`,
+			existing:  func(c *ManifestCheck) { c.ObjectName = `web02` },
+			manifest:  func(c *ManifestCheck) {},
+			steps:     []string{},
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		f := newFakeRepository()
+		f.bucket(`web`).nodes[`web01`] = &fakeObject{id: `node-web01`}
+		f.bucket(`web`).nodes[`web02`] = &fakeObject{id: `node-web02`}
+		if tt.existing != nil {
+			c := testManifestCheck()
+			tt.existing(&c)
+			f.setCheck(c, `check-http`)
+		}
+		c := testManifestCheck()
+		tt.manifest(&c)
+
+		p := newTestPlanner(&Manifest{
+			Repository: `test`,
+			Checks:     []ManifestCheck{c},
+		}, f)
+		if err := p.loadTree(); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if err := p.diffChecks(); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if texts := planTexts(p.plan); !reflect.DeepEqual(texts,
+			tt.steps) {
+			t.Errorf("%s: expected steps %v, got %v", tt.name,
+				tt.steps, texts)
+		}
+		if len(p.plan.Conflicts) != tt.conflicts {
+			t.Errorf("%s: expected %d conflicts, got %v", tt.name,
+				tt.conflicts, p.plan.Conflicts)
+		}
+	}
+}
+
+func TestPlanIdempotent(t *testing.T) {
+	f := newFakeRepository()
+	f.bucket(`web`).nodes[`web01`] = &fakeObject{id: `node-web01`}
+	existing := testManifestCheck()
+	existing.Interval = `300`
+	f.setCheck(existing, `check-http`)
+
+	ping := testManifestCheck()
+	ping.Name = `ping`
+	ping.ObjectType = `group`
+	ping.ObjectName = `frontend`
+	ping.Capability = `nagios.ping`
+
+	m := &Manifest{
+		Repository: `test`,
+		Properties: []ManifestProperty{
+			{Type: `oncall`, Name: `web-oncall`, View: `internal`},
+		},
+		Buckets: []ManifestBucket{
+			{
+				Name:        `web`,
+				Environment: `live`,
+				Groups: []ManifestGroup{
+					{
+						Name:           `frontend`,
+						MemberGroups:   []string{`static`},
+						MemberClusters: []string{`lb`},
+						MemberNodes:    []string{`web01`, `web02`},
+						Properties: []ManifestProperty{
+							{Type: `service`, Name: `nginx`,
+								View: `internal`},
+						},
+					},
+					{Name: `static`},
+				},
+				Clusters: []ManifestCluster{
+					{Name: `lb`, MemberNodes: []string{`lb01`}},
+				},
+				Nodes: []ManifestNode{
+					{
+						Name: `web01`,
+						Properties: []ManifestProperty{
+							{Type: `system`, Name: `fqdn`,
+								Value: `web01.example.com`,
+								View:  `internal`},
+						},
+					},
+				},
+			},
+			{
+				Name:        `db`,
+				Environment: `live`,
+				Nodes: []ManifestNode{
+					{
+						Name: `db01`,
+						Properties: []ManifestProperty{
+							{Type: `custom`, Name: `role`,
+								Value: `primary`, View: `any`},
+						},
+					},
+				},
+			},
+		},
+		Checks: []ManifestCheck{testManifestCheck(), ping},
+	}
+	if err := m.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := newTestPlanner(m, f).compute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Conflicts) != 0 {
+		t.Fatalf("Unexpected conflicts: %v", plan.Conflicts)
+	}
+	texts := planTexts(plan)
+	for _, want := range []string{
+		`create bucket db (environment live)`,
+		`create group frontend in bucket web`,
+		`assign node lb01 to bucket web`,
+		`add cluster lb to group frontend in bucket web`,
+		`add custom property role to node db01 in view any`,
+		`update check http on node web01`,
+		`create check ping on group frontend in bucket web`,
+	} {
+		found := false
+		for _, text := range texts {
+			if text == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Plan is missing step: %s", want)
+		}
+	}
+
+	for _, s := range plan.Steps {
+		f.apply(s.change)
+	}
+	replan, err := newTestPlanner(m, f).compute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !replan.Empty() {
+		t.Errorf("Plan after apply is not empty: %v, conflicts %v",
+			planTexts(replan), replan.Conflicts)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
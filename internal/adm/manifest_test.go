@@ -0,0 +1,69 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadManifestYAML(t *testing.T) {
+	dir, err := ioutil.TempDir(``, `manifest`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, `repo.yaml`)
+	if err = ioutil.WriteFile(path, []byte(`
+repository: test
+buckets:
+  - name: web
+    environment: live
+    nodes:
+      - name: web01
+        properties:
+          - type: system
+            name: fqdn
+            value: web01.example.com
+            view: internal
+checks:
+  - name: http
+    bucket: web
+    objectType: node
+    objectName: web01
+    capability: nagios.http
+    interval: 60
+    childrenOnly: false
+    thresholds:
+      - predicate: ">="
+        level: warning
+        value: 500
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Repository != `test` || len(m.Buckets) != 1 ||
+		len(m.Buckets[0].Nodes) != 1 ||
+		m.Buckets[0].Nodes[0].Properties[0].Value != `web01.example.com` {
+		t.Errorf("Unexpected manifest: %+v", m)
+	}
+	if len(m.Checks) != 1 || m.Checks[0].Interval != `60` ||
+		m.Checks[0].ChildrenOnly != `false` ||
+		m.Checks[0].Thresholds[0].Value != `500` {
+		t.Errorf("Unexpected check: %+v", m.Checks)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
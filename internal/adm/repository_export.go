@@ -0,0 +1,51 @@
+package adm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/1and1/soma/lib/proto"
+)
+
+// ExportRepository returns the export of the repository with UUID id
+func ExportRepository(id string) (*proto.RepositoryExport, error) {
+	req := proto.NewSystemOperationRequest()
+	req.SystemOperation.Request = `export_repository`
+	req.SystemOperation.RepositoryId = id
+
+	resp, err := PostReqBody(req, `/system/`)
+	if err != nil {
+		return nil, err
+	}
+	res, err := decodeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkApplicationError(res); err != nil {
+		return nil, err
+	}
+	if res.SystemOperations == nil || len(*res.SystemOperations) == 0 ||
+		(*res.SystemOperations)[0].Export == nil {
+		return nil, fmt.Errorf(`Result contained no repository export`)
+	}
+	return (*res.SystemOperations)[0].Export, nil
+}
+
+// LoadRepositoryExport reads a repository export from file path
+func LoadRepositoryExport(path string) (*proto.RepositoryExport, error) {
+	file, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	export := &proto.RepositoryExport{}
+	if err = json.Unmarshal(file, export); err != nil {
+		return nil, err
+	}
+	if export.RepositoryId == `` || export.Queries == nil {
+		return nil, fmt.Errorf("%s is not a repository export", path)
+	}
+	return export, nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
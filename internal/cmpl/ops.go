@@ -6,4 +6,12 @@ func OpsRepoRebuild(c *cli.Context) {
 	Generic(c, []string{`level`})
 }
 
+func OpsRepoExport(c *cli.Context) {
+	Generic(c, []string{`to`})
+}
+
+func OpsRepoImport(c *cli.Context) {
+	Generic(c, []string{`from`})
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
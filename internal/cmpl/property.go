@@ -10,4 +10,14 @@ func Repository(c *cli.Context) {
 	Generic(c, []string{`repository`})
 }
 
+func PropertySchema(c *cli.Context) {
+	GenericMulti(c, []string{`type`, `minimum`, `maximum`, `pattern`},
+		[]string{`value`})
+}
+
+func RepositoryPropertySchema(c *cli.Context) {
+	GenericMulti(c, []string{`repository`, `type`, `minimum`,
+		`maximum`, `pattern`}, []string{`value`})
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -35,6 +35,7 @@ type Request struct {
 
 type Filter struct {
 	IsDetailed bool
+	Audit      proto.AuditFilter
 	Job        proto.JobFilter
 }
 
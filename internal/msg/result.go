@@ -23,6 +23,7 @@ type Result struct {
 
 	Super *Supervisor
 
+	Audit      []proto.AuditEntry
 	Category   []proto.Category
 	Grant      []proto.Grant
 	Instance   []proto.Instance
@@ -53,6 +54,8 @@ func (r *Result) RowCnt(i int64, err error) bool {
 
 func (r *Result) Clear(s string) {
 	switch s {
+	case `audit`:
+		r.Audit = []proto.AuditEntry{}
 	case `category`:
 		r.Category = []proto.Category{}
 	case `grant`:
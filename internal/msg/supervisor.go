@@ -30,11 +30,14 @@ type Supervisor struct {
 	PermRepository string
 	PermMonitoring string
 	PermNode       string
+	PermBucket     string
+	PermInstance   string
 	// Fields for map update notifications
 	Action string
 	Object string
 	User   proto.User
 	Team   proto.Team
+	Node   proto.Node
 	// Fields for Grant revocation
 	GrantId string
 }
@@ -0,0 +1,68 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß <joerg.pernfuss@1und1.de>
+ * All rights reserved
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package stmt
+
+const (
+	AuditStatements = ``
+
+	AuditInsert = `
+INSERT INTO soma.audit_log (
+            audit_id,
+            audit_time,
+            user_uid,
+            remote_address,
+            http_method,
+            route,
+            request_path,
+            permissions,
+            authorized,
+            status_code,
+            request_body)
+SELECT $1::uuid,
+       $2::timestamptz,
+       $3::varchar,
+       $4::varchar,
+       $5::varchar,
+       $6::text,
+       $7::text,
+       $8::varchar[],
+       $9::boolean,
+       $10::integer,
+       $11::text;`
+
+	AuditSearch = `
+SELECT audit_id,
+       audit_time,
+       user_uid,
+       remote_address,
+       http_method,
+       route,
+       request_path,
+       permissions,
+       authorized,
+       status_code,
+       request_body
+FROM   soma.audit_log
+WHERE  ($1::varchar = '' OR user_uid = $1::varchar)
+  AND  ($2::text = ''
+        OR strpos(request_path, $2::text) > 0
+        OR strpos(request_body, $2::text) > 0)
+  AND  ($3::timestamptz IS NULL OR audit_time >= $3::timestamptz)
+  AND  ($4::timestamptz IS NULL OR audit_time <= $4::timestamptz)
+ORDER  BY audit_time DESC
+LIMIT  1000;`
+)
+
+func init() {
+	m[AuditInsert] = `AuditInsert`
+	m[AuditSearch] = `AuditSearch`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -107,6 +107,12 @@ SELECT bucket_id,
        environment,
        organizational_team_id
 FROM   soma.buckets
+WHERE  bucket_id = $1::uuid;`
+
+	BucketState = `
+SELECT bucket_frozen,
+       bucket_deleted
+FROM   soma.buckets
 WHERE  bucket_id = $1::uuid;`
 )
 
@@ -118,6 +124,7 @@ func init() {
 	m[BucketOncallPropertyForDelete] = `BucketOncallPropertyForDelete`
 	m[BucketServicePropertyForDelete] = `BucketServicePropertyForDelete`
 	m[BucketShow] = `BucketShow`
+	m[BucketState] = `BucketState`
 	m[BucketSvcProps] = `BucketSvcProps`
 	m[BucketSysProps] = `BucketSysProps`
 	m[BucketSystemPropertyForDelete] = `BucketSystemPropertyForDelete`
@@ -24,6 +24,15 @@ WHERE  scc.configuration_id = $1::uuid
   AND  sc.check_id          = sc.source_check_id
   AND  NOT sc.deleted;`
 
+	CheckDetailsForUpdate = `
+SELECT scc.configuration_object,
+       scc.configuration_object_type,
+       scc.capability_id
+FROM   soma.check_configurations scc
+WHERE  scc.configuration_id = $1::uuid
+  AND  scc.repository_id    = $2::uuid
+  AND  NOT scc.deleted;`
+
 	CheckConfigList = `
 SELECT configuration_id,
        repository_id,
@@ -69,7 +78,8 @@ SELECT scc.configuration_id,
        sccp.custom_property_id,
        sccp.repository_id,
        sccp.property_value,
-       scp.custom_property
+       scp.custom_property,
+       sccp.constraint_operator
 FROM   soma.check_configurations scc
 JOIN   soma.constraints_custom_property sccp
 ON     scc.configuration_id = sccp.configuration_id
@@ -81,7 +91,8 @@ WHERE  scc.configuration_id = $1::uuid;`
 	CheckConfigShowConstrSystem = `
 SELECT scc.configuration_id,
        scsp.system_property,
-       scsp.property_value
+       scsp.property_value,
+       scsp.constraint_operator
 FROM   soma.check_configurations scc
 JOIN   soma.constraints_system_property scsp
 ON     scc.configuration_id = scsp.configuration_id
@@ -90,7 +101,8 @@ WHERE  scc.configuration_id = $1::uuid;`
 	CheckConfigShowConstrNative = `
 SELECT scc.configuration_id,
        scnp.native_property,
-       scnp.property_value
+       scnp.property_value,
+       scnp.constraint_operator
 FROM   soma.check_configurations scc
 JOIN   soma.constraints_native_property scnp
 ON     scc.configuration_id = scnp.configuration_id
@@ -108,7 +120,8 @@ WHERE  scc.configuration_id = $1::uuid;`
 	CheckConfigShowConstrAttribute = `
 SELECT scc.configuration_id,
        scsa.service_property_attribute,
-       scsa.attribute_value
+       scsa.attribute_value,
+       scsa.constraint_operator
 FROM   soma.check_configurations scc
 JOIN   soma.constraints_service_attribute scsa
 ON     scc.configuration_id = scsa.configuration_id
@@ -179,6 +192,7 @@ func init() {
 	m[CheckConfigShowConstrSystem] = `CheckConfigShowConstrSystem`
 	m[CheckConfigShowThreshold] = `CheckConfigShowThreshold`
 	m[CheckDetailsForDelete] = `CheckDetailsForDelete`
+	m[CheckDetailsForUpdate] = `CheckDetailsForUpdate`
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -43,6 +43,13 @@ SELECT sc.bucket_id
 FROM   soma.clusters sc
 WHERE  sc.cluster_id = $1;`
 
+	ClusterHasMember = `
+SELECT EXISTS (
+  SELECT 1
+  FROM   soma.cluster_membership
+  WHERE  cluster_id = $1::uuid
+    AND  node_id = $2::uuid );`
+
 	ClusterOncProps = `
 SELECT op.instance_id,
        op.source_instance_id,
@@ -129,6 +136,7 @@ func init() {
 	m[ClusterBucketId] = `ClusterBucketId`
 	m[ClusterCstProps] = `ClusterCstProps`
 	m[ClusterCustomPropertyForDelete] = `ClusterCustomPropertyForDelete`
+	m[ClusterHasMember] = `ClusterHasMember`
 	m[ClusterList] = `ClusterList`
 	m[ClusterMemberList] = `ClusterMemberList`
 	m[ClusterOncProps] = `ClusterOncProps`
@@ -23,6 +23,8 @@ FROM   public.schema_versions
 GROUP  BY schema;`
 
 	ReadOnlyTransaction = `SET TRANSACTION READ ONLY, DEFERRABLE;`
+
+	ReadOnlyRepeatableRead = `SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY;`
 )
 
 func init() {
@@ -30,6 +32,7 @@ func init() {
 	m[DatabaseIsolationLevel] = `DatabaseIsolationLevel`
 	m[DatabaseSchemaVersion] = `DatabaseSchemaVersion`
 	m[ReadOnlyTransaction] = `ReadOnlyTransaction`
+	m[ReadOnlyRepeatableRead] = `ReadOnlyRepeatableRead`
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -30,13 +30,30 @@ SELECT repository_name,
 FROM   soma.repositories
 WHERE  repository_id = $1::uuid;`
 
+	ForestRepositoryIsEmpty = `
+SELECT NOT EXISTS (
+         SELECT bucket_id
+         FROM   soma.buckets
+         WHERE  repository_id = $1::uuid)
+AND    NOT EXISTS (
+         SELECT instance_id
+         FROM   soma.property_instances
+         WHERE  repository_id = $1::uuid)
+AND    NOT EXISTS (
+         SELECT configuration_id
+         FROM   soma.check_configurations
+         WHERE  repository_id = $1::uuid
+         AND    NOT deleted);`
+
 	ForestLoadRepository = `
 SELECT repository_id,
        repository_name,
        repository_deleted,
        repository_active,
        organizational_team_id
-FROM   soma.repositories;`
+FROM   soma.repositories
+-- purged repositories remain as deleted and inactive tombstones
+WHERE  NOT ( repository_deleted AND NOT repository_active );`
 
 	ForestAddRepository = `
 INSERT INTO soma.repositories (
@@ -67,6 +84,7 @@ func init() {
 	m[ForestRebuildDeleteChecks] = `ForestRebuildDeleteChecks`
 	m[ForestRebuildDeleteInstances] = `ForestRebuildDeleteInstances`
 	m[ForestRepoNameById] = `ForestRepoNameById`
+	m[ForestRepositoryIsEmpty] = `ForestRepositoryIsEmpty`
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
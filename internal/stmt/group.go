@@ -65,6 +65,23 @@ SELECT sg.bucket_id
 FROM   soma.groups sg
 WHERE  sg.group_id = $1;`
 
+	GroupHasMember = `
+SELECT EXISTS (
+  SELECT 1
+  FROM   soma.group_membership_groups
+  WHERE  group_id = $1::uuid
+    AND  child_group_id = $2::uuid
+  UNION ALL
+  SELECT 1
+  FROM   soma.group_membership_clusters
+  WHERE  group_id = $1::uuid
+    AND  child_cluster_id = $2::uuid
+  UNION ALL
+  SELECT 1
+  FROM   soma.group_membership_nodes
+  WHERE  group_id = $1::uuid
+    AND  child_node_id = $2::uuid );`
+
 	GroupOncProps = `
 SELECT op.instance_id,
        op.source_instance_id,
@@ -151,6 +168,7 @@ func init() {
 	m[GroupBucketId] = `GroupBucketId`
 	m[GroupCstProps] = `GroupCstProps`
 	m[GroupCustomPropertyForDelete] = `GroupCustomPropertyForDelete`
+	m[GroupHasMember] = `GroupHasMember`
 	m[GroupList] = `GroupList`
 	m[GroupMemberClusterList] = `GroupMemberClusterList`
 	m[GroupMemberGroupList] = `GroupMemberGroupList`
@@ -90,9 +90,27 @@ SELECT $1::uuid,
        $7::jsonb
 FROM   inventory.users iu
 WHERE  iu.user_uid = $6::varchar;`
+
+	JobCancel = `
+UPDATE soma.jobs sj
+SET    job_status = 'processed',
+       job_result = 'cancelled',
+       job_finished = $2::timestamptz,
+       job_error = ''
+WHERE  sj.job_id = $1::uuid
+  AND  sj.job_status = 'queued'
+  AND  sj.job_started IS NULL
+  AND  ( $3::boolean
+  OR     EXISTS (
+         SELECT iu.user_id
+         FROM   inventory.users iu
+         WHERE  iu.user_uid = $4::varchar
+           AND  ( iu.user_id = sj.user_id
+           OR     iu.organizational_team_id = sj.organizational_team_id )));`
 )
 
 func init() {
+	m[JobCancel] = `JobCancel`
 	m[JobResultForId] = `JobResultForId`
 	m[JobResultsForList] = `JobResultsForList`
 	m[JobSave] = `JobSave`
@@ -144,8 +144,11 @@ SELECT    sn.node_asset_id,
           sn.organizational_team_id,
           sn.server_id,
           sn.node_online,
-          sn.node_deleted
+          sn.node_deleted,
+          iis.server_name
 FROM      soma.nodes sn
+JOIN      inventory.servers iis
+ON        sn.server_id = iis.server_id
 LEFT JOIN soma.node_bucket_assignment snba
 ON        sn.node_id = snba.node_id
 WHERE     sn.node_online = 'yes'
@@ -55,12 +55,14 @@ FROM   soma.service_properties;`
 	PropertyCustomList = `
 SELECT custom_property_id,
        repository_id,
-       custom_property
+       custom_property,
+       value_schema
 FROM   soma.custom_properties
 WHERE  repository_id = $1::uuid;`
 
 	PropertySystemShow = `
-SELECT system_property
+SELECT system_property,
+       value_schema
 FROM   soma.system_properties
 WHERE  system_property = $1::varchar;`
 
@@ -72,7 +74,8 @@ WHERE  native_property = $1::varchar;`
 	PropertyCustomShow = `
 SELECT custom_property_id,
        repository_id,
-       custom_property
+       custom_property,
+       value_schema
 FROM   soma.custom_properties
 WHERE  custom_property_id = $1::uuid
 AND    repository_id = $2::uuid;`
@@ -99,8 +102,9 @@ WHERE  sp.service_property = $1::varchar;`
 
 	PropertySystemAdd = `
 INSERT INTO soma.system_properties (
-            system_property)
-SELECT $1::varchar
+            system_property,
+            value_schema)
+SELECT $1::varchar, $2::jsonb
 WHERE  NOT EXISTS (
    SELECT system_property
    FROM   soma.system_properties
@@ -119,8 +123,9 @@ WHERE  NOT EXISTS (
 INSERT INTO soma.custom_properties (
             custom_property_id,
             repository_id,
-            custom_property)
-SELECT $1::uuid, $2::uuid, $3::varchar
+            custom_property,
+            value_schema)
+SELECT $1::uuid, $2::uuid, $3::varchar, $4::jsonb
 WHERE  NOT EXISTS (
    SELECT custom_property
    FROM   soma.custom_properties
@@ -162,6 +167,17 @@ INSERT INTO soma.service_property_values (
             value)
 SELECT $1::varchar, $2::varchar, $3::varchar;`
 
+	PropertySystemSchemaUpdate = `
+UPDATE soma.system_properties
+SET    value_schema = $2::jsonb
+WHERE  system_property = $1::varchar;`
+
+	PropertyCustomSchemaUpdate = `
+UPDATE soma.custom_properties
+SET    value_schema = $3::jsonb
+WHERE  repository_id = $1::uuid
+AND    custom_property_id = $2::uuid;`
+
 	PropertySystemDel = `
 DELETE FROM soma.system_properties
 WHERE  system_property = $1::varchar;`
@@ -198,6 +214,7 @@ func init() {
 	m[PropertyCustomAdd] = `PropertyCustomAdd`
 	m[PropertyCustomDel] = `PropertyCustomDel`
 	m[PropertyCustomList] = `PropertyCustomList`
+	m[PropertyCustomSchemaUpdate] = `PropertyCustomSchemaUpdate`
 	m[PropertyCustomShow] = `PropertyCustomShow`
 	m[PropertyNativeAdd] = `PropertyNativeAdd`
 	m[PropertyNativeDel] = `PropertyNativeDel`
@@ -212,6 +229,7 @@ func init() {
 	m[PropertySystemAdd] = `PropertySystemAdd`
 	m[PropertySystemDel] = `PropertySystemDel`
 	m[PropertySystemList] = `PropertySystemList`
+	m[PropertySystemSchemaUpdate] = `PropertySystemSchemaUpdate`
 	m[PropertySystemShow] = `PropertySystemShow`
 	m[PropertyTemplateAdd] = `PropertyTemplateAdd`
 	m[PropertyTemplateAttributeAdd] = `PropertyTemplateAttributeAdd`
@@ -143,6 +143,36 @@ SELECT repository_name
 FROM   soma.repositories
 WHERE  repository_id = $1::uuid;`
 
+	RepoState = `
+SELECT repository_active,
+       repository_deleted
+FROM   soma.repositories
+WHERE  repository_id = $1::uuid;`
+
+	RepoTeamBound = `
+SELECT EXISTS (
+       SELECT 1
+       FROM   soma.node_bucket_assignment snba
+       JOIN   soma.buckets sb
+         ON   snba.bucket_id = sb.bucket_id
+       WHERE  sb.repository_id = $1::uuid)
+    OR EXISTS (
+       SELECT 1
+       FROM   soma.repository_service_properties
+       WHERE  repository_id = $1::uuid)
+    OR EXISTS (
+       SELECT 1
+       FROM   soma.bucket_service_properties
+       WHERE  repository_id = $1::uuid)
+    OR EXISTS (
+       SELECT 1
+       FROM   soma.group_service_properties
+       WHERE  repository_id = $1::uuid)
+    OR EXISTS (
+       SELECT 1
+       FROM   soma.cluster_service_properties
+       WHERE  repository_id = $1::uuid);`
+
 	RepoByBucketId = `
 SELECT sb.repository_id,
        sr.repository_name
@@ -162,9 +192,11 @@ func init() {
 	m[RepoOncProps] = `RepoOncProps`
 	m[RepoOncallPropertyForDelete] = `RepoOncallPropertyForDelete`
 	m[RepoServicePropertyForDelete] = `RepoServicePropertyForDelete`
+	m[RepoState] = `RepoState`
 	m[RepoSvcProps] = `RepoSvcProps`
 	m[RepoSysProps] = `RepoSysProps`
 	m[RepoSystemPropertyForDelete] = `RepoSystemPropertyForDelete`
+	m[RepoTeamBound] = `RepoTeamBound`
 	m[ShowRepository] = `ShowRepository`
 }
 
@@ -47,6 +47,24 @@ INSERT INTO soma.authorizations_repository (
 	permission_type,
 	created_by
 )
+VALUES (
+	$1::uuid,
+	$2::uuid,
+	$3::uuid,
+	$4::uuid,
+	$5::varchar,
+	$6::uuid
+);`
+
+	GrantLimitedRepoToTeam = `
+INSERT INTO soma.authorizations_repository (
+	grant_id,
+	organizational_team_id,
+	repository_id,
+	permission_id,
+	permission_type,
+	created_by
+)
 VALUES (
 	$1::uuid,
 	$2::uuid,
@@ -60,6 +78,110 @@ VALUES (
 DELETE FROM soma.authorizations_repository
 WHERE grant_id = $1::uuid;`
 
+	LoadLimitedRepoGrants = `
+SELECT grant_id,
+       COALESCE(user_id, organizational_team_id),
+       permission_id,
+       repository_id
+FROM   soma.authorizations_repository
+WHERE  tool_id IS NULL;`
+
+	GrantLimitedTeamToUser = `
+INSERT INTO soma.authorizations_team (
+	grant_id,
+	user_id,
+	authorized_team_id,
+	permission_id,
+	permission_type,
+	created_by
+)
+VALUES (
+	$1::uuid,
+	$2::uuid,
+	$3::uuid,
+	$4::uuid,
+	$5::varchar,
+	$6::uuid
+);`
+
+	GrantLimitedTeamToTeam = `
+INSERT INTO soma.authorizations_team (
+	grant_id,
+	organizational_team_id,
+	authorized_team_id,
+	permission_id,
+	permission_type,
+	created_by
+)
+VALUES (
+	$1::uuid,
+	$2::uuid,
+	$3::uuid,
+	$4::uuid,
+	$5::varchar,
+	$6::uuid
+);`
+
+	RevokeLimitedTeam = `
+DELETE FROM soma.authorizations_team
+WHERE grant_id = $1::uuid;`
+
+	LoadLimitedTeamGrants = `
+SELECT grant_id,
+       COALESCE(user_id, organizational_team_id),
+       permission_id,
+       authorized_team_id
+FROM   soma.authorizations_team
+WHERE  tool_id IS NULL;`
+
+	GrantLimitedMonitoringToUser = `
+INSERT INTO soma.authorizations_monitoring (
+	grant_id,
+	user_id,
+	monitoring_id,
+	permission_id,
+	permission_type,
+	created_by
+)
+VALUES (
+	$1::uuid,
+	$2::uuid,
+	$3::uuid,
+	$4::uuid,
+	$5::varchar,
+	$6::uuid
+);`
+
+	GrantLimitedMonitoringToTeam = `
+INSERT INTO soma.authorizations_monitoring (
+	grant_id,
+	organizational_team_id,
+	monitoring_id,
+	permission_id,
+	permission_type,
+	created_by
+)
+VALUES (
+	$1::uuid,
+	$2::uuid,
+	$3::uuid,
+	$4::uuid,
+	$5::varchar,
+	$6::uuid
+);`
+
+	RevokeLimitedMonitoring = `
+DELETE FROM soma.authorizations_monitoring
+WHERE grant_id = $1::uuid;`
+
+	LoadLimitedMonitoringGrants = `
+SELECT grant_id,
+       COALESCE(user_id, organizational_team_id),
+       permission_id,
+       monitoring_id
+FROM   soma.authorizations_monitoring
+WHERE  tool_id IS NULL;`
+
 	SearchGlobalSystemGrant = `
 SELECT grant_id
 FROM   soma.authorizations_global
@@ -72,10 +194,20 @@ WHERE  permission_id = $1::uuid
 
 func init() {
 	m[GrantGlobalOrSystemToUser] = `GrantGlobalOrSystemToUser`
+	m[GrantLimitedMonitoringToTeam] = `GrantLimitedMonitoringToTeam`
+	m[GrantLimitedMonitoringToUser] = `GrantLimitedMonitoringToUser`
+	m[GrantLimitedRepoToTeam] = `GrantLimitedRepoToTeam`
 	m[GrantLimitedRepoToUser] = `GrantLimitedRepoToUser`
+	m[GrantLimitedTeamToTeam] = `GrantLimitedTeamToTeam`
+	m[GrantLimitedTeamToUser] = `GrantLimitedTeamToUser`
 	m[LoadGlobalOrSystemUserGrants] = `LoadGlobalOrSystemUserGrants`
+	m[LoadLimitedMonitoringGrants] = `LoadLimitedMonitoringGrants`
+	m[LoadLimitedRepoGrants] = `LoadLimitedRepoGrants`
+	m[LoadLimitedTeamGrants] = `LoadLimitedTeamGrants`
 	m[RevokeGlobalOrSystemFromUser] = `RevokeGlobalOrSystemFromUser`
+	m[RevokeLimitedMonitoring] = `RevokeLimitedMonitoring`
 	m[RevokeLimitedRepoFromUser] = `RevokeLimitedRepoFromUser`
+	m[RevokeLimitedTeam] = `RevokeLimitedTeam`
 	m[SearchGlobalSystemGrant] = `SearchGlobalSystemGrant`
 }
 
@@ -20,10 +20,33 @@ SELECT iu.user_id,
 FROM   inventory.users iu
 JOIN   inventory.organizational_teams iot
 ON     iu.organizational_team_id = iot.organizational_team_id;`
+
+	LoadNodeTeamMapping = `
+SELECT node_id,
+       organizational_team_id
+FROM   soma.nodes
+WHERE  NOT node_deleted;`
+
+	SupervisorRepoByNode = `
+SELECT sb.repository_id
+FROM   soma.node_bucket_assignment snba
+JOIN   soma.buckets sb
+  ON   snba.bucket_id = sb.bucket_id
+WHERE  snba.node_id = $1::uuid;`
+
+	SupervisorRepoByInstance = `
+SELECT sc.repository_id
+FROM   soma.check_instances sci
+JOIN   soma.checks sc
+  ON   sci.check_id = sc.check_id
+WHERE  sci.check_instance_id = $1::uuid;`
 )
 
 func init() {
+	m[LoadNodeTeamMapping] = `LoadNodeTeamMapping`
 	m[LoadUserTeamMapping] = `LoadUserTeamMapping`
+	m[SupervisorRepoByInstance] = `SupervisorRepoByInstance`
+	m[SupervisorRepoByNode] = `SupervisorRepoByNode`
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -17,7 +17,13 @@ UPDATE soma.jobs
 SET    job_started = $2::timestamptz,
        job_status = 'in_progress'
 WHERE  job_id = $1::uuid
-AND    job_started IS NULL;`
+AND    job_started IS NULL
+AND    job_status != 'processed';`
+
+	TreekeeperJobCancelled = `
+SELECT job_result = 'cancelled'
+FROM   soma.jobs
+WHERE  job_id = $1::uuid;`
 
 	TreekeeperGetViewFromCapability = `
 SELECT capability_view
@@ -87,6 +93,7 @@ func init() {
 	m[TreekeeperGetComputedDeployments] = `TreekeeperGetComputedDeployments`
 	m[TreekeeperGetPreviousDeployment] = `TreekeeperGetPreviousDeployment`
 	m[TreekeeperGetViewFromCapability] = `TreekeeperGetViewFromCapability`
+	m[TreekeeperJobCancelled] = `TreekeeperJobCancelled`
 	m[TreekeeperSetDependency] = `TreekeeperSetDependency`
 	m[TreekeeperStartJob] = `TreekeeperStartJob`
 	m[TreekeeperUpdateCheckInstance] = `TreekeeperUpdateCheckInstance`
@@ -93,7 +93,8 @@ WHERE  configuration_id = $1::uuid;`
 	TkStartLoadCheckConstraintCustom = `
 SELECT sccp.custom_property_id,
        scp.custom_property,
-       sccp.property_value
+       sccp.property_value,
+       sccp.constraint_operator
 FROM   soma.constraints_custom_property sccp
 JOIN   soma.custom_properties scp
 ON     sccp.custom_property_id = scp.custom_property_id
@@ -101,12 +102,14 @@ AND    sccp.repository_id = scp.repository_id
 WHERE  configuration_id = $1::uuid;`
 
 	// do not get distracted by the squirrels! All constraint
-	// statements are constructed to use three result variables,
-	// so they can be loaded in one unified loop.
+	// statements are constructed to use four result variables,
+	// so they can be loaded in one unified loop. The last result
+	// is always the constraint operator.
 	TkStartLoadCheckConstraintNative = `
 SELECT native_property,
        property_value,
-       'squirrel'
+       'squirrel',
+       constraint_operator
 FROM   soma.constraints_native_property
 WHERE  configuration_id = $1::uuid;`
 
@@ -114,7 +117,8 @@ WHERE  configuration_id = $1::uuid;`
 	TkStartLoadCheckConstraintOncall = `
 SELECT scop.oncall_duty_id,
        oncall_duty_name,
-       oncall_duty_phone_number
+       oncall_duty_phone_number,
+       ''::varchar
 FROM   soma.constraints_oncall_property scop
 JOIN   inventory.oncall_duty_teams iodt
 ON     scop.oncall_duty_id = iodt.oncall_duty_id
@@ -123,21 +127,24 @@ WHERE  scop.configuration_id = $1::uuid;`
 	TkStartLoadCheckConstraintAttribute = `
 SELECT service_property_attribute,
        attribute_value,
-       'squirrel'
+       'squirrel',
+       constraint_operator
 FROM   soma.constraints_service_attribute
 WHERE  configuration_id = $1::uuid;`
 
 	TkStartLoadCheckConstraintService = `
 SELECT organizational_team_id,
        service_property,
-       'squirrel'
+       'squirrel',
+       ''::varchar
 FROM   soma.constraints_service_property
 WHERE  configuration_id = $1::uuid;`
 
 	TkStartLoadCheckConstraintSystem = `
 SELECT system_property,
        property_value,
-       'squirrel'
+       'squirrel',
+       constraint_operator
 FROM   soma.constraints_system_property
 WHERE  configuration_id = $1::uuid;`
 
@@ -253,7 +260,8 @@ SELECT    sn.node_id,
           sn.node_deleted,
           snba.bucket_id,
           scm.cluster_id,
-          sgmn.group_id
+          sgmn.group_id,
+          iis.server_name
 FROM      soma.repositories sr
 JOIN      soma.buckets sb
 ON        sr.repository_id = sb.repository_id
@@ -261,6 +269,8 @@ JOIN      soma.node_bucket_assignment snba
 ON        sb.bucket_id = snba.bucket_id
 JOIN      soma.nodes sn
 ON        snba.node_id = sn.node_id
+JOIN      inventory.servers iis
+ON        sn.server_id = iis.server_id
 LEFT JOIN soma.cluster_membership scm
 ON        sn.node_id = scm.node_id
 LEFT JOIN soma.group_membership_nodes sgmn
@@ -136,19 +136,23 @@ SELECT $1::uuid,
 INSERT INTO soma.constraints_system_property (
             configuration_id,
             system_property,
-            property_value)
+            property_value,
+            constraint_operator)
 SELECT $1::uuid,
        $2::varchar,
-       $3::text;`
+       $3::text,
+       $4::varchar;`
 
 	TxCreateCheckConfigurationConstraintNative = `
 INSERT INTO soma.constraints_native_property (
             configuration_id,
             native_property,
-            property_value)
+            property_value,
+            constraint_operator)
 SELECT $1::uuid,
        $2::varchar,
-       $3::text;`
+       $3::text,
+       $4::varchar;`
 
 	TxCreateCheckConfigurationConstraintOncall = `
 INSERT INTO soma.constraints_oncall_property (
@@ -162,11 +166,13 @@ INSERT INTO soma.constraints_custom_property (
             configuration_id,
             custom_property_id,
             repository_id,
-            property_value)
+            property_value,
+            constraint_operator)
 SELECT $1::uuid,
        $2::uuid,
        $3::uuid,
-       $4::text;`
+       $4::text,
+       $5::varchar;`
 
 	TxCreateCheckConfigurationConstraintService = `
 INSERT INTO soma.constraints_service_property (
@@ -181,10 +187,185 @@ SELECT $1::uuid,
 INSERT INTO soma.constraints_service_attribute (
             configuration_id,
             service_property_attribute,
-            attribute_value)
+            attribute_value,
+            constraint_operator)
 SELECT $1::uuid,
        $2::varchar,
-       $3::varchar;`
+       $3::varchar,
+       $4::varchar;`
+
+	TxUpdateCheckConfigurationBase = `
+UPDATE soma.check_configurations
+SET    configuration_name = $2::varchar,
+       interval = $3::integer,
+       inheritance_enabled = $4::boolean,
+       children_only = $5::boolean
+WHERE  configuration_id = $1::uuid;`
+
+	TxDeleteCheckConfigurationDetails = `
+WITH thr AS ( DELETE FROM soma.configuration_thresholds
+              WHERE       configuration_id = $1::uuid ),
+     sys AS ( DELETE FROM soma.constraints_system_property
+              WHERE       configuration_id = $1::uuid ),
+     nat AS ( DELETE FROM soma.constraints_native_property
+              WHERE       configuration_id = $1::uuid ),
+     onc AS ( DELETE FROM soma.constraints_oncall_property
+              WHERE       configuration_id = $1::uuid ),
+     cst AS ( DELETE FROM soma.constraints_custom_property
+              WHERE       configuration_id = $1::uuid ),
+     svc AS ( DELETE FROM soma.constraints_service_property
+              WHERE       configuration_id = $1::uuid )
+DELETE FROM soma.constraints_service_attribute
+WHERE       configuration_id = $1::uuid;`
+
+	TxCopyCheckConfigurationBase = `
+INSERT INTO soma.check_configurations (
+            configuration_id,
+            repository_id,
+            bucket_id,
+            configuration_name,
+            configuration_object,
+            configuration_object_type,
+            configuration_active,
+            inheritance_enabled,
+            children_only,
+            capability_id,
+            interval,
+            enabled,
+            external_id)
+SELECT $2::uuid,
+       $3::uuid,
+       $4::uuid,
+       configuration_name,
+       configuration_object,
+       configuration_object_type,
+       configuration_active,
+       inheritance_enabled,
+       children_only,
+       capability_id,
+       interval,
+       enabled,
+       external_id
+FROM   soma.check_configurations
+WHERE  configuration_id = $1::uuid;`
+
+	TxCopyCheckConfigurationDetails = `
+WITH thr AS ( INSERT INTO soma.configuration_thresholds (
+                          configuration_id,
+                          predicate,
+                          threshold,
+                          notification_level)
+              SELECT $2::uuid,
+                     predicate,
+                     threshold,
+                     notification_level
+              FROM   soma.configuration_thresholds
+              WHERE  configuration_id = $1::uuid ),
+     sys AS ( INSERT INTO soma.constraints_system_property (
+                          configuration_id,
+                          system_property,
+                          property_value,
+                          constraint_operator)
+              SELECT $2::uuid,
+                     system_property,
+                     property_value,
+                     constraint_operator
+              FROM   soma.constraints_system_property
+              WHERE  configuration_id = $1::uuid ),
+     nat AS ( INSERT INTO soma.constraints_native_property (
+                          configuration_id,
+                          native_property,
+                          property_value,
+                          constraint_operator)
+              SELECT $2::uuid,
+                     native_property,
+                     property_value,
+                     constraint_operator
+              FROM   soma.constraints_native_property
+              WHERE  configuration_id = $1::uuid ),
+     onc AS ( INSERT INTO soma.constraints_oncall_property (
+                          configuration_id,
+                          oncall_duty_id)
+              SELECT $2::uuid,
+                     oncall_duty_id
+              FROM   soma.constraints_oncall_property
+              WHERE  configuration_id = $1::uuid ),
+     cst AS ( INSERT INTO soma.constraints_custom_property (
+                          configuration_id,
+                          custom_property_id,
+                          repository_id,
+                          property_value,
+                          constraint_operator)
+              SELECT $2::uuid,
+                     tcp.custom_property_id,
+                     $3::uuid,
+                     ccp.property_value,
+                     ccp.constraint_operator
+              FROM   soma.constraints_custom_property ccp
+              JOIN   soma.custom_properties scp
+                ON   ccp.custom_property_id = scp.custom_property_id
+              JOIN   soma.custom_properties tcp
+                ON   scp.custom_property = tcp.custom_property
+              WHERE  ccp.configuration_id = $1::uuid
+                AND  tcp.repository_id = $3::uuid ),
+     svc AS ( INSERT INTO soma.constraints_service_property (
+                          configuration_id,
+                          organizational_team_id,
+                          service_property)
+              SELECT $2::uuid,
+                     organizational_team_id,
+                     service_property
+              FROM   soma.constraints_service_property
+              WHERE  configuration_id = $1::uuid )
+INSERT INTO soma.constraints_service_attribute (
+            configuration_id,
+            service_property_attribute,
+            attribute_value,
+            constraint_operator)
+SELECT $2::uuid,
+       service_property_attribute,
+       attribute_value,
+       constraint_operator
+FROM   soma.constraints_service_attribute
+WHERE  configuration_id = $1::uuid;`
+
+	TxMoveCheckConfigurationBucket = `
+UPDATE soma.check_configurations
+SET    bucket_id = $2::uuid
+WHERE  configuration_id = $1::uuid;`
+
+	TxRelocationCheckConfigAllowed = `
+SELECT NOT EXISTS (
+         SELECT 1
+         FROM   soma.check_configurations scc
+         JOIN   soma.check_configurations tcc
+           ON   scc.configuration_name = tcc.configuration_name
+         WHERE  scc.configuration_id = $1::uuid
+           AND  tcc.repository_id = $2::uuid
+           AND  NOT tcc.deleted)
+   AND NOT EXISTS (
+         SELECT 1
+         FROM   soma.constraints_custom_property ccp
+         JOIN   soma.custom_properties scp
+           ON   ccp.custom_property_id = scp.custom_property_id
+         LEFT   JOIN soma.custom_properties tcp
+           ON   scp.custom_property = tcp.custom_property
+          AND   tcp.repository_id = $2::uuid
+         WHERE  ccp.configuration_id = $1::uuid
+           AND  tcp.custom_property_id IS NULL)
+   AND NOT EXISTS (
+         SELECT 1
+         FROM   soma.constraints_service_property
+         WHERE  configuration_id = $1::uuid
+           AND  organizational_team_id != $3::uuid);`
+
+	TxRelocationCustomPropertyId = `
+SELECT tcp.custom_property_id
+FROM   soma.custom_properties scp
+JOIN   soma.custom_properties tcp
+  ON   scp.custom_property = tcp.custom_property
+WHERE  scp.custom_property_id = $1::uuid
+  AND  tcp.repository_id = $2::uuid;`
 
 	TxPropertyInstanceCreate = `
 INSERT INTO soma.property_instances (
@@ -235,6 +416,13 @@ SELECT $1::uuid,
 DELETE FROM soma.repository_oncall_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxRepositoryPropertyOncallUpdate = `
+UPDATE soma.repository_oncall_properties
+SET    oncall_duty_id = $2::uuid,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxRepositoryPropertyServiceCreate = `
 INSERT INTO soma.repository_service_properties (
             instance_id,
@@ -258,6 +446,12 @@ SELECT $1::uuid,
 DELETE FROM soma.repository_service_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxRepositoryPropertyServiceUpdate = `
+UPDATE soma.repository_service_properties
+SET    inheritance_enabled = $2::boolean,
+       children_only = $3::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxRepositoryPropertySystemCreate = `
 INSERT INTO soma.repository_system_properties (
             instance_id,
@@ -285,6 +479,13 @@ SELECT $1::uuid,
 DELETE FROM soma.repository_system_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxRepositoryPropertySystemUpdate = `
+UPDATE soma.repository_system_properties
+SET    value = $2::text,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxRepositoryPropertyCustomCreate = `
 INSERT INTO soma.repository_custom_properties (
             instance_id,
@@ -308,6 +509,54 @@ SELECT $1::uuid,
 DELETE FROM soma.repository_custom_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxRepositoryPropertyCustomUpdate = `
+UPDATE soma.repository_custom_properties
+SET    value = $2::text,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
+	TxRepositoryUpdate = `
+UPDATE soma.repositories
+SET    repository_name = $2::varchar,
+       organizational_team_id = $3::uuid,
+       repository_active = $4::boolean,
+       repository_deleted = $5::boolean
+WHERE  repository_id = $1::uuid;`
+
+	TxRepositoryUpdateTeam = `
+WITH bck AS ( UPDATE soma.buckets
+              SET    organizational_team_id = $2::uuid
+              WHERE  repository_id = $1::uuid ),
+     grp AS ( UPDATE soma.groups sg
+              SET    organizational_team_id = $2::uuid
+              FROM   soma.buckets sb
+              WHERE  sg.bucket_id = sb.bucket_id
+              AND    sb.repository_id = $1::uuid )
+UPDATE soma.clusters sc
+SET    organizational_team_id = $2::uuid
+FROM   soma.buckets sb
+WHERE  sc.bucket_id = sb.bucket_id
+AND    sb.repository_id = $1::uuid;`
+
+	TxRepositoryDetachChecks = `
+WITH cfg AS ( UPDATE soma.check_configurations
+              SET    deleted = 'yes'::boolean
+              WHERE  repository_id = $1::uuid )
+UPDATE soma.checks
+SET    deleted = 'yes'::boolean
+WHERE  repository_id = $1::uuid;`
+
+	TxRepositoryRemoveGrants = `
+WITH grp AS ( DELETE FROM soma.authorizations_group
+              WHERE       repository_id = $1::uuid ),
+     clr AS ( DELETE FROM soma.authorizations_cluster
+              WHERE       repository_id = $1::uuid ),
+     bck AS ( DELETE FROM soma.authorizations_bucket
+              WHERE       repository_id = $1::uuid )
+DELETE FROM soma.authorizations_repository
+WHERE       repository_id = $1::uuid;`
+
 	TxUpdateNodeState = `
 UPDATE soma.nodes
 SET    object_state = $2::varchar
@@ -342,6 +591,13 @@ SELECT $1::uuid,
 DELETE FROM soma.node_oncall_property
 WHERE       instance_id = $1::uuid;`
 
+	TxNodePropertyOncallUpdate = `
+UPDATE soma.node_oncall_property
+SET    oncall_duty_id = $2::uuid,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxNodePropertyServiceCreate = `
 INSERT INTO soma.node_service_properties (
             instance_id,
@@ -367,6 +623,12 @@ SELECT $1::uuid,
 DELETE FROM soma.node_service_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxNodePropertyServiceUpdate = `
+UPDATE soma.node_service_properties
+SET    inheritance_enabled = $2::boolean,
+       children_only = $3::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxNodePropertySystemCreate = `
 INSERT INTO soma.node_system_properties (
             instance_id,
@@ -396,6 +658,13 @@ SELECT $1::uuid,
 DELETE FROM soma.node_system_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxNodePropertySystemUpdate = `
+UPDATE soma.node_system_properties
+SET    value = $2::text,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxNodePropertyCustomCreate = `
 INSERT INTO soma.node_custom_properties (
             instance_id,
@@ -423,6 +692,13 @@ SELECT $1::uuid,
 DELETE FROM soma.node_custom_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxNodePropertyCustomUpdate = `
+UPDATE soma.node_custom_properties
+SET    value = $2::text,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxGroupCreate = `
 INSERT INTO soma.groups (
             group_id,
@@ -442,9 +718,23 @@ WHERE  iu.user_uid = $6::varchar;`
 
 	TxGroupUpdate = `
 UPDATE soma.groups
-SET    object_state = $2::varchar
+SET    object_state = $2::varchar,
+       group_name = $3::varchar
 WHERE  group_id = $1::uuid;`
 
+	TxGroupDetachChecks = `
+WITH cfg AS ( UPDATE soma.check_configurations
+              SET    deleted = 'yes'::boolean
+              WHERE  configuration_object = $1::uuid )
+UPDATE soma.checks
+SET    deleted = 'yes'::boolean
+WHERE  object_id = $1::uuid
+OR     source_object_id = $1::uuid;`
+
+	TxGroupRemoveGrants = `
+DELETE FROM soma.authorizations_group
+WHERE       group_id = $1::uuid;`
+
 	TxGroupDelete = `
 DELETE FROM soma.groups
 WHERE       group_id = $1::uuid;`
@@ -514,6 +804,13 @@ SELECT $1::uuid,
 DELETE FROM soma.group_oncall_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxGroupPropertyOncallUpdate = `
+UPDATE soma.group_oncall_properties
+SET    oncall_duty_id = $2::uuid,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxGroupPropertyServiceCreate = `
 INSERT INTO soma.group_service_properties (
             instance_id,
@@ -539,6 +836,12 @@ SELECT $1::uuid,
 DELETE FROM soma.group_service_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxGroupPropertyServiceUpdate = `
+UPDATE soma.group_service_properties
+SET    inheritance_enabled = $2::boolean,
+       children_only = $3::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxGroupPropertySystemCreate = `
 INSERT INTO soma.group_system_properties (
             instance_id,
@@ -568,6 +871,13 @@ SELECT $1::uuid,
 DELETE FROM soma.group_system_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxGroupPropertySystemUpdate = `
+UPDATE soma.group_system_properties
+SET    value = $2::text,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxGroupPropertyCustomCreate = `
 INSERT INTO soma.group_custom_properties (
             instance_id,
@@ -595,6 +905,13 @@ SELECT $1::uuid,
 DELETE FROM soma.group_custom_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxGroupPropertyCustomUpdate = `
+UPDATE soma.group_custom_properties
+SET    value = $2::text,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxClusterCreate = `
 INSERT INTO soma.clusters (
             cluster_id,
@@ -614,9 +931,23 @@ WHERE  iu.user_uid = $6::varchar;`
 
 	TxClusterUpdate = `
 UPDATE soma.clusters
-SET    object_state = $2::varchar
+SET    object_state = $2::varchar,
+       cluster_name = $3::varchar
 WHERE  cluster_id = $1::uuid;`
 
+	TxClusterDetachChecks = `
+WITH cfg AS ( UPDATE soma.check_configurations
+              SET    deleted = 'yes'::boolean
+              WHERE  configuration_object = $1::uuid )
+UPDATE soma.checks
+SET    deleted = 'yes'::boolean
+WHERE  object_id = $1::uuid
+OR     source_object_id = $1::uuid;`
+
+	TxClusterRemoveGrants = `
+DELETE FROM soma.authorizations_cluster
+WHERE       cluster_id = $1::uuid;`
+
 	TxClusterDelete = `
 DELETE FROM soma.clusters
 WHERE       cluster_id = $1::uuid;`
@@ -658,6 +989,13 @@ SELECT $1::uuid,
 DELETE FROM soma.cluster_oncall_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxClusterPropertyOncallUpdate = `
+UPDATE soma.cluster_oncall_properties
+SET    oncall_duty_id = $2::uuid,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxClusterPropertyServiceCreate = `
 INSERT INTO soma.cluster_service_properties (
             instance_id,
@@ -683,6 +1021,12 @@ SELECT $1::uuid,
 DELETE FROM soma.cluster_service_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxClusterPropertyServiceUpdate = `
+UPDATE soma.cluster_service_properties
+SET    inheritance_enabled = $2::boolean,
+       children_only = $3::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxClusterPropertySystemCreate = `
 INSERT INTO soma.cluster_system_properties (
             instance_id,
@@ -712,6 +1056,13 @@ SELECT $1::uuid,
 DELETE FROM soma.cluster_system_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxClusterPropertySystemUpdate = `
+UPDATE soma.cluster_system_properties
+SET    value = $2::text,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxClusterPropertyCustomCreate = `
 INSERT INTO soma.cluster_custom_properties (
             instance_id,
@@ -739,6 +1090,13 @@ SELECT $1::uuid,
 DELETE FROM soma.cluster_custom_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxClusterPropertyCustomUpdate = `
+UPDATE soma.cluster_custom_properties
+SET    value = $2::text,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxCreateBucket = `
 INSERT INTO soma.buckets (
             bucket_id,
@@ -775,6 +1133,35 @@ WHERE       node_id = $1::uuid
 AND         bucket_id = $2::uuid
 AND         organizational_team_id = $3::uuid;`
 
+	TxBucketUpdate = `
+UPDATE soma.buckets
+SET    bucket_name = $2::varchar,
+       bucket_frozen = $3::boolean,
+       bucket_deleted = $4::boolean
+WHERE  bucket_id = $1::uuid;`
+
+	TxBucketDetachChecks = `
+WITH cfg AS ( UPDATE soma.check_configurations
+              SET    deleted = 'yes'::boolean,
+                     bucket_id = NULL
+              WHERE  bucket_id = $1::uuid )
+UPDATE soma.checks
+SET    deleted = 'yes'::boolean,
+       bucket_id = NULL
+WHERE  bucket_id = $1::uuid;`
+
+	TxBucketRemoveGrants = `
+WITH grp AS ( DELETE FROM soma.authorizations_group
+              WHERE       bucket_id = $1::uuid ),
+     clr AS ( DELETE FROM soma.authorizations_cluster
+              WHERE       bucket_id = $1::uuid )
+DELETE FROM soma.authorizations_bucket
+WHERE       bucket_id = $1::uuid;`
+
+	TxBucketDelete = `
+DELETE FROM soma.buckets
+WHERE       bucket_id = $1::uuid;`
+
 	TxBucketPropertyOncallCreate = `
 INSERT INTO soma.bucket_oncall_properties (
             instance_id,
@@ -798,6 +1185,13 @@ SELECT $1::uuid,
 DELETE FROM soma.bucket_oncall_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxBucketPropertyOncallUpdate = `
+UPDATE soma.bucket_oncall_properties
+SET    oncall_duty_id = $2::uuid,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxBucketPropertyServiceCreate = `
 INSERT INTO soma.bucket_service_properties (
             instance_id,
@@ -823,6 +1217,12 @@ SELECT $1::uuid,
 DELETE FROM soma.bucket_service_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxBucketPropertyServiceUpdate = `
+UPDATE soma.bucket_service_properties
+SET    inheritance_enabled = $2::boolean,
+       children_only = $3::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxBucketPropertySystemCreate = `
 INSERT INTO soma.bucket_system_properties (
             instance_id,
@@ -852,6 +1252,13 @@ SELECT $1::uuid,
 DELETE FROM soma.bucket_system_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxBucketPropertySystemUpdate = `
+UPDATE soma.bucket_system_properties
+SET    value = $2::text,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxBucketPropertyCustomCreate = `
 INSERT INTO soma.bucket_custom_properties (
             instance_id,
@@ -877,6 +1284,13 @@ SELECT $1::uuid,
 DELETE FROM soma.bucket_custom_properties
 WHERE       instance_id = $1::uuid;`
 
+	TxBucketPropertyCustomUpdate = `
+UPDATE soma.bucket_custom_properties
+SET    value = $2::text,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  instance_id = $1::uuid;`
+
 	TxDeployDetailsComputeList = `
 SELECT scic.check_instance_config_id
 FROM   soma.checks sc
@@ -1152,28 +1566,44 @@ AND    server_name = 'soma-null-server';`
 
 func init() {
 	m[TxBucketAssignNode] = `TxBucketAssignNode`
+	m[TxBucketDelete] = `TxBucketDelete`
+	m[TxBucketDetachChecks] = `TxBucketDetachChecks`
 	m[TxBucketPropertyCustomCreate] = `TxBucketPropertyCustomCreate`
 	m[TxBucketPropertyCustomDelete] = `TxBucketPropertyCustomDelete`
+	m[TxBucketPropertyCustomUpdate] = `TxBucketPropertyCustomUpdate`
 	m[TxBucketPropertyOncallCreate] = `TxBucketPropertyOncallCreate`
 	m[TxBucketPropertyOncallDelete] = `TxBucketPropertyOncallDelete`
+	m[TxBucketPropertyOncallUpdate] = `TxBucketPropertyOncallUpdate`
 	m[TxBucketPropertyServiceCreate] = `TxBucketPropertyServiceCreate`
 	m[TxBucketPropertyServiceDelete] = `TxBucketPropertyServiceDelete`
+	m[TxBucketPropertyServiceUpdate] = `TxBucketPropertyServiceUpdate`
 	m[TxBucketPropertySystemCreate] = `TxBucketPropertySystemCreate`
 	m[TxBucketPropertySystemDelete] = `TxBucketPropertySystemDelete`
+	m[TxBucketPropertySystemUpdate] = `TxBucketPropertySystemUpdate`
+	m[TxBucketRemoveGrants] = `TxBucketRemoveGrants`
 	m[TxBucketRemoveNode] = `TxBucketRemoveNode`
+	m[TxBucketUpdate] = `TxBucketUpdate`
 	m[TxClusterCreate] = `TxClusterCreate`
 	m[TxClusterDelete] = `TxClusterDelete`
+	m[TxClusterDetachChecks] = `TxClusterDetachChecks`
 	m[TxClusterMemberNew] = `TxClusterMemberNew`
 	m[TxClusterMemberRemove] = `TxClusterMemberRemove`
 	m[TxClusterPropertyCustomCreate] = `TxClusterPropertyCustomCreate`
 	m[TxClusterPropertyCustomDelete] = `TxClusterPropertyCustomDelete`
+	m[TxClusterPropertyCustomUpdate] = `TxClusterPropertyCustomUpdate`
 	m[TxClusterPropertyOncallCreate] = `TxClusterPropertyOncallCreate`
 	m[TxClusterPropertyOncallDelete] = `TxClusterPropertyOncallDelete`
+	m[TxClusterPropertyOncallUpdate] = `TxClusterPropertyOncallUpdate`
 	m[TxClusterPropertyServiceCreate] = `TxClusterPropertyServiceCreate`
 	m[TxClusterPropertyServiceDelete] = `TxClusterPropertyServiceDelete`
+	m[TxClusterPropertyServiceUpdate] = `TxClusterPropertyServiceUpdate`
 	m[TxClusterPropertySystemCreate] = `TxClusterPropertySystemCreate`
 	m[TxClusterPropertySystemDelete] = `TxClusterPropertySystemDelete`
+	m[TxClusterPropertySystemUpdate] = `TxClusterPropertySystemUpdate`
+	m[TxClusterRemoveGrants] = `TxClusterRemoveGrants`
 	m[TxClusterUpdate] = `TxClusterUpdate`
+	m[TxCopyCheckConfigurationBase] = `TxCopyCheckConfigurationBase`
+	m[TxCopyCheckConfigurationDetails] = `TxCopyCheckConfigurationDetails`
 	m[TxCreateBucket] = `TxCreateBucket`
 	m[TxCreateCheckConfigurationBase] = `TxCreateCheckConfigurationBase`
 	m[TxCreateCheckConfigurationConstraintAttribute] = `TxCreateCheckConfigurationConstraintAttribute`
@@ -1187,6 +1617,7 @@ func init() {
 	m[TxCreateCheckInstance] = `TxCreateCheckInstance`
 	m[TxCreateCheck] = `TxCreateCheck`
 	m[TxDeferAllConstraints] = `TxDeferAllConstraints`
+	m[TxDeleteCheckConfigurationDetails] = `TxDeleteCheckConfigurationDetails`
 	m[TxDeployDetailClusterCustProp] = `TxDeployDetailClusterCustProp`
 	m[TxDeployDetailClusterSysProp] = `TxDeployDetailClusterSysProp`
 	m[TxDeployDetailDefaultDatacenter] = `TxDeployDetailDefaultDatacenter`
@@ -1215,6 +1646,7 @@ func init() {
 	m[TxFinishJob] = `TxFinishJob`
 	m[TxGroupCreate] = `TxGroupCreate`
 	m[TxGroupDelete] = `TxGroupDelete`
+	m[TxGroupDetachChecks] = `TxGroupDetachChecks`
 	m[TxGroupMemberNewCluster] = `TxGroupMemberNewCluster`
 	m[TxGroupMemberNewGroup] = `TxGroupMemberNewGroup`
 	m[TxGroupMemberNewNode] = `TxGroupMemberNewNode`
@@ -1223,35 +1655,56 @@ func init() {
 	m[TxGroupMemberRemoveNode] = `TxGroupMemberRemoveNode`
 	m[TxGroupPropertyCustomCreate] = `TxGroupPropertyCustomCreate`
 	m[TxGroupPropertyCustomDelete] = `TxGroupPropertyCustomDelete`
+	m[TxGroupPropertyCustomUpdate] = `TxGroupPropertyCustomUpdate`
 	m[TxGroupPropertyOncallCreate] = `TxGroupPropertyOncallCreate`
 	m[TxGroupPropertyOncallDelete] = `TxGroupPropertyOncallDelete`
+	m[TxGroupPropertyOncallUpdate] = `TxGroupPropertyOncallUpdate`
 	m[TxGroupPropertyServiceCreate] = `TxGroupPropertyServiceCreate`
 	m[TxGroupPropertyServiceDelete] = `TxGroupPropertyServiceDelete`
+	m[TxGroupPropertyServiceUpdate] = `TxGroupPropertyServiceUpdate`
 	m[TxGroupPropertySystemCreate] = `TxGroupPropertySystemCreate`
 	m[TxGroupPropertySystemDelete] = `TxGroupPropertySystemDelete`
+	m[TxGroupPropertySystemUpdate] = `TxGroupPropertySystemUpdate`
+	m[TxGroupRemoveGrants] = `TxGroupRemoveGrants`
 	m[TxGroupUpdate] = `TxGroupUpdate`
 	m[TxMarkCheckConfigDeleted] = `TxMarkCheckConfigDeleted`
 	m[TxMarkCheckDeleted] = `TxMarkCheckDeleted`
 	m[TxMarkCheckInstanceDeleted] = `TxMarkCheckInstanceDeleted`
+	m[TxMoveCheckConfigurationBucket] = `TxMoveCheckConfigurationBucket`
 	m[TxNodePropertyCustomCreate] = `TxNodePropertyCustomCreate`
 	m[TxNodePropertyCustomDelete] = `TxNodePropertyCustomDelete`
+	m[TxNodePropertyCustomUpdate] = `TxNodePropertyCustomUpdate`
 	m[TxNodePropertyOncallCreate] = `TxNodePropertyOncallCreate`
 	m[TxNodePropertyOncallDelete] = `TxNodePropertyOncallDelete`
+	m[TxNodePropertyOncallUpdate] = `TxNodePropertyOncallUpdate`
 	m[TxNodePropertyServiceCreate] = `TxNodePropertyServiceCreate`
 	m[TxNodePropertyServiceDelete] = `TxNodePropertyServiceDelete`
+	m[TxNodePropertyServiceUpdate] = `TxNodePropertyServiceUpdate`
 	m[TxNodePropertySystemCreate] = `TxNodePropertySystemCreate`
 	m[TxNodePropertySystemDelete] = `TxNodePropertySystemDelete`
+	m[TxNodePropertySystemUpdate] = `TxNodePropertySystemUpdate`
 	m[TxNodeUnassignFromBucket] = `TxNodeUnassignFromBucket`
 	m[TxPropertyInstanceCreate] = `TxPropertyInstanceCreate`
 	m[TxPropertyInstanceDelete] = `TxPropertyInstanceDelete`
+	m[TxRelocationCheckConfigAllowed] = `TxRelocationCheckConfigAllowed`
+	m[TxRelocationCustomPropertyId] = `TxRelocationCustomPropertyId`
 	m[TxRepositoryPropertyCustomCreate] = `TxRepositoryPropertyCustomCreate`
 	m[TxRepositoryPropertyCustomDelete] = `TxRepositoryPropertyCustomDelete`
+	m[TxRepositoryPropertyCustomUpdate] = `TxRepositoryPropertyCustomUpdate`
 	m[TxRepositoryPropertyOncallCreate] = `TxRepositoryPropertyOncallCreate`
 	m[TxRepositoryPropertyOncallDelete] = `TxRepositoryPropertyOncallDelete`
+	m[TxRepositoryPropertyOncallUpdate] = `TxRepositoryPropertyOncallUpdate`
 	m[TxRepositoryPropertyServiceCreate] = `TxRepositoryPropertyServiceCreate`
 	m[TxRepositoryPropertyServiceDelete] = `TxRepositoryPropertyServiceDelete`
+	m[TxRepositoryPropertyServiceUpdate] = `TxRepositoryPropertyServiceUpdate`
 	m[TxRepositoryPropertySystemCreate] = `TxRepositoryPropertySystemCreate`
 	m[TxRepositoryPropertySystemDelete] = `TxRepositoryPropertySystemDelete`
+	m[TxRepositoryPropertySystemUpdate] = `TxRepositoryPropertySystemUpdate`
+	m[TxRepositoryDetachChecks] = `TxRepositoryDetachChecks`
+	m[TxRepositoryRemoveGrants] = `TxRepositoryRemoveGrants`
+	m[TxRepositoryUpdate] = `TxRepositoryUpdate`
+	m[TxRepositoryUpdateTeam] = `TxRepositoryUpdateTeam`
+	m[TxUpdateCheckConfigurationBase] = `TxUpdateCheckConfigurationBase`
 	m[TxUpdateNodeState] = `TxUpdateNodeState`
 }
 
@@ -37,18 +37,21 @@ func (teb *Bucket) Destroy() {
 	}
 	teb.deletePropertyAllLocal()
 	teb.deletePropertyAllInherited()
-	// TODO delete all checks + check instances
-	// TODO delete all inherited checks + check instances
-
-	wg := new(sync.WaitGroup)
-	for child, _ := range teb.Children {
-		wg.Add(1)
-		go func(c string) {
-			defer wg.Done()
-			teb.Children[c].Destroy()
-		}(child)
+	// check instances of all children must be deprovisioned before
+	// the children are destroyed
+	teb.deprovisionInstances()
+	// TODO delete all checks
+	// TODO delete all inherited checks
+
+	// children unlink themselves from teb.Children, destroy them
+	// one after the other from a copy of the keys
+	children := make([]string, 0, len(teb.Children))
+	for child := range teb.Children {
+		children = append(children, child)
+	}
+	for _, child := range children {
+		teb.Children[child].Destroy()
 	}
-	wg.Wait()
 
 	teb.Parent.Unlink(UnlinkRequest{
 		ParentType: teb.Parent.(Builder).GetType(),
@@ -8,8 +8,6 @@
 
 package tree
 
-import "sync"
-
 //
 // Interface: Attacher
 func (tec *Cluster) Attach(a AttachRequest) {
@@ -34,7 +32,7 @@ func (tec *Cluster) ReAttach(a AttachRequest) {
 		panic(`Cluster.ReAttach: not attached`)
 	}
 	tec.deletePropertyAllInherited()
-	// TODO delete all inherited checks + check instances
+	tec.deleteCheckMembershipInherited()
 
 	tec.Parent.Unlink(UnlinkRequest{
 		ParentType: tec.Parent.(Builder).GetType(),
@@ -72,18 +70,21 @@ func (tec *Cluster) Destroy() {
 	tec.actionDelete()
 	tec.deletePropertyAllLocal()
 	tec.deletePropertyAllInherited()
-	// TODO delete all checks + check instances
-	// TODO delete all inherited checks + check instances
-
-	wg := new(sync.WaitGroup)
-	for child, _ := range tec.Children {
-		wg.Add(1)
-		go func(c string) {
-			defer wg.Done()
-			tec.Children[c].Destroy()
-		}(child)
+	// check instances of all children must be deprovisioned before
+	// the children are destroyed
+	tec.deprovisionInstances()
+	// TODO delete all checks
+	// TODO delete all inherited checks
+
+	// children unlink themselves from tec.Children, destroy them
+	// one after the other from a copy of the keys
+	children := make([]string, 0, len(tec.Children))
+	for child := range tec.Children {
+		children = append(children, child)
+	}
+	for _, child := range children {
+		tec.Children[child].Destroy()
 	}
-	wg.Wait()
 
 	tec.Parent.Unlink(UnlinkRequest{
 		ParentType: tec.Parent.(Builder).GetType(),
@@ -106,7 +107,7 @@ func (tec *Cluster) Detach() {
 	bucket := tec.Parent.(Bucketeer).GetBucket()
 
 	tec.deletePropertyAllInherited()
-	// TODO delete all inherited checks + check instances
+	tec.deleteCheckMembershipInherited()
 
 	tec.Parent.Unlink(UnlinkRequest{
 		ParentType: tec.Parent.(Builder).GetType(),
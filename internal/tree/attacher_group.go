@@ -8,8 +8,6 @@
 
 package tree
 
-import "sync"
-
 //
 // Interface: Attacher
 func (teg *Group) Attach(a AttachRequest) {
@@ -34,7 +32,7 @@ func (teg *Group) ReAttach(a AttachRequest) {
 		panic(`Group.ReAttach: not attached`)
 	}
 	teg.deletePropertyAllInherited()
-	// TODO delete all inherited checks + check instances
+	teg.deleteCheckMembershipInherited()
 
 	teg.Parent.Unlink(UnlinkRequest{
 		ParentType: teg.Parent.(Builder).GetType(),
@@ -72,18 +70,21 @@ func (teg *Group) Destroy() {
 	teg.actionDelete()
 	teg.deletePropertyAllLocal()
 	teg.deletePropertyAllInherited()
-	// TODO delete all checks + check instances
-	// TODO delete all inherited checks + check instances
-
-	wg := new(sync.WaitGroup)
-	for child, _ := range teg.Children {
-		wg.Add(1)
-		go func(c string) {
-			defer wg.Done()
-			teg.Children[c].Destroy()
-		}(child)
+	// check instances of all children must be deprovisioned before
+	// the children are destroyed
+	teg.deprovisionInstances()
+	// TODO delete all checks
+	// TODO delete all inherited checks
+
+	// children unlink themselves from teg.Children, destroy them
+	// one after the other from a copy of the keys
+	children := make([]string, 0, len(teg.Children))
+	for child := range teg.Children {
+		children = append(children, child)
+	}
+	for _, child := range children {
+		teg.Children[child].Destroy()
 	}
-	wg.Wait()
 
 	teg.Parent.Unlink(UnlinkRequest{
 		ParentType: teg.Parent.(Builder).GetType(),
@@ -106,7 +107,7 @@ func (teg *Group) Detach() {
 	bucket := teg.Parent.(Bucketeer).GetBucket()
 
 	teg.deletePropertyAllInherited()
-	// TODO delete all inherited checks + check instances
+	teg.deleteCheckMembershipInherited()
 
 	teg.Parent.Unlink(UnlinkRequest{
 		ParentType: teg.Parent.(Builder).GetType(),
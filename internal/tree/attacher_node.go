@@ -34,7 +34,7 @@ func (ten *Node) ReAttach(a AttachRequest) {
 		panic(`Node.ReAttach: not attached`)
 	}
 	ten.deletePropertyAllInherited()
-	// TODO delete all inherited checks + check instances
+	ten.deleteCheckMembershipInherited()
 
 	ten.Parent.Unlink(UnlinkRequest{
 		ParentType: ten.Parent.(Builder).GetType(),
@@ -71,8 +71,10 @@ func (ten *Node) Destroy() {
 	ten.actionDelete()
 	ten.deletePropertyAllLocal()
 	ten.deletePropertyAllInherited()
-	// TODO delete all checks + check instances
-	// TODO delete all inherited checks + check instances
+	// check instances must be deprovisioned before the node is
+	// unlinked from the tree
+	ten.deprovisionInstances()
+	ten.deleteCheckAll()
 
 	ten.Parent.Unlink(UnlinkRequest{
 		ParentType: ten.Parent.(Builder).GetType(),
@@ -95,7 +97,7 @@ func (ten *Node) Detach() {
 	bucket := ten.Parent.(Bucketeer).GetBucket()
 
 	ten.deletePropertyAllInherited()
-	// TODO delete all inherited checks + check instances
+	ten.deleteCheckMembershipInherited()
 
 	ten.Parent.Unlink(UnlinkRequest{
 		ParentType: ten.Parent.(Builder).GetType(),
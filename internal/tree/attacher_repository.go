@@ -8,8 +8,6 @@
 
 package tree
 
-import "sync"
-
 //
 // Interface: Attacher
 func (ter *Repository) Attach(a AttachRequest) {
@@ -41,15 +39,15 @@ func (ter *Repository) Destroy() {
 	// TODO delete all checks + check instances
 	// TODO delete all inherited checks + check instances
 
-	wg := new(sync.WaitGroup)
-	for child, _ := range ter.Children {
-		wg.Add(1)
-		go func(c string) {
-			defer wg.Done()
-			ter.Children[c].Destroy()
-		}(child)
+	// children unlink themselves from ter.Children, destroy them
+	// one after the other from a copy of the keys
+	children := make([]string, 0, len(ter.Children))
+	for child := range ter.Children {
+		children = append(children, child)
+	}
+	for _, child := range children {
+		ter.Children[child].Destroy()
 	}
-	wg.Wait()
 
 	// the Destroy handler of Fault calls
 	// updateFaultRecursive(nil) on us
@@ -227,6 +227,11 @@ func (teb *Bucket) ComputeCheckInstances() {
 		`bucket`,
 		teb.Id.String(),
 	)
+	// deleted buckets have all their check instances deprovisioned
+	// and must not spawn new ones until they are restored
+	if teb.Deleted {
+		return
+	}
 	/* var wg sync.WaitGroup
 	for child, _ := range teb.Children {
 		wg.Add(1)
@@ -310,6 +315,42 @@ func (teb *Bucket) actionDelete() {
 	}
 }
 
+//
+// Lifecycle
+func (teb *Bucket) Delete() {
+	if teb.Deleted {
+		return
+	}
+	teb.Deleted = true
+	teb.deprovisionInstances()
+	teb.actionUpdate()
+}
+
+func (teb *Bucket) Restore() {
+	if !teb.Deleted {
+		return
+	}
+	teb.Deleted = false
+	// force recomputation of all check instances below the bucket
+	teb.invalidateInstances()
+	teb.actionUpdate()
+}
+
+func (teb *Bucket) Freeze() {
+	teb.Frozen = true
+	teb.actionUpdate()
+}
+
+func (teb *Bucket) Thaw() {
+	teb.Frozen = false
+	teb.actionUpdate()
+}
+
+func (teb *Bucket) Rename(name string) {
+	teb.Name = name
+	teb.actionUpdate()
+}
+
 func (teb *Bucket) actionAssignNode(a Action) {
 	a.Action = "node_assignment"
 	a.Type = teb.Type
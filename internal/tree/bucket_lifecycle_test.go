@@ -0,0 +1,139 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid"
+)
+
+func TestBucketLifecycle(t *testing.T) {
+	actionC := make(chan *Action, 128)
+	errC := make(chan *Error, 128)
+
+	rootId := uuid.NewV4().String()
+	teamId := uuid.NewV4().String()
+	repoId := uuid.NewV4().String()
+	buckId := uuid.NewV4().String()
+	grpId := uuid.NewV4().String()
+
+	// create tree
+	sTree := New(TreeSpec{
+		Id:     rootId,
+		Name:   `root_testing`,
+		Action: actionC,
+	})
+
+	// create repository
+	NewRepository(RepositorySpec{
+		Id:      repoId,
+		Name:    `test`,
+		Team:    teamId,
+		Deleted: false,
+		Active:  true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `root`,
+		ParentId:   rootId,
+	})
+	sTree.SetError(errC)
+
+	// create bucket
+	NewBucket(BucketSpec{
+		Id:          buckId,
+		Name:        `test_master`,
+		Environment: `testing`,
+		Team:        teamId,
+		Deleted:     false,
+		Frozen:      false,
+		Repository:  repoId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `repository`,
+		ParentId:   repoId,
+	})
+
+	// create group
+	NewGroup(GroupSpec{
+		Id:   grpId,
+		Name: `testgroup`,
+		Team: teamId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `bucket`,
+		ParentId:   buckId,
+	})
+
+	// drain setup actions
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	bucket := sTree.Find(FindRequest{
+		ElementType: `bucket`,
+		ElementId:   buckId,
+	}, true).(*Bucket)
+
+	bucket.Delete()
+	if !bucket.Deleted {
+		t.Error(`Bucket not marked as deleted`)
+	}
+	if a := <-actionC; a.Action != `update` || !a.Bucket.IsDeleted {
+		t.Error(`Expected bucket update action with deleted flag`)
+	}
+
+	// deleting twice is a noop
+	bucket.Delete()
+	if len(actionC) != 0 {
+		t.Error(len(actionC), `elements in action channel`)
+	}
+
+	bucket.Restore()
+	if bucket.Deleted {
+		t.Error(`Bucket still marked as deleted`)
+	}
+	if !bucket.Children[grpId].(*Group).hasUpdate {
+		t.Error(`Group check instances not invalidated`)
+	}
+	<-actionC
+
+	bucket.Freeze()
+	if a := <-actionC; !a.Bucket.IsFrozen {
+		t.Error(`Expected bucket update action with frozen flag`)
+	}
+	bucket.Thaw()
+	if a := <-actionC; a.Bucket.IsFrozen {
+		t.Error(`Expected bucket update action without frozen flag`)
+	}
+
+	bucket.Rename(`test_renamed`)
+	if a := <-actionC; a.Bucket.Name != `test_renamed` {
+		t.Error(`Expected bucket update action with new name`)
+	}
+
+	bucket.Destroy()
+	if _, ok := sTree.Child.Children[buckId]; ok {
+		t.Error(`Bucket still attached after Destroy`)
+	}
+
+	close(actionC)
+	close(errC)
+
+	if len(errC) > 0 {
+		t.Error(`Error channel not empty`)
+	}
+
+	// group delete, bucket delete
+	if len(actionC) != 2 {
+		t.Error(len(actionC), `elements in action channel`)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
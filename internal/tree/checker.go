@@ -33,8 +33,16 @@ type Checker interface {
 	deleteCheckOnChildren(c Check)
 	rmCheck(c Check)
 
+	UpdateCheck(c Check)
+	updateCheckInherited(c Check)
+	updateCheckOnChildren(c Check)
+	updCheck(c Check)
+
 	syncCheck(childId string)
 	checkCheck(checkId string) bool
+
+	deprovisionInstances()
+	invalidateInstances()
 }
 
 type CheckGetter interface {
@@ -102,6 +110,24 @@ func (c *Check) Clone() Check {
 	return ng
 }
 
+// update copies the user modifiable attributes of n into c. Ids,
+// capability and view of a check are fixed after creation.
+func (c *Check) update(n Check) {
+	c.Inheritance = n.Inheritance
+	c.ChildrenOnly = n.ChildrenOnly
+	c.Interval = n.Interval
+
+	c.Thresholds = make([]CheckThreshold, len(n.Thresholds))
+	for i, _ := range n.Thresholds {
+		c.Thresholds[i] = n.Thresholds[i].Clone()
+	}
+
+	c.Constraints = make([]CheckConstraint, len(n.Constraints))
+	for i, _ := range n.Constraints {
+		c.Constraints[i] = n.Constraints[i].Clone()
+	}
+}
+
 type CheckItem struct {
 	ObjectId   uuid.UUID
 	ObjectType string
@@ -133,16 +159,18 @@ func (ct *CheckThreshold) Clone() CheckThreshold {
 }
 
 type CheckConstraint struct {
-	Type  string
-	Key   string
-	Value string
+	Type     string
+	Key      string
+	Value    string
+	Operator string
 }
 
 func (cc *CheckConstraint) Clone() CheckConstraint {
 	return CheckConstraint{
-		Type:  cc.Type,
-		Key:   cc.Key,
-		Value: cc.Value,
+		Type:     cc.Type,
+		Key:      cc.Key,
+		Value:    cc.Value,
+		Operator: cc.Operator,
 	}
 }
 
@@ -160,6 +188,7 @@ type CheckInstance struct {
 	ConstraintCustom      map[string]string              // Id->value
 	ConstraintNative      map[string]string              // prop->value
 	ConstraintAttribute   map[string]map[string][]string // svcId->attr->[ value, value, ... ]
+	ConstraintOperator    []string                       // type/key/operator/value
 	InstanceServiceConfig map[string]string              // attr->value
 	InstanceService       string
 	InstanceSvcCfgHash    string
@@ -270,6 +299,8 @@ func (tci *CheckInstance) Clone() CheckInstance {
 		t := v
 		cl.ConstraintNative[k] = t
 	}
+	cl.ConstraintOperator = make([]string, len(tci.ConstraintOperator))
+	copy(cl.ConstraintOperator, tci.ConstraintOperator)
 	cl.InstanceServiceConfig = make(map[string]string)
 	for k, v := range tci.InstanceServiceConfig {
 		t := v
@@ -292,6 +323,9 @@ func (tci *CheckInstance) Clone() CheckInstance {
 func (tci *CheckInstance) calcConstraintHash() {
 	h := sha512.New()
 	io.WriteString(h, tci.ConstraintOncall)
+	for _, op := range tci.ConstraintOperator {
+		io.WriteString(h, op)
+	}
 
 	services := []string{}
 	for i, _ := range tci.ConstraintService {
@@ -357,6 +391,9 @@ func (tci *CheckInstance) calcConstraintHash() {
 func (tci *CheckInstance) calcConstraintValHash() {
 	h := sha512.New()
 	io.WriteString(h, tci.ConstraintOncall)
+	for _, op := range tci.ConstraintOperator {
+		io.WriteString(h, op)
+	}
 
 	services := []string{}
 	for i, _ := range tci.ConstraintService {
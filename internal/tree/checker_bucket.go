@@ -143,6 +143,73 @@ func (teb *Bucket) rmCheck(c Check) {
 	}
 }
 
+//
+// Checker:> Update Check
+
+func (teb *Bucket) UpdateCheck(c Check) {
+	for id, _ := range teb.Checks {
+		if !uuid.Equal(teb.Checks[id].SourceId, c.SourceId) {
+			continue
+		}
+		f := teb.Checks[id]
+		switch {
+		case f.Inheritance && c.Inheritance:
+			teb.updateCheckOnChildren(c)
+		case f.Inheritance && !c.Inheritance:
+			// inheritance was disabled
+			teb.deleteCheckOnChildren(f.Clone())
+		case !f.Inheritance && c.Inheritance:
+			// inheritance was enabled, send a scrubbed copy of the
+			// updated check downward
+			n := f.Clone()
+			n.update(c)
+			n.Inherited = true
+			n.Id = uuid.Nil
+			n.Items = nil
+			teb.setCheckOnChildren(n)
+		}
+		teb.updCheck(c)
+		return
+	}
+}
+
+func (teb *Bucket) updateCheckInherited(c Check) {
+	teb.updateCheckOnChildren(c)
+	teb.updCheck(c)
+}
+
+func (teb *Bucket) updateCheckOnChildren(c Check) {
+	// groups
+	for i := 0; i < teb.ordNumChildGrp; i++ {
+		if child, ok := teb.ordChildrenGrp[i]; ok {
+			teb.Children[child].(Checker).updateCheckInherited(c)
+		}
+	}
+	// clusters
+	for i := 0; i < teb.ordNumChildClr; i++ {
+		if child, ok := teb.ordChildrenClr[i]; ok {
+			teb.Children[child].(Checker).updateCheckInherited(c)
+		}
+	}
+	// nodes
+	for i := 0; i < teb.ordNumChildNod; i++ {
+		if child, ok := teb.ordChildrenNod[i]; ok {
+			teb.Children[child].(Checker).updateCheckInherited(c)
+		}
+	}
+}
+
+func (teb *Bucket) updCheck(c Check) {
+	for id, _ := range teb.Checks {
+		if uuid.Equal(teb.Checks[id].SourceId, c.SourceId) {
+			f := teb.Checks[id]
+			f.update(c)
+			teb.Checks[id] = f
+			return
+		}
+	}
+}
+
 //
 // Checker:> Meta
 
@@ -172,4 +239,49 @@ func (teb *Bucket) checkCheck(checkId string) bool {
 func (teb *Bucket) LoadInstance(i CheckInstance) {
 }
 
+//
+// Checker:> Instance Lifecycle
+
+func (teb *Bucket) deprovisionInstances() {
+	// groups
+	for i := 0; i < teb.ordNumChildGrp; i++ {
+		if child, ok := teb.ordChildrenGrp[i]; ok {
+			teb.Children[child].(Checker).deprovisionInstances()
+		}
+	}
+	// clusters
+	for i := 0; i < teb.ordNumChildClr; i++ {
+		if child, ok := teb.ordChildrenClr[i]; ok {
+			teb.Children[child].(Checker).deprovisionInstances()
+		}
+	}
+	// nodes
+	for i := 0; i < teb.ordNumChildNod; i++ {
+		if child, ok := teb.ordChildrenNod[i]; ok {
+			teb.Children[child].(Checker).deprovisionInstances()
+		}
+	}
+}
+
+func (teb *Bucket) invalidateInstances() {
+	// groups
+	for i := 0; i < teb.ordNumChildGrp; i++ {
+		if child, ok := teb.ordChildrenGrp[i]; ok {
+			teb.Children[child].(Checker).invalidateInstances()
+		}
+	}
+	// clusters
+	for i := 0; i < teb.ordNumChildClr; i++ {
+		if child, ok := teb.ordChildrenClr[i]; ok {
+			teb.Children[child].(Checker).invalidateInstances()
+		}
+	}
+	// nodes
+	for i := 0; i < teb.ordNumChildNod; i++ {
+		if child, ok := teb.ordChildrenNod[i]; ok {
+			teb.Children[child].(Checker).invalidateInstances()
+		}
+	}
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
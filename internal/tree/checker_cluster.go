@@ -121,6 +121,82 @@ func (tec *Cluster) rmCheck(c Check) {
 	}
 }
 
+// deleteCheckMembershipInherited removes all inherited checks that
+// were not received from the repository or bucket, ie. checks that
+// were inherited via group or cluster membership
+func (tec *Cluster) deleteCheckMembershipInherited() {
+	checks := []Check{}
+	for id, _ := range tec.Checks {
+		if !tec.Checks[id].Inherited {
+			continue
+		}
+		switch tec.Checks[id].SourceType {
+		case `repository`, `bucket`:
+			continue
+		}
+		f := tec.Checks[id]
+		checks = append(checks, f.Clone())
+	}
+	for _, c := range checks {
+		tec.deleteCheckInherited(c)
+	}
+}
+
+//
+// Checker:> Update Check
+
+func (tec *Cluster) UpdateCheck(c Check) {
+	for id, _ := range tec.Checks {
+		if !uuid.Equal(tec.Checks[id].SourceId, c.SourceId) {
+			continue
+		}
+		f := tec.Checks[id]
+		switch {
+		case f.Inheritance && c.Inheritance:
+			tec.updateCheckOnChildren(c)
+		case f.Inheritance && !c.Inheritance:
+			// inheritance was disabled
+			tec.deleteCheckOnChildren(f.Clone())
+		case !f.Inheritance && c.Inheritance:
+			// inheritance was enabled, send a scrubbed copy of the
+			// updated check downward
+			n := f.Clone()
+			n.update(c)
+			n.Inherited = true
+			n.Id = uuid.Nil
+			n.Items = nil
+			tec.setCheckOnChildren(n)
+		}
+		tec.updCheck(c)
+		return
+	}
+}
+
+func (tec *Cluster) updateCheckInherited(c Check) {
+	tec.updateCheckOnChildren(c)
+	tec.updCheck(c)
+}
+
+func (tec *Cluster) updateCheckOnChildren(c Check) {
+	for i := 0; i < tec.ordNumChildNod; i++ {
+		if child, ok := tec.ordChildrenNod[i]; ok {
+			tec.Children[child].(Checker).updateCheckInherited(c)
+		}
+	}
+}
+
+func (tec *Cluster) updCheck(c Check) {
+	for id, _ := range tec.Checks {
+		if uuid.Equal(tec.Checks[id].SourceId, c.SourceId) {
+			f := tec.Checks[id]
+			f.update(c)
+			tec.Checks[id] = f
+			tec.hasUpdate = true
+			return
+		}
+	}
+}
+
 //
 // Checker:> Meta
 
@@ -155,4 +231,42 @@ func (tec *Cluster) LoadInstance(i CheckInstance) {
 	tec.loadedInstances[ckId][ckInstId] = i
 }
 
+//
+// Checker:> Instance Lifecycle
+
+func (tec *Cluster) deprovisionInstances() {
+	// nodes
+	for i := 0; i < tec.ordNumChildNod; i++ {
+		if child, ok := tec.ordChildrenNod[i]; ok {
+			tec.Children[child].(Checker).deprovisionInstances()
+		}
+	}
+	repoName := tec.GetRepositoryName()
+	for ck, _ := range tec.CheckInstances {
+		for _, i := range tec.CheckInstances[ck] {
+			tec.actionCheckInstanceDelete(tec.Instances[i].MakeAction())
+			tec.log.Printf("TK[%s]: Action=%s, ObjectType=%s, ObjectId=%s, CheckId=%s, InstanceId=%s",
+				repoName,
+				`DeprovisionInstance`,
+				`cluster`,
+				tec.Id.String(),
+				ck,
+				i,
+			)
+			delete(tec.Instances, i)
+		}
+		delete(tec.CheckInstances, ck)
+	}
+}
+
+func (tec *Cluster) invalidateInstances() {
+	// nodes
+	for i := 0; i < tec.ordNumChildNod; i++ {
+		if child, ok := tec.ordChildrenNod[i]; ok {
+			tec.Children[child].(Checker).invalidateInstances()
+		}
+	}
+	tec.hasUpdate = true
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
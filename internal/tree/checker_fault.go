@@ -34,6 +34,18 @@ func (tef *Fault) deleteCheckOnChildren(c Check) {
 func (tef *Fault) rmCheck(c Check) {
 }
 
+func (tef *Fault) UpdateCheck(c Check) {
+}
+
+func (tef *Fault) updateCheckInherited(c Check) {
+}
+
+func (tef *Fault) updateCheckOnChildren(c Check) {
+}
+
+func (tef *Fault) updCheck(c Check) {
+}
+
 func (tef *Fault) syncCheck(childId string) {
 }
 
@@ -41,4 +53,10 @@ func (tef *Fault) checkCheck(checkId string) bool {
 	return false
 }
 
+func (tef *Fault) deprovisionInstances() {
+}
+
+func (tef *Fault) invalidateInstances() {
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -146,6 +146,95 @@ func (teg *Group) rmCheck(c Check) {
 	}
 }
 
+// deleteCheckMembershipInherited removes all inherited checks that
+// were not received from the repository or bucket, ie. checks that
+// were inherited via group or cluster membership
+func (teg *Group) deleteCheckMembershipInherited() {
+	checks := []Check{}
+	for id, _ := range teg.Checks {
+		if !teg.Checks[id].Inherited {
+			continue
+		}
+		switch teg.Checks[id].SourceType {
+		case `repository`, `bucket`:
+			continue
+		}
+		f := teg.Checks[id]
+		checks = append(checks, f.Clone())
+	}
+	for _, c := range checks {
+		teg.deleteCheckInherited(c)
+	}
+}
+
+//
+// Checker:> Update Check
+
+func (teg *Group) UpdateCheck(c Check) {
+	for id, _ := range teg.Checks {
+		if !uuid.Equal(teg.Checks[id].SourceId, c.SourceId) {
+			continue
+		}
+		f := teg.Checks[id]
+		switch {
+		case f.Inheritance && c.Inheritance:
+			teg.updateCheckOnChildren(c)
+		case f.Inheritance && !c.Inheritance:
+			// inheritance was disabled
+			teg.deleteCheckOnChildren(f.Clone())
+		case !f.Inheritance && c.Inheritance:
+			// inheritance was enabled, send a scrubbed copy of the
+			// updated check downward
+			n := f.Clone()
+			n.update(c)
+			n.Inherited = true
+			n.Id = uuid.Nil
+			n.Items = nil
+			teg.setCheckOnChildren(n)
+		}
+		teg.updCheck(c)
+		return
+	}
+}
+
+func (teg *Group) updateCheckInherited(c Check) {
+	teg.updateCheckOnChildren(c)
+	teg.updCheck(c)
+}
+
+func (teg *Group) updateCheckOnChildren(c Check) {
+	// groups
+	for i := 0; i < teg.ordNumChildGrp; i++ {
+		if child, ok := teg.ordChildrenGrp[i]; ok {
+			teg.Children[child].(Checker).updateCheckInherited(c)
+		}
+	}
+	// clusters
+	for i := 0; i < teg.ordNumChildClr; i++ {
+		if child, ok := teg.ordChildrenClr[i]; ok {
+			teg.Children[child].(Checker).updateCheckInherited(c)
+		}
+	}
+	// nodes
+	for i := 0; i < teg.ordNumChildNod; i++ {
+		if child, ok := teg.ordChildrenNod[i]; ok {
+			teg.Children[child].(Checker).updateCheckInherited(c)
+		}
+	}
+}
+
+func (teg *Group) updCheck(c Check) {
+	for id, _ := range teg.Checks {
+		if uuid.Equal(teg.Checks[id].SourceId, c.SourceId) {
+			f := teg.Checks[id]
+			f.update(c)
+			teg.Checks[id] = f
+			teg.hasUpdate = true
+			return
+		}
+	}
+}
+
 //
 // Checker:> Meta
 
@@ -181,4 +270,66 @@ func (teg *Group) LoadInstance(i CheckInstance) {
 	teg.loadedInstances[ckId][ckInstId] = i
 }
 
+//
+// Checker:> Instance Lifecycle
+
+func (teg *Group) deprovisionInstances() {
+	// groups
+	for i := 0; i < teg.ordNumChildGrp; i++ {
+		if child, ok := teg.ordChildrenGrp[i]; ok {
+			teg.Children[child].(Checker).deprovisionInstances()
+		}
+	}
+	// clusters
+	for i := 0; i < teg.ordNumChildClr; i++ {
+		if child, ok := teg.ordChildrenClr[i]; ok {
+			teg.Children[child].(Checker).deprovisionInstances()
+		}
+	}
+	// nodes
+	for i := 0; i < teg.ordNumChildNod; i++ {
+		if child, ok := teg.ordChildrenNod[i]; ok {
+			teg.Children[child].(Checker).deprovisionInstances()
+		}
+	}
+	repoName := teg.GetRepositoryName()
+	for ck, _ := range teg.CheckInstances {
+		for _, i := range teg.CheckInstances[ck] {
+			teg.actionCheckInstanceDelete(teg.Instances[i].MakeAction())
+			teg.log.Printf("TK[%s]: Action=%s, ObjectType=%s, ObjectId=%s, CheckId=%s, InstanceId=%s",
+				repoName,
+				`DeprovisionInstance`,
+				`group`,
+				teg.Id.String(),
+				ck,
+				i,
+			)
+			delete(teg.Instances, i)
+		}
+		delete(teg.CheckInstances, ck)
+	}
+}
+
+func (teg *Group) invalidateInstances() {
+	// groups
+	for i := 0; i < teg.ordNumChildGrp; i++ {
+		if child, ok := teg.ordChildrenGrp[i]; ok {
+			teg.Children[child].(Checker).invalidateInstances()
+		}
+	}
+	// clusters
+	for i := 0; i < teg.ordNumChildClr; i++ {
+		if child, ok := teg.ordChildrenClr[i]; ok {
+			teg.Children[child].(Checker).invalidateInstances()
+		}
+	}
+	// nodes
+	for i := 0; i < teg.ordNumChildNod; i++ {
+		if child, ok := teg.ordChildrenNod[i]; ok {
+			teg.Children[child].(Checker).invalidateInstances()
+		}
+	}
+	teg.hasUpdate = true
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -75,6 +75,66 @@ func (ten *Node) rmCheck(c Check) {
 	}
 }
 
+// deleteCheckMembershipInherited removes all inherited checks that
+// were not received from the repository or bucket, ie. checks that
+// were inherited via group or cluster membership
+func (ten *Node) deleteCheckMembershipInherited() {
+	checks := []Check{}
+	for id, _ := range ten.Checks {
+		if !ten.Checks[id].Inherited {
+			continue
+		}
+		switch ten.Checks[id].SourceType {
+		case `repository`, `bucket`:
+			continue
+		}
+		f := ten.Checks[id]
+		checks = append(checks, f.Clone())
+	}
+	for _, c := range checks {
+		ten.deleteCheckInherited(c)
+	}
+}
+
+// deleteCheckAll removes all checks from the node, local as well as
+// inherited ones
+func (ten *Node) deleteCheckAll() {
+	checks := []Check{}
+	for id, _ := range ten.Checks {
+		f := ten.Checks[id]
+		checks = append(checks, f.Clone())
+	}
+	for _, c := range checks {
+		ten.rmCheck(c)
+	}
+}
+
+//
+// Checker:> Update Check
+
+func (ten *Node) UpdateCheck(c Check) {
+	ten.updCheck(c)
+}
+
+func (ten *Node) updateCheckInherited(c Check) {
+	ten.updCheck(c)
+}
+
+func (ten *Node) updateCheckOnChildren(c Check) {
+}
+
+func (ten *Node) updCheck(c Check) {
+	for id, _ := range ten.Checks {
+		if uuid.Equal(ten.Checks[id].SourceId, c.SourceId) {
+			f := ten.Checks[id]
+			f.update(c)
+			ten.Checks[id] = f
+			ten.hasUpdate = true
+			return
+		}
+	}
+}
+
 // noop, satisfy interface
 func (ten *Node) syncCheck(childId string) {
 }
@@ -86,6 +146,24 @@ func (ten *Node) checkCheck(checkId string) bool {
 	return false
 }
 
+// LocalChecks returns clones of all checks that were set on the node
+// itself and not inherited. The clones carry no id or item
+// information and can be set on a different node.
+func (ten *Node) LocalChecks() []Check {
+	checks := []Check{}
+	for id, _ := range ten.Checks {
+		if ten.Checks[id].Inherited {
+			continue
+		}
+		f := ten.Checks[id]
+		c := f.Clone()
+		c.Id = uuid.Nil
+		c.Items = nil
+		checks = append(checks, c)
+	}
+	return checks
+}
+
 //
 func (ten *Node) LoadInstance(i CheckInstance) {
 	ckId := i.CheckId.String()
@@ -96,4 +174,30 @@ func (ten *Node) LoadInstance(i CheckInstance) {
 	ten.loadedInstances[ckId][ckInstId] = i
 }
 
+//
+// Checker:> Instance Lifecycle
+
+func (ten *Node) deprovisionInstances() {
+	repoName := ten.GetRepositoryName()
+	for ck, _ := range ten.CheckInstances {
+		for _, i := range ten.CheckInstances[ck] {
+			ten.actionCheckInstanceDelete(ten.Instances[i].MakeAction())
+			ten.log.Printf("TK[%s]: Action=%s, ObjectType=%s, ObjectId=%s, CheckId=%s, InstanceId=%s",
+				repoName,
+				`DeprovisionInstance`,
+				`node`,
+				ten.Id.String(),
+				ck,
+				i,
+			)
+			delete(ten.Instances, i)
+		}
+		delete(ten.CheckInstances, ck)
+	}
+}
+
+func (ten *Node) invalidateInstances() {
+	ten.hasUpdate = true
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -119,6 +119,60 @@ func (ter *Repository) rmCheck(c Check) {
 	}
 }
 
+//
+// Checker:> Update Check
+
+func (ter *Repository) UpdateCheck(c Check) {
+	for id, _ := range ter.Checks {
+		if !uuid.Equal(ter.Checks[id].SourceId, c.SourceId) {
+			continue
+		}
+		f := ter.Checks[id]
+		switch {
+		case f.Inheritance && c.Inheritance:
+			ter.updateCheckOnChildren(c)
+		case f.Inheritance && !c.Inheritance:
+			// inheritance was disabled
+			ter.deleteCheckOnChildren(f.Clone())
+		case !f.Inheritance && c.Inheritance:
+			// inheritance was enabled, send a scrubbed copy of the
+			// updated check downward
+			n := f.Clone()
+			n.update(c)
+			n.Inherited = true
+			n.Id = uuid.Nil
+			n.Items = nil
+			ter.setCheckOnChildren(n)
+		}
+		ter.updCheck(c)
+		return
+	}
+}
+
+func (ter *Repository) updateCheckInherited(c Check) {
+	ter.updateCheckOnChildren(c)
+	ter.updCheck(c)
+}
+
+func (ter *Repository) updateCheckOnChildren(c Check) {
+	for i := 0; i < ter.ordNumChildBck; i++ {
+		if child, ok := ter.ordChildrenBck[i]; ok {
+			ter.Children[child].(Checker).updateCheckInherited(c)
+		}
+	}
+}
+
+func (ter *Repository) updCheck(c Check) {
+	for id, _ := range ter.Checks {
+		if uuid.Equal(ter.Checks[id].SourceId, c.SourceId) {
+			f := ter.Checks[id]
+			f.update(c)
+			ter.Checks[id] = f
+			return
+		}
+	}
+}
+
 //
 // Checker:> Meta
 
@@ -148,4 +202,25 @@ func (ter *Repository) checkCheck(checkId string) bool {
 func (ter *Repository) LoadInstance(i CheckInstance) {
 }
 
+//
+// Checker:> Instance Lifecycle
+
+func (ter *Repository) deprovisionInstances() {
+	// buckets
+	for i := 0; i < ter.ordNumChildBck; i++ {
+		if child, ok := ter.ordChildrenBck[i]; ok {
+			ter.Children[child].(Checker).deprovisionInstances()
+		}
+	}
+}
+
+func (ter *Repository) invalidateInstances() {
+	// buckets
+	for i := 0; i < ter.ordNumChildBck; i++ {
+		if child, ok := ter.ordChildrenBck[i]; ok {
+			ter.Children[child].(Checker).invalidateInstances()
+		}
+	}
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
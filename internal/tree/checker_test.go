@@ -9,8 +9,10 @@
 package tree
 
 import (
+	"io/ioutil"
 	"testing"
 
+	log "github.com/Sirupsen/logrus"
 	"github.com/satori/go.uuid"
 )
 
@@ -56,4 +58,150 @@ func TestCheckClone(t *testing.T) {
 	}
 }
 
+func TestCheckUpdate(t *testing.T) {
+	actionC := make(chan *Action, 128)
+	errC := make(chan *Error, 128)
+
+	rootId := uuid.NewV4().String()
+	teamId := uuid.NewV4().String()
+	repoId := uuid.NewV4().String()
+	buckId := uuid.NewV4().String()
+	grpId := uuid.NewV4().String()
+	nodeId := uuid.NewV4().String()
+	servId := uuid.NewV4().String()
+
+	logger := log.New()
+	logger.Out = ioutil.Discard
+
+	sTree := New(TreeSpec{
+		Id:     rootId,
+		Name:   `root_testing`,
+		Action: actionC,
+		Log:    logger,
+	})
+	NewRepository(RepositorySpec{
+		Id:      repoId,
+		Name:    `test`,
+		Team:    teamId,
+		Deleted: false,
+		Active:  true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `root`,
+		ParentId:   rootId,
+	})
+	sTree.SetError(errC)
+	NewBucket(BucketSpec{
+		Id:          buckId,
+		Name:        `test_master`,
+		Environment: `testing`,
+		Team:        teamId,
+		Repository:  repoId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `repository`,
+		ParentId:   repoId,
+	})
+	NewGroup(GroupSpec{
+		Id:   grpId,
+		Name: `testgroup`,
+		Team: teamId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `bucket`,
+		ParentId:   buckId,
+	})
+	NewNode(NodeSpec{
+		Id:       nodeId,
+		AssetId:  1,
+		Name:     `testnode`,
+		Team:     teamId,
+		ServerId: servId,
+		Online:   true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `group`,
+		ParentId:   grpId,
+	})
+
+	group := sTree.Find(FindRequest{
+		ElementType: `group`,
+		ElementId:   grpId,
+	}, true).(*Group)
+	node := sTree.Find(FindRequest{
+		ElementType: `node`,
+		ElementId:   nodeId,
+	}, true).(*Node)
+
+	group.SetCheck(Check{
+		Id:           uuid.Nil,
+		CapabilityId: uuid.NewV4(),
+		ConfigId:     uuid.NewV4(),
+		Inheritance:  true,
+		View:         `any`,
+		Interval:     60,
+	})
+	sTree.ComputeCheckInstances()
+	if len(node.Instances) != 1 {
+		t.Fatal(len(node.Instances), `instances on node, expected 1`)
+	}
+	var source Check
+	for _, c := range group.Checks {
+		source = c
+	}
+	var instance CheckInstance
+	for _, i := range node.Instances {
+		instance = i
+	}
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	update := source.Clone()
+	update.Interval = 300
+	group.UpdateCheck(update)
+	for _, c := range node.Checks {
+		if c.Interval != 300 {
+			t.Error(`Inherited check was not updated`)
+		}
+	}
+
+	sTree.ComputeCheckInstances()
+	// instance updates for the group and the node
+	if len(actionC) != 2 {
+		t.Fatal(len(actionC), `elements in action channel, expected 2`)
+	}
+	for i := len(actionC); i > 0; i-- {
+		if a := <-actionC; a.Action != `check_instance_update` {
+			t.Error(`Expected check_instance_update action, got`, a.Action)
+		}
+	}
+	for id, i := range node.Instances {
+		if id != instance.InstanceId.String() {
+			t.Error(`Check instance was replaced instead of updated`)
+		}
+		if i.Version != instance.Version+1 {
+			t.Error(`Check instance version was not increased`)
+		}
+	}
+
+	// disabling inheritance removes the check from the children
+	update.Inheritance = false
+	group.UpdateCheck(update)
+	if len(node.Checks) != 0 {
+		t.Error(`Inherited check survived disabled inheritance`)
+	}
+	sTree.ComputeCheckInstances()
+	if len(node.Instances) != 0 {
+		t.Error(`Check instances survived disabled inheritance`)
+	}
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	if len(errC) > 0 {
+		t.Error(`Error channel not empty`)
+	}
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -318,6 +318,13 @@ func (tec *Cluster) ClearLoadInfo() {
 	tec.loadedInstances = map[string]map[string]CheckInstance{}
 }
 
+//
+//
+func (tec *Cluster) Rename(name string) {
+	tec.Name = name
+	tec.actionUpdate()
+}
+
 //
 //
 func (tec *Cluster) export() proto.Cluster {
@@ -361,6 +368,7 @@ func (tec *Cluster) actionMemberNew(a Action) {
 	a.Cluster = tec.export()
 
 	tec.Action <- &a
+	tec.membersUpdated()
 }
 
 func (tec *Cluster) actionMemberRemoved(a Action) {
@@ -369,6 +377,17 @@ func (tec *Cluster) actionMemberRemoved(a Action) {
 	a.Cluster = tec.export()
 
 	tec.Action <- &a
+	tec.membersUpdated()
+}
+
+// membersUpdated flags the cluster and the group it is a member of
+// for a recalculation of their check instances, since the
+// hardware_node constraint depends on the member nodes
+func (tec *Cluster) membersUpdated() {
+	tec.hasUpdate = true
+	if parent, ok := tec.Parent.(*Group); ok {
+		parent.membersUpdated()
+	}
 }
 
 //
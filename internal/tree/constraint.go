@@ -0,0 +1,150 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// regexCache holds the compiled patterns of regex constraints
+var regexCache = struct {
+	sync.RWMutex
+	m map[string]*regexp.Regexp
+}{m: map[string]*regexp.Regexp{}}
+
+// ValidateConstraintOperator checks that operator op can be used on
+// a constraint of type typ with value val. The empty operator and
+// == both request an exact match.
+func ValidateConstraintOperator(typ, op, val string) error {
+	switch op {
+	case ``, `==`:
+		return nil
+	}
+
+	switch typ {
+	case `native`, `system`, `custom`, `attribute`:
+	default:
+		return fmt.Errorf("Constraint type %s does not support"+
+			" operator %s", typ, op)
+	}
+	if val == `@defined` {
+		return fmt.Errorf("Operator %s can not be used with @defined",
+			op)
+	}
+
+	switch op {
+	case `!=`:
+	case `glob`, `!glob`:
+		if _, err := path.Match(val, ``); err != nil {
+			return fmt.Errorf("Invalid glob pattern %s: %s", val,
+				err.Error())
+		}
+	case `regex`, `!regex`:
+		if _, err := regexp.Compile(val); err != nil {
+			return fmt.Errorf("Invalid regular expression %s: %s",
+				val, err.Error())
+		}
+	case `<`, `<=`, `>`, `>=`:
+		if _, err := strconv.ParseFloat(val, 64); err != nil {
+			return fmt.Errorf("Operator %s requires a numeric value,"+
+				" got %s", op, val)
+		}
+	default:
+		return fmt.Errorf("Unknown constraint operator %s", op)
+	}
+	return nil
+}
+
+// matchConstraint reports whether the property value has matches
+// the constraint value want under operator op. Negated operators
+// only match values that are present, numeric operators only match
+// numeric values.
+func matchConstraint(op, want, has string) bool {
+	switch op {
+	case ``, `==`:
+		return has == want || want == `@defined`
+	case `!=`:
+		return has != want
+	case `glob`, `!glob`:
+		ok, err := path.Match(want, has)
+		if err != nil {
+			return false
+		}
+		return ok == (op == `glob`)
+	case `regex`, `!regex`:
+		re, err := compileRegex(want)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(has) == (op == `regex`)
+	case `<`, `<=`, `>`, `>=`:
+		w, err := strconv.ParseFloat(want, 64)
+		if err != nil {
+			return false
+		}
+		h, err := strconv.ParseFloat(has, 64)
+		if err != nil {
+			return false
+		}
+		switch op {
+		case `<`:
+			return h < w
+		case `<=`:
+			return h <= w
+		case `>`:
+			return h > w
+		default:
+			return h >= w
+		}
+	}
+	return false
+}
+
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	regexCache.RLock()
+	re, ok := regexCache.m[expr]
+	regexCache.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Lock()
+	regexCache.m[expr] = re
+	regexCache.Unlock()
+	return re, nil
+}
+
+// constraintOperators returns the constraints of the check that do
+// not use an exact match, as sorted list of type/key/operator/value
+// strings. They are part of the constraint hashes of the check
+// instances.
+func (c Check) constraintOperators() []string {
+	ops := []string{}
+	for _, cstr := range c.Constraints {
+		switch cstr.Operator {
+		case ``, `==`:
+			continue
+		}
+		ops = append(ops, fmt.Sprintf("%s/%s/%s/%s", cstr.Type,
+			cstr.Key, cstr.Operator, cstr.Value))
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
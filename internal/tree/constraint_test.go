@@ -0,0 +1,221 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"io/ioutil"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/satori/go.uuid"
+)
+
+func TestMatchConstraint(t *testing.T) {
+	for _, tc := range []struct {
+		op, want, has string
+		match         bool
+	}{
+		{``, `linux`, `linux`, true},
+		{`==`, `linux`, `windows`, false},
+		{``, `@defined`, `windows`, true},
+		{`!=`, `windows`, `linux`, true},
+		{`!=`, `windows`, `windows`, false},
+		{`glob`, `db-*`, `db-master`, true},
+		{`glob`, `db-*`, `web-01`, false},
+		{`!glob`, `db-*`, `web-01`, true},
+		{`regex`, `^db-[0-9]+$`, `db-12`, true},
+		{`regex`, `^db-[0-9]+$`, `db-master`, false},
+		{`!regex`, `^db-`, `db-12`, false},
+		{`<`, `1024`, `80`, true},
+		{`<`, `1024`, `8080`, false},
+		{`<=`, `1024`, `1024`, true},
+		{`>`, `1.5`, `2`, true},
+		{`>=`, `10`, `9.99`, false},
+		{`<`, `1024`, `http`, false},
+	} {
+		if m := matchConstraint(tc.op, tc.want, tc.has); m != tc.match {
+			t.Errorf("%s %s %s: got %t, expected %t",
+				tc.has, tc.op, tc.want, m, tc.match)
+		}
+	}
+}
+
+func TestValidateConstraintOperator(t *testing.T) {
+	for _, tc := range []struct {
+		typ, op, val string
+		valid        bool
+	}{
+		{`system`, ``, `@defined`, true},
+		{`system`, `==`, `linux`, true},
+		{`oncall`, `!=`, `x`, false},
+		{`service`, `glob`, `db*`, false},
+		{`custom`, `glob`, `db-[`, false},
+		{`custom`, `regex`, `db-(`, false},
+		{`attribute`, `<`, `1024`, true},
+		{`attribute`, `<`, `many`, false},
+		{`native`, `!=`, `@defined`, false},
+		{`native`, `~`, `x`, false},
+	} {
+		err := ValidateConstraintOperator(tc.typ, tc.op, tc.val)
+		if (err == nil) != tc.valid {
+			t.Errorf("%s %s %s: valid %t, got error %v",
+				tc.typ, tc.op, tc.val, tc.valid, err)
+		}
+	}
+}
+
+func TestConstraintOperatorHash(t *testing.T) {
+	chk := Check{Constraints: []CheckConstraint{
+		{Type: `system`, Key: `os`, Value: `linux`},
+	}}
+	inst := CheckInstance{
+		ConstraintSystem:   map[string]string{`os`: `linux`},
+		ConstraintOperator: chk.constraintOperators(),
+	}
+	inst.calcConstraintHash()
+	inst.calcConstraintValHash()
+	plain, plainVal := inst.ConstraintHash, inst.ConstraintValHash
+
+	// an exact match does not change the hashes
+	chk.Constraints[0].Operator = `==`
+	inst.ConstraintOperator = chk.constraintOperators()
+	inst.calcConstraintHash()
+	if inst.ConstraintHash != plain {
+		t.Errorf(`Operator == changed the constraint hash`)
+	}
+
+	chk.Constraints[0].Operator = `glob`
+	chk.Constraints[0].Value = `lin*`
+	inst.ConstraintOperator = chk.constraintOperators()
+	inst.calcConstraintHash()
+	inst.calcConstraintValHash()
+	if inst.ConstraintHash == plain || inst.ConstraintValHash == plainVal {
+		t.Errorf(`Operator glob did not change the constraint hashes`)
+	}
+}
+
+func TestHardwareNodeConstraint(t *testing.T) {
+	actionC := make(chan *Action, 128)
+	errC := make(chan *Error, 128)
+
+	rootId := uuid.NewV4().String()
+	teamId := uuid.NewV4().String()
+	repoId := uuid.NewV4().String()
+	buckId := uuid.NewV4().String()
+	grpId := uuid.NewV4().String()
+	clrId := uuid.NewV4().String()
+	nodeId := uuid.NewV4().String()
+
+	logger := log.New()
+	logger.Out = ioutil.Discard
+
+	sTree := New(TreeSpec{
+		Id:     rootId,
+		Name:   `root_testing`,
+		Action: actionC,
+		Log:    logger,
+	})
+	NewRepository(RepositorySpec{
+		Id:      repoId,
+		Name:    `test`,
+		Team:    teamId,
+		Deleted: false,
+		Active:  true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `root`,
+		ParentId:   rootId,
+	})
+	sTree.SetError(errC)
+	NewBucket(BucketSpec{
+		Id:          buckId,
+		Name:        `test_master`,
+		Environment: `testing`,
+		Team:        teamId,
+		Repository:  repoId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `repository`,
+		ParentId:   repoId,
+	})
+	NewGroup(GroupSpec{
+		Id:   grpId,
+		Name: `testgroup`,
+		Team: teamId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `bucket`,
+		ParentId:   buckId,
+	})
+	NewCluster(ClusterSpec{
+		Id:   clrId,
+		Name: `testcluster`,
+		Team: teamId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `group`,
+		ParentId:   grpId,
+	})
+	NewNode(NodeSpec{
+		Id:         nodeId,
+		AssetId:    1,
+		Name:       `testnode`,
+		Team:       teamId,
+		ServerId:   uuid.NewV4().String(),
+		ServerName: `hv01`,
+		Online:     true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `cluster`,
+		ParentId:   clrId,
+	})
+
+	node := sTree.Find(FindRequest{
+		ElementType: `node`,
+		ElementId:   nodeId,
+	}, true).(*Node)
+	cluster := sTree.Find(FindRequest{
+		ElementType: `cluster`,
+		ElementId:   clrId,
+	}, true).(*Cluster)
+	group := sTree.Find(FindRequest{
+		ElementType: `group`,
+		ElementId:   grpId,
+	}, true).(*Group)
+
+	if !newCheckEvaluator(node).evalNativeProp(`hardware_node`, `hv01`, ``) {
+		t.Errorf(`Node did not match its server`)
+	}
+	if newCheckEvaluator(node).evalNativeProp(`hardware_node`, `hv02`, ``) {
+		t.Errorf(`Node matched a foreign server`)
+	}
+	if !newCheckEvaluator(cluster).evalNativeProp(`hardware_node`, `hv0*`, `glob`) {
+		t.Errorf(`Cluster did not match the server of its member`)
+	}
+	if !newCheckEvaluator(group).evalNativeProp(`hardware_node`, `hv01`, ``) {
+		t.Errorf(`Group did not match the server of a nested member`)
+	}
+
+	group.hasUpdate = false
+	cluster.hasUpdate = false
+	node.Detach()
+
+	if newCheckEvaluator(cluster).evalNativeProp(`hardware_node`, `hv01`, ``) {
+		t.Errorf(`Cluster matched the server of a removed member`)
+	}
+	if !cluster.hasUpdate || !group.hasUpdate {
+		t.Errorf(`Member removal did not flag check recalculation`)
+	}
+
+	if len(errC) > 0 {
+		t.Error(`Error channel not empty`)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,746 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	log "github.com/Sirupsen/logrus"
+	uuid "github.com/satori/go.uuid"
+)
+
+// checkEvaluable is implemented by the tree elements that spawn check
+// instances. It exposes the properties, views and native attributes
+// that check constraints are evaluated against, and the check
+// bookkeeping of the element.
+type checkEvaluable interface {
+	GetID() string
+	GetType() string
+	GetRepositoryName() string
+
+	// properties returns the properties of type oncall, service,
+	// system or custom
+	properties(typ string) map[string]Property
+	// nativeAttribute returns the values of native attribute name
+	// that a native constraint can match
+	nativeAttribute(name string) []string
+	// supportsView reports whether checks in view are instantiated
+	// on the element
+	supportsView(view string) bool
+	checkState() checkState
+
+	actionCheckInstanceCreate(a Action)
+	actionCheckInstanceUpdate(a Action)
+	actionCheckInstanceDelete(a Action)
+}
+
+// checkState references the check bookkeeping of a tree element.
+// The maps are shared with the element.
+type checkState struct {
+	checks          map[string]Check
+	checkInstances  map[string][]string
+	instances       map[string]CheckInstance
+	loadedInstances map[string]map[string]CheckInstance
+	log             *log.Logger
+	fault           *Fault
+}
+
+// constraintBinding holds the property values that the constraints
+// of a check bound against
+type constraintBinding struct {
+	oncall    string                         // Id
+	system    map[string]string              // Id->Value
+	native    map[string]string              // Property->Value
+	service   map[string]string              // Id->Value
+	custom    map[string]string              // Id->Value
+	attribute map[string]map[string][]string // svcId->attr->[ value, ... ]
+}
+
+// constraintKey identifies a memoised constraint evaluation
+type constraintKey struct {
+	typ     string
+	service string
+	view    string
+	prop    string
+	op      string
+	value   string
+}
+
+// constraintResult is a memoised constraint evaluation
+type constraintResult struct {
+	hit   bool
+	id    string
+	bind  string
+	binds map[string]string
+}
+
+// checkEvaluator computes the check instances of a single tree
+// element. The properties of the element do not change while its
+// instances are computed, so constraint results are memoised across
+// all checks of one pass.
+type checkEvaluator struct {
+	checkState
+	obj      checkEvaluable
+	repoName string
+	objType  string
+	objId    string
+	memo     map[constraintKey]constraintResult
+}
+
+func newCheckEvaluator(obj checkEvaluable) *checkEvaluator {
+	return &checkEvaluator{
+		obj:        obj,
+		checkState: obj.checkState(),
+		repoName:   obj.GetRepositoryName(),
+		objType:    obj.GetType(),
+		objId:      obj.GetID(),
+		memo:       map[constraintKey]constraintResult{},
+	}
+}
+
+// update recomputes the check instances of the element if pending is
+// set or the tree is being loaded. It returns false if the pass was
+// aborted with a fault.
+func (e *checkEvaluator) update(pending bool) bool {
+	// object may have no checks, but there could be instances to mop up
+	if len(e.checks) == 0 && len(e.instances) == 0 {
+		e.log.Printf("TK[%s]: Action=%s, ObjectType=%s, ObjectId=%s, HasChecks=%t",
+			e.repoName,
+			`UpdateCheckInstances`,
+			e.objType,
+			e.objId,
+			false,
+		)
+		return true
+	}
+
+	// if there are loaded instances, then this is the initial rebuild
+	// of the tree
+	startupLoad := len(e.loadedInstances) > 0
+
+	// if this is not the startupLoad and there are no updates, then there
+	// is noting to do
+	if !startupLoad && !pending {
+		return true
+	}
+
+	e.cleanupInstances()
+	disabled := e.disableChecks()
+
+	for checkId := range e.checks {
+		if disabled[checkId] {
+			continue
+		}
+		if !e.computeInstances(checkId, startupLoad) {
+			return false
+		}
+	}
+	return true
+}
+
+// cleanupInstances deletes the instances of checks that no longer
+// exist
+func (e *checkEvaluator) cleanupInstances() {
+	for ck := range e.checkInstances {
+		if _, ok := e.checks[ck]; ok {
+			// check still exists
+			continue
+		}
+
+		// check no longer exists -> cleanup
+		for _, i := range e.checkInstances[ck] {
+			e.deleteInstance(`CleanupInstance`, ck, i)
+		}
+		delete(e.checkInstances, ck)
+	}
+}
+
+// disableChecks deletes the instances of all checks that are
+// disabled via the system properties `disable_all_monitoring` for
+// the view of the check or `disable_check_configuration` for the
+// check configuration that spawned the check. It returns the set of
+// disabled checks.
+func (e *checkEvaluator) disableChecks() map[string]bool {
+	disabled := map[string]bool{}
+	for chk := range e.checks {
+		if _, hit, _ := e.evalSystemProp(
+			`disable_all_monitoring`,
+			`true`,
+			e.checks[chk].View,
+			``,
+		); !hit {
+			if _, hit, _ = e.evalSystemProp(
+				`disable_check_configuration`,
+				e.checks[chk].ConfigId.String(),
+				e.checks[chk].View,
+				``,
+			); !hit {
+				continue
+			}
+		}
+		disabled[chk] = true
+
+		for _, i := range e.checkInstances[chk] {
+			e.deleteInstance(`RemoveDisabledInstance`, chk, i)
+		}
+		delete(e.checkInstances, chk)
+	}
+	return disabled
+}
+
+// computeInstances computes the instances of check checkId and
+// replaces the existing instances of the check with them
+func (e *checkEvaluator) computeInstances(checkId string,
+	startupLoad bool) bool {
+	check := e.checks[checkId]
+	if check.Inherited == false && check.ChildrenOnly == true {
+		return true
+	}
+	if !e.obj.supportsView(check.View) {
+		return true
+	}
+
+	binding, hasServiceConstraint, ok := e.evalConstraints(check)
+	e.log.Printf("TK[%s]: Action=%s, ObjectType=%s, ObjectId=%s, CheckId=%s, Match=%t",
+		e.repoName,
+		`ConstraintEvaluation`,
+		e.objType,
+		e.objId,
+		checkId,
+		ok,
+	)
+	if !ok {
+		// the check no longer matches, remove the instances it
+		// spawned while it did
+		for _, i := range e.checkInstances[checkId] {
+			e.deleteInstance(`RemoveUnmatchedInstance`, checkId, i)
+		}
+		delete(e.checkInstances, checkId)
+		return true
+	}
+
+	newInstances := map[string]CheckInstance{}
+	newCheckInstances := []string{}
+
+	candidates := []CheckInstance{}
+	if !hasServiceConstraint {
+		// if there are no service constraints, one check instance
+		// is created for this check
+		candidates = append(candidates,
+			e.newInstance(checkId, binding, ``, nil))
+	} else {
+		// if service constraints are in effect, then one instance
+		// is generated for every attribute value permutation of
+		// every service that bound
+		for svcId := range binding.service {
+			for _, cfg := range e.serviceConfigs(svcId) {
+				candidates = append(candidates,
+					e.newInstance(checkId, binding, svcId, cfg))
+			}
+		}
+	}
+
+	for _, inst := range candidates {
+		if startupLoad {
+			if !e.matchLoadedInstance(checkId, &inst) {
+				// if we hit here, then we just computed an instance
+				// that we could not match to any loaded instances
+				// -> something is wrong
+				e.log.Printf("TK[%s]: Failed to match computed instance to loaded instances."+
+					" ObjType=%s, ObjId=%s, CheckId=%s", e.repoName, e.objType, e.objId, checkId)
+				e.fault.Error <- &Error{Action: `Failed to match a computed instance to loaded data`}
+				return false
+			}
+		} else {
+			e.matchExistingInstance(checkId, &inst)
+		}
+		e.log.Printf("TK[%s]: Action=%s, ObjectType=%s, ObjectId=%s, CheckId=%s, InstanceId=%s, ServiceConstrained=%t",
+			e.repoName,
+			`ComputeInstance`,
+			e.objType,
+			e.objId,
+			checkId,
+			inst.InstanceId.String(),
+			hasServiceConstraint,
+		)
+		newInstances[inst.InstanceId.String()] = inst
+		newCheckInstances = append(newCheckInstances, inst.InstanceId.String())
+	}
+
+	// all instances have been built and matched to
+	// loaded instances, but there are loaded
+	// instances left. why?
+	if startupLoad && len(e.loadedInstances[checkId]) != 0 {
+		e.fault.Error <- &Error{Action: `Leftover matched instances after assignment, computed instances missing`}
+		return false
+	}
+
+	e.commitInstances(checkId, newInstances, newCheckInstances,
+		startupLoad)
+	return true
+}
+
+// evalConstraints evaluates the constraints of check. It returns the
+// bound property values, whether the instances are constrained to
+// services and whether all constraints matched.
+func (e *checkEvaluator) evalConstraints(check Check) (
+	constraintBinding, bool, bool) {
+	hasServiceConstraint := false
+	view := check.View
+	attributes := []CheckConstraint{}
+	b := constraintBinding{
+		system:    map[string]string{},
+		native:    map[string]string{},
+		service:   map[string]string{},
+		custom:    map[string]string{},
+		attribute: map[string]map[string][]string{},
+	}
+
+	// these constaint types must always match for the instance to
+	// be valid. defer service and attribute
+	for _, c := range check.Constraints {
+		switch c.Type {
+		case "native":
+			if !e.evalNativeProp(c.Key, c.Value, c.Operator) {
+				return b, false, false
+			}
+			b.native[c.Key] = c.Value
+		case "system":
+			id, hit, bind := e.evalSystemProp(c.Key, c.Value, view, c.Operator)
+			if !hit {
+				return b, false, false
+			}
+			b.system[id] = bind
+		case "oncall":
+			id, hit := e.evalOncallProp(c.Key, c.Value, view)
+			if !hit {
+				return b, false, false
+			}
+			b.oncall = id
+		case "custom":
+			id, hit, bind := e.evalCustomProp(c.Key, c.Value, view, c.Operator)
+			if !hit {
+				return b, false, false
+			}
+			b.custom[id] = bind
+		case "service":
+			hasServiceConstraint = true
+			id, hit, bind := e.evalServiceProp(c.Key, c.Value, view)
+			if !hit {
+				return b, false, false
+			}
+			b.service[id] = bind
+		case "attribute":
+			attributes = append(attributes, c)
+		}
+	}
+	if len(attributes) == 0 {
+		return b, hasServiceConstraint, true
+	}
+
+	/* if the check has both service and attribute constraints,
+	* then for the check to hit, the tree element needs to have
+	* all the services, and each of them needs to match all
+	* attribute constraints
+	 */
+	if hasServiceConstraint {
+		for id := range b.service {
+			for _, attr := range attributes {
+				hit, bind := e.evalAttributeOfService(id, view, attr.Key, attr.Value, attr.Operator)
+				if !hit {
+					return b, true, false
+				}
+				if b.attribute[id] == nil {
+					// b.attribute[id] might still be a nil map
+					b.attribute[id] = map[string][]string{}
+				}
+				b.attribute[id][attr.Key] = append(b.attribute[id][attr.Key], bind)
+			}
+		}
+		return b, true, true
+	}
+
+	/* if the check has only attribute constraints and no
+	* service constraint, then we pull in every service that
+	* matches all attribute constraints and generate a check
+	* instance for it
+	 */
+	for _, attr := range attributes {
+		hit, svcIdMap := e.evalAttributeProp(view, attr.Key, attr.Value, attr.Operator)
+		if !hit {
+			continue
+		}
+		for id, bind := range svcIdMap {
+			b.service[id] = bind
+			if b.attribute[id] == nil {
+				// b.attribute[id] might still be a nil map
+				b.attribute[id] = map[string][]string{}
+			}
+			b.attribute[id][attr.Key] = append(b.attribute[id][attr.Key], bind)
+		}
+	}
+	// delete all services that did not match all attributes
+	//
+	// if a check has two attribute constraints on the same
+	// attribute, then len(b.attribute[id]) != len(attributes)
+	for id := range b.attribute {
+		if countAttribC(b.attribute[id]) != len(attributes) {
+			delete(b.service, id)
+			delete(b.attribute, id)
+		}
+	}
+	// declare service constraints in effect if we found a
+	// service that bound all attribute constraints
+	return b, true, len(b.service) > 0
+}
+
+// newInstance builds a check instance of check checkId from the
+// constraint binding. Instances of services carry the service id and
+// service configuration.
+func (e *checkEvaluator) newInstance(checkId string,
+	b constraintBinding, svcId string,
+	cfg map[string]string) CheckInstance {
+	inst := CheckInstance{
+		InstanceId: uuid.UUID{},
+		CheckId: func(id string) uuid.UUID {
+			f, _ := uuid.FromString(id)
+			return f
+		}(checkId),
+		ConfigId: func(id string) uuid.UUID {
+			f, _ := uuid.FromString(e.checks[id].ConfigId.String())
+			return f
+		}(checkId),
+		InstanceConfigId:      uuid.NewV4(),
+		ConstraintOncall:      b.oncall,
+		ConstraintService:     b.service,
+		ConstraintSystem:      b.system,
+		ConstraintCustom:      b.custom,
+		ConstraintNative:      b.native,
+		ConstraintAttribute:   b.attribute,
+		ConstraintOperator:    e.checks[checkId].constraintOperators(),
+		InstanceService:       svcId,
+		InstanceServiceConfig: cfg,
+	}
+	inst.calcConstraintHash()
+	inst.calcConstraintValHash()
+	if svcId != `` {
+		inst.calcInstanceSvcCfgHash()
+	}
+	return inst
+}
+
+// matchLoadedInstance assigns inst the identity of the loaded
+// instance that was bound against the same constraints. It returns
+// false if there is no such instance.
+func (e *checkEvaluator) matchLoadedInstance(checkId string,
+	inst *CheckInstance) bool {
+	for ldInstId, ldInst := range e.loadedInstances[checkId] {
+		// check for data from loaded instance
+		if ldInst.InstanceSvcCfgHash != inst.InstanceSvcCfgHash ||
+			ldInst.ConstraintHash != inst.ConstraintHash ||
+			ldInst.ConstraintValHash != inst.ConstraintValHash ||
+			!uuid.Equal(ldInst.ConfigId, inst.ConfigId) {
+			continue
+		}
+		if inst.InstanceService != `` &&
+			ldInst.InstanceService != inst.InstanceService {
+			continue
+		}
+
+		// found a match. InstanceServiceConfig can be assumed to
+		// be equal, since InstanceSvcCfgHash is equal
+		inst.InstanceId, _ = uuid.FromString(ldInstId)
+		inst.InstanceConfigId, _ = uuid.FromString(ldInst.InstanceConfigId.String())
+		inst.Version = ldInst.Version
+		delete(e.loadedInstances[checkId], ldInstId)
+		return true
+	}
+	return false
+}
+
+// matchExistingInstance determines if inst is an update of an
+// existing instance of check checkId, or a new instance
+func (e *checkEvaluator) matchExistingInstance(checkId string,
+	inst *CheckInstance) {
+	for _, exInstId := range e.checkInstances[checkId] {
+		exInst := e.instances[exInstId]
+		if inst.InstanceService == `` {
+			// ignore instances with service constraints and check
+			// if an instance exists bound against the same
+			// constraints
+			if exInst.InstanceSvcCfgHash != `` ||
+				exInst.ConstraintHash != inst.ConstraintHash {
+				continue
+			}
+		} else if exInst.InstanceSvcCfgHash != inst.InstanceSvcCfgHash {
+			// an existing instance for the same service
+			// configuration is an update
+			continue
+		}
+		inst.InstanceId, _ = uuid.FromString(exInst.InstanceId.String())
+		inst.Version = exInst.Version + 1
+		return
+	}
+	// no match was found, this is a new instance
+	inst.Version = 0
+	inst.InstanceId = uuid.NewV4()
+}
+
+// commitInstances replaces the instances of check checkId with the
+// newly computed instances and sends the resulting actions
+func (e *checkEvaluator) commitInstances(checkId string,
+	newInstances map[string]CheckInstance, newCheckInstances []string,
+	startupLoad bool) {
+	// all new check instances have been built, check which
+	// existing instances did not get an update and need to be
+	// deleted
+	for _, oldInstanceId := range e.checkInstances[checkId] {
+		if _, ok := newInstances[oldInstanceId]; !ok {
+			// there is no new version for this instance id
+			e.deleteInstance(`DeleteInstance`, checkId, oldInstanceId)
+			continue
+		}
+		delete(e.instances, oldInstanceId)
+		e.instances[oldInstanceId] = newInstances[oldInstanceId]
+		e.obj.actionCheckInstanceUpdate(e.instances[oldInstanceId].MakeAction())
+		e.logInstance(`UpdateInstance`, checkId, oldInstanceId)
+	}
+	for _, newInstanceId := range newCheckInstances {
+		if _, ok := e.instances[newInstanceId]; ok {
+			continue
+		}
+		// this instance is new, not an update
+		e.instances[newInstanceId] = newInstances[newInstanceId]
+		// no need to send a create action during load; the
+		// action channel is drained anyway
+		if !startupLoad {
+			e.obj.actionCheckInstanceCreate(e.instances[newInstanceId].MakeAction())
+			e.logInstance(`CreateInstance`, checkId, newInstanceId)
+		} else {
+			e.logInstance(`RecreateInstance`, checkId, newInstanceId)
+		}
+	}
+	delete(e.checkInstances, checkId)
+	e.checkInstances[checkId] = newCheckInstances
+}
+
+func (e *checkEvaluator) deleteInstance(action, checkId,
+	instanceId string) {
+	e.obj.actionCheckInstanceDelete(e.instances[instanceId].MakeAction())
+	e.logInstance(action, checkId, instanceId)
+	delete(e.instances, instanceId)
+}
+
+func (e *checkEvaluator) logInstance(action, checkId,
+	instanceId string) {
+	e.log.Printf("TK[%s]: Action=%s, ObjectType=%s, ObjectId=%s, CheckId=%s, InstanceId=%s",
+		e.repoName,
+		action,
+		e.objType,
+		e.objId,
+		checkId,
+		instanceId,
+	)
+}
+
+// serviceConfigs returns all attribute value permutations of
+// service svcId. Since service attributes can be specified more than
+// once, but the semantics are unclear what the expected behaviour of
+// for example a file age check is that is specified against more
+// than one file path, one check instance is built for each of these
+// service config permutations.
+func (e *checkEvaluator) serviceConfigs(svcId string) []map[string]string {
+	svcCfg := map[string][]string{}
+	for _, v := range e.obj.properties(`service`)[svcId].(*PropertyService).Attributes {
+		svcCfg[v.Name] = append(svcCfg[v.Name], v.Value)
+	}
+
+	// calculate how many instances this service spawns
+	combinations := 1
+	for attr := range svcCfg {
+		combinations = combinations * len(svcCfg[attr])
+	}
+
+	// build all attribute combinations
+	results := make([]map[string]string, 0, combinations)
+	for attr := range svcCfg {
+		if len(results) == 0 {
+			for i := range svcCfg[attr] {
+				res := map[string]string{}
+				res[attr] = svcCfg[attr][i]
+				results = append(results, res)
+			}
+			continue
+		}
+		ires := make([]map[string]string, 0, combinations)
+		for r := range results {
+			for j := range svcCfg[attr] {
+				res := map[string]string{}
+				for k, v := range results[r] {
+					res[k] = v
+				}
+				res[attr] = svcCfg[attr][j]
+				ires = append(ires, res)
+			}
+		}
+		results = ires
+	}
+	return results
+}
+
+func (e *checkEvaluator) evalNativeProp(prop string, val string,
+	op string) bool {
+	key := constraintKey{typ: `native`, prop: prop, op: op, value: val}
+	if r, ok := e.memo[key]; ok {
+		return r.hit
+	}
+
+	r := constraintResult{}
+	for _, has := range e.obj.nativeAttribute(prop) {
+		if matchConstraint(op, val, has) {
+			r.hit = true
+			break
+		}
+	}
+	e.memo[key] = r
+	return r.hit
+}
+
+func (e *checkEvaluator) evalSystemProp(prop string, val string,
+	view string, op string) (string, bool, string) {
+	return e.evalKeyValueProp(`system`, prop, val, view, op)
+}
+
+func (e *checkEvaluator) evalCustomProp(prop string, val string,
+	view string, op string) (string, bool, string) {
+	return e.evalKeyValueProp(`custom`, prop, val, view, op)
+}
+
+// evalKeyValueProp evaluates a constraint against the system or
+// custom properties of the element
+func (e *checkEvaluator) evalKeyValueProp(typ string, prop string,
+	val string, view string, op string) (string, bool, string) {
+	key := constraintKey{typ: typ, view: view, prop: prop, op: op,
+		value: val}
+	if r, ok := e.memo[key]; ok {
+		return r.id, r.hit, r.bind
+	}
+
+	r := constraintResult{}
+	for _, v := range e.obj.properties(typ) {
+		var k, value string
+		switch t := v.(type) {
+		case *PropertySystem:
+			k, value = t.Key, t.Value
+		case *PropertyCustom:
+			k, value = t.Key, t.Value
+		}
+		if k == prop && matchConstraint(op, val, value) &&
+			(v.GetView() == view || v.GetView() == `any`) {
+			r = constraintResult{hit: true, id: k, bind: value}
+			break
+		}
+	}
+	e.memo[key] = r
+	return r.id, r.hit, r.bind
+}
+
+func (e *checkEvaluator) evalOncallProp(prop string, val string,
+	view string) (string, bool) {
+	key := constraintKey{typ: `oncall`, view: view, prop: prop, value: val}
+	if r, ok := e.memo[key]; ok {
+		return r.id, r.hit
+	}
+
+	r := constraintResult{}
+	for _, v := range e.obj.properties(`oncall`) {
+		t := v.(*PropertyOncall)
+		if "OncallId" == prop && t.Id.String() == val && (t.View == view || t.View == `any`) {
+			r = constraintResult{hit: true, id: t.Id.String()}
+			break
+		}
+	}
+	e.memo[key] = r
+	return r.id, r.hit
+}
+
+func (e *checkEvaluator) evalServiceProp(prop string, val string,
+	view string) (string, bool, string) {
+	key := constraintKey{typ: `service`, view: view, prop: prop,
+		value: val}
+	if r, ok := e.memo[key]; ok {
+		return r.id, r.hit, r.bind
+	}
+
+	r := constraintResult{}
+	for _, v := range e.obj.properties(`service`) {
+		t := v.(*PropertyService)
+		if prop == "name" && (t.Service == val || val == `@defined`) && (t.View == view || t.View == `any`) {
+			r = constraintResult{hit: true, id: t.Id.String(), bind: t.Service}
+			break
+		}
+	}
+	e.memo[key] = r
+	return r.id, r.hit, r.bind
+}
+
+func (e *checkEvaluator) evalAttributeOfService(svcId string,
+	view string, attribute string, value string,
+	op string) (bool, string) {
+	key := constraintKey{typ: `attribute`, service: svcId, view: view,
+		prop: attribute, op: op, value: value}
+	if r, ok := e.memo[key]; ok {
+		return r.hit, r.bind
+	}
+
+	r := constraintResult{}
+	t := e.obj.properties(`service`)[svcId].(*PropertyService)
+	for _, a := range t.Attributes {
+		if a.Name == attribute && (t.View == view || t.View == `any`) && matchConstraint(op, value, a.Value) {
+			r = constraintResult{hit: true, bind: a.Value}
+			break
+		}
+	}
+	e.memo[key] = r
+	return r.hit, r.bind
+}
+
+// evalAttributeProp returns the services that have an attribute
+// matching the constraint. The returned map is shared between all
+// callers of one pass and must not be modified.
+func (e *checkEvaluator) evalAttributeProp(view string, attr string,
+	value string, op string) (bool, map[string]string) {
+	key := constraintKey{typ: `attribute`, view: view, prop: attr, op: op,
+		value: value}
+	if r, ok := e.memo[key]; ok {
+		return r.hit, r.binds
+	}
+
+	f := map[string]string{}
+svcloop:
+	for _, v := range e.obj.properties(`service`) {
+		t := v.(*PropertyService)
+		for _, a := range t.Attributes {
+			if a.Name == attr && matchConstraint(op, value, a.Value) && (t.View == view || t.View == `any`) {
+				f[t.Id.String()] = a.Value
+				continue svcloop
+			}
+		}
+	}
+	e.memo[key] = constraintResult{hit: len(f) > 0, binds: f}
+	return len(f) > 0, f
+}
+
+func countAttribC(attributeC map[string][]string) int {
+	var count int = 0
+	for key := range attributeC {
+		count = count + len(attributeC[key])
+	}
+	return count
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,330 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/1and1/soma/lib/proto"
+	log "github.com/Sirupsen/logrus"
+	"github.com/satori/go.uuid"
+)
+
+func TestEvaluatorInstances(t *testing.T) {
+	actionC := make(chan *Action, 1024)
+	errC := make(chan *Error, 128)
+
+	rootId := uuid.NewV4().String()
+	teamId := uuid.NewV4().String()
+	repoId := uuid.NewV4().String()
+	buckId := uuid.NewV4().String()
+	grpId := uuid.NewV4().String()
+	nodeId := uuid.NewV4().String()
+
+	logger := log.New()
+	logger.Out = ioutil.Discard
+
+	sTree := New(TreeSpec{
+		Id:     rootId,
+		Name:   `root_testing`,
+		Action: actionC,
+		Log:    logger,
+	})
+	NewRepository(RepositorySpec{
+		Id:      repoId,
+		Name:    `test`,
+		Team:    teamId,
+		Deleted: false,
+		Active:  true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `root`,
+		ParentId:   rootId,
+	})
+	sTree.SetError(errC)
+	NewBucket(BucketSpec{
+		Id:          buckId,
+		Name:        `test_master`,
+		Environment: `testing`,
+		Team:        teamId,
+		Repository:  repoId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `repository`,
+		ParentId:   repoId,
+	})
+	NewGroup(GroupSpec{
+		Id:   grpId,
+		Name: `testgroup`,
+		Team: teamId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `bucket`,
+		ParentId:   buckId,
+	})
+	NewNode(NodeSpec{
+		Id:       nodeId,
+		AssetId:  1,
+		Name:     `testnode`,
+		Team:     teamId,
+		ServerId: uuid.NewV4().String(),
+		Online:   true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `group`,
+		ParentId:   grpId,
+	})
+
+	group := sTree.Find(FindRequest{
+		ElementType: `group`,
+		ElementId:   grpId,
+	}, true).(*Group)
+	node := sTree.Find(FindRequest{
+		ElementType: `node`,
+		ElementId:   nodeId,
+	}, true).(*Node)
+
+	node.SetProperty(&PropertySystem{
+		Id:          uuid.NewV4(),
+		Inheritance: true,
+		View:        `any`,
+		Key:         `os`,
+		Value:       `linux`,
+	})
+	node.SetProperty(&PropertyService{
+		Id:          uuid.NewV4(),
+		Inheritance: true,
+		View:        `any`,
+		Service:     `www`,
+		Attributes: []proto.ServiceAttribute{
+			{Name: `port`, Value: `80`},
+			{Name: `port`, Value: `443`},
+		},
+	})
+
+	// one instance on the node only, the group has no os property
+	group.SetCheck(Check{
+		Id:           uuid.Nil,
+		CapabilityId: uuid.NewV4(),
+		ConfigId:     uuid.NewV4(),
+		Inheritance:  true,
+		View:         `any`,
+		Interval:     60,
+		Constraints: []CheckConstraint{
+			{Type: `system`, Key: `os`, Value: `lin*`, Operator: `glob`},
+		},
+	})
+	// one instance per port of the www service on the node
+	group.SetCheck(Check{
+		Id:           uuid.Nil,
+		CapabilityId: uuid.NewV4(),
+		ConfigId:     uuid.NewV4(),
+		Inheritance:  true,
+		View:         `any`,
+		Interval:     60,
+		Constraints: []CheckConstraint{
+			{Type: `attribute`, Key: `port`, Value: `1`, Operator: `>=`},
+		},
+	})
+	// local checks are not instantiated on groups
+	group.SetCheck(Check{
+		Id:           uuid.Nil,
+		CapabilityId: uuid.NewV4(),
+		ConfigId:     uuid.NewV4(),
+		Inheritance:  true,
+		View:         `local`,
+		Interval:     60,
+	})
+	sTree.ComputeCheckInstances()
+
+	if len(group.Instances) != 0 {
+		t.Error(len(group.Instances), `instances on group, expected 0`)
+	}
+	if len(node.Instances) != 4 {
+		t.Error(len(node.Instances), `instances on node, expected 4`)
+	}
+
+	// constraint results are memoised for the duration of a pass
+	e := newCheckEvaluator(node)
+	if _, hit, bind := e.evalSystemProp(`os`, `linux`, `internal`, ``); !hit || bind != `linux` {
+		t.Fatal(`System property did not match`)
+	}
+	for _, p := range node.PropertySystem {
+		p.(*PropertySystem).Value = `bsd`
+	}
+	if _, hit, _ := e.evalSystemProp(`os`, `linux`, `internal`, ``); !hit {
+		t.Error(`Constraint result was not memoised`)
+	}
+	if _, hit, _ := newCheckEvaluator(node).evalSystemProp(
+		`os`, `linux`, `internal`, ``); hit {
+		t.Error(`Memoised result leaked into a new pass`)
+	}
+
+	if len(errC) > 0 {
+		t.Error(`Error channel not empty`)
+	}
+}
+
+func TestEvaluatorPropertyUpdate(t *testing.T) {
+	actionC := make(chan *Action, 1024)
+	errC := make(chan *Error, 128)
+
+	rootId := uuid.NewV4().String()
+	teamId := uuid.NewV4().String()
+	repoId := uuid.NewV4().String()
+	buckId := uuid.NewV4().String()
+	grpId := uuid.NewV4().String()
+	nodeId := uuid.NewV4().String()
+
+	logger := log.New()
+	logger.Out = ioutil.Discard
+
+	sTree := New(TreeSpec{
+		Id:     rootId,
+		Name:   `root_testing`,
+		Action: actionC,
+		Log:    logger,
+	})
+	NewRepository(RepositorySpec{
+		Id:      repoId,
+		Name:    `test`,
+		Team:    teamId,
+		Deleted: false,
+		Active:  true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `root`,
+		ParentId:   rootId,
+	})
+	sTree.SetError(errC)
+	NewBucket(BucketSpec{
+		Id:          buckId,
+		Name:        `test_master`,
+		Environment: `testing`,
+		Team:        teamId,
+		Repository:  repoId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `repository`,
+		ParentId:   repoId,
+	})
+	NewGroup(GroupSpec{
+		Id:   grpId,
+		Name: `testgroup`,
+		Team: teamId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `bucket`,
+		ParentId:   buckId,
+	})
+	NewNode(NodeSpec{
+		Id:       nodeId,
+		AssetId:  1,
+		Name:     `testnode`,
+		Team:     teamId,
+		ServerId: uuid.NewV4().String(),
+		Online:   true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `group`,
+		ParentId:   grpId,
+	})
+
+	group := sTree.Find(FindRequest{
+		ElementType: `group`,
+		ElementId:   grpId,
+	}, true).(*Group)
+	node := sTree.Find(FindRequest{
+		ElementType: `node`,
+		ElementId:   nodeId,
+	}, true).(*Node)
+
+	propId := uuid.NewV4()
+	group.SetProperty(&PropertySystem{
+		Id:          propId,
+		Inheritance: true,
+		View:        `any`,
+		Key:         `os`,
+		Value:       `linux`,
+	})
+	node.SetCheck(Check{
+		Id:           uuid.Nil,
+		CapabilityId: uuid.NewV4(),
+		ConfigId:     uuid.NewV4(),
+		Inheritance:  true,
+		View:         `any`,
+		Interval:     60,
+		Constraints: []CheckConstraint{
+			{Type: `system`, Key: `os`, Value: `lin*`, Operator: `glob`},
+		},
+	})
+	sTree.ComputeCheckInstances()
+	if len(node.Instances) != 1 {
+		t.Fatal(len(node.Instances), `instances on node, expected 1`)
+	}
+	var instId string
+	for id := range node.Instances {
+		instId = id
+	}
+	for len(actionC) > 0 {
+		<-actionC
+	}
+
+	// the inherited copy is updated in place, the instance is kept
+	group.UpdateProperty(&PropertySystem{
+		SourceId:    propId,
+		Inheritance: true,
+		View:        `any`,
+		Key:         `os`,
+		Value:       `linux-lts`,
+	})
+	sTree.ComputeCheckInstances()
+
+	for _, p := range node.PropertySystem {
+		if p.GetValue() != `linux-lts` {
+			t.Error(`Inherited copy not updated:`, p.GetValue())
+		}
+	}
+	if _, ok := node.Instances[instId]; !ok || len(node.Instances) != 1 {
+		t.Error(`Check instance was not kept across the update`)
+	}
+	updates := 0
+	for len(actionC) > 0 {
+		a := <-actionC
+		switch a.Action {
+		case `check_instance_create`, `check_instance_delete`:
+			t.Error(`Unexpected action`, a.Type, a.Action)
+		case `check_instance_update`:
+			updates++
+		}
+	}
+	if updates != 1 {
+		t.Error(updates, `check instance updates, expected 1`)
+	}
+
+	// a value that no longer matches removes the instance
+	group.UpdateProperty(&PropertySystem{
+		SourceId:    propId,
+		Inheritance: true,
+		View:        `any`,
+		Key:         `os`,
+		Value:       `bsd`,
+	})
+	sTree.ComputeCheckInstances()
+	if len(node.Instances) != 0 {
+		t.Error(len(node.Instances), `instances on node, expected 0`)
+	}
+
+	if len(errC) > 0 {
+		t.Error(`Error channel not empty`)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
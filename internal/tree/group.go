@@ -352,6 +352,13 @@ func (teg *Group) ClearLoadInfo() {
 	teg.loadedInstances = map[string]map[string]CheckInstance{}
 }
 
+//
+//
+func (teg *Group) Rename(name string) {
+	teg.Name = name
+	teg.actionUpdate()
+}
+
 //
 //
 func (teg *Group) export() proto.Group {
@@ -395,6 +402,7 @@ func (teg *Group) actionMemberNew(a Action) {
 	a.Group = teg.export()
 
 	teg.Action <- &a
+	teg.membersUpdated()
 }
 
 func (teg *Group) actionMemberRemoved(a Action) {
@@ -403,6 +411,17 @@ func (teg *Group) actionMemberRemoved(a Action) {
 	a.Group = teg.export()
 
 	teg.Action <- &a
+	teg.membersUpdated()
+}
+
+// membersUpdated flags the group and all groups it is a member of
+// for a recalculation of their check instances, since the
+// hardware_node constraint depends on the member nodes
+func (teg *Group) membersUpdated() {
+	teg.hasUpdate = true
+	if parent, ok := teg.Parent.(*Group); ok {
+		parent.membersUpdated()
+	}
 }
 
 //
@@ -0,0 +1,189 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid"
+)
+
+func TestGroupLifecycle(t *testing.T) {
+	actionC := make(chan *Action, 128)
+	errC := make(chan *Error, 128)
+
+	rootId := uuid.NewV4().String()
+	teamId := uuid.NewV4().String()
+	repoId := uuid.NewV4().String()
+	buckId := uuid.NewV4().String()
+	grpId := uuid.NewV4().String()
+	clrId := uuid.NewV4().String()
+	nodeId := uuid.NewV4().String()
+	servId := uuid.NewV4().String()
+
+	// create tree
+	sTree := New(TreeSpec{
+		Id:     rootId,
+		Name:   `root_testing`,
+		Action: actionC,
+	})
+
+	// create repository
+	NewRepository(RepositorySpec{
+		Id:      repoId,
+		Name:    `test`,
+		Team:    teamId,
+		Deleted: false,
+		Active:  true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `root`,
+		ParentId:   rootId,
+	})
+	sTree.SetError(errC)
+
+	// create bucket
+	NewBucket(BucketSpec{
+		Id:          buckId,
+		Name:        `test_master`,
+		Environment: `testing`,
+		Team:        teamId,
+		Deleted:     false,
+		Frozen:      false,
+		Repository:  repoId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `repository`,
+		ParentId:   repoId,
+	})
+
+	// create group
+	NewGroup(GroupSpec{
+		Id:   grpId,
+		Name: `testgroup`,
+		Team: teamId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `bucket`,
+		ParentId:   buckId,
+	})
+
+	// create cluster
+	NewCluster(ClusterSpec{
+		Id:   clrId,
+		Name: `testcluster`,
+		Team: teamId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `group`,
+		ParentId:   grpId,
+	})
+
+	// create node
+	NewNode(NodeSpec{
+		Id:       nodeId,
+		AssetId:  1,
+		Name:     `testnode`,
+		Team:     teamId,
+		ServerId: servId,
+		Online:   true,
+		Deleted:  false,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `cluster`,
+		ParentId:   clrId,
+	})
+
+	bucket := sTree.Child.Children[buckId].(*Bucket)
+	group := bucket.Children[grpId].(*Group)
+	cluster := group.Children[clrId].(*Cluster)
+	node := cluster.Children[nodeId].(*Node)
+
+	// set an inheriting check on the group
+	group.SetCheck(Check{
+		Id:           uuid.Nil,
+		CapabilityId: uuid.NewV4(),
+		ConfigId:     uuid.NewV4(),
+		Inheritance:  true,
+		ChildrenOnly: false,
+		View:         `any`,
+		Interval:     60,
+	})
+	if len(cluster.Checks) != 1 || len(node.Checks) != 1 {
+		t.Error(`Group check was not inherited`)
+	}
+
+	// drain setup actions
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	group.Rename(`renamedgroup`)
+	if a := <-actionC; a.Action != `update` || a.Group.Name != `renamedgroup` {
+		t.Error(`Expected group update action with new name`)
+	}
+
+	cluster.Rename(`renamedcluster`)
+	if a := <-actionC; a.Action != `update` || a.Cluster.Name != `renamedcluster` {
+		t.Error(`Expected cluster update action with new name`)
+	}
+
+	// removing the cluster from the group must remove the checks it
+	// inherited via its group membership
+	cluster.Detach()
+	if _, ok := bucket.Children[clrId]; !ok {
+		t.Error(`Cluster was not moved to the bucket`)
+	}
+	if len(cluster.Checks) != 0 || len(node.Checks) != 0 {
+		t.Error(`Inherited group check survived the member removal`)
+	}
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	group.Destroy()
+	if _, ok := bucket.Children[grpId]; ok {
+		t.Error(`Group still attached after Destroy`)
+	}
+	if a := <-actionC; a.Action != `delete` || a.Type != `group` {
+		t.Error(`Expected group delete action`)
+	}
+
+	node.Detach()
+	if _, ok := bucket.Children[nodeId]; !ok {
+		t.Error(`Node was not moved to the bucket`)
+	}
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	cluster.Destroy()
+	if _, ok := bucket.Children[clrId]; ok {
+		t.Error(`Cluster still attached after Destroy`)
+	}
+	if _, ok := bucket.Children[nodeId]; !ok {
+		t.Error(`Destroying the cluster removed the former member node`)
+	}
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	close(actionC)
+	close(errC)
+
+	if len(errC) > 0 {
+		t.Error(`Error channel not empty`)
+	}
+
+	if len(actionC) != 0 {
+		t.Error(len(actionC), `elements in action channel`)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -23,6 +23,7 @@ type Node struct {
 	AssetId         uint64
 	Team            uuid.UUID
 	ServerId        uuid.UUID
+	ServerName      string
 	State           string
 	Online          bool
 	Deleted         bool
@@ -43,13 +44,14 @@ type Node struct {
 }
 
 type NodeSpec struct {
-	Id       string
-	AssetId  uint64
-	Name     string
-	Team     string
-	ServerId string
-	Online   bool
-	Deleted  bool
+	Id         string
+	AssetId    uint64
+	Name       string
+	Team       string
+	ServerId   string
+	ServerName string
+	Online     bool
+	Deleted    bool
 }
 
 //
@@ -66,6 +68,7 @@ func NewNode(spec NodeSpec) *Node {
 	ten.AssetId = spec.AssetId
 	ten.Team, _ = uuid.FromString(spec.Team)
 	ten.ServerId, _ = uuid.FromString(spec.ServerId)
+	ten.ServerName = spec.ServerName
 	ten.Online = spec.Online
 	ten.Deleted = spec.Deleted
 	ten.Type = "node"
@@ -85,12 +88,13 @@ func NewNode(spec NodeSpec) *Node {
 
 func (ten Node) Clone() *Node {
 	cl := Node{
-		Name:    ten.Name,
-		State:   ten.State,
-		Online:  ten.Online,
-		Deleted: ten.Deleted,
-		Type:    ten.Type,
-		log:     ten.log,
+		Name:       ten.Name,
+		ServerName: ten.ServerName,
+		State:      ten.State,
+		Online:     ten.Online,
+		Deleted:    ten.Deleted,
+		Type:       ten.Type,
+		log:        ten.log,
 	}
 	cl.Id, _ = uuid.FromString(ten.Id.String())
 	cl.AssetId = ten.AssetId
@@ -348,7 +352,7 @@ func (ten *Node) actionDispatch(action string, a Action) {
 	ten.Action <- &a
 }
 
-func (ten *Node) repositoryName() string {
+func (ten *Node) GetRepositoryName() string {
 	return ten.Parent.(Bucketeer).GetBucket().(Bucketeer).GetRepositoryName()
 }
 
@@ -0,0 +1,191 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid"
+)
+
+func TestNodeRelocation(t *testing.T) {
+	actionC := make(chan *Action, 128)
+	errC := make(chan *Error, 128)
+
+	teamId := uuid.NewV4().String()
+	nodeId := uuid.NewV4().String()
+	servId := uuid.NewV4().String()
+
+	// create source and target trees
+	trees := make([]*Tree, 2)
+	buckets := make([]string, 2)
+	for i := range trees {
+		rootId := uuid.NewV4().String()
+		repoId := uuid.NewV4().String()
+		buckets[i] = uuid.NewV4().String()
+
+		trees[i] = New(TreeSpec{
+			Id:     rootId,
+			Name:   `root_testing`,
+			Action: actionC,
+		})
+		NewRepository(RepositorySpec{
+			Id:      repoId,
+			Name:    `test`,
+			Team:    teamId,
+			Deleted: false,
+			Active:  true,
+		}).Attach(AttachRequest{
+			Root:       trees[i],
+			ParentType: `root`,
+			ParentId:   rootId,
+		})
+		trees[i].SetError(errC)
+		NewBucket(BucketSpec{
+			Id:          buckets[i],
+			Name:        `test_master`,
+			Environment: `testing`,
+			Team:        teamId,
+			Deleted:     false,
+			Frozen:      false,
+			Repository:  repoId,
+		}).Attach(AttachRequest{
+			Root:       trees[i],
+			ParentType: `repository`,
+			ParentId:   repoId,
+		})
+	}
+
+	spec := NodeSpec{
+		Id:       nodeId,
+		AssetId:  1,
+		Name:     `testnode`,
+		Team:     teamId,
+		ServerId: servId,
+		Online:   true,
+		Deleted:  false,
+	}
+	NewNode(spec).Attach(AttachRequest{
+		Root:       trees[0],
+		ParentType: `bucket`,
+		ParentId:   buckets[0],
+	})
+	bucket := trees[0].Child.Children[buckets[0]].(*Bucket)
+	node := bucket.Children[nodeId].(*Node)
+
+	// inherited check from the bucket
+	bucket.SetCheck(Check{
+		Id:           uuid.Nil,
+		CapabilityId: uuid.NewV4(),
+		ConfigId:     uuid.NewV4(),
+		Inheritance:  true,
+		ChildrenOnly: false,
+		View:         `any`,
+		Interval:     60,
+	})
+
+	// local check and property on the node
+	localConfig := uuid.NewV4()
+	node.SetCheck(Check{
+		Id:           uuid.Nil,
+		CapabilityId: uuid.NewV4(),
+		ConfigId:     localConfig,
+		Inheritance:  true,
+		ChildrenOnly: false,
+		View:         `any`,
+		Interval:     300,
+	})
+	node.SetProperty(&PropertySystem{
+		Id:           uuid.NewV4(),
+		Inheritance:  true,
+		ChildrenOnly: false,
+		View:         `testview`,
+		Key:          `testkey`,
+		Value:        `testvalue`,
+	})
+	if len(node.Checks) != 2 {
+		t.Fatal(`Expected 2 checks on node, found`, len(node.Checks))
+	}
+
+	props := node.LocalProperties()
+	checks := node.LocalChecks()
+	if len(props) != 1 {
+		t.Fatal(`Expected 1 local property, found`, len(props))
+	}
+	oldPropId := props[0].GetID()
+	if len(checks) != 1 || !uuid.Equal(checks[0].ConfigId, localConfig) {
+		t.Error(`Expected the local check to be exported`)
+	}
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	// unassign from the source tree
+	node.Destroy()
+	if _, ok := bucket.Children[nodeId]; ok {
+		t.Error(`Node still attached after Destroy`)
+	}
+	removed := 0
+	for i := len(actionC); i > 0; i-- {
+		if a := <-actionC; a.Action == `check_removed` {
+			removed++
+		}
+	}
+	if removed != 2 {
+		t.Error(`Expected 2 check_removed actions, got`, removed)
+	}
+
+	// assign in the target tree
+	NewNode(spec).Attach(AttachRequest{
+		Root:       trees[1],
+		ParentType: `bucket`,
+		ParentId:   buckets[1],
+	})
+	target := trees[1].Child.Children[buckets[1]].(*Bucket).
+		Children[nodeId].(*Node)
+	for _, p := range props {
+		target.SetProperty(p)
+	}
+	for _, c := range checks {
+		target.SetCheck(c)
+	}
+
+	if len(target.PropertySystem) != 1 {
+		t.Error(`Local property was not carried over`)
+	}
+	for _, p := range target.PropertySystem {
+		if p.GetID() == oldPropId {
+			t.Error(`Carried property kept its old instance id`)
+		}
+	}
+	if len(target.Checks) != 1 {
+		t.Error(`Expected only the local check to be carried over`)
+	}
+	for _, c := range target.Checks {
+		if c.Inherited || !uuid.Equal(c.SourceId, c.Id) {
+			t.Error(`Carried check is not a local source check`)
+		}
+	}
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	close(actionC)
+	close(errC)
+
+	if len(errC) > 0 {
+		t.Error(`Error channel not empty`)
+	}
+
+	if len(actionC) != 0 {
+		t.Error(len(actionC), `elements in action channel`)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -337,6 +337,30 @@ func (ten *Node) deletePropertyAllLocal() {
 	}
 }
 
+// LocalProperties returns clones of all properties that were set on
+// the node itself and not inherited. The clones carry no id or
+// instance information and can be set on a different node.
+func (ten *Node) LocalProperties() []Property {
+	props := []Property{}
+	for _, pMap := range []map[string]Property{
+		ten.PropertyCustom,
+		ten.PropertySystem,
+		ten.PropertyService,
+		ten.PropertyOncall,
+	} {
+		for _, p := range pMap {
+			if p.GetIsInherited() {
+				continue
+			}
+			f := p.Clone()
+			f.SetId(uuid.Nil)
+			f.clearInstances()
+			props = append(props, f)
+		}
+	}
+	return props
+}
+
 func (ten *Node) rmProperty(p Property) bool {
 	delId := ten.findIdForSource(
 		p.GetSourceInstance(),
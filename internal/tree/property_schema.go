@@ -0,0 +1,181 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/1and1/soma/lib/proto"
+)
+
+// ValidatePropertySchema checks that s can be used as value schema
+// of a system or custom property. A nil schema is valid and accepts
+// every value.
+func ValidatePropertySchema(s *proto.PropertySchema) error {
+	if s == nil {
+		return nil
+	}
+
+	switch {
+	case (s.Minimum != `` || s.Maximum != ``) &&
+		s.Type != `int` && s.Type != `duration`:
+		return fmt.Errorf("Schema type %s does not support a range",
+			s.Type)
+	case len(s.Values) != 0 && s.Type != `enum`:
+		return fmt.Errorf("Schema type %s does not support values",
+			s.Type)
+	case s.Pattern != `` && s.Type != `regex`:
+		return fmt.Errorf("Schema type %s does not support a pattern",
+			s.Type)
+	}
+
+	switch s.Type {
+	case `bool`, `hostname`:
+	case `int`, `duration`:
+		var min, max int64
+		var err error
+		if s.Minimum != `` {
+			if min, err = parseSchemaNumber(s.Type, s.Minimum); err != nil {
+				return fmt.Errorf("Invalid %s minimum %s", s.Type,
+					s.Minimum)
+			}
+		}
+		if s.Maximum != `` {
+			if max, err = parseSchemaNumber(s.Type, s.Maximum); err != nil {
+				return fmt.Errorf("Invalid %s maximum %s", s.Type,
+					s.Maximum)
+			}
+		}
+		if s.Minimum != `` && s.Maximum != `` && min > max {
+			return fmt.Errorf("Schema minimum %s is above maximum %s",
+				s.Minimum, s.Maximum)
+		}
+	case `enum`:
+		if len(s.Values) == 0 {
+			return fmt.Errorf(`Schema type enum requires values`)
+		}
+	case `regex`:
+		if s.Pattern == `` {
+			return fmt.Errorf(`Schema type regex requires a pattern`)
+		}
+		if _, err := compileRegex(anchorPattern(s.Pattern)); err != nil {
+			return fmt.Errorf("Invalid regular expression %s: %s",
+				s.Pattern, err.Error())
+		}
+	default:
+		return fmt.Errorf("Unknown property schema type %s", s.Type)
+	}
+	return nil
+}
+
+// ValidatePropertyValue checks that value is accepted by schema s.
+// Patterns of type regex have to match the whole value. Booleans
+// have to be spelled true or false, since check constraints compare
+// them as strings.
+func ValidatePropertyValue(s *proto.PropertySchema, value string) error {
+	if s == nil {
+		return nil
+	}
+
+	switch s.Type {
+	case `int`, `duration`:
+		v, err := parseSchemaNumber(s.Type, value)
+		if err != nil {
+			return fmt.Errorf("Value %s is not a valid %s", value,
+				s.Type)
+		}
+		if s.Minimum != `` {
+			if min, _ := parseSchemaNumber(s.Type, s.Minimum); v < min {
+				return fmt.Errorf("Value %s is below the minimum %s",
+					value, s.Minimum)
+			}
+		}
+		if s.Maximum != `` {
+			if max, _ := parseSchemaNumber(s.Type, s.Maximum); v > max {
+				return fmt.Errorf("Value %s is above the maximum %s",
+					value, s.Maximum)
+			}
+		}
+	case `bool`:
+		if value != `true` && value != `false` {
+			return fmt.Errorf("Value %s is not a valid bool,"+
+				" expected true or false", value)
+		}
+	case `enum`:
+		for _, v := range s.Values {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("Value %s is not one of: %s", value,
+			strings.Join(s.Values, `, `))
+	case `regex`:
+		re, err := compileRegex(anchorPattern(s.Pattern))
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("Value %s does not match pattern %s",
+				value, s.Pattern)
+		}
+	case `hostname`:
+		if !isHostname(value) {
+			return fmt.Errorf("Value %s is not a valid hostname",
+				value)
+		}
+	default:
+		return fmt.Errorf("Unknown property schema type %s", s.Type)
+	}
+	return nil
+}
+
+// parseSchemaNumber parses v as int or duration, durations are
+// returned in nanoseconds
+func parseSchemaNumber(typ, v string) (int64, error) {
+	if typ == `duration` {
+		d, err := time.ParseDuration(v)
+		return int64(d), err
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+func anchorPattern(p string) string {
+	return `^(?:` + p + `)$`
+}
+
+// isHostname reports whether h is a hostname as per RFC 1123
+func isHostname(h string) bool {
+	if len(h) == 0 || len(h) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(h, `.`) {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			switch {
+			case c >= 'a' && c <= 'z':
+			case c >= 'A' && c <= 'Z':
+			case c >= '0' && c <= '9':
+			case c == '-':
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
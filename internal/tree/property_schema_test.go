@@ -0,0 +1,85 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"testing"
+
+	"github.com/1and1/soma/lib/proto"
+)
+
+func TestValidatePropertySchema(t *testing.T) {
+	for _, tc := range []struct {
+		schema *proto.PropertySchema
+		valid  bool
+	}{
+		{nil, true},
+		{&proto.PropertySchema{Type: `bool`}, true},
+		{&proto.PropertySchema{Type: `int`, Minimum: `1`, Maximum: `65535`}, true},
+		{&proto.PropertySchema{Type: `int`, Minimum: `10`, Maximum: `1`}, false},
+		{&proto.PropertySchema{Type: `int`, Minimum: `1.5`}, false},
+		{&proto.PropertySchema{Type: `duration`, Maximum: `24h`}, true},
+		{&proto.PropertySchema{Type: `duration`, Minimum: `ten`}, false},
+		{&proto.PropertySchema{Type: `enum`, Values: []string{`a`, `b`}}, true},
+		{&proto.PropertySchema{Type: `enum`}, false},
+		{&proto.PropertySchema{Type: `regex`, Pattern: `db[0-9]+`}, true},
+		{&proto.PropertySchema{Type: `regex`, Pattern: `db(`}, false},
+		{&proto.PropertySchema{Type: `regex`}, false},
+		{&proto.PropertySchema{Type: `hostname`, Maximum: `10`}, false},
+		{&proto.PropertySchema{Type: `bool`, Values: []string{`true`}}, false},
+		{&proto.PropertySchema{Type: `string`}, false},
+	} {
+		err := ValidatePropertySchema(tc.schema)
+		if (err == nil) != tc.valid {
+			t.Errorf("%+v: valid %t, got error %v", tc.schema,
+				tc.valid, err)
+		}
+	}
+}
+
+func TestValidatePropertyValue(t *testing.T) {
+	port := &proto.PropertySchema{Type: `int`, Minimum: `1`, Maximum: `65535`}
+	ttl := &proto.PropertySchema{Type: `duration`, Minimum: `1m`, Maximum: `1h`}
+	env := &proto.PropertySchema{Type: `enum`, Values: []string{`live`, `qa`}}
+	fqdn := &proto.PropertySchema{Type: `regex`, Pattern: `[a-z0-9.-]+\.example\.com`}
+
+	for _, tc := range []struct {
+		schema *proto.PropertySchema
+		value  string
+		valid  bool
+	}{
+		{nil, `anything`, true},
+		{port, `443`, true},
+		{port, `0`, false},
+		{port, `65536`, false},
+		{port, `http`, false},
+		{ttl, `15m`, true},
+		{ttl, `30s`, false},
+		{ttl, `2h`, false},
+		{&proto.PropertySchema{Type: `bool`}, `true`, true},
+		{&proto.PropertySchema{Type: `bool`}, `yes`, false},
+		{env, `qa`, true},
+		{env, `prod`, false},
+		{fqdn, `db01.example.com`, true},
+		{fqdn, `db01.exmaple.com`, false},
+		{fqdn, `db01.example.com.evil.org`, false},
+		{&proto.PropertySchema{Type: `hostname`}, `db01.example.com`, true},
+		{&proto.PropertySchema{Type: `hostname`}, `db_01.example.com`, false},
+		{&proto.PropertySchema{Type: `hostname`}, `-db01.example.com`, false},
+		{&proto.PropertySchema{Type: `hostname`}, `db01..example.com`, false},
+	} {
+		err := ValidatePropertyValue(tc.schema, tc.value)
+		if (err == nil) != tc.valid {
+			t.Errorf("%+v %s: valid %t, got error %v", tc.schema,
+				tc.value, tc.valid, err)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -96,7 +96,7 @@ func (ter Repository) Clone() Repository {
 		log:            ter.log,
 	}
 	cl.Id, _ = uuid.FromString(ter.Id.String())
-	cl.Team, _ = uuid.FromString(ter.Id.String())
+	cl.Team, _ = uuid.FromString(ter.Team.String())
 	f := make(map[string]RepositoryAttacher)
 	for k, child := range ter.Children {
 		f[k] = child.CloneRepository()
@@ -252,6 +252,12 @@ func (ter *Repository) ComputeCheckInstances() {
 		`repository`,
 		ter.Id.String(),
 	)
+	// deleted repositories have all their check instances
+	// deprovisioned and must not spawn new ones until they are
+	// restored
+	if ter.Deleted {
+		return
+	}
 	/*	var wg sync.WaitGroup
 		for child, _ := range ter.Children {
 			wg.Add(1)
@@ -316,6 +322,94 @@ func (ter *Repository) actionDelete() {
 	}
 }
 
+//
+// Lifecycle
+func (ter *Repository) Delete() {
+	if ter.Deleted {
+		return
+	}
+	ter.Deleted = true
+	ter.deprovisionInstances()
+	ter.actionUpdate()
+}
+
+func (ter *Repository) Restore() {
+	if !ter.Deleted {
+		return
+	}
+	ter.Deleted = false
+	// force recomputation of all check instances inside the
+	// repository
+	ter.invalidateInstances()
+	ter.actionUpdate()
+}
+
+func (ter *Repository) Activate() {
+	ter.Active = true
+	ter.actionUpdate()
+}
+
+func (ter *Repository) Rename(name string) {
+	ter.Name = name
+	ter.actionUpdate()
+}
+
+// Repossess transfers the repository and all buckets, groups and
+// clusters inside it to a new team. Nodes remain owned by their
+// team.
+func (ter *Repository) Repossess(team string) {
+	ter.Team, _ = uuid.FromString(team)
+	for _, child := range ter.Children {
+		repossessRecursive(child, ter.Team)
+	}
+	ter.actionUpdate()
+}
+
+// Clear destroys all buckets inside the repository, including
+// all their objects and check instances
+func (ter *Repository) Clear() {
+	buckets := []string{}
+	for i := 0; i < ter.ordNumChildBck; i++ {
+		if child, ok := ter.ordChildrenBck[i]; ok {
+			buckets = append(buckets, child)
+		}
+	}
+	for _, child := range buckets {
+		ter.Children[child].Destroy()
+	}
+}
+
+// Purge clears the repository and drops all properties and checks
+// defined on it. The repository is left behind as a deleted and
+// inactive tombstone.
+func (ter *Repository) Purge() {
+	ter.deletePropertyAllLocal()
+	ter.Clear()
+	ter.Checks = make(map[string]Check)
+	ter.Deleted = true
+	ter.Active = false
+	ter.actionDelete()
+}
+
+func repossessRecursive(a Attacher, team uuid.UUID) {
+	switch a.(type) {
+	case *Bucket:
+		b := a.(*Bucket)
+		b.Team = team
+		for _, child := range b.Children {
+			repossessRecursive(child, team)
+		}
+	case *Group:
+		g := a.(*Group)
+		g.Team = team
+		for _, child := range g.Children {
+			repossessRecursive(child, team)
+		}
+	case *Cluster:
+		a.(*Cluster).Team = team
+	}
+}
+
 func (ter *Repository) actionPropertyNew(a Action) {
 	a.Action = "property_new"
 	ter.actionProperty(a)
@@ -0,0 +1,173 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid"
+)
+
+func TestRepositoryLifecycle(t *testing.T) {
+	actionC := make(chan *Action, 128)
+	errC := make(chan *Error, 128)
+
+	rootId := uuid.NewV4().String()
+	teamId := uuid.NewV4().String()
+	newTeamId := uuid.NewV4().String()
+	repoId := uuid.NewV4().String()
+	buckId := uuid.NewV4().String()
+	grpId := uuid.NewV4().String()
+	clrId := uuid.NewV4().String()
+
+	// create tree
+	sTree := New(TreeSpec{
+		Id:     rootId,
+		Name:   `root_testing`,
+		Action: actionC,
+	})
+
+	// create repository
+	NewRepository(RepositorySpec{
+		Id:      repoId,
+		Name:    `test`,
+		Team:    teamId,
+		Deleted: false,
+		Active:  false,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `root`,
+		ParentId:   rootId,
+	})
+	sTree.SetError(errC)
+
+	// create bucket
+	NewBucket(BucketSpec{
+		Id:          buckId,
+		Name:        `test_master`,
+		Environment: `testing`,
+		Team:        teamId,
+		Deleted:     false,
+		Frozen:      false,
+		Repository:  repoId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `repository`,
+		ParentId:   repoId,
+	})
+
+	// create group
+	NewGroup(GroupSpec{
+		Id:   grpId,
+		Name: `testgroup`,
+		Team: teamId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `bucket`,
+		ParentId:   buckId,
+	})
+
+	// create cluster
+	NewCluster(ClusterSpec{
+		Id:   clrId,
+		Name: `testcluster`,
+		Team: teamId,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `group`,
+		ParentId:   grpId,
+	})
+
+	// drain setup actions
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	repo := sTree.Child
+
+	repo.Activate()
+	if a := <-actionC; a.Action != `update` || !a.Repository.IsActive {
+		t.Error(`Expected repository update action with active flag`)
+	}
+
+	repo.Delete()
+	if a := <-actionC; !a.Repository.IsDeleted {
+		t.Error(`Expected repository update action with deleted flag`)
+	}
+
+	// deleting twice is a noop
+	repo.Delete()
+	if len(actionC) != 0 {
+		t.Error(len(actionC), `elements in action channel`)
+	}
+
+	repo.Restore()
+	if repo.Deleted {
+		t.Error(`Repository still marked as deleted`)
+	}
+	<-actionC
+
+	repo.Rename(`renamed`)
+	if a := <-actionC; a.Repository.Name != `renamed` {
+		t.Error(`Expected repository update action with new name`)
+	}
+
+	repo.Repossess(newTeamId)
+	if a := <-actionC; a.Repository.TeamId != newTeamId {
+		t.Error(`Expected repository update action with new team`)
+	}
+	bucket := repo.Children[buckId].(*Bucket)
+	group := bucket.Children[grpId].(*Group)
+	cluster := group.Children[clrId].(*Cluster)
+	if bucket.Team.String() != newTeamId ||
+		group.Team.String() != newTeamId ||
+		cluster.Team.String() != newTeamId {
+		t.Error(`Repository contents not repossessed`)
+	}
+
+	clone := repo.Clone()
+	if clone.Team.String() != newTeamId {
+		t.Error(`Clone did not copy the repository team`)
+	}
+
+	repo.Clear()
+	if len(repo.Children) != 0 {
+		t.Error(len(repo.Children), `children left after Clear`)
+	}
+
+	// drain clear actions: group delete, cluster delete, member
+	// removal, bucket delete
+	if len(actionC) != 4 {
+		t.Error(len(actionC), `elements in action channel`)
+	}
+	for i := len(actionC); i > 0; i-- {
+		<-actionC
+	}
+
+	repo.Purge()
+	if !repo.Deleted || repo.Active {
+		t.Error(`Purged repository is not a deleted, inactive tombstone`)
+	}
+	if a := <-actionC; a.Action != `delete` {
+		t.Error(`Expected repository delete action`)
+	}
+
+	close(actionC)
+	close(errC)
+
+	if len(errC) > 0 {
+		t.Error(`Error channel not empty`)
+	}
+
+	if len(actionC) != 0 {
+		t.Error(len(actionC), `elements in action channel`)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
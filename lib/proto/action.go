@@ -0,0 +1,31 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß <joerg.pernfuss@1und1.de>
+ * All rights reserved
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto
+
+// Action is a single change emitted by a repository tree. Dry-run
+// requests return the list of actions they would have caused.
+type Action struct {
+	Action        string        `json:"action,omitempty"`
+	Type          string        `json:"type,omitempty"`
+	Bucket        Bucket        `json:"bucket,omitempty"`
+	Check         Check         `json:"check,omitempty"`
+	CheckInstance CheckInstance `json:"check_instance,omitempty"`
+	ChildCluster  Cluster       `json:"child_cluster,omitempty"`
+	ChildGroup    Group         `json:"child_group,omitempty"`
+	ChildNode     Node          `json:"child_node,omitempty"`
+	ChildType     string        `json:"child_type,omitempty"`
+	Cluster       Cluster       `json:"cluster,omitempty"`
+	Group         Group         `json:"group,omitempty"`
+	Node          Node          `json:"node,omitempty"`
+	Property      Property      `json:"property,omitempty"`
+	Repository    Repository    `json:"repository,omitempty"`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,53 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß <joerg.pernfuss@1und1.de>
+ * All rights reserved
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto
+
+// AuditEntry records a single mutating request and the
+// authorization decisions made while serving it. Authorized is
+// unset if no authorization check was made for the request.
+type AuditEntry struct {
+	Id            string   `json:"id,omitempty"`
+	Time          string   `json:"time,omitempty"`
+	User          string   `json:"user,omitempty"`
+	RemoteAddress string   `json:"remoteAddress,omitempty"`
+	Method        string   `json:"method,omitempty"`
+	Route         string   `json:"route,omitempty"`
+	Path          string   `json:"path,omitempty"`
+	Permissions   []string `json:"permissions,omitempty"`
+	Authorized    *bool    `json:"authorized,omitempty"`
+	StatusCode    int      `json:"statusCode,omitempty"`
+	Body          string   `json:"body,omitempty"`
+}
+
+// AuditFilter searches the audit trail. Object matches the request
+// path or body, Since and Until are RFC3339 timestamps.
+type AuditFilter struct {
+	User   string `json:"user,omitempty"`
+	Object string `json:"object,omitempty"`
+	Since  string `json:"since,omitempty"`
+	Until  string `json:"until,omitempty"`
+}
+
+func NewAuditFilter() Request {
+	return Request{
+		Filter: &Filter{
+			Audit: &AuditFilter{},
+		},
+	}
+}
+
+func NewAuditResult() Result {
+	return Result{
+		Errors: &[]string{},
+		Audit:  &[]AuditEntry{},
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -28,8 +28,21 @@ type CheckConfig struct {
 	Details      *CheckConfigDetails     `json:"details,omitempty"`
 }
 
+// CheckConfigConstraint restricts the objects a check configuration
+// creates check instances on. Operator selects how the constraint
+// value is compared with the property value:
+//
+//	""/"==", "!="       exact match, not equal
+//	"glob", "!glob"     shell pattern as for path.Match
+//	"regex", "!regex"   regular expression
+//	"<", "<=", ">", ">="  numeric comparison
+//
+// Only the native, system, custom and attribute constraints support
+// operators other than an exact match. Negated and numeric operators
+// only match properties that are set.
 type CheckConfigConstraint struct {
 	ConstraintType string            `json:"constraintType,omitempty"`
+	Operator       string            `json:"operator,omitempty"`
 	Native         *PropertyNative   `json:"native,omitempty"`
 	Oncall         *PropertyOncall   `json:"oncall,omitempty"`
 	Custom         *PropertyCustom   `json:"custom,omitempty"`
@@ -39,7 +52,7 @@ type CheckConfigConstraint struct {
 }
 
 func (c *CheckConfigConstraint) DeepCompare(a *CheckConfigConstraint) bool {
-	if c.ConstraintType != a.ConstraintType {
+	if c.ConstraintType != a.ConstraintType || c.Operator != a.Operator {
 		return false
 	}
 	switch c.ConstraintType {
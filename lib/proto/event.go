@@ -0,0 +1,25 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß <joerg.pernfuss@1und1.de>
+ * All rights reserved
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto
+
+// Event is a single message of the event stream. Depending on Type
+// either Job or Action is set.
+type Event struct {
+	Type         string  `json:"type,omitempty"`
+	Time         string  `json:"time,omitempty"`
+	RepositoryId string  `json:"repositoryId,omitempty"`
+	TeamId       string  `json:"teamId,omitempty"`
+	JobId        string  `json:"jobId,omitempty"`
+	User         string  `json:"user,omitempty"`
+	Job          *Job    `json:"job,omitempty"`
+	Action       *Action `json:"action,omitempty"`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -10,6 +10,7 @@
 package proto
 
 type Filter struct {
+	Audit       *AuditFilter       `json:"audit,omitempty"`
 	Bucket      *BucketFilter      `json:"bucket,omitempty"`
 	Capability  *CapabilityFilter  `json:"capability,omitempty"`
 	CheckConfig *CheckConfigFilter `json:"checkConfig,omitempty"`
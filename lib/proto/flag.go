@@ -18,6 +18,7 @@ type Flags struct {
 	Activate bool `json:"activate"` // repository
 	Detailed bool `json:"detailed"` // jobs
 	Forced   bool `json:"forced"`   // workflow
+	DryRun   bool `json:"dryrun"`   // tree requests
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
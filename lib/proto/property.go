@@ -40,10 +40,11 @@ type PropertyDetails struct {
 }
 
 type PropertyCustom struct {
-	Id           string `json:"id,omitempty"`
-	Name         string `json:"name,omitempty"`
-	RepositoryId string `json:"repositoryId,omitempty"`
-	Value        string `json:"value,omitempty"`
+	Id           string          `json:"id,omitempty"`
+	Name         string          `json:"name,omitempty"`
+	RepositoryId string          `json:"repositoryId,omitempty"`
+	Value        string          `json:"value,omitempty"`
+	Schema       *PropertySchema `json:"schema,omitempty"`
 }
 
 func (t *PropertyCustom) DeepCompare(a *PropertyCustom) bool {
@@ -66,8 +67,9 @@ func (t *PropertyCustom) DeepCompareSlice(a *[]PropertyCustom) bool {
 }
 
 type PropertySystem struct {
-	Name  string `json:"name,omitempty"`
-	Value string `json:"value,omitempty"`
+	Name   string          `json:"name,omitempty"`
+	Value  string          `json:"value,omitempty"`
+	Schema *PropertySchema `json:"schema,omitempty"`
 }
 
 func (t *PropertySystem) DeepCompare(a *PropertySystem) bool {
@@ -89,6 +91,18 @@ func (t *PropertySystem) DeepCompareSlice(a *[]PropertySystem) bool {
 	return false
 }
 
+// PropertySchema restricts the values a system or custom property
+// can be attached with. Minimum and Maximum are the allowed range for
+// types int and duration, Values are the allowed values of type enum
+// and Pattern the regular expression for type regex.
+type PropertySchema struct {
+	Type    string   `json:"type"`
+	Minimum string   `json:"minimum,omitempty"`
+	Maximum string   `json:"maximum,omitempty"`
+	Values  []string `json:"values,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+}
+
 type PropertyService struct {
 	Name       string             `json:"name,omitempty"`
 	TeamId     string             `json:"teamId,omitempty"`
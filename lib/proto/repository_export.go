@@ -0,0 +1,32 @@
+/*-
+ * Copyright (c) 2016, 1&1 Internet SE
+ * Copyright (c) 2016, Jörg Pernfuß <joerg.pernfuss@1und1.de>
+ * All rights reserved
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto
+
+// RepositoryExport is the content of a repository, recorded as the
+// results of the queries the treekeeper runs while loading it
+type RepositoryExport struct {
+	RepositoryId     string                  `json:"repositoryId"`
+	RepositoryName   string                  `json:"repositoryName"`
+	TeamId           string                  `json:"teamId"`
+	ExportedAt       string                  `json:"exportedAt,omitempty"`
+	CustomProperties []PropertyCustom        `json:"customProperties,omitempty"`
+	Queries          []RepositoryExportQuery `json:"queries"`
+}
+
+// RepositoryExportQuery is the result of one startup statement
+// executed with Arguments. NULL values are exported as null.
+type RepositoryExportQuery struct {
+	Statement string      `json:"statement"`
+	Arguments []string    `json:"arguments,omitempty"`
+	Columns   []string    `json:"columns"`
+	Rows      [][]*string `json:"rows,omitempty"`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
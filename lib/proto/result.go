@@ -53,7 +53,9 @@ type Result struct {
 	DeploymentsList *[]string `json:"deploymentsList,omitempty"`
 
 	// Request dependent data
+	Actions          *[]Action          `json:"actions,omitempty"`
 	Attributes       *[]Attribute       `json:"attributes,omitempty"`
+	Audit            *[]AuditEntry      `json:"audit,omitempty"`
 	Buckets          *[]Bucket          `json:"buckets,omitempty"`
 	Capabilities     *[]Capability      `json:"capability,omitempty"`
 	Categories       *[]Category        `json:"categories,omitempty"`
@@ -13,6 +13,9 @@ type SystemOperation struct {
 	Request      string `json:"request,omitempty"`
 	RepositoryId string `json:"repositoryId,omitempty"`
 	RebuildLevel string `json:"rebuildLevel,omitempty"`
+	// repository import and export
+	KeepInstanceIds bool              `json:"keepInstanceIds,omitempty"`
+	Export          *RepositoryExport `json:"export,omitempty"`
 }
 
 func NewSystemOperationRequest() Request {